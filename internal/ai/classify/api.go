@@ -0,0 +1,118 @@
+package classify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/header"
+)
+
+// ApiResponse represents the response of a local inference server, for example:
+//
+//	{"labels": [{"name": "tabby cat", "confidence": 0.92}, {"name": "sofa", "confidence": 0.41}]}
+type ApiResponse struct {
+	Labels []ApiLabel `json:"labels"`
+}
+
+// ApiLabel represents a label name and its confidence between 0 and 1.
+type ApiLabel struct {
+	Name       string  `json:"name"`
+	Confidence float32 `json:"confidence"`
+}
+
+// Api represents an image classification backend that sends images to a local HTTP inference server.
+type Api struct {
+	uri       string
+	timeout   time.Duration
+	rules     LabelRules
+	threshold float32
+}
+
+// NewApi returns a new inference server backend. The label rules and threshold are applied to the results.
+func NewApi(uri string, timeout time.Duration, rules LabelRules, threshold float32) *Api {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	if rules == nil {
+		rules = Rules
+	}
+
+	if threshold <= 0 {
+		threshold = 0.1
+	}
+
+	return &Api{uri: uri, timeout: timeout, rules: rules, threshold: threshold}
+}
+
+// Init checks if the inference server URI is configured.
+func (a *Api) Init() error {
+	if a.uri == "" {
+		return errors.New("classify: inference server uri not set")
+	}
+
+	return nil
+}
+
+// File returns matching labels for a jpeg media file.
+func (a *Api) File(fileName string) (result Labels, err error) {
+	imageBuffer, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Labels(imageBuffer)
+}
+
+// Labels sends the jpeg image to the inference server and returns matching labels.
+func (a *Api) Labels(img []byte) (result Labels, err error) {
+	if err = a.Init(); err != nil {
+		return result, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, a.uri, bytes.NewReader(img))
+
+	if err != nil {
+		return result, err
+	}
+
+	req.Header.Set(header.ContentType, fs.MimeTypeJPEG)
+	req.Header.Set(header.Accept, header.ContentTypeJson)
+
+	client := &http.Client{Timeout: a.timeout}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return result, fmt.Errorf("classify: %s (inference server)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return result, fmt.Errorf("classify: inference server %s returned status %d", clean.Log(a.uri), resp.StatusCode)
+	}
+
+	var r ApiResponse
+
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return result, fmt.Errorf("classify: %s (decode inference server response)", err)
+	}
+
+	for _, l := range r.Labels {
+		if label, ok := a.rules.Label(l.Name, l.Confidence, a.threshold); ok {
+			result = append(result, label)
+		}
+	}
+
+	// Return the best labels only.
+	return topLabels(result, 5), nil
+}
@@ -0,0 +1,92 @@
+package classify
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/header"
+)
+
+func TestApi_Labels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body, err := io.ReadAll(r.Body); err != nil || len(body) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set(header.ContentType, header.ContentTypeJson)
+		_, _ = w.Write([]byte(`{"labels": [{"name": "tabby", "confidence": 0.92}, {"name": "sofa", "confidence": 0.4}, {"name": "lamp", "confidence": 0.05}]}`))
+	}))
+
+	defer server.Close()
+
+	rules, err := LoadRules("testdata/rules.yml")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		a := NewApi(server.URL, time.Second, rules, 0.1)
+
+		result, err := a.Labels([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The "sofa" rule requires a confidence of at least 0.5.
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "cat", result[0].Name)
+			assert.Equal(t, 8, result[0].Uncertainty)
+			assert.Equal(t, 5, result[0].Priority)
+		}
+	})
+	t.Run("DefaultRules", func(t *testing.T) {
+		a := NewApi(server.URL, 0, nil, 0)
+
+		result, err := a.Labels([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+	})
+	t.Run("Threshold", func(t *testing.T) {
+		a := NewApi(server.URL, time.Second, nil, 0.95)
+
+		result, err := a.Labels([]byte("jpeg"))
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 0)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		a := NewApi(server.URL, time.Second, nil, 0)
+
+		_, err := a.Labels(nil)
+
+		assert.Error(t, err)
+	})
+	t.Run("NoUri", func(t *testing.T) {
+		a := NewApi("", time.Second, nil, 0)
+
+		assert.Error(t, a.Init())
+
+		_, err := a.Labels([]byte("jpeg"))
+
+		assert.Error(t, err)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		a := NewApi(server.URL, time.Second, nil, 0)
+
+		_, err := a.File("testdata/missing.jpg")
+
+		assert.Error(t, err)
+	})
+}
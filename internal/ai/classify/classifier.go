@@ -0,0 +1,85 @@
+package classify
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Classifier represents an image classification backend.
+type Classifier interface {
+	Init() error
+	File(fileName string) (Labels, error)
+}
+
+// Classifiers represents multiple image classification backends whose results are merged.
+type Classifiers []Classifier
+
+// Init initializes all backends. Backends that fail to initialize are logged and disabled so that
+// the others can still be used. An error is only returned if none of them could be initialized.
+func (c *Classifiers) Init() (err error) {
+	if len(*c) == 0 {
+		return nil
+	}
+
+	var ready Classifiers
+
+	for _, b := range *c {
+		if initErr := b.Init(); initErr != nil {
+			log.Errorf("classify: %s (backend disabled)", clean.Error(initErr))
+			err = initErr
+			continue
+		}
+
+		ready = append(ready, b)
+	}
+
+	if len(ready) == 0 {
+		return err
+	}
+
+	*c = ready
+
+	return nil
+}
+
+// File returns the merged labels of all backends for a jpeg media file. An error is only
+// returned if none of the backends succeeded.
+func (c Classifiers) File(fileName string) (result Labels, err error) {
+	if len(c) == 0 {
+		return result, errors.New("classify: no backend configured")
+	}
+
+	failed := 0
+
+	for _, b := range c {
+		labels, fileErr := b.File(fileName)
+
+		if fileErr != nil {
+			log.Debugf("classify: %s", fileErr)
+			err = fileErr
+			failed++
+			continue
+		}
+
+		result = result.Merge(labels)
+	}
+
+	if failed < len(c) {
+		return result, nil
+	}
+
+	return result, err
+}
+
+// topLabels sorts the labels by priority and uncertainty and returns the best n labels.
+func topLabels(labels Labels, n int) Labels {
+	sort.Sort(labels)
+
+	if len(labels) > n {
+		return labels[:n]
+	}
+
+	return labels
+}
@@ -0,0 +1,78 @@
+package classify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testClassifier returns the same labels for every file.
+type testClassifier struct {
+	labels Labels
+	err    error
+}
+
+func (c testClassifier) Init() error {
+	return c.err
+}
+
+func (c testClassifier) File(fileName string) (Labels, error) {
+	return c.labels, c.err
+}
+
+func TestClassifiers_File(t *testing.T) {
+	cat := testClassifier{labels: Labels{{Name: "cat", Source: SrcImage, Uncertainty: 20, Priority: 5}}}
+	sofa := testClassifier{labels: Labels{{Name: "sofa", Source: SrcImage, Uncertainty: 10}, {Name: "cat", Source: SrcImage, Uncertainty: 5}}}
+	failed := testClassifier{err: errors.New("failed")}
+
+	t.Run("Merge", func(t *testing.T) {
+		result, err := Classifiers{cat, sofa}.File("cat.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, "cat", result[0].Name)
+			assert.Equal(t, 5, result[0].Uncertainty)
+			assert.Equal(t, "sofa", result[1].Name)
+		}
+	})
+	t.Run("PartialFailure", func(t *testing.T) {
+		result, err := Classifiers{failed, cat}.File("cat.jpg")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		_, err := Classifiers{failed}.File("cat.jpg")
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := Classifiers{}.File("cat.jpg")
+		assert.Error(t, err)
+	})
+}
+
+func TestClassifiers_Init(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c := Classifiers{testClassifier{}}
+		assert.NoError(t, c.Init())
+		assert.Len(t, c, 1)
+	})
+	t.Run("PartialFailure", func(t *testing.T) {
+		c := Classifiers{testClassifier{err: errors.New("failed")}, testClassifier{}}
+		assert.NoError(t, c.Init())
+		assert.Len(t, c, 1)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		c := Classifiers{testClassifier{err: errors.New("failed")}}
+		assert.Error(t, c.Init())
+		assert.Len(t, c, 1)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		c := Classifiers{}
+		assert.NoError(t, c.Init())
+	})
+}
@@ -1,5 +1,5 @@
 /*
-Package classify encapsulates image classification using TensorFlow.
+Package classify encapsulates image classification using TensorFlow models and other pluggable backends.
 
 Copyright (c) 2018 - 2024 PhotoPrism UG. All rights reserved.
 
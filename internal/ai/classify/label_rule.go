@@ -1,5 +1,14 @@
 package classify
 
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
 // LabelRule defines the rule for a given Label
 type LabelRule struct {
 	Label      string
@@ -19,3 +28,81 @@ func (rules LabelRules) Find(label string) (rule LabelRule, ok bool) {
 
 	return LabelRule{Threshold: 0.1}, false
 }
+
+// Label returns a new image label for the model output name and probability based on the matching rule,
+// or false if the probability is below the threshold of the rule or the backend.
+func (rules LabelRules) Label(name string, probability, threshold float32) (result Label, ok bool) {
+	// Discard labels with low probabilities.
+	if probability < threshold {
+		return result, false
+	}
+
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	rule, _ := rules.Find(name)
+
+	// Discard labels that don't meet the rule threshold.
+	if probability < rule.Threshold {
+		return result, false
+	}
+
+	// Use rule label name instead of the model output name if it exists.
+	if rule.Label != "" {
+		name = strings.TrimSpace(rule.Label)
+	}
+
+	if name == "" {
+		return result, false
+	}
+
+	uncertainty := 100 - int(math.Round(float64(probability*100)))
+
+	return Label{Name: name, Source: SrcImage, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories}, true
+}
+
+// labelRuleYaml represents a label rule in a YAML file, which may refer to another rule.
+type labelRuleYaml struct {
+	Label      string   `yaml:"label"`
+	See        string   `yaml:"see"`
+	Threshold  float32  `yaml:"threshold"`
+	Categories []string `yaml:"categories"`
+	Priority   int      `yaml:"priority"`
+}
+
+// LoadRules reads label rules from a YAML file in the same format as the bundled rules.yml file.
+func LoadRules(fileName string) (LabelRules, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]labelRuleYaml)
+
+	if err = yaml.Unmarshal(data, values); err != nil {
+		return nil, err
+	}
+
+	rules := make(LabelRules, len(values))
+
+	for name, v := range values {
+		if v.See != "" {
+			ref, ok := values[v.See]
+
+			if !ok {
+				return nil, fmt.Errorf("classify: rule %s refers to missing label %s", name, v.See)
+			}
+
+			v = ref
+		}
+
+		rules[strings.ToLower(name)] = LabelRule{
+			Label:      v.Label,
+			Threshold:  v.Threshold,
+			Categories: v.Categories,
+			Priority:   v.Priority,
+		}
+	}
+
+	return rules, nil
+}
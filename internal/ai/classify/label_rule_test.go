@@ -36,3 +36,84 @@ func TestLabelRule_Find(t *testing.T) {
 		assert.Equal(t, float32(0.1), result.Threshold)
 	})
 }
+
+func TestLabelRules_Label(t *testing.T) {
+	rules := LabelRules{
+		"tabby": {
+			Label:      "cat",
+			Threshold:  0.2,
+			Priority:   5,
+			Categories: []string{"animal"},
+		},
+		"abacus": {
+			Threshold: 1,
+			Priority:  -2,
+		},
+	}
+
+	t.Run("RuleLabel", func(t *testing.T) {
+		result, ok := rules.Label(" Tabby ", 0.92, 0.1)
+
+		assert.True(t, ok)
+		assert.Equal(t, "cat", result.Name)
+		assert.Equal(t, SrcImage, result.Source)
+		assert.Equal(t, 8, result.Uncertainty)
+		assert.Equal(t, 5, result.Priority)
+		assert.Equal(t, []string{"animal"}, result.Categories)
+	})
+	t.Run("NoRule", func(t *testing.T) {
+		result, ok := rules.Label("Sofa", 0.5, 0.1)
+
+		assert.True(t, ok)
+		assert.Equal(t, "sofa", result.Name)
+		assert.Equal(t, 50, result.Uncertainty)
+	})
+	t.Run("BelowRuleThreshold", func(t *testing.T) {
+		_, ok := rules.Label("tabby", 0.15, 0.1)
+		assert.False(t, ok)
+
+		_, ok = rules.Label("abacus", 0.99, 0.1)
+		assert.False(t, ok)
+	})
+	t.Run("BelowBackendThreshold", func(t *testing.T) {
+		_, ok := rules.Label("tabby", 0.25, 0.3)
+		assert.False(t, ok)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := rules.Label(" ", 0.9, 0.1)
+		assert.False(t, ok)
+	})
+}
+
+func TestLoadRules(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		rules, err := LoadRules("testdata/rules.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, rules, 3)
+
+		kitten, ok := rules.Find("kitten")
+
+		assert.True(t, ok)
+		assert.Equal(t, "cat", kitten.Label)
+		assert.Equal(t, float32(0.2), kitten.Threshold)
+		assert.Equal(t, 5, kitten.Priority)
+		assert.Equal(t, []string{"animal"}, kitten.Categories)
+	})
+	t.Run("Bundled", func(t *testing.T) {
+		rules, err := LoadRules("rules.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Rules["tabby cat"], rules["tabby cat"])
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := LoadRules("testdata/missing.yml")
+		assert.Error(t, err)
+	})
+}
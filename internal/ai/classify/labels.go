@@ -2,6 +2,7 @@ package classify
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -34,6 +35,45 @@ func (l Labels) AppendLabel(label Label) Labels {
 	return append(l, label)
 }
 
+// Merge adds labels that are not in the list yet and returns the combined list, sorted by priority
+// and uncertainty. If a label exists in both lists, the lower uncertainty and higher priority are kept.
+func (l Labels) Merge(other Labels) Labels {
+	result := make(Labels, 0, len(l)+len(other))
+	index := make(map[string]int, len(l)+len(other))
+
+	for _, label := range append(append(Labels{}, l...), other...) {
+		if label.Name == "" {
+			continue
+		}
+
+		key := strings.ToLower(label.Name)
+
+		i, found := index[key]
+
+		if !found {
+			index[key] = len(result)
+			result = append(result, label)
+			continue
+		}
+
+		if label.Uncertainty < result[i].Uncertainty {
+			result[i].Uncertainty = label.Uncertainty
+		}
+
+		if label.Priority > result[i].Priority {
+			result[i].Priority = label.Priority
+		}
+
+		if len(result[i].Categories) == 0 {
+			result[i].Categories = label.Categories
+		}
+	}
+
+	sort.Sort(result)
+
+	return result
+}
+
 // Keywords returns all keywords contains in Labels and their categories
 func (l Labels) Keywords() (result []string) {
 	for _, label := range l {
@@ -120,3 +120,39 @@ func TestLabel_Sort(t *testing.T) {
 	assert.Equal(t, "label 1", labels[7].Name)
 	assert.Equal(t, "label 8", labels[8].Name)
 }
+
+func TestLabels_Merge(t *testing.T) {
+	t.Run("Duplicates", func(t *testing.T) {
+		a := Labels{
+			{Name: "cat", Source: SrcImage, Uncertainty: 40, Priority: 5, Categories: []string{"animal"}},
+			{Name: "sofa", Source: SrcImage, Uncertainty: 30},
+		}
+		b := Labels{
+			{Name: "Cat", Source: SrcImage, Uncertainty: 10, Priority: 2},
+			{Name: "lamp", Source: SrcImage, Uncertainty: 20},
+			{Name: "", Source: SrcImage, Uncertainty: 0},
+		}
+
+		result := a.Merge(b)
+
+		if assert.Len(t, result, 3) {
+			assert.Equal(t, "cat", result[0].Name)
+			assert.Equal(t, 10, result[0].Uncertainty)
+			assert.Equal(t, 5, result[0].Priority)
+			assert.Equal(t, []string{"animal"}, result[0].Categories)
+			assert.Equal(t, "lamp", result[1].Name)
+			assert.Equal(t, "sofa", result[2].Name)
+		}
+
+		// The original lists must not be changed.
+		assert.Equal(t, 40, a[0].Uncertainty)
+		assert.Len(t, b, 3)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		var a Labels
+
+		result := a.Merge(Labels{{Name: "cat", Uncertainty: 10}})
+
+		assert.Len(t, result, 1)
+	})
+}
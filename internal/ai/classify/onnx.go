@@ -0,0 +1,276 @@
+package classify
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// onnxMean and onnxStd are the ImageNet channel statistics used to normalize the model input.
+var (
+	onnxMean = [3]float32{0.485, 0.456, 0.406}
+	onnxStd  = [3]float32{0.229, 0.224, 0.225}
+)
+
+// OnnxSession runs an ONNX model with a single input and output tensor.
+type OnnxSession interface {
+	Run(input []float32) ([]float32, error)
+	Destroy() error
+}
+
+// NewOnnxSession creates an ONNX inference session for a model file with the specified input
+// and output names, input image size, and number of output classes. It is only available if
+// the application was built with the "onnx" tag, see onnx_runtime.go.
+var NewOnnxSession = func(modelFile, input, output string, size, classes int) (OnnxSession, error) {
+	return nil, errors.New("classify: onnx runtime is not supported by this build")
+}
+
+// Onnx represents an image classification backend that runs an ONNX model file locally. The model
+// must accept a normalized RGB image in NCHW layout, e.g. as exported by PyTorch, and the labels
+// must be listed in a "labels.txt" file in the same folder.
+type Onnx struct {
+	mutex     sync.Mutex
+	session   OnnxSession
+	modelFile string
+	labels    []string
+	input     string
+	output    string
+	size      int
+	rules     LabelRules
+	threshold float32
+}
+
+// NewOnnx returns a new ONNX backend for the model file in the backend configuration.
+func NewOnnx(b Backend, rules LabelRules) *Onnx {
+	o := &Onnx{
+		modelFile: b.Path,
+		input:     "input",
+		output:    "output",
+		size:      224,
+		rules:     Rules,
+		threshold: 0.1,
+	}
+
+	if b.Input != "" {
+		o.input = b.Input
+	}
+
+	if b.Output != "" {
+		o.output = b.Output
+	}
+
+	if b.Size > 0 {
+		o.size = b.Size
+	}
+
+	if rules != nil {
+		o.rules = rules
+	}
+
+	if b.Threshold > 0 {
+		o.threshold = b.Threshold
+	}
+
+	return o
+}
+
+// Init loads the labels and the ONNX model if they have not been loaded yet.
+func (o *Onnx) Init() error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.loadModel()
+}
+
+// File returns matching labels for a jpeg media file.
+func (o *Onnx) File(fileName string) (result Labels, err error) {
+	imageBuffer, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return o.Labels(imageBuffer)
+}
+
+// Labels returns matching labels for a jpeg image.
+func (o *Onnx) Labels(img []byte) (result Labels, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("classify: %s (onnx inference panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	input, err := o.createInput(img)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Sessions use preallocated tensors, so only one image can be classified at a time.
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if err = o.loadModel(); err != nil {
+		return result, err
+	}
+
+	output, err := o.session.Run(input)
+
+	if err != nil {
+		return result, fmt.Errorf("classify: %s (run onnx inference)", err)
+	}
+
+	// Return best labels.
+	result = o.bestLabels(output)
+
+	if len(result) > 0 {
+		log.Tracef("classify: image classified as %+v", result)
+	}
+
+	return result, nil
+}
+
+// loadModel loads the labels and creates the inference session, if needed.
+func (o *Onnx) loadModel() (err error) {
+	if o.session != nil {
+		return nil
+	} else if o.modelFile == "" {
+		return errors.New("classify: onnx model path not set")
+	}
+
+	if len(o.labels) == 0 {
+		if o.labels, err = loadOnnxLabels(filepath.Join(filepath.Dir(o.modelFile), "labels.txt")); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("classify: loading %s", clean.Log(filepath.Base(o.modelFile)))
+
+	o.session, err = NewOnnxSession(o.modelFile, o.input, o.output, o.size, len(o.labels))
+
+	return err
+}
+
+// loadOnnxLabels reads the label names from a text file with one label per line.
+func loadOnnxLabels(fileName string) (labels []string, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return labels, err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		labels = append(labels, strings.TrimSpace(scanner.Text()))
+	}
+
+	if err = scanner.Err(); err != nil {
+		return labels, err
+	} else if len(labels) == 0 {
+		return labels, fmt.Errorf("classify: no labels found in %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	return labels, nil
+}
+
+// createInput decodes a jpeg image and returns it as normalized model input in NCHW layout.
+func (o *Onnx) createInput(img []byte) ([]float32, error) {
+	decoded, err := imaging.Decode(bytes.NewReader(img), imaging.AutoOrientation(true))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return imageToOnnxInput(imaging.Fill(decoded, o.size, o.size, imaging.Center, imaging.Lanczos), o.size), nil
+}
+
+// imageToOnnxInput converts an image with the specified size to a normalized RGB tensor in NCHW layout.
+func imageToOnnxInput(img image.Image, size int) []float32 {
+	plane := size * size
+	result := make([]float32, 3*plane)
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			i := y*size + x
+
+			for c, v := range [3]uint32{r, g, b} {
+				result[c*plane+i] = (float32(v>>8)/255 - onnxMean[c]) / onnxStd[c]
+			}
+		}
+	}
+
+	return result
+}
+
+// bestLabels returns the best 5 labels, if their probability is high enough.
+func (o *Onnx) bestLabels(output []float32) Labels {
+	var result Labels
+
+	for i, p := range onnxProbabilities(output) {
+		if i >= len(o.labels) {
+			// Stop if the number of probabilities and labels does not match.
+			break
+		}
+
+		// Discard labels with low probabilities and apply label rules.
+		if label, ok := o.rules.Label(o.labels[i], p, o.threshold); ok {
+			result = append(result, label)
+		}
+	}
+
+	// Return the best labels only.
+	return topLabels(result, 5)
+}
+
+// onnxProbabilities returns the model output as probabilities, applying softmax if it contains logits.
+func onnxProbabilities(output []float32) []float32 {
+	maxVal := float32(math.Inf(-1))
+	logits := false
+
+	for _, v := range output {
+		if v < 0 || v > 1 {
+			logits = true
+		}
+
+		if v > maxVal {
+			maxVal = v
+		}
+	}
+
+	if !logits {
+		return output
+	}
+
+	result := make([]float32, len(output))
+
+	var sum float64
+
+	for i, v := range output {
+		e := math.Exp(float64(v - maxVal))
+		result[i] = float32(e)
+		sum += e
+	}
+
+	for i := range result {
+		result[i] = float32(float64(result[i]) / sum)
+	}
+
+	return result
+}
@@ -0,0 +1,87 @@
+//go:build onnx
+// +build onnx
+
+package classify
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+var onnxRuntimeMutex = sync.Mutex{}
+
+func init() {
+	NewOnnxSession = newOnnxRuntimeSession
+}
+
+// onnxRuntimeSession runs ONNX models with the ONNX Runtime shared library, whose location
+// can be specified with the PHOTOPRISM_ONNX_LIBRARY environment variable.
+type onnxRuntimeSession struct {
+	session *ort.AdvancedSession
+	input   *ort.Tensor[float32]
+	output  *ort.Tensor[float32]
+}
+
+// newOnnxRuntimeSession creates a new ONNX Runtime session with preallocated input and output tensors.
+func newOnnxRuntimeSession(modelFile, input, output string, size, classes int) (OnnxSession, error) {
+	onnxRuntimeMutex.Lock()
+	defer onnxRuntimeMutex.Unlock()
+
+	if !ort.IsInitialized() {
+		if lib := os.Getenv("PHOTOPRISM_ONNX_LIBRARY"); lib != "" {
+			ort.SetSharedLibraryPath(lib)
+		}
+
+		if err := ort.InitializeEnvironment(); err != nil {
+			return nil, fmt.Errorf("classify: %s (init onnx runtime)", err)
+		}
+	}
+
+	s := &onnxRuntimeSession{}
+
+	var err error
+
+	if s.input, err = ort.NewEmptyTensor[float32](ort.NewShape(1, 3, int64(size), int64(size))); err != nil {
+		return nil, err
+	} else if s.output, err = ort.NewEmptyTensor[float32](ort.NewShape(1, int64(classes))); err != nil {
+		_ = s.Destroy()
+		return nil, err
+	} else if s.session, err = ort.NewAdvancedSession(modelFile, []string{input}, []string{output},
+		[]ort.Value{s.input}, []ort.Value{s.output}, nil); err != nil {
+		_ = s.Destroy()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Run runs the model with the specified input and returns a copy of the output.
+func (s *onnxRuntimeSession) Run(input []float32) ([]float32, error) {
+	copy(s.input.GetData(), input)
+
+	if err := s.session.Run(); err != nil {
+		return nil, err
+	}
+
+	return append([]float32{}, s.output.GetData()...), nil
+}
+
+// Destroy releases the session and its tensors.
+func (s *onnxRuntimeSession) Destroy() error {
+	if s.session != nil {
+		_ = s.session.Destroy()
+	}
+
+	if s.input != nil {
+		_ = s.input.Destroy()
+	}
+
+	if s.output != nil {
+		_ = s.output.Destroy()
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package classify
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testOnnxSession returns the same output for every input.
+type testOnnxSession struct {
+	output []float32
+}
+
+func (s testOnnxSession) Run(input []float32) ([]float32, error) {
+	if len(input) == 0 {
+		return nil, errors.New("empty input")
+	}
+
+	return s.output, nil
+}
+
+func (s testOnnxSession) Destroy() error {
+	return nil
+}
+
+func TestOnnx_File(t *testing.T) {
+	newSession := NewOnnxSession
+
+	defer func() { NewOnnxSession = newSession }()
+
+	rules, err := LoadRules("testdata/rules.yml")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		NewOnnxSession = func(modelFile, input, output string, size, classes int) (OnnxSession, error) {
+			assert.Equal(t, 3, classes)
+			return testOnnxSession{output: []float32{0.9, 0.05, 0.05}}, nil
+		}
+
+		o := NewOnnx(Backend{Type: BackendOnnx, Path: "testdata/onnx/model.onnx", Size: 32}, rules)
+
+		if err := o.Init(); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := o.File(examplesPath + "/cat_black.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "cat", result[0].Name)
+			assert.Equal(t, 10, result[0].Uncertainty)
+		}
+	})
+	t.Run("Logits", func(t *testing.T) {
+		NewOnnxSession = func(modelFile, input, output string, size, classes int) (OnnxSession, error) {
+			return testOnnxSession{output: []float32{-2, 4, -3}}, nil
+		}
+
+		o := NewOnnx(Backend{Type: BackendOnnx, Path: "testdata/onnx/model.onnx", Size: 32}, rules)
+
+		result, err := o.File(examplesPath + "/cat_black.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "furniture", result[0].Name)
+		}
+	})
+	t.Run("NotSupported", func(t *testing.T) {
+		NewOnnxSession = newSession
+
+		o := NewOnnx(Backend{Type: BackendOnnx, Path: "testdata/onnx/model.onnx"}, rules)
+
+		assert.Error(t, o.Init())
+	})
+	t.Run("LabelsNotFound", func(t *testing.T) {
+		o := NewOnnx(Backend{Type: BackendOnnx, Path: "testdata/missing/model.onnx"}, rules)
+
+		assert.Error(t, o.Init())
+	})
+	t.Run("NoPath", func(t *testing.T) {
+		o := NewOnnx(Backend{Type: BackendOnnx}, rules)
+
+		assert.Error(t, o.Init())
+	})
+}
+
+func TestImageToOnnxInput(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.RGBA{R: 255, G: 0, B: 0, A: 255})
+		}
+	}
+
+	result := imageToOnnxInput(img, 2)
+
+	if assert.Len(t, result, 12) {
+		assert.InDelta(t, (1-onnxMean[0])/onnxStd[0], result[0], 0.0001)
+		assert.InDelta(t, (0-onnxMean[1])/onnxStd[1], result[4], 0.0001)
+		assert.InDelta(t, (0-onnxMean[2])/onnxStd[2], result[8], 0.0001)
+	}
+}
+
+func TestOnnxProbabilities(t *testing.T) {
+	t.Run("Probabilities", func(t *testing.T) {
+		assert.Equal(t, []float32{0.2, 0.8}, onnxProbabilities([]float32{0.2, 0.8}))
+	})
+	t.Run("Logits", func(t *testing.T) {
+		result := onnxProbabilities([]float32{1, 1, -5})
+
+		assert.InDelta(t, 0.5, result[0], 0.01)
+		assert.InDelta(t, 0.5, result[1], 0.01)
+		assert.InDelta(t, 0, result[2], 0.01)
+	})
+}
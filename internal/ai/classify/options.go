@@ -0,0 +1,144 @@
+package classify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Backend types.
+const (
+	BackendTensorFlow = "tensorflow"
+	BackendOnnx       = "onnx"
+	BackendApi        = "api"
+)
+
+// Backend represents the configuration of an image classification backend.
+type Backend struct {
+	Type      string   `yaml:"Type" json:"Type"`
+	Name      string   `yaml:"Name,omitempty" json:"Name,omitempty"`
+	Path      string   `yaml:"Path,omitempty" json:"Path,omitempty"`
+	Tags      []string `yaml:"Tags,omitempty" json:"Tags,omitempty"`
+	Input     string   `yaml:"Input,omitempty" json:"Input,omitempty"`
+	Output    string   `yaml:"Output,omitempty" json:"Output,omitempty"`
+	Size      int      `yaml:"Size,omitempty" json:"Size,omitempty"`
+	Uri       string   `yaml:"Uri,omitempty" json:"Uri,omitempty"`
+	Timeout   int      `yaml:"Timeout,omitempty" json:"Timeout,omitempty"`
+	Rules     string   `yaml:"Rules,omitempty" json:"Rules,omitempty"`
+	Threshold float32  `yaml:"Threshold,omitempty" json:"Threshold,omitempty"`
+	Disabled  bool     `yaml:"Disabled,omitempty" json:"Disabled,omitempty"`
+}
+
+// Options represents the image classification backend configuration, e.g. from a "classify.yml" file:
+//
+//	Backends:
+//	  - Type: tensorflow
+//	    Name: nasnet
+//	  - Type: tensorflow
+//	    Path: /models/custom
+//	    Rules: /models/custom/rules.yml
+//	    Threshold: 0.3
+//	  - Type: onnx
+//	    Path: /models/resnet/model.onnx
+//	  - Type: api
+//	    Uri: http://localhost:5000/classify
+type Options struct {
+	Backends []Backend `yaml:"Backends" json:"Backends"`
+}
+
+// DefaultOptions returns the default configuration with the bundled Nasnet model.
+func DefaultOptions() Options {
+	return Options{Backends: []Backend{{Type: BackendTensorFlow, Name: "nasnet"}}}
+}
+
+// LoadOptions reads the backend configuration from a YAML file and returns the defaults if it does not exist.
+// Relative file paths are resolved based on the folder in which the configuration file is located.
+func LoadOptions(fileName string) (opt Options, err error) {
+	if fileName == "" || !fs.FileExists(fileName) {
+		return DefaultOptions(), nil
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return opt, err
+	}
+
+	if err = yaml.Unmarshal(data, &opt); err != nil {
+		return opt, fmt.Errorf("classify: %s in %s", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	dir := filepath.Dir(fileName)
+
+	for i := range opt.Backends {
+		opt.Backends[i].Type = strings.ToLower(strings.TrimSpace(opt.Backends[i].Type))
+		opt.Backends[i].Path = absPath(dir, opt.Backends[i].Path)
+		opt.Backends[i].Rules = absPath(dir, opt.Backends[i].Rules)
+	}
+
+	return opt, nil
+}
+
+// absPath returns the absolute path of a file name relative to the specified folder.
+func absPath(dir, fileName string) string {
+	if fileName == "" || filepath.IsAbs(fileName) {
+		return fileName
+	}
+
+	return filepath.Join(dir, fileName)
+}
+
+// NewClassifier returns the configured image classification backend, or a list of backends if there
+// are several. The bundled model in assetsPath is used if no other model path is configured.
+func NewClassifier(opt Options, assetsPath string, disabled bool) (Classifier, error) {
+	// Classification is disabled?
+	if disabled {
+		return New(assetsPath, true), nil
+	}
+
+	var result Classifiers
+
+	for _, b := range opt.Backends {
+		if b.Disabled {
+			continue
+		}
+
+		// Load custom label rules?
+		rules := Rules
+
+		if b.Rules != "" {
+			if r, err := LoadRules(b.Rules); err != nil {
+				return nil, err
+			} else {
+				rules = r
+			}
+		}
+
+		switch b.Type {
+		case BackendTensorFlow, "":
+			result = append(result, NewTensorFlow(assetsPath, b, rules))
+		case BackendOnnx:
+			result = append(result, NewOnnx(b, rules))
+		case BackendApi:
+			result = append(result, NewApi(b.Uri, time.Duration(b.Timeout)*time.Second, rules, b.Threshold))
+		default:
+			return nil, fmt.Errorf("classify: unsupported backend type %s", clean.Log(b.Type))
+		}
+	}
+
+	switch len(result) {
+	case 0:
+		return New(assetsPath, true), nil
+	case 1:
+		return result[0], nil
+	default:
+		return &result, nil
+	}
+}
@@ -0,0 +1,125 @@
+package classify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestLoadOptions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		opt, err := LoadOptions("testdata/classify.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, opt.Backends, 5) {
+			assert.Equal(t, BackendTensorFlow, opt.Backends[0].Type)
+			assert.Equal(t, "nasnet", opt.Backends[0].Name)
+			assert.Equal(t, BackendTensorFlow, opt.Backends[1].Type)
+			assert.Equal(t, "testdata/models/custom", opt.Backends[1].Path)
+			assert.Equal(t, []string{"serve"}, opt.Backends[1].Tags)
+			assert.Equal(t, float32(0.3), opt.Backends[1].Threshold)
+			assert.Equal(t, BackendOnnx, opt.Backends[2].Type)
+			assert.Equal(t, "testdata/models/resnet/model.onnx", opt.Backends[2].Path)
+			assert.Equal(t, BackendApi, opt.Backends[3].Type)
+			assert.Equal(t, "testdata/rules.yml", opt.Backends[3].Rules)
+			assert.True(t, opt.Backends[4].Disabled)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		opt, err := LoadOptions("testdata/missing.yml")
+
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultOptions(), opt)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := LoadOptions("testdata/invalid.yml")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewClassifier(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		c, err := NewClassifier(DefaultOptions(), assetsPath, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if tf, ok := c.(*TensorFlow); assert.True(t, ok) {
+			assert.Equal(t, "nasnet", tf.modelName)
+			assert.Equal(t, assetsPath, tf.modelsPath)
+		}
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		c, err := NewClassifier(DefaultOptions(), assetsPath, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := c.File(examplesPath + "/chameleon_lime.jpg")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("Multiple", func(t *testing.T) {
+		opt, err := LoadOptions("testdata/classify.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := NewClassifier(opt, assetsPath, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, ok := c.(*Classifiers)
+
+		if !assert.True(t, ok) || !assert.Len(t, *result, 4) {
+			return
+		}
+
+		backends := *result
+
+		if tf, ok := backends[1].(*TensorFlow); assert.True(t, ok) {
+			assert.Equal(t, fs.Abs("testdata/models")+"/", fs.Abs(tf.modelsPath)+"/")
+			assert.Equal(t, "custom", tf.modelName)
+			assert.Equal(t, []string{"serve"}, tf.modelTags)
+			assert.Equal(t, "input", tf.input)
+			assert.Equal(t, "output/Softmax", tf.output)
+			assert.Equal(t, 299, tf.size)
+			assert.Equal(t, float32(0.3), tf.threshold)
+		}
+
+		if o, ok := backends[2].(*Onnx); assert.True(t, ok) {
+			assert.Equal(t, "testdata/models/resnet/model.onnx", o.modelFile)
+			assert.Equal(t, "pixel_values", o.input)
+			assert.Equal(t, "logits", o.output)
+			assert.Equal(t, 224, o.size)
+			assert.Equal(t, float32(0.2), o.threshold)
+		}
+
+		if a, ok := backends[3].(*Api); assert.True(t, ok) {
+			assert.Equal(t, "http://localhost:5000/classify", a.uri)
+			assert.Equal(t, 10*time.Second, a.timeout)
+			assert.Equal(t, float32(0.25), a.threshold)
+			assert.Len(t, a.rules, 3)
+		}
+	})
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, err := NewClassifier(Options{Backends: []Backend{{Type: "pytorch"}}}, assetsPath, false)
+		assert.Error(t, err)
+	})
+	t.Run("RulesNotFound", func(t *testing.T) {
+		_, err := NewClassifier(Options{Backends: []Backend{{Type: BackendApi, Rules: "testdata/missing.yml"}}}, assetsPath, false)
+		assert.Error(t, err)
+	})
+}
@@ -5,13 +5,10 @@ import (
 	"bytes"
 	"fmt"
 	"image"
-	"math"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime/debug"
-	"sort"
-	"strings"
 
 	tf "github.com/tensorflow/tensorflow/tensorflow/go"
 
@@ -27,11 +24,64 @@ type TensorFlow struct {
 	modelName  string
 	modelTags  []string
 	labels     []string
+	input      string
+	output     string
+	size       int
+	rules      LabelRules
+	threshold  float32
 }
 
 // New returns new TensorFlow instance with Nasnet model.
 func New(modelsPath string, disabled bool) *TensorFlow {
-	return &TensorFlow{modelsPath: modelsPath, disabled: disabled, modelName: "nasnet", modelTags: []string{"photoprism"}}
+	return &TensorFlow{
+		modelsPath: modelsPath,
+		disabled:   disabled,
+		modelName:  "nasnet",
+		modelTags:  []string{"photoprism"},
+		input:      "input_1",
+		output:     "predictions/Softmax",
+		size:       224,
+		rules:      Rules,
+		threshold:  0.1,
+	}
+}
+
+// NewTensorFlow returns a new TensorFlow instance for the model in the backend configuration, which must
+// contain a "labels.txt" file. Models without a path are loaded from the specified models folder.
+func NewTensorFlow(modelsPath string, b Backend, rules LabelRules) *TensorFlow {
+	t := New(modelsPath, b.Disabled)
+
+	if b.Path != "" {
+		t.modelsPath, t.modelName = filepath.Split(filepath.Clean(b.Path))
+	} else if b.Name != "" {
+		t.modelName = b.Name
+	}
+
+	if len(b.Tags) > 0 {
+		t.modelTags = b.Tags
+	}
+
+	if b.Input != "" {
+		t.input = b.Input
+	}
+
+	if b.Output != "" {
+		t.output = b.Output
+	}
+
+	if b.Size > 0 {
+		t.size = b.Size
+	}
+
+	if rules != nil {
+		t.rules = rules
+	}
+
+	if b.Threshold > 0 {
+		t.threshold = b.Threshold
+	}
+
+	return t
 }
 
 // Init initialises tensorflow models if not disabled
@@ -84,10 +134,10 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 	// Run inference.
 	output, err := t.model.Session.Run(
 		map[tf.Output]*tf.Tensor{
-			t.model.Graph.Operation("input_1").Output(0): tensor,
+			t.model.Graph.Operation(t.input).Output(0): tensor,
 		},
 		[]tf.Output{
-			t.model.Graph.Operation("predictions/Softmax").Output(0),
+			t.model.Graph.Operation(t.output).Output(0),
 		},
 		nil)
 
@@ -173,41 +223,14 @@ func (t *TensorFlow) bestLabels(probabilities []float32) Labels {
 			break
 		}
 
-		// discard labels with low probabilities
-		if p < 0.1 {
-			continue
-		}
-
-		labelText := strings.ToLower(t.labels[i])
-
-		rule, _ := Rules.Find(labelText)
-
-		// discard labels that don't met the threshold
-		if p < rule.Threshold {
-			continue
-		}
-
-		// Get rule label name instead of t.labels name if it exists
-		if rule.Label != "" {
-			labelText = rule.Label
+		// Discard labels with low probabilities and apply label rules.
+		if label, ok := t.rules.Label(t.labels[i], p, t.threshold); ok {
+			result = append(result, label)
 		}
-
-		labelText = strings.TrimSpace(labelText)
-
-		uncertainty := 100 - int(math.Round(float64(p*100)))
-
-		result = append(result, Label{Name: labelText, Source: SrcImage, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
 	}
 
-	// Sort by probability
-	sort.Sort(result)
-
 	// Return the best labels only.
-	if l := len(result); l < 5 {
-		return result[:l]
-	} else {
-		return result[:5]
-	}
+	return topLabels(result, 5)
 }
 
 // createTensor converts bytes jpeg image in a tensor object required as tensorflow model input
@@ -218,7 +241,7 @@ func (t *TensorFlow) createTensor(image []byte, imageFormat string) (*tf.Tensor,
 		return nil, err
 	}
 
-	width, height := 224, 224
+	width, height := t.size, t.size
 
 	img = imaging.Fill(img, width, height, imaging.Center, imaging.Lanczos)
 
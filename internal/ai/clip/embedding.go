@@ -0,0 +1,81 @@
+package clip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Embedding represents a normalized image or text embedding.
+type Embedding []float32
+
+// NewEmbedding creates a new normalized embedding from an inference result.
+func NewEmbedding(inference []float32) Embedding {
+	result := make(Embedding, len(inference))
+
+	copy(result, inference)
+
+	return result.Normalize()
+}
+
+// Normalize scales the embedding to unit length, so that the dot product equals the cosine similarity.
+func (m Embedding) Normalize() Embedding {
+	var sum float64
+
+	for _, v := range m {
+		sum += float64(v) * float64(v)
+	}
+
+	if sum == 0 {
+		return m
+	}
+
+	norm := float32(math.Sqrt(sum))
+
+	for i := range m {
+		m[i] /= norm
+	}
+
+	return m
+}
+
+// Similarity returns the cosine similarity of two normalized embeddings, or -1 if they cannot be compared.
+func (m Embedding) Similarity(other Embedding) float32 {
+	if len(m) == 0 || len(m) != len(other) {
+		return -1
+	}
+
+	var result float32
+
+	for i := range m {
+		result += m[i] * other[i]
+	}
+
+	return result
+}
+
+// Bytes returns the embedding as little-endian binary data for storage.
+func (m Embedding) Bytes() []byte {
+	result := make([]byte, len(m)*4)
+
+	for i, v := range m {
+		binary.LittleEndian.PutUint32(result[i*4:], math.Float32bits(v))
+	}
+
+	return result
+}
+
+// ParseEmbedding decodes an embedding from little-endian binary data.
+func ParseEmbedding(data []byte) (Embedding, error) {
+	if len(data) == 0 || len(data)%4 != 0 {
+		return nil, fmt.Errorf("invalid embedding size %d", len(data))
+	}
+
+	result := make(Embedding, len(data)/4)
+
+	for i := range result {
+		result[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package clip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEmbedding(t *testing.T) {
+	inference := []float32{3, 4}
+
+	e := NewEmbedding(inference)
+
+	assert.InDeltaSlice(t, []float32{0.6, 0.8}, e, 0.00001)
+	assert.Equal(t, []float32{3, 4}, inference)
+}
+
+func TestEmbedding_Normalize(t *testing.T) {
+	assert.Equal(t, Embedding{0, 0}, Embedding{0, 0}.Normalize())
+	assert.InDeltaSlice(t, []float32{0, -1}, Embedding{0, -2}.Normalize(), 0.00001)
+}
+
+func TestEmbedding_Similarity(t *testing.T) {
+	a := NewEmbedding([]float32{1, 0})
+	b := NewEmbedding([]float32{1, 1})
+	c := NewEmbedding([]float32{-1, 0})
+
+	assert.InDelta(t, 1, a.Similarity(a), 0.00001)
+	assert.InDelta(t, 0.70711, a.Similarity(b), 0.00001)
+	assert.InDelta(t, -1, a.Similarity(c), 0.00001)
+	assert.Equal(t, float32(-1), a.Similarity(Embedding{1, 0, 0}))
+	assert.Equal(t, float32(-1), Embedding{}.Similarity(Embedding{}))
+}
+
+func TestParseEmbedding(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		e := NewEmbedding([]float32{0.25, -1, 0.5})
+
+		result, err := ParseEmbedding(e.Bytes())
+
+		assert.NoError(t, err)
+		assert.Equal(t, e, result)
+		assert.Len(t, e.Bytes(), 12)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseEmbedding([]byte{1, 2, 3})
+		assert.Error(t, err)
+
+		_, err = ParseEmbedding(nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,215 @@
+package clip
+
+import (
+	"math"
+	"sort"
+)
+
+// IvfMinSize is the minimum number of embeddings for which an inverted file index is created,
+// as brute-force search is fast and exact for smaller libraries.
+var IvfMinSize = 10000
+
+// ivfIterations is the number of k-means iterations when training the inverted file index.
+const ivfIterations = 10
+
+// Match represents a search result and its similarity to the query.
+type Match struct {
+	ID         string
+	Similarity float32
+}
+
+// Matches represents a list of search results.
+type Matches []Match
+
+// IDs returns the result IDs in the order of their similarity.
+func (m Matches) IDs() []string {
+	result := make([]string, len(m))
+
+	for i := range m {
+		result[i] = m[i].ID
+	}
+
+	return result
+}
+
+// Index is an in-memory nearest neighbor index for embeddings. It performs a brute-force
+// search unless it has been trained, in which case only the nearest clusters are searched.
+type Index struct {
+	ids       []string
+	vectors   []Embedding
+	centroids []Embedding
+	lists     [][]int
+	probes    int
+}
+
+// NewIndex returns a new, empty index.
+func NewIndex() *Index {
+	return &Index{}
+}
+
+// Add adds an embedding with the specified ID to the index. Note that the index must
+// be trained again to include the embedding in the clusters.
+func (idx *Index) Add(id string, e Embedding) {
+	if id == "" || len(e) == 0 {
+		return
+	}
+
+	idx.ids = append(idx.ids, id)
+	idx.vectors = append(idx.vectors, e)
+	idx.centroids, idx.lists = nil, nil
+}
+
+// Len returns the number of embeddings in the index.
+func (idx *Index) Len() int {
+	return len(idx.ids)
+}
+
+// Trained checks if the inverted file index has been created.
+func (idx *Index) Trained() bool {
+	return len(idx.centroids) > 0
+}
+
+// Optimize creates an inverted file index if the index contains enough embeddings.
+func (idx *Index) Optimize() {
+	n := idx.Len()
+
+	if n < IvfMinSize || idx.Trained() {
+		return
+	}
+
+	lists := int(math.Sqrt(float64(n)))
+	idx.Train(lists, lists/8+1)
+}
+
+// Train clusters the embeddings with k-means, so that searches only need to compare the
+// query with the embeddings in the nearest clusters.
+func (idx *Index) Train(lists, probes int) {
+	n := idx.Len()
+
+	if lists < 2 || n < lists {
+		return
+	}
+
+	// Use evenly distributed embeddings as initial centroids for deterministic results.
+	centroids := make([]Embedding, lists)
+
+	for i := range centroids {
+		centroids[i] = append(Embedding{}, idx.vectors[i*n/lists]...)
+	}
+
+	assign := make([]int, n)
+
+	for iteration := 0; iteration < ivfIterations; iteration++ {
+		changed := false
+
+		for i, v := range idx.vectors {
+			if c := nearest(centroids, v); iteration == 0 || c != assign[i] {
+				assign[i] = c
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+
+		// Move centroids to the mean of their members.
+		sums := make([]Embedding, lists)
+
+		for i, v := range idx.vectors {
+			c := assign[i]
+
+			if sums[c] == nil {
+				sums[c] = make(Embedding, len(v))
+			}
+
+			for j := range v {
+				sums[c][j] += v[j]
+			}
+		}
+
+		for c := range centroids {
+			if sums[c] != nil {
+				centroids[c] = sums[c].Normalize()
+			}
+		}
+	}
+
+	idx.lists = make([][]int, lists)
+
+	for i, c := range assign {
+		idx.lists[c] = append(idx.lists[c], i)
+	}
+
+	idx.centroids = centroids
+	idx.probes = max(1, min(probes, lists))
+}
+
+// Search returns up to limit embeddings with a similarity of at least minSim, ordered by similarity.
+func (idx *Index) Search(query Embedding, limit int, minSim float32) (results Matches) {
+	if len(query) == 0 || limit <= 0 {
+		return results
+	}
+
+	compare := func(i int) {
+		if sim := idx.vectors[i].Similarity(query); sim >= minSim {
+			results = append(results, Match{ID: idx.ids[i], Similarity: sim})
+		}
+	}
+
+	if idx.Trained() {
+		for _, c := range nearestN(idx.centroids, query, idx.probes) {
+			for _, i := range idx.lists[c] {
+				compare(i)
+			}
+		}
+	} else {
+		for i := range idx.vectors {
+			compare(i)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Similarity > results[j].Similarity
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// nearest returns the index of the most similar centroid.
+func nearest(centroids []Embedding, v Embedding) int {
+	best, bestSim := 0, float32(-2)
+
+	for i, c := range centroids {
+		if sim := c.Similarity(v); sim > bestSim {
+			best, bestSim = i, sim
+		}
+	}
+
+	return best
+}
+
+// nearestN returns the indexes of the n most similar centroids.
+func nearestN(centroids []Embedding, v Embedding, n int) []int {
+	result := make([]int, len(centroids))
+	sims := make([]float32, len(centroids))
+
+	for i, c := range centroids {
+		result[i] = i
+		sims[i] = c.Similarity(v)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return sims[result[i]] > sims[result[j]]
+	})
+
+	if len(result) > n {
+		result = result[:n]
+	}
+
+	return result
+}
@@ -0,0 +1,87 @@
+package clip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_Search(t *testing.T) {
+	idx := NewIndex()
+
+	idx.Add("a", NewEmbedding([]float32{1, 0, 0}))
+	idx.Add("b", NewEmbedding([]float32{1, 1, 0}))
+	idx.Add("c", NewEmbedding([]float32{0, 0, 1}))
+	idx.Add("", NewEmbedding([]float32{1, 0, 0}))
+	idx.Add("d", nil)
+
+	assert.Equal(t, 3, idx.Len())
+
+	t.Run("Nearest", func(t *testing.T) {
+		results := idx.Search(NewEmbedding([]float32{1, 0.1, 0}), 10, 0.5)
+
+		assert.Equal(t, []string{"a", "b"}, results.IDs())
+		assert.Greater(t, results[0].Similarity, results[1].Similarity)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		results := idx.Search(NewEmbedding([]float32{1, 0.1, 0}), 1, -1)
+
+		assert.Equal(t, []string{"a"}, results.IDs())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, idx.Search(nil, 10, 0))
+		assert.Empty(t, idx.Search(NewEmbedding([]float32{1, 0, 0}), 0, 0))
+	})
+}
+
+func TestIndex_Train(t *testing.T) {
+	idx := NewIndex()
+
+	// Create two well separated groups of embeddings.
+	for i := 0; i < 50; i++ {
+		idx.Add(fmt.Sprintf("x%d", i), NewEmbedding([]float32{1, float32(i) / 500, 0}))
+		idx.Add(fmt.Sprintf("z%d", i), NewEmbedding([]float32{0, float32(i) / 500, 1}))
+	}
+
+	idx.Train(2, 1)
+
+	assert.True(t, idx.Trained())
+	assert.Len(t, idx.lists[0], 50)
+	assert.Len(t, idx.lists[1], 50)
+
+	results := idx.Search(NewEmbedding([]float32{1, 0, 0}), 100, -1)
+
+	// Only the nearest cluster is searched.
+	assert.Len(t, results, 50)
+	assert.Equal(t, "x0", results[0].ID)
+
+	// Adding embeddings resets the clusters.
+	idx.Add("y", NewEmbedding([]float32{0, 1, 0}))
+
+	assert.False(t, idx.Trained())
+	assert.Len(t, idx.Search(NewEmbedding([]float32{1, 0, 0}), 200, -1), 101)
+}
+
+func TestIndex_Optimize(t *testing.T) {
+	idx := NewIndex()
+
+	for i := 0; i < 100; i++ {
+		idx.Add(fmt.Sprintf("x%d", i), NewEmbedding([]float32{1, float32(i), 0}))
+	}
+
+	idx.Optimize()
+
+	assert.False(t, idx.Trained())
+
+	minSize := IvfMinSize
+	IvfMinSize = 100
+
+	defer func() { IvfMinSize = minSize }()
+
+	idx.Optimize()
+
+	assert.True(t, idx.Trained())
+	assert.Len(t, idx.centroids, 10)
+	assert.Equal(t, 2, idx.probes)
+}
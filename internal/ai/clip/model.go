@@ -0,0 +1,277 @@
+package clip
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Image normalization parameters used when the model was trained.
+var (
+	imageMean = [3]float32{0.48145466, 0.4578275, 0.40821073}
+	imageStd  = [3]float32{0.26862954, 0.26130258, 0.27577711}
+)
+
+// Model is a wrapper for a CLIP-style TensorFlow model with an image and a text encoder.
+// The model folder must contain the exported SavedModel and the "merges.txt" file with
+// the byte pair encoding merges of its vocabulary.
+type Model struct {
+	model       *tf.SavedModel
+	tokenizer   *Tokenizer
+	modelPath   string
+	modelTags   []string
+	disabled    bool
+	imageInput  string
+	imageOutput string
+	textInput   string
+	textMask    string
+	textOutput  string
+	mutex       sync.Mutex
+}
+
+// NewModel returns a new CLIP model instance.
+func NewModel(modelPath string, disabled bool) *Model {
+	return &Model{
+		modelPath:   modelPath,
+		modelTags:   []string{"serve"},
+		disabled:    disabled,
+		imageInput:  "serving_default_pixel_values",
+		imageOutput: "StatefulPartitionedCall",
+		textInput:   "serving_default_input_ids",
+		textMask:    "serving_default_attention_mask",
+		textOutput:  "StatefulPartitionedCall_1",
+	}
+}
+
+// Disabled checks if the model is disabled.
+func (m *Model) Disabled() bool {
+	return m == nil || m.disabled
+}
+
+// Name returns the model name, which is stored along with the embeddings.
+func (m *Model) Name() string {
+	if m == nil {
+		return ""
+	}
+
+	return filepath.Base(m.modelPath)
+}
+
+// Init loads the model if it is not disabled.
+func (m *Model) Init() error {
+	if m.Disabled() {
+		return nil
+	}
+
+	return m.loadModel()
+}
+
+// File returns the image embedding of a JPEG file.
+func (m *Model) File(fileName string) (Embedding, error) {
+	if m.Disabled() {
+		return nil, fmt.Errorf("clip: model is disabled")
+	}
+
+	img, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Image(img)
+}
+
+// Image returns the embedding of a JPEG image.
+func (m *Model) Image(img []byte) (Embedding, error) {
+	if m.Disabled() {
+		return nil, fmt.Errorf("clip: model is disabled")
+	}
+
+	src, err := imaging.Decode(bytes.NewReader(img), imaging.AutoOrientation(true))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Embed(src)
+}
+
+// Embed returns the embedding of a decoded image, e.g. a cropped area of a larger picture.
+func (m *Model) Embed(img image.Image) (result Embedding, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("clip: %s (image inference panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if m.Disabled() {
+		return nil, fmt.Errorf("clip: model is disabled")
+	} else if img == nil {
+		return nil, fmt.Errorf("clip: image is nil")
+	}
+
+	if err = m.loadModel(); err != nil {
+		return nil, err
+	}
+
+	tensor, err := imageToTensor(imaging.Fill(img, ImageSize, ImageSize, imaging.Center, imaging.Lanczos))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m.run(map[string]*tf.Tensor{m.imageInput: tensor}, m.imageOutput)
+}
+
+// Text returns the embedding of a natural-language text, e.g. a search query.
+func (m *Model) Text(text string) (result Embedding, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("clip: %s (text inference panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if m.Disabled() {
+		return nil, fmt.Errorf("clip: model is disabled")
+	}
+
+	if err = m.loadModel(); err != nil {
+		return nil, err
+	}
+
+	ids := m.tokenizer.Encode(text)
+
+	inputs := make(map[string]*tf.Tensor, 2)
+
+	if inputs[m.textInput], err = tf.NewTensor([][]int32{ids}); err != nil {
+		return nil, err
+	}
+
+	// Add attention mask if the model requires it.
+	if m.model.Graph.Operation(m.textMask) != nil {
+		mask := make([]int32, len(ids))
+
+		for i := range ids {
+			if ids[i] != 0 {
+				mask[i] = 1
+			}
+		}
+
+		if inputs[m.textMask], err = tf.NewTensor([][]int32{mask}); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.run(inputs, m.textOutput)
+}
+
+// run performs inference with the specified inputs and returns the normalized embedding.
+func (m *Model) run(inputs map[string]*tf.Tensor, output string) (Embedding, error) {
+	feeds := make(map[tf.Output]*tf.Tensor, len(inputs))
+
+	for name, tensor := range inputs {
+		op := m.model.Graph.Operation(name)
+
+		if op == nil {
+			return nil, fmt.Errorf("clip: unknown input %s", clean.Log(name))
+		}
+
+		feeds[op.Output(0)] = tensor
+	}
+
+	outputOp := m.model.Graph.Operation(output)
+
+	if outputOp == nil {
+		return nil, fmt.Errorf("clip: unknown output %s", clean.Log(output))
+	}
+
+	result, err := m.model.Session.Run(feeds, []tf.Output{outputOp.Output(0)}, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("clip: %s (run inference)", err)
+	} else if len(result) < 1 {
+		return nil, fmt.Errorf("clip: inference failed, no output")
+	}
+
+	values, ok := result[0].Value().([][]float32)
+
+	if !ok || len(values) < 1 || len(values[0]) == 0 {
+		return nil, fmt.Errorf("clip: inference failed, invalid output")
+	}
+
+	return NewEmbedding(values[0]), nil
+}
+
+// ModelLoaded tests if the TensorFlow model is loaded.
+func (m *Model) ModelLoaded() bool {
+	return m.model != nil
+}
+
+func (m *Model) loadModel() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.ModelLoaded() {
+		return nil
+	}
+
+	log.Infof("clip: loading %s", clean.Log(filepath.Base(m.modelPath)))
+
+	tokenizer, err := NewTokenizer(filepath.Join(m.modelPath, "merges.txt"))
+
+	if err != nil {
+		return err
+	}
+
+	model, err := tf.LoadSavedModel(m.modelPath, m.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	m.model = model
+	m.tokenizer = tokenizer
+
+	return nil
+}
+
+// imageToTensor converts an image to a normalized tensor in channels first order.
+func imageToTensor(img image.Image) (*tf.Tensor, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("clip: image width and height must be > 0")
+	}
+
+	var pixels [1][3][][]float32
+
+	for c := 0; c < 3; c++ {
+		pixels[0][c] = make([][]float32, height)
+
+		for y := 0; y < height; y++ {
+			pixels[0][c][y] = make([]float32, width)
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+
+			for c, v := range [3]uint32{r, g, b} {
+				pixels[0][c][y][x] = (float32(v>>8)/255 - imageMean[c]) / imageStd[c]
+			}
+		}
+	}
+
+	return tf.NewTensor(pixels)
+}
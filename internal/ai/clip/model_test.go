@@ -0,0 +1,59 @@
+package clip
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_Disabled(t *testing.T) {
+	m := NewModel("testdata/clip", true)
+
+	assert.True(t, m.Disabled())
+	assert.Equal(t, "clip", m.Name())
+	assert.NoError(t, m.Init())
+
+	_, err := m.Text("dog on a beach")
+	assert.Error(t, err)
+
+	_, err = m.File("testdata/missing.jpg")
+	assert.Error(t, err)
+
+	var none *Model
+
+	assert.True(t, none.Disabled())
+	assert.Equal(t, "", none.Name())
+}
+
+func TestModel_Init(t *testing.T) {
+	m := NewModel("testdata/missing", false)
+
+	assert.False(t, m.Disabled())
+	assert.Error(t, m.Init())
+	assert.False(t, m.ModelLoaded())
+}
+
+func TestModel_Embed(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+
+	t.Run("Disabled", func(t *testing.T) {
+		m := NewModel("testdata/clip", true)
+
+		_, err := m.Embed(img)
+		assert.Error(t, err)
+	})
+	t.Run("NoImage", func(t *testing.T) {
+		m := NewModel("testdata/missing", false)
+
+		_, err := m.Embed(nil)
+		assert.Error(t, err)
+		assert.False(t, m.ModelLoaded())
+	})
+	t.Run("ModelNotFound", func(t *testing.T) {
+		m := NewModel("testdata/missing", false)
+
+		_, err := m.Embed(img)
+		assert.Error(t, err)
+	})
+}
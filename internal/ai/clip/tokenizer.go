@@ -0,0 +1,221 @@
+package clip
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	startOfText = "<|startoftext|>"
+	endOfText   = "<|endoftext|>"
+	endOfWord   = "</w>"
+)
+
+// maxMerges is the number of merges used by the original CLIP vocabulary.
+const maxMerges = 49152 - 256 - 2
+
+// tokenPattern splits text into the words and symbols that are encoded separately.
+var tokenPattern = regexp.MustCompile(`<\|startoftext\|>|<\|endoftext\|>|'s|'t|'re|'ve|'m|'ll|'d|\p{L}+|\p{N}|[^\s\p{L}\p{N}]+`)
+
+// Tokenizer converts text into the byte pair encoding (BPE) token ids expected by the CLIP text encoder.
+type Tokenizer struct {
+	encoder     map[string]int32
+	ranks       map[[2]string]int
+	byteEncoder [256]rune
+}
+
+// NewTokenizer returns a new tokenizer with the BPE merges in the specified file,
+// e.g. "bpe_simple_vocab_16e6.txt" from the original CLIP repository.
+func NewTokenizer(mergesFile string) (*Tokenizer, error) {
+	f, err := os.Open(mergesFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var merges [][2]string
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Skip version header and empty lines.
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		} else if len(merges) >= maxMerges {
+			break
+		}
+
+		pair := strings.Fields(line)
+
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("invalid merge %q in %s", line, mergesFile)
+		}
+
+		merges = append(merges, [2]string{pair[0], pair[1]})
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newTokenizer(merges), nil
+}
+
+// newTokenizer creates the vocabulary from the byte alphabet and the specified merges.
+func newTokenizer(merges [][2]string) *Tokenizer {
+	t := &Tokenizer{
+		encoder: make(map[string]int32, 512+len(merges)+2),
+		ranks:   make(map[[2]string]int, len(merges)),
+	}
+
+	alphabet := byteAlphabet()
+
+	for i, b := range alphabet {
+		t.byteEncoder[b] = rune(byteRune(b, i))
+	}
+
+	var vocab []string
+
+	for _, b := range alphabet {
+		vocab = append(vocab, string(t.byteEncoder[b]))
+	}
+
+	for _, b := range alphabet {
+		vocab = append(vocab, string(t.byteEncoder[b])+endOfWord)
+	}
+
+	for i, m := range merges {
+		vocab = append(vocab, m[0]+m[1])
+		t.ranks[m] = i
+	}
+
+	vocab = append(vocab, startOfText, endOfText)
+
+	for i, v := range vocab {
+		t.encoder[v] = int32(i)
+	}
+
+	return t
+}
+
+// Encode returns the token ids of the specified text, padded or truncated to the context length.
+func (t *Tokenizer) Encode(text string) []int32 {
+	result := make([]int32, ContextLength)
+
+	tokens := []int32{t.encoder[startOfText]}
+
+	for _, word := range tokenPattern.FindAllString(cleanText(text), -1) {
+		var encoded strings.Builder
+
+		for _, b := range []byte(word) {
+			encoded.WriteRune(t.byteEncoder[b])
+		}
+
+		for _, token := range t.bpe(encoded.String()) {
+			if id, ok := t.encoder[token]; ok {
+				tokens = append(tokens, id)
+			}
+		}
+	}
+
+	// Truncate tokens so that the end of text marker fits into the context.
+	if len(tokens) > ContextLength-1 {
+		tokens = tokens[:ContextLength-1]
+	}
+
+	tokens = append(tokens, t.encoder[endOfText])
+
+	copy(result, tokens)
+
+	return result
+}
+
+// bpe merges the characters of a word into the known tokens with the lowest rank.
+func (t *Tokenizer) bpe(token string) []string {
+	var word []string
+
+	for _, r := range token {
+		word = append(word, string(r))
+	}
+
+	if len(word) == 0 {
+		return word
+	}
+
+	word[len(word)-1] += endOfWord
+
+	for len(word) > 1 {
+		best, bestRank := [2]string{}, math.MaxInt
+
+		for i := 0; i < len(word)-1; i++ {
+			pair := [2]string{word[i], word[i+1]}
+
+			if rank, ok := t.ranks[pair]; ok && rank < bestRank {
+				best, bestRank = pair, rank
+			}
+		}
+
+		if bestRank == math.MaxInt {
+			break
+		}
+
+		merged := make([]string, 0, len(word))
+
+		for i := 0; i < len(word); i++ {
+			if i < len(word)-1 && word[i] == best[0] && word[i+1] == best[1] {
+				merged = append(merged, best[0]+best[1])
+				i++
+			} else {
+				merged = append(merged, word[i])
+			}
+		}
+
+		word = merged
+	}
+
+	return word
+}
+
+// cleanText unescapes HTML entities, removes redundant whitespace, and converts the text to lowercase.
+func cleanText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(html.UnescapeString(text)), " "))
+}
+
+// byteAlphabet returns the bytes in the order of the CLIP vocabulary, starting with printable characters.
+func byteAlphabet() (result []byte) {
+	printable := make(map[int]bool, 256)
+
+	for _, r := range [][2]int{{'!', '~'}, {'¡', '¬'}, {'®', 'ÿ'}} {
+		for b := r[0]; b <= r[1]; b++ {
+			result = append(result, byte(b))
+			printable[b] = true
+		}
+	}
+
+	for b := 0; b < 256; b++ {
+		if !printable[b] {
+			result = append(result, byte(b))
+		}
+	}
+
+	return result
+}
+
+// byteRune returns the unicode character that represents a byte, so that whitespace
+// and control characters are mapped to visible characters.
+func byteRune(b byte, index int) int {
+	if printable := 188; index < printable {
+		return int(b)
+	} else {
+		return 256 + index - printable
+	}
+}
@@ -0,0 +1,70 @@
+package clip
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTokenizer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tokenizer, err := NewTokenizer("testdata/merges.txt")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 256 byte tokens, 256 end of word tokens, 4 merges, and 2 markers.
+		assert.Len(t, tokenizer.encoder, 518)
+		assert.Equal(t, int32(516), tokenizer.encoder[startOfText])
+		assert.Equal(t, int32(517), tokenizer.encoder[endOfText])
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewTokenizer("testdata/missing.txt")
+		assert.Error(t, err)
+	})
+}
+
+func TestTokenizer_Encode(t *testing.T) {
+	tokenizer, err := NewTokenizer("testdata/merges.txt")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Words", func(t *testing.T) {
+		ids := tokenizer.Encode("A  Dog")
+
+		assert.Len(t, ids, ContextLength)
+		assert.Equal(t, []int32{516, 320, 513, 517, 0}, ids[:5])
+	})
+	t.Run("Partial", func(t *testing.T) {
+		ids := tokenizer.Encode("cats")
+
+		// "ca" + "t" + "s</w>", as the "ca t</w>" merge only applies at the end of a word.
+		assert.Equal(t, []int32{516, 514, 83, 338, 517, 0}, ids[:6])
+	})
+	t.Run("Empty", func(t *testing.T) {
+		ids := tokenizer.Encode("")
+
+		assert.Equal(t, []int32{516, 517, 0}, ids[:3])
+	})
+	t.Run("Truncate", func(t *testing.T) {
+		ids := tokenizer.Encode(strings.Repeat("dog ", 100))
+
+		assert.Len(t, ids, ContextLength)
+		assert.Equal(t, int32(513), ids[ContextLength-2])
+		assert.Equal(t, int32(517), ids[ContextLength-1])
+	})
+}
+
+func TestByteAlphabet(t *testing.T) {
+	alphabet := byteAlphabet()
+
+	assert.Len(t, alphabet, 256)
+	assert.Equal(t, byte('!'), alphabet[0])
+	assert.Equal(t, byte(0), alphabet[188])
+	assert.Equal(t, 'a', rune(byteRune('a', 64)))
+	assert.Equal(t, rune(256), rune(byteRune(0, 188)))
+}
@@ -11,6 +11,7 @@ const (
 	KidsFace
 	IgnoredFace
 	AmbiguousFace
+	PetFace
 )
 
 // RandomDist returns a distance threshold for matching RandomDEmbeddings.
@@ -0,0 +1,256 @@
+package object
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+
+	"github.com/photoprism/photoprism/internal/thumb/crop"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Detector is a wrapper for TensorFlow object detection models, e.g. SSD MobileNet trained on COCO.
+// The model folder must contain the exported SavedModel and a "labels.txt" file with one name per
+// class id, starting with class id 1.
+type Detector struct {
+	model     *tf.SavedModel
+	modelPath string
+	modelTags []string
+	labels    []string
+	disabled  bool
+	mutex     sync.Mutex
+}
+
+// NewDetector returns a new object detector.
+func NewDetector(modelPath string, disabled bool) *Detector {
+	return &Detector{modelPath: modelPath, modelTags: []string{"serve"}, disabled: disabled}
+}
+
+// Disabled checks if object detection is disabled.
+func (t *Detector) Disabled() bool {
+	return t == nil || t.disabled
+}
+
+// Init loads the model if object detection is not disabled.
+func (t *Detector) Init() error {
+	if t.Disabled() {
+		return nil
+	}
+
+	return t.loadModel()
+}
+
+// File returns the objects detected in a JPEG file.
+func (t *Detector) File(fileName string) (Objects, error) {
+	if t.Disabled() {
+		return Objects{}, nil
+	}
+
+	img, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Image(img)
+}
+
+// Image returns the objects detected in a JPEG image.
+func (t *Detector) Image(img []byte) (result Objects, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("objects: %s (inference panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if t.Disabled() {
+		return Objects{}, nil
+	}
+
+	if err = t.loadModel(); err != nil {
+		return nil, err
+	}
+
+	src, err := imaging.Decode(bytes.NewReader(img), imaging.AutoOrientation(true))
+
+	if err != nil {
+		return nil, err
+	}
+
+	tensor, err := imageToTensor(src)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := t.model.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			t.model.Graph.Operation("image_tensor").Output(0): tensor,
+		},
+		[]tf.Output{
+			t.model.Graph.Operation("detection_boxes").Output(0),
+			t.model.Graph.Operation("detection_scores").Output(0),
+			t.model.Graph.Operation("detection_classes").Output(0),
+		},
+		nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("objects: %s (run inference)", err)
+	} else if len(output) < 3 {
+		return nil, fmt.Errorf("objects: inference failed, no output")
+	}
+
+	boxes, _ := output[0].Value().([][][]float32)
+	scores, _ := output[1].Value().([][]float32)
+	classes, _ := output[2].Value().([][]float32)
+
+	if len(boxes) < 1 || len(scores) < 1 || len(classes) < 1 {
+		return nil, fmt.Errorf("objects: inference failed, invalid output")
+	}
+
+	return t.objects(boxes[0], scores[0], classes[0], src.Bounds().Dx()), nil
+}
+
+// objects converts the detection results to a list of objects, with the most likely objects first.
+func (t *Detector) objects(boxes [][]float32, scores []float32, classes []float32, width int) (result Objects) {
+	result = Objects{}
+
+	for i := 0; i < len(boxes) && i < len(scores) && i < len(classes); i++ {
+		score := int(math.Round(float64(scores[i]) * 100))
+
+		if score < ScoreThreshold || len(boxes[i]) < 4 {
+			continue
+		}
+
+		name := t.label(int(classes[i]))
+
+		if name == "" {
+			continue
+		}
+
+		// Boxes contain the relative top, left, bottom, and right coordinates.
+		top, left, bottom, right := boxes[i][0], boxes[i][1], boxes[i][2], boxes[i][3]
+		area := crop.NewArea(name, left, top, right-left, bottom-top)
+
+		result = append(result, Object{
+			Name:  name,
+			Score: score,
+			Area:  area,
+			Size:  int(area.W * float32(width)),
+		})
+	}
+
+	result.Sort()
+
+	if len(result) > MaxObjects {
+		result = result[:MaxObjects]
+	}
+
+	return result
+}
+
+// label returns the normalized name of the specified class id.
+func (t *Detector) label(class int) string {
+	if class < 1 || class > len(t.labels) {
+		return ""
+	}
+
+	name := strings.ToLower(strings.TrimSpace(t.labels[class-1]))
+
+	// Skip placeholders for unused class ids.
+	if strings.Trim(name, "?") == "" {
+		return ""
+	}
+
+	return name
+}
+
+// ModelLoaded tests if the TensorFlow model is loaded.
+func (t *Detector) ModelLoaded() bool {
+	return t.model != nil
+}
+
+func (t *Detector) loadModel() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.ModelLoaded() {
+		return nil
+	}
+
+	log.Infof("objects: loading %s", clean.Log(filepath.Base(t.modelPath)))
+
+	if err := t.loadLabels(filepath.Join(t.modelPath, "labels.txt")); err != nil {
+		return err
+	}
+
+	model, err := tf.LoadSavedModel(t.modelPath, t.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	t.model = model
+
+	return nil
+}
+
+func (t *Detector) loadLabels(fileName string) error {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	var labels []string
+
+	scanner := bufio.NewScanner(f)
+
+	// Labels are separated by newlines.
+	for scanner.Scan() {
+		labels = append(labels, scanner.Text())
+	}
+
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+
+	t.labels = labels
+
+	return nil
+}
+
+// imageToTensor converts an image to an uint8 tensor with the original pixel values.
+func imageToTensor(img image.Image) (*tf.Tensor, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("objects: image width and height must be > 0")
+	}
+
+	pixels := make([][][3]uint8, height)
+
+	for y := 0; y < height; y++ {
+		pixels[y] = make([][3]uint8, width)
+
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixels[y][x] = [3]uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)}
+		}
+	}
+
+	return tf.NewTensor([][][][3]uint8{pixels})
+}
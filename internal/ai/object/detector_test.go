@@ -0,0 +1,82 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetector_Disabled(t *testing.T) {
+	d := NewDetector("testdata", true)
+
+	assert.True(t, d.Disabled())
+	assert.NoError(t, d.Init())
+
+	result, err := d.File("testdata/missing.jpg")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	var none *Detector
+
+	assert.True(t, none.Disabled())
+}
+
+func TestDetector_Init(t *testing.T) {
+	d := NewDetector("testdata/missing", false)
+
+	assert.False(t, d.Disabled())
+	assert.Error(t, d.Init())
+	assert.False(t, d.ModelLoaded())
+}
+
+func TestDetector_LoadLabels(t *testing.T) {
+	d := NewDetector("testdata", false)
+
+	assert.NoError(t, d.loadLabels("testdata/labels.txt"))
+	assert.Len(t, d.labels, 5)
+	assert.Error(t, d.loadLabels("testdata/missing.txt"))
+}
+
+func TestDetector_Objects(t *testing.T) {
+	d := NewDetector("testdata", false)
+
+	if err := d.loadLabels("testdata/labels.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	boxes := [][]float32{
+		{0.1, 0.2, 0.5, 0.6},
+		{0.5, 0.5, 1, 1},
+		{0, 0, 1, 1},
+		{0, 0, 0.5, 0.5},
+		{0.2, 0.2, 0.4, 0.4},
+	}
+
+	scores := []float32{0.8, 0.95, 0.3, 0.9, 0.7}
+	classes := []float32{3, 1, 2, 4, 9}
+
+	result := d.objects(boxes, scores, classes, 1000)
+
+	// Low scores, placeholders, and unknown class ids are skipped.
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "person", result[0].Name)
+		assert.Equal(t, 95, result[0].Score)
+		assert.Equal(t, "car", result[1].Name)
+		assert.Equal(t, 80, result[1].Score)
+		assert.InDelta(t, 0.2, result[1].Area.X, 0.0001)
+		assert.InDelta(t, 0.1, result[1].Area.Y, 0.0001)
+		assert.InDelta(t, 0.4, result[1].Area.W, 0.0001)
+		assert.InDelta(t, 0.4, result[1].Area.H, 0.0001)
+		assert.Equal(t, 400, result[1].Size)
+	}
+
+	t.Run("MaxObjects", func(t *testing.T) {
+		maxObjects := MaxObjects
+		MaxObjects = 1
+
+		defer func() { MaxObjects = maxObjects }()
+
+		assert.Len(t, d.objects(boxes, scores, classes, 1000), 1)
+	})
+}
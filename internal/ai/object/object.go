@@ -0,0 +1,74 @@
+/*
+Package object detects objects in images and returns their names, confidence scores, and bounding boxes.
+
+Copyright (c) 2018 - 2024 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package object
+
+import (
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
+)
+
+var log = event.Log
+
+// ScoreThreshold is the minimum confidence score in percent for objects to be returned.
+var ScoreThreshold = 50
+
+// MaxObjects is the maximum number of objects returned for an image.
+var MaxObjects = 20
+
+// Object represents a detected object.
+type Object struct {
+	Name  string    `json:"name"`
+	Score int       `json:"score"`
+	Area  crop.Area `json:"area"`
+	Size  int       `json:"size"`
+}
+
+// Objects represents a list of detected objects.
+type Objects []Object
+
+// Sort orders the objects by score, starting with the most likely.
+func (o Objects) Sort() Objects {
+	sort.SliceStable(o, func(i, j int) bool {
+		return o[i].Score > o[j].Score
+	})
+
+	return o
+}
+
+// Names returns the unique object names.
+func (o Objects) Names() (names []string) {
+	found := make(map[string]bool, len(o))
+
+	for _, obj := range o {
+		if obj.Name != "" && !found[obj.Name] {
+			found[obj.Name] = true
+			names = append(names, obj.Name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,24 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjects_Sort(t *testing.T) {
+	objects := Objects{{Name: "car", Score: 60}, {Name: "person", Score: 90}, {Name: "bicycle", Score: 75}}
+
+	result := objects.Sort()
+
+	assert.Equal(t, "person", result[0].Name)
+	assert.Equal(t, "bicycle", result[1].Name)
+	assert.Equal(t, "car", result[2].Name)
+}
+
+func TestObjects_Names(t *testing.T) {
+	objects := Objects{{Name: "car"}, {Name: "person"}, {Name: "car"}, {Name: ""}}
+
+	assert.Equal(t, []string{"car", "person"}, objects.Names())
+	assert.Empty(t, Objects{}.Names())
+}
@@ -0,0 +1,105 @@
+/*
+Package pet provides thresholds and types for detecting pets such as dogs and cats, so they can be clustered and named like people.
+
+Copyright (c) 2018 - 2024 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package pet
+
+import (
+	"github.com/photoprism/photoprism/internal/ai/clip"
+	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
+)
+
+// Classes are the detected object names that are considered pets.
+var Classes = map[string]bool{
+	"dog": true,
+	"cat": true,
+}
+
+var ScoreThreshold = 60               // Min detection score in percent.
+var SizeThreshold = 80                // Min pet size in pixels.
+var ClusterDist = 0.5                 // Similarity distance threshold of pets forming a cluster core.
+var MatchDist = 0.2                   // Dist offset threshold for matching new pets with clusters.
+var ClusterCore = 3                   // Min number of pets forming a cluster core.
+var SampleThreshold = 2 * ClusterCore // Threshold for automatic clustering to start.
+
+// Pet represents a detected pet and the embedding used to recognize it.
+type Pet struct {
+	Name      string         `json:"name"`
+	Score     int            `json:"score"`
+	Size      int            `json:"size"`
+	Area      crop.Area      `json:"area"`
+	Embedding face.Embedding `json:"embedding,omitempty"`
+}
+
+// Pets represents a list of detected pets.
+type Pets []Pet
+
+// New returns a new pet based on the detected object and the image embedding of its area.
+// Note that the embedding must have the same number of values as face embeddings (512),
+// so that pets can be clustered and stored like faces.
+func New(o object.Object, e clip.Embedding) Pet {
+	embedding := make(face.Embedding, len(e))
+
+	for i := range e {
+		embedding[i] = float64(e[i])
+	}
+
+	return Pet{
+		Name:      o.Name,
+		Score:     o.Score,
+		Size:      o.Size,
+		Area:      o.Area,
+		Embedding: embedding,
+	}
+}
+
+// IsPet checks if the object name belongs to a pet.
+func IsPet(name string) bool {
+	return Classes[name]
+}
+
+// Filter returns the detected objects that are pets and large enough to be recognized.
+func Filter(objects object.Objects) (result object.Objects) {
+	for _, o := range objects {
+		if !IsPet(o.Name) || o.Score < ScoreThreshold || o.Size < SizeThreshold {
+			continue
+		}
+
+		result = append(result, o)
+	}
+
+	return result
+}
+
+// Embeddings returns the embeddings of the detected pets.
+func (p Pets) Embeddings() (result face.Embeddings) {
+	for _, pet := range p {
+		if len(pet.Embedding) > 0 {
+			result = append(result, pet.Embedding)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,60 @@
+package pet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
+)
+
+func TestNew(t *testing.T) {
+	o := object.Object{Name: "dog", Score: 90, Size: 300, Area: crop.NewArea("dog", 0.1, 0.2, 0.3, 0.4)}
+	e := clip.NewEmbedding([]float32{3, 4})
+
+	result := New(o, e)
+
+	assert.Equal(t, "dog", result.Name)
+	assert.Equal(t, 90, result.Score)
+	assert.Equal(t, 300, result.Size)
+	assert.Equal(t, o.Area, result.Area)
+	assert.Len(t, result.Embedding, 2)
+	assert.InEpsilon(t, 0.6, result.Embedding[0], 0.0001)
+	assert.InEpsilon(t, 0.8, result.Embedding[1], 0.0001)
+}
+
+func TestIsPet(t *testing.T) {
+	assert.True(t, IsPet("dog"))
+	assert.True(t, IsPet("cat"))
+	assert.False(t, IsPet("car"))
+	assert.False(t, IsPet(""))
+}
+
+func TestFilter(t *testing.T) {
+	objects := object.Objects{
+		{Name: "dog", Score: 90, Size: 300},
+		{Name: "cat", Score: 40, Size: 300},
+		{Name: "cat", Score: 80, Size: 50},
+		{Name: "person", Score: 99, Size: 400},
+		{Name: "cat", Score: 75, Size: 120},
+	}
+
+	result := Filter(objects)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "dog", result[0].Name)
+	assert.Equal(t, "cat", result[1].Name)
+	assert.Empty(t, Filter(nil))
+}
+
+func TestPets_Embeddings(t *testing.T) {
+	pets := Pets{
+		{Name: "dog", Embedding: []float64{0.6, 0.8}},
+		{Name: "cat"},
+	}
+
+	assert.Len(t, pets.Embeddings(), 1)
+	assert.Empty(t, Pets{}.Embeddings())
+}
@@ -67,6 +67,9 @@ func GetAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
 		}
 
 		c.JSON(http.StatusOK, a)
@@ -102,7 +105,23 @@ func CreateAlbum(router *gin.RouterGroup) {
 		albumMutex.Lock()
 		defer albumMutex.Unlock()
 
-		a := entity.NewUserAlbum(f.AlbumTitle, entity.AlbumManual, s.UserUID)
+		var a *entity.Album
+
+		// Create a smart album if a search filter has been specified.
+		if f.AlbumType == entity.AlbumSmart {
+			smart, err := entity.NewSmartAlbum(f.AlbumTitle, f.AlbumFilter, s.UserUID)
+
+			if err != nil {
+				log.Debugf("album: %s", err)
+				AbortBadRequest(c)
+				return
+			}
+
+			a = smart
+		} else {
+			a = entity.NewUserAlbum(f.AlbumTitle, entity.AlbumManual, s.UserUID)
+		}
+
 		a.AlbumFavorite = f.AlbumFavorite
 
 		// Existing album?
@@ -172,6 +191,9 @@ func UpdateAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
 		}
 
 		f, err := form.NewAlbum(a)
@@ -243,14 +265,17 @@ func DeleteAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
 		}
 
 		albumMutex.Lock()
 		defer albumMutex.Unlock()
 
-		// Regular, manually created album?
-		if a.IsDefault() {
-			// Soft delete manually created albums.
+		// Regular, manually created album or smart album?
+		if a.IsDefault() || a.IsSmart() {
+			// Soft delete albums created by users.
 			err = a.Delete()
 
 			// Also update album YAML backup.
@@ -317,6 +342,9 @@ func LikeAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
 		}
 
 		if err := a.Update("AlbumFavorite", true); err != nil {
@@ -367,6 +395,9 @@ func DislikeAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
 		}
 
 		if err = a.Update("AlbumFavorite", false); err != nil {
@@ -419,6 +450,9 @@ func CloneAlbums(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
 		}
 
 		var f form.Selection
@@ -427,6 +461,9 @@ func CloneAlbums(router *gin.RouterGroup) {
 		if err = c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if !canAccessAlbums(s, f.Albums) {
+			AbortForbidden(c)
+			return
 		}
 
 		var added []entity.PhotoAlbum
@@ -507,13 +544,23 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 		} else if !a.HasID() {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
+		} else if a.IsSmart() {
+			// Smart album contents are defined by their search filter.
+			Abort(c, http.StatusBadRequest, i18n.ErrUnsupported)
+			return
 		} else if f.Empty() {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !s.CanAccessPhotos(f.Photos) {
+			AbortForbidden(c)
+			return
 		}
 
 		// Fetch selection from index.
-		photos, err := query.SelectedPhotos(f)
+		photos, err := selectedPhotos(s, f)
 
 		if err != nil {
 			log.Errorf("album: %s", err)
@@ -602,6 +649,9 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !s.CanAccessPhotos(f.Photos) {
+			AbortForbidden(c)
+			return
 		}
 
 		// Get sanitized album UID from request path.
@@ -622,6 +672,13 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 		} else if !a.HasID() {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
+		} else if a.IsSmart() {
+			// Smart album contents are defined by their search filter.
+			Abort(c, http.StatusBadRequest, i18n.ErrUnsupported)
+			return
 		}
 
 		removed := a.RemovePhotos(f.Photos)
@@ -644,3 +701,18 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "album": a, "photos": f.Photos, "removed": removed})
 	})
 }
+
+// canAccessAlbums checks if the session user may access all albums with the specified UIDs.
+func canAccessAlbums(s *entity.Session, uids []string) bool {
+	if !s.UserLibrary() {
+		return true
+	}
+
+	for _, uid := range uids {
+		if a, err := query.AlbumByUID(clean.UID(uid)); err == nil && !s.CanAccessAlbum(&a) {
+			return false
+		}
+	}
+
+	return true
+}
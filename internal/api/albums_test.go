@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/query"
 
 	"github.com/stretchr/testify/assert"
@@ -48,6 +50,20 @@ func TestCreateAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": 333, "Description": "Created via unit test", "Notes": "", "Favorite": true}`)
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("Smart", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Dogs", "Type": "smart", "Filter": "country:de label:dog"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "smart", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "label:dog country:de", gjson.Get(r.Body.String(), "Filter").String())
+	})
+	t.Run("SmartInvalidFilter", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Invalid", "Type": "smart", "Filter": "foo:bar"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 }
 func TestUpdateAlbum(t *testing.T) {
 	app, router, _ := NewApiTest()
@@ -232,6 +248,19 @@ func TestAddPhotosToAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/photos", `{"photos": ["ps6sg6be2lvl0yxx"]}`)
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
+	t.Run("SmartAlbum", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		AddPhotosToAlbum(router)
+		RemovePhotosFromAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Add", "Type": "smart", "Filter": "public:true"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		smartUid := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/"+smartUid+"/photos", `{"photos": ["ps6sg6be2lvl0y12"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		r = PerformRequestWithBody(app, "DELETE", "/api/v1/albums/"+smartUid+"/photos", `{"photos": ["ps6sg6be2lvl0y12"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 }
 
 func TestRemovePhotosFromAlbum(t *testing.T) {
@@ -332,3 +361,76 @@ func TestCloneAlbums(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestAlbums_UserLibrary(t *testing.T) {
+	app, router, conf := NewApiTest()
+
+	conf.SetAuthMode(config.AuthModePasswd)
+	defer conf.SetAuthMode(config.AuthModePublic)
+
+	entity.LibraryMode = entity.LibraryUser
+	defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+	// Register routes.
+	GetAlbum(router)
+	UpdateAlbum(router)
+	DeleteAlbum(router)
+	LikeAlbum(router)
+	DislikeAlbum(router)
+	CloneAlbums(router)
+	AddPhotosToAlbum(router)
+	RemovePhotosFromAlbum(router)
+	BatchAlbumsDelete(router)
+	GetAlbumLinks(router)
+	CreateAlbumLink(router)
+
+	var before, after entity.Album
+
+	if err := entity.UnscopedDb().Where("album_uid = ?", "as6sg6bxpogaaba9").First(&before).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Bob is not a super admin and has not created the fixture albums.
+	authToken := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+	for _, req := range []struct{ method, path, body string }{
+		{http.MethodGet, "/api/v1/albums/as6sg6bxpogaaba9", ""},
+		{http.MethodPut, "/api/v1/albums/as6sg6bxpogaaba9", `{"Title": "Hijacked"}`},
+		{http.MethodDelete, "/api/v1/albums/as6sg6bxpogaaba9", ""},
+		{http.MethodPost, "/api/v1/albums/as6sg6bxpogaaba9/like", ""},
+		{http.MethodDelete, "/api/v1/albums/as6sg6bxpogaaba9/like", ""},
+		{http.MethodPost, "/api/v1/albums/as6sg6bxpogaaba9/clone", `{"albums": ["as6sg6bxpogaaba8"]}`},
+		{http.MethodPost, "/api/v1/albums/as6sg6bxpogaaba9/photos", `{"photos": ["ps6sg6be2lvl0yh8"]}`},
+		{http.MethodDelete, "/api/v1/albums/as6sg6bxpogaaba9/photos", `{"photos": ["ps6sg6be2lvl0yh8"]}`},
+		{http.MethodPost, "/api/v1/batch/albums/delete", `{"albums": ["as6sg6bxpogaaba9"]}`},
+		{http.MethodGet, "/api/v1/albums/as6sg6bxpogaaba9/links", ""},
+		{http.MethodPost, "/api/v1/albums/as6sg6bxpogaaba9/links", `{"Expires": 0}`},
+	} {
+		t.Run(req.method+req.path, func(t *testing.T) {
+			r := AuthenticatedRequestWithBody(app, req.method, req.path, req.body, authToken)
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		})
+	}
+
+	// The album must not have been changed.
+	if err := entity.UnscopedDb().Where("album_uid = ?", "as6sg6bxpogaaba9").First(&after).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, before.AlbumTitle, after.AlbumTitle)
+	assert.Equal(t, before.AlbumFavorite, after.AlbumFavorite)
+	assert.Equal(t, before.DeletedAt, after.DeletedAt)
+
+	t.Run("Owner", func(t *testing.T) {
+		album := entity.NewUserAlbum("Bob's Album", entity.AlbumManual, entity.UserFixtures.Pointer("bob").UserUID)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer album.DeletePermanently()
+
+		r := AuthenticatedRequest(app, http.MethodGet, "/api/v1/albums/"+album.AlbumUID, authToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
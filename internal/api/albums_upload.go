@@ -0,0 +1,274 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/i18n"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// UploadAlbumFiles adds files uploaded by visitors of a share link to a staging folder,
+// from where they can be imported and added to the shared album once they have been reviewed.
+//
+//	@Summary	uploads files to a shared album
+//	@Id			UploadAlbumFiles
+//	@Tags		Albums, Files
+//	@Produce	json
+//	@Success	200						{object}	i18n.Response
+//	@Failure	400,401,403,413,415,429	{object}	i18n.Response
+//	@Param		uid						path		string	true	"album uid"
+//	@Param		token					path		string	true	"upload token"
+//	@Router		/api/v1/albums/{uid}/upload/{token} [post]
+func UploadAlbumFiles(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/upload/:token", func(c *gin.Context) {
+		conf := get.Config()
+
+		// Abort in read-only mode or if uploads by visitors are disabled.
+		if conf.ReadOnly() || conf.ShareUploadLimit() <= 0 {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		s := Auth(c, acl.ResourceAlbums, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		// Uploads require a share link or team grant that allows contributing files.
+		shareUid, shareRef := albumUploadShare(s, uid)
+
+		if shareUid == "" {
+			event.AuditErr([]string{ClientIP(c), "session %s", "upload files to album %s", "share does not permit uploads"}, s.RefID, clean.Log(uid))
+			AbortForbidden(c)
+			return
+		}
+
+		start := time.Now()
+		token := clean.Token(c.Param("token"))
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		files := f.File["files"]
+		byteLimit := conf.ShareUploadByteLimit()
+
+		// Limit the number of files per request.
+		if len(files) > config.ShareUploadFiles {
+			log.Infof("upload: %s exceeds the limit of %d files", english.Plural(len(files), "file", "files"), config.ShareUploadFiles)
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrFileTooLarge)
+			return
+		}
+
+		// Check file types and sizes before saving any files.
+		for _, file := range files {
+			fileName := filepath.Base(file.Filename)
+
+			if !media.MainFile(fileName) {
+				log.Infof("upload: %s has an unsupported file type", clean.Log(fileName))
+				Abort(c, http.StatusUnsupportedMediaType, i18n.ErrUnsupportedType)
+				return
+			} else if file.Size > byteLimit {
+				log.Infof("upload: %s exceeds the size limit of %s", clean.Log(fileName), english.Plural(conf.ShareUploadLimit(), "megabyte", "megabytes"))
+				Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrFileTooLarge)
+				return
+			}
+		}
+
+		var uploads []string
+
+		// Compose staging path for the share.
+		uploadDir, err := conf.ShareUploadPath(shareUid, s.RefID+token)
+
+		if err != nil {
+			log.Errorf("upload: failed to create storage folder (%s)", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		// Limit the number and total size of files that have not been imported yet,
+		// including those uploaded with other requests for the same share.
+		fileCount, totalSize := shareUploadUsage(filepath.Dir(uploadDir))
+
+		for _, file := range files {
+			fileCount++
+			totalSize += file.Size
+		}
+
+		if fileCount > config.ShareUploadFiles || totalSize > conf.ShareUploadTotalByteLimit() {
+			log.Infof("upload: share %s exceeds the limit of %d files or %s", clean.Log(shareRef), config.ShareUploadFiles, english.Plural(conf.ShareUploadLimit()*config.ShareUploadTotal, "megabyte", "megabytes"))
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrFileTooLarge)
+			return
+		}
+
+		// Save uploaded files.
+		for _, file := range files {
+			fileName := filepath.Base(file.Filename)
+			filePath := path.Join(uploadDir, fileName)
+
+			if err = c.SaveUploadedFile(file, filePath); err != nil {
+				log.Errorf("upload: failed saving file %s", clean.Log(fileName))
+				Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+				return
+			} else {
+				log.Debugf("upload: saved file %s", clean.Log(fileName))
+			}
+
+			uploads = append(uploads, filePath)
+		}
+
+		// Check if uploaded files are safe.
+		if !conf.UploadNSFW() && deleteOffensiveUploads(uploads) {
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		elapsed := int(time.Since(start).Seconds())
+
+		msg := i18n.Msg(i18n.MsgFilesUploadedIn, len(files), elapsed)
+
+		log.Infof("upload: %s to album %s via share %s", msg, clean.Log(uid), clean.Log(shareRef))
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
+
+// ProcessAlbumUpload imports the files uploaded by visitors of a share link and adds them to
+// the shared album, where they become visible once they have been approved in review.
+//
+//	@Summary	imports the files uploaded to a shared album
+//	@Id			ProcessAlbumUpload
+//	@Tags		Albums, Files
+//	@Produce	json
+//	@Success	200					{object}	i18n.Response
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string	true	"album uid"
+//	@Param		token				path		string	true	"upload token"
+//	@Router		/api/v1/albums/{uid}/upload/{token} [put]
+func ProcessAlbumUpload(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/upload/:token", func(c *gin.Context) {
+		conf := get.Config()
+
+		if conf.ReadOnly() || conf.ShareUploadLimit() <= 0 {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		s := Auth(c, acl.ResourceAlbums, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		// Uploads require a share link or team grant that allows contributing files.
+		shareUid, _ := albumUploadShare(s, uid)
+
+		if shareUid == "" {
+			AbortForbidden(c)
+			return
+		}
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		start := time.Now()
+		token := clean.Token(c.Param("token"))
+		uploadPath, err := conf.ShareUploadPath(shareUid, s.RefID+token)
+
+		if err != nil {
+			log.Errorf("upload: failed to create storage folder (%s)", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		// Import uploaded files and add them to the shared album for review.
+		opt := photoprism.ImportOptionsShareUpload(uploadPath, conf.ImportDest(), uid)
+
+		// Set user UID if known.
+		if s.UserUID != "" {
+			opt.UID = s.UserUID
+		}
+
+		imported := get.Import().Start(opt)
+
+		// Delete empty import directory.
+		if fs.DirIsEmpty(uploadPath) {
+			if err := os.Remove(uploadPath); err != nil {
+				log.Errorf("upload: failed to delete empty folder %s: %s", clean.Log(uploadPath), err)
+			} else {
+				log.Infof("upload: deleted empty folder %s", clean.Log(uploadPath))
+			}
+		}
+
+		if n := len(imported); n == 0 {
+			log.Infof("upload: found no new files to import from %s", clean.Log(uploadPath))
+		} else {
+			log.Infof("upload: imported %s for review in album %s", english.Plural(n, "file", "files"), clean.Log(uid))
+		}
+
+		log.Debugf("upload: processed files in %s", time.Since(start))
+
+		// Update the review count.
+		UpdateClientConfig()
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: i18n.Msg(i18n.MsgUploadProcessed)})
+	})
+}
+
+// albumUploadShare returns the UID and reference of the share that permits uploads to the specified album,
+// or empty strings if there is none. Since albums that are shared with a team have no share link, the files
+// uploaded by team members are staged in a folder named after the album.
+func albumUploadShare(s *entity.Session, uid string) (shareUid, shareRef string) {
+	if link := s.ShareLink(uid, entity.PermEdit); link != nil {
+		return link.LinkUID, link.RefID
+	} else if s.IsRegistered() && s.SharePerm(uid)&entity.PermUpload != 0 {
+		return uid, s.RefID
+	}
+
+	return "", ""
+}
+
+// shareUploadUsage returns the number and total size of the files in the staging folder of a share.
+func shareUploadUsage(dir string) (count int, size int64) {
+	_ = filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+
+		if info, infoErr := d.Info(); infoErr == nil {
+			count++
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return count, size
+}
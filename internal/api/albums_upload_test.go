@@ -0,0 +1,207 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/header"
+)
+
+func TestUploadAlbumFiles(t *testing.T) {
+	t.Run("Forbidden", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadAlbumFiles(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba8/upload/abc123456789", "{foo:123}")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("VisitorUnsupportedType", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		UploadAlbumFiles(router)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("files", "notes.txt")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = part.Write([]byte("hello"))
+		_ = writer.Close()
+
+		req, _ := http.NewRequest("POST", "/api/v1/albums/as6sg6bxpogaaba8/upload/abc123456789", body)
+		req.Header.Set(header.ContentType, writer.FormDataContentType())
+		sess := entity.SessionFixtures.Get("visitor")
+		header.SetAuthorization(req, sess.AuthToken())
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+	t.Run("VisitorTooManyFiles", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		UploadAlbumFiles(router)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+
+		for i := 0; i <= config.ShareUploadFiles; i++ {
+			part, err := writer.CreateFormFile("files", fmt.Sprintf("photo%d.jpg", i))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _ = part.Write([]byte("hello"))
+		}
+
+		_ = writer.Close()
+
+		req, _ := http.NewRequest("POST", "/api/v1/albums/as6sg6bxpogaaba8/upload/abc123456789", body)
+		req.Header.Set(header.ContentType, writer.FormDataContentType())
+		sess := entity.SessionFixtures.Get("visitor")
+		header.SetAuthorization(req, sess.AuthToken())
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+	t.Run("VisitorTotalSizeExceeded", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		UploadAlbumFiles(router)
+
+		sess := entity.SessionFixtures.Get("visitor")
+		link := sess.ShareLink("as6sg6bxpogaaba8", entity.PermEdit)
+
+		if link == nil {
+			t.Fatal("share link not found")
+		}
+
+		// Create a file that was uploaded with another request and has not been imported yet.
+		uploadDir, err := conf.ShareUploadPath(link.LinkUID, "other-request")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(filepath.Dir(uploadDir))
+
+		if err = os.WriteFile(filepath.Join(uploadDir, "pending.jpg"), []byte("hello"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		} else if err = os.Truncate(filepath.Join(uploadDir, "pending.jpg"), conf.ShareUploadTotalByteLimit()); err != nil {
+			t.Fatal(err)
+		}
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("files", "photo.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = part.Write([]byte("hello"))
+		_ = writer.Close()
+
+		req, _ := http.NewRequest("POST", "/api/v1/albums/as6sg6bxpogaaba8/upload/abc123456789", body)
+		req.Header.Set(header.ContentType, writer.FormDataContentType())
+		header.SetAuthorization(req, sess.AuthToken())
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+	t.Run("Team", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		UploadAlbumFiles(router)
+
+		team := entity.NewTeam("Upload Team", "")
+
+		if err := team.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer team.Delete()
+
+		if err := team.AddMember(entity.UserFixtures.Pointer("friend")); err != nil {
+			t.Fatal(err)
+		}
+
+		upload := func(authToken string) int {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			part, err := writer.CreateFormFile("files", "notes.txt")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _ = part.Write([]byte("hello"))
+			_ = writer.Close()
+
+			req, _ := http.NewRequest("POST", "/api/v1/albums/as6sg6bxpogaaba8/upload/abc123456789", body)
+			req.Header.Set(header.ContentType, writer.FormDataContentType())
+			header.SetAuthorization(req, authToken)
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+
+			return w.Code
+		}
+
+		authToken := AuthenticateUser(app, router, "friend", "!Friend321")
+
+		// Team members may only upload files if the team has upload permission.
+		if err := team.Share("as6sg6bxpogaaba8", entity.TeamPerm("comment")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusForbidden, upload(authToken))
+
+		if err := team.Share("as6sg6bxpogaaba8", entity.TeamPerm("upload")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, upload(authToken))
+	})
+	t.Run("VisitorForbidden", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		UploadAlbumFiles(router)
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba7/upload/abc123456789", "{foo:123}", sess.AuthToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestProcessAlbumUpload(t *testing.T) {
+	t.Run("Forbidden", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ProcessAlbumUpload(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/as6sg6bxpogaaba8/upload/abc123456789", "{}")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -22,3 +22,21 @@ func InvalidPreviewToken(c *gin.Context) bool {
 func InvalidDownloadToken(c *gin.Context) bool {
 	return entity.InvalidDownloadToken(clean.UrlToken(c.Query("t")))
 }
+
+// DownloadSession returns the session that the download token found in the request belongs to,
+// or nil if the token is not bound to a session, e.g. because it was set in the config options.
+func DownloadSession(c *gin.Context) *entity.Session {
+	id := entity.DownloadToken.Get(clean.UrlToken(c.Query("t")))
+
+	if id == "" || id == entity.TokenConfig {
+		return nil
+	}
+
+	sess, err := entity.FindSession(id)
+
+	if err != nil {
+		return nil
+	}
+
+	return sess
+}
@@ -50,13 +50,16 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !s.CanAccessPhotos(f.Photos) {
+			AbortForbidden(c)
+			return
 		}
 
 		log.Infof("photos: archiving %s", clean.Log(f.String()))
 
 		if get.Config().SidecarYaml() {
 			// Fetch selection from index.
-			photos, err := query.SelectedPhotos(f)
+			photos, err := selectedPhotos(s, f)
 
 			if err != nil {
 				AbortEntityNotFound(c)
@@ -120,13 +123,16 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !s.CanAccessPhotos(f.Photos) {
+			AbortForbidden(c)
+			return
 		}
 
 		log.Infof("photos: restoring %s", clean.Log(f.String()))
 
 		if get.Config().SidecarYaml() {
 			// Fetch selection from index.
-			photos, err := query.SelectedPhotos(f)
+			photos, err := selectedPhotos(s, f)
 
 			if err != nil {
 				AbortEntityNotFound(c)
@@ -189,12 +195,15 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !s.CanAccessPhotos(f.Photos) {
+			AbortForbidden(c)
+			return
 		}
 
 		log.Infof("photos: approving %s", clean.Log(f.String()))
 
 		// Fetch selection from index.
-		photos, err := query.SelectedPhotos(f)
+		photos, err := selectedPhotos(s, f)
 
 		if err != nil {
 			AbortEntityNotFound(c)
@@ -220,6 +229,62 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 	})
 }
 
+// BatchPhotosTime shifts the date and time of multiple photos, e.g. to correct a wrong camera clock.
+//
+//	@Summary	shifts the date and time of multiple photos, e.g. to correct a wrong camera clock
+//	@Id			BatchPhotosTime
+//	@Tags		Photos
+//	@Produce	json
+//	@Success	200					{object}	i18n.Response
+//	@Failure	400,401,403,429		{object}	i18n.Response
+//	@Param		photos				body		form.TimeShift	true	"Photo Selection and Time Offset"
+//	@Router		/api/v1/batch/photos/time [post]
+func BatchPhotosTime(router *gin.RouterGroup) {
+	router.POST("/batch/photos/time", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.TimeShift
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Empty() && f.Serial == "" {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if !s.CanAccessPhotos(f.Photos) || f.RefUID != "" && !s.CanAccessPhotos([]string{f.RefUID}) {
+			AbortForbidden(c)
+			return
+		}
+
+		if f.Empty() {
+			log.Infof("photos: shifting date and time of pictures taken with camera %s", clean.Log(f.Serial))
+		} else {
+			log.Infof("photos: shifting date and time of %s", clean.Log(f.String()))
+		}
+
+		// Apply time offset and update sidecar files.
+		photos, err := get.TimeShift().Start(f, s)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		UpdateClientConfig()
+
+		event.EntitiesUpdated("photos", photos)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
+
 // BatchAlbumsDelete permanently removes multiple albums.
 //
 //	@Summary	permanently removes multiple albums
@@ -268,6 +333,14 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 			return
 		}
 
+		// Abort if the session user may not access all selected albums.
+		for i := range albums {
+			if !s.CanAccessAlbum(&albums[i]) {
+				AbortForbidden(c)
+				return
+			}
+		}
+
 		deleted := 0
 		conf := get.Config()
 
@@ -321,6 +394,9 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !s.CanAccessPhotos(f.Photos) {
+			AbortForbidden(c)
+			return
 		}
 
 		log.Infof("photos: updating private flag for %s", clean.Log(f.String()))
@@ -336,7 +412,7 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		logWarn("index", entity.UpdateCounts())
 
 		// Fetch selection from index.
-		if photos, err := query.SelectedPhotos(f); err == nil {
+		if photos, err := selectedPhotos(s, f); err == nil {
 			for _, p := range photos {
 				SaveSidecarYaml(&p)
 			}
@@ -453,8 +529,12 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 			return
 		} else if f.All {
 			photos, err = query.ArchivedPhotos(1000000, 0)
+			photos = s.AccessiblePhotos(photos)
+		} else if !s.CanAccessPhotos(f.Photos) {
+			AbortForbidden(c)
+			return
 		} else {
-			photos, err = query.SelectedPhotos(f)
+			photos, err = selectedPhotos(s, f)
 		}
 
 		// Abort if the query failed or no photos were found.
@@ -507,3 +587,14 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPermanentlyDeleted))
 	})
 }
+
+// selectedPhotos returns the selected pictures that the session user may access.
+func selectedPhotos(s *entity.Session, f form.Selection) (entity.Photos, error) {
+	photos, err := query.SelectedPhotos(f)
+
+	if err != nil {
+		return photos, err
+	}
+
+	return s.AccessiblePhotos(photos), nil
+}
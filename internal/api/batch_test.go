@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/i18n"
 )
 
@@ -176,6 +178,60 @@ func TestBatchPhotosPrivate(t *testing.T) {
 	})
 }
 
+func TestBatchPhotosTime(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		// Restore original values.
+		orig := entity.PhotoFixtures.Get("Photo19")
+		defer func() { _ = orig.SaveTakenAt() }()
+
+		// Register routes.
+		GetPhoto(router)
+		BatchPhotosTime(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6bexxvl0yh0")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "2008-01-01T00:00:00Z", gjson.Get(r.Body.String(), "TakenAtLocal").String())
+
+		r2 := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["ps6sg6bexxvl0yh0"], "offset": "3h"}`)
+		val2 := gjson.Get(r2.Body.String(), "message")
+		assert.Equal(t, i18n.Msg(i18n.MsgChangesSaved), val2.String())
+		assert.Equal(t, http.StatusOK, r2.Code)
+
+		r3 := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6bexxvl0yh0")
+		assert.Equal(t, http.StatusOK, r3.Code)
+		assert.Equal(t, "2008-01-01T03:00:00Z", gjson.Get(r3.Body.String(), "TakenAtLocal").String())
+		assert.Equal(t, entity.SrcBatch, gjson.Get(r3.Body.String(), "TakenSrc").String())
+
+		r4 := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["ps6sg6bexxvl0yh0"], "refUID": "ps6sg6bexxvl0yh0", "refTime": "2008-01-01 00:00:00"}`)
+		assert.Equal(t, http.StatusOK, r4.Code)
+
+		r5 := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6bexxvl0yh0")
+		assert.Equal(t, "2008-01-01T00:00:00Z", gjson.Get(r5.Body.String(), "TakenAtLocal").String())
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTime(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": [], "offset": "1h"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTime(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["ps6sg6bexxvl0yh0"], "offset": "one hour"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTime(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
 func TestBatchLabelsDelete(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -275,3 +331,79 @@ func TestBatchPhotosDelete(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestBatchPhotos_UserLibrary(t *testing.T) {
+	app, router, conf := NewApiTest()
+
+	conf.SetAuthMode(config.AuthModePasswd)
+	defer conf.SetAuthMode(config.AuthModePublic)
+
+	entity.LibraryMode = entity.LibraryUser
+	defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+	// Register routes.
+	BatchPhotosArchive(router)
+	BatchPhotosRestore(router)
+	BatchPhotosApprove(router)
+	BatchPhotosPrivate(router)
+	BatchPhotosDelete(router)
+	BatchPhotosTime(router)
+	AddPhotosToAlbum(router)
+	RemovePhotosFromAlbum(router)
+
+	var before, after entity.Photo
+
+	if err := entity.UnscopedDb().Where("photo_uid = ?", "ps6sg6be2lvl0yh8").First(&before).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Bob is not a super admin and does not own the fixture pictures.
+	authToken := AuthenticateUser(app, router, "bob", "Bobbob123!")
+	selection := `{"photos": ["ps6sg6be2lvl0yh8"]}`
+
+	for _, req := range []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/batch/photos/archive"},
+		{http.MethodPost, "/api/v1/batch/photos/restore"},
+		{http.MethodPost, "/api/v1/batch/photos/approve"},
+		{http.MethodPost, "/api/v1/batch/photos/private"},
+		{http.MethodPost, "/api/v1/batch/photos/delete"},
+		{http.MethodPost, "/api/v1/batch/photos/time"},
+		{http.MethodPost, "/api/v1/albums/as6sg6bxpogaaba8/photos"},
+		{http.MethodDelete, "/api/v1/albums/as6sg6bxpogaaba8/photos"},
+	} {
+		t.Run(req.path, func(t *testing.T) {
+			r := AuthenticatedRequestWithBody(app, req.method, req.path, selection, authToken)
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		})
+	}
+
+	// The picture must not have been changed.
+	if err := entity.UnscopedDb().Where("photo_uid = ?", "ps6sg6be2lvl0yh8").First(&after).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, before.PhotoPrivate, after.PhotoPrivate)
+	assert.Equal(t, before.PhotoQuality, after.PhotoQuality)
+	assert.Equal(t, before.DeletedAt, after.DeletedAt)
+
+	t.Run("TimeShiftReference", func(t *testing.T) {
+		r := AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/batch/photos/time",
+			`{"serial": "123", "refUID": "ps6sg6be2lvl0yh8", "refTime": "2008-01-01 00:00:00"}`, authToken)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("TimeShiftSerial", func(t *testing.T) {
+		orig := entity.PhotoFixtures.Get("Photo05")
+
+		r := AuthenticatedRequestWithBody(app, http.MethodPost, "/api/v1/batch/photos/time", `{"serial": "123", "offset": "1h"}`, authToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var photo entity.Photo
+
+		if err := entity.UnscopedDb().Where("photo_uid = ?", orig.PhotoUID).First(&photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		// Pictures taken with the camera that Bob may not access must remain unchanged.
+		assert.Equal(t, orig.TakenAt.UTC(), photo.TakenAt.UTC())
+	})
+}
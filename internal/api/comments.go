@@ -0,0 +1,312 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// CommentAuthorGuest is the author name used for anonymous link visitors.
+const CommentAuthorGuest = "Guest"
+
+// GetPhotoComments returns the comments on a photo as JSON.
+//
+//	@Summary	returns the comments on a photo as JSON
+//	@Id			GetPhotoComments
+//	@Tags		Photos, Comments
+//	@Produce	json
+//	@Success	200				{object}	entity.Comments
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"photo uid"
+//	@Router		/api/v1/photos/{uid}/comments [get]
+func GetPhotoComments(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		// Visitors and other restricted users can only access shared content.
+		if _, ok := photoCommentShare(s, uid); !ok {
+			AbortForbidden(c)
+			return
+		}
+
+		if p, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		} else if !s.CanAccessPhoto(&p) {
+			AbortForbidden(c)
+			return
+		}
+
+		results, err := query.PhotoComments(uid)
+
+		if err != nil {
+			log.Errorf("comments: %s", err)
+			AbortUnexpectedError(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// CreatePhotoComment adds a comment to a photo and returns it as JSON.
+//
+//	@Summary	adds a comment to a photo and returns it as JSON
+//	@Id			CreatePhotoComment
+//	@Tags		Photos, Comments
+//	@Produce	json
+//	@Success	200					{object}	entity.Comment
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string			true	"photo uid"
+//	@Param		comment				body		form.Comment	true	"comment text and optional author name"
+//	@Router		/api/v1/photos/{uid}/comments [post]
+func CreatePhotoComment(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionComment)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		share, ok := photoCommentShare(s, uid)
+
+		// Visitors and other restricted users can only comment on shared content if permitted.
+		if !ok || !commentAllowed(s, acl.ResourcePhotos, share) {
+			AbortForbidden(c)
+			return
+		}
+
+		if p, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		} else if !s.CanAccessPhoto(&p) {
+			AbortForbidden(c)
+			return
+		}
+
+		var f form.Comment
+
+		if err := c.BindJSON(&f); err != nil || strings.TrimSpace(f.Text) == "" {
+			AbortBadRequest(c)
+			return
+		}
+
+		name, createdBy := commentAuthor(s, f.AuthorName)
+		m := entity.NewPhotoComment(uid, strings.TrimSpace(f.Text), name, createdBy)
+
+		if err := m.Create(); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.PublishEntities("comments", StatusCreated.String(), entity.Comments{*m})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GetAlbumComments returns the comments on an album as JSON.
+//
+//	@Summary	returns the comments on an album as JSON
+//	@Id			GetAlbumComments
+//	@Tags		Albums, Comments
+//	@Produce	json
+//	@Success	200				{object}	entity.Comments
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"album uid"
+//	@Router		/api/v1/albums/{uid}/comments [get]
+func GetAlbumComments(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		// Visitors and other restricted users can only access shared content.
+		if _, ok := albumCommentShare(s, uid); !ok {
+			AbortForbidden(c)
+			return
+		}
+
+		if a, err := query.AlbumByUID(uid); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
+		}
+
+		results, err := query.AlbumComments(uid)
+
+		if err != nil {
+			log.Errorf("comments: %s", err)
+			AbortUnexpectedError(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// CreateAlbumComment adds a comment to an album and returns it as JSON.
+//
+//	@Summary	adds a comment to an album and returns it as JSON
+//	@Id			CreateAlbumComment
+//	@Tags		Albums, Comments
+//	@Produce	json
+//	@Success	200					{object}	entity.Comment
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string			true	"album uid"
+//	@Param		comment				body		form.Comment	true	"comment text and optional author name"
+//	@Router		/api/v1/albums/{uid}/comments [post]
+func CreateAlbumComment(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionComment)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+		share, ok := albumCommentShare(s, uid)
+
+		// Visitors and other restricted users can only comment on shared content if permitted.
+		if !ok || !commentAllowed(s, acl.ResourceAlbums, share) {
+			AbortForbidden(c)
+			return
+		}
+
+		if a, err := query.AlbumByUID(uid); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
+		}
+
+		var f form.Comment
+
+		if err := c.BindJSON(&f); err != nil || strings.TrimSpace(f.Text) == "" {
+			AbortBadRequest(c)
+			return
+		}
+
+		name, createdBy := commentAuthor(s, f.AuthorName)
+		m := entity.NewAlbumComment(uid, strings.TrimSpace(f.Text), name, createdBy)
+
+		if err := m.Create(); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.PublishEntities("comments", StatusCreated.String(), entity.Comments{*m})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteComment removes a comment. Users without full access can only delete their own comments.
+//
+//	@Summary	removes a comment
+//	@Id			DeleteComment
+//	@Tags		Comments
+//	@Produce	json
+//	@Success	200					{object}	entity.Comment
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"comment uid"
+//	@Router		/api/v1/comments/{uid} [delete]
+func DeleteComment(router *gin.RouterGroup) {
+	router.DELETE("/comments/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindComment(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if _, createdBy := commentAuthor(s, ""); m.CreatedBy != createdBy && !acl.Rules.Allow(acl.ResourceComments, s.UserRole(), acl.AccessAll) {
+			AbortForbidden(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.PublishEntities("comments", StatusDeleted.String(), entity.Comments{*m})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// photoCommentShare returns the uid of the shared content that grants access to the photo comments.
+func photoCommentShare(s *entity.Session, uid string) (string, bool) {
+	if !s.User().HasSharedAccessOnly(acl.ResourcePhotos) && !s.NotRegistered() {
+		return uid, true
+	} else if s.HasShare(uid) {
+		return uid, true
+	} else if share := query.PhotoShareUID(uid, s.SharedUIDs()); share != "" {
+		return share, true
+	}
+
+	return "", false
+}
+
+// albumCommentShare returns the uid of the shared content that grants access to the album comments.
+func albumCommentShare(s *entity.Session, uid string) (string, bool) {
+	if !s.User().HasSharedAccessOnly(acl.ResourceAlbums) && !s.NotRegistered() {
+		return uid, true
+	} else if s.HasShare(uid) {
+		return uid, true
+	}
+
+	return "", false
+}
+
+// commentAllowed checks if the session may comment on the specified shared content.
+func commentAllowed(s *entity.Session, resource acl.Resource, share string) bool {
+	if !s.User().HasSharedAccessOnly(resource) && !s.NotRegistered() {
+		return true
+	}
+
+	return s.SharePerm(share)&entity.PermComment != 0
+}
+
+// commentAuthor returns the author name and creator id for comments created with the session.
+func commentAuthor(s *entity.Session, name string) (string, string) {
+	if user := s.User(); s.IsRegistered() {
+		return user.FullName(), user.UserUID
+	} else if name = clean.Name(name); name == "" {
+		return CommentAuthorGuest, s.RefID
+	}
+
+	return name, s.RefID
+}
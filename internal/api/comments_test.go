@@ -0,0 +1,170 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetPhotoComments(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6be2lvl0yh8/comments")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "What a beautiful place!", gjson.Get(r.Body.String(), "0.Text").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6be2lvl0xxx/comments")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("VisitorForbidden", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetPhotoComments(router)
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos/ps6sg6be2lvl0yh8/comments", sess.AuthToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestCreatePhotoComment(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreatePhotoComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/ps6sg6be2lvl0yh9/comments", `{"Text": "Nice!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Nice!", gjson.Get(r.Body.String(), "Text").String())
+		assert.Equal(t, "ps6sg6be2lvl0yh9", gjson.Get(r.Body.String(), "PhotoUID").String())
+	})
+	t.Run("EmptyText", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreatePhotoComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/ps6sg6be2lvl0yh9/comments", `{"Text": " "}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreatePhotoComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/ps6sg6be2lvl0xxx/comments", `{"Text": "Nice!"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Visitor", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		CreatePhotoComment(router)
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/photos/ps6sg6be2lvl0y21/comments", `{"Text": "Hello from a shared album.", "AuthorName": "Aunt Mary"}`, sess.AuthToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Aunt Mary", gjson.Get(r.Body.String(), "AuthorName").String())
+	})
+	t.Run("VisitorForbidden", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		CreatePhotoComment(router)
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/photos/ps6sg6be2lvl0yh8/comments", `{"Text": "Hello"}`, sess.AuthToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestGetAlbumComments(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba8/comments")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Thanks for sharing!", gjson.Get(r.Body.String(), "0.Text").String())
+		assert.Equal(t, "See you next year.", gjson.Get(r.Body.String(), "1.Text").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaxxxx/comments")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Visitor", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetAlbumComments(router)
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba8/comments", sess.AuthToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("VisitorForbidden", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetAlbumComments(router)
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba7/comments", sess.AuthToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestCreateAlbumComment(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba7/comments", `{"Text": "Merry Christmas!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "as6sg6bxpogaaba7", gjson.Get(r.Body.String(), "AlbumUID").String())
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba7/comments", `{"Text": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Visitor", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		CreateAlbumComment(router)
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba8/comments", `{"Text": "Great pictures!"}`, sess.AuthToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, CommentAuthorGuest, gjson.Get(r.Body.String(), "AuthorName").String())
+		assert.Equal(t, sess.RefID, gjson.Get(r.Body.String(), "CreatedBy").String())
+	})
+}
+
+func TestDeleteComment(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		DeleteComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba9/comments", `{"Text": "Delete me"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequest(app, "DELETE", "/api/v1/comments/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Nil(t, entity.FindComment(uid))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteComment(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/comments/ks6sg6bxpogaxxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("VisitorForbidden", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		DeleteComment(router)
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequest(app, "DELETE", "/api/v1/comments/"+entity.CommentFixtures.Get("AlbumBob").CommentUID, sess.AuthToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/internal/entity/search"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -49,6 +50,14 @@ func DownloadAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Check album permissions if each user has a separate library.
+		s := DownloadSession(c)
+
+		if s == nil && entity.UserLibraries() && !conf.Public() || !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
+		}
+
 		files, err := search.AlbumPhotos(a, 10000, true)
 
 		if err != nil {
@@ -56,6 +65,25 @@ func DownloadAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Exclude pictures the session user may not access.
+		if s.UserLibrary() {
+			allowed := make(map[string]bool, len(files))
+
+			for _, uid := range s.AccessiblePhotoUIDs(files.UIDs()) {
+				allowed[uid] = true
+			}
+
+			accessible := make(search.PhotoResults, 0, len(files))
+
+			for _, file := range files {
+				if allowed[file.PhotoUID] {
+					accessible = append(accessible, file)
+				}
+			}
+
+			files = accessible
+		}
+
 		zipFileName := a.ZipName()
 
 		AddDownloadHeader(c, zipFileName)
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestDownloadAlbum(t *testing.T) {
@@ -36,3 +39,38 @@ func TestDownloadAlbum(t *testing.T) {
 		conf.Settings().Features.Download = true
 	})
 }
+
+func TestDownloadAlbum_UserLibrary(t *testing.T) {
+	app, router, conf := NewApiTest()
+
+	conf.SetAuthMode(config.AuthModePasswd)
+	defer conf.SetAuthMode(config.AuthModePublic)
+
+	DownloadAlbum(router)
+
+	// Bob is not a super admin and has not created the fixture albums.
+	sess, err := entity.FindSessionByAuthToken(AuthenticateUser(app, router, "bob", "Bobbob123!"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Shared", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba8/dl?t="+sess.DownloadToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("User", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryUser
+		defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba8/dl?t="+sess.DownloadToken)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("ConfigToken", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryUser
+		defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba8/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -51,6 +51,9 @@ func DeleteFile(router *gin.RouterGroup) {
 			log.Errorf("files: %s (delete)", err)
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessFile(file) {
+			AbortForbidden(c)
+			return
 		}
 
 		// Primary file?
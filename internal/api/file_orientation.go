@@ -54,6 +54,9 @@ func ChangeFileOrientation(router *gin.RouterGroup) {
 			log.Errorf("files: %s (change orientation)", err)
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessFile(m) {
+			AbortForbidden(c)
+			return
 		}
 
 		// Init form with model values
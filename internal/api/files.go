@@ -34,6 +34,9 @@ func GetFile(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessFile(p) {
+			AbortForbidden(c)
+			return
 		}
 
 		c.JSON(http.StatusOK, p)
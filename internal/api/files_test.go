@@ -7,6 +7,9 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestGetFile(t *testing.T) {
@@ -25,4 +28,19 @@ func TestGetFile(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/files/111")
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
+	t.Run("UserLibrary", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+
+		entity.LibraryMode = entity.LibraryUser
+		defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+		GetFile(router)
+
+		// Bob is not a super admin and does not own the fixture pictures.
+		authToken := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/files/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", authToken)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
 }
@@ -25,7 +25,15 @@ func UpdateLink(c *gin.Context) {
 		return
 	}
 
-	var f form.Link
+	link := entity.FindLink(clean.Token(c.Param("link")))
+
+	if link == nil {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	// Keep the current visitor permissions unless they are explicitly changed.
+	f := form.Link{CanComment: link.CanComment(), CanEdit: link.CanEdit()}
 
 	// Assign and validate request form values.
 	if err := c.BindJSON(&f); err != nil {
@@ -34,9 +42,8 @@ func UpdateLink(c *gin.Context) {
 		return
 	}
 
-	link := entity.FindLink(clean.Token(c.Param("link")))
-
 	link.SetSlug(f.ShareSlug)
+	link.SetPerms(f.CanComment, f.CanEdit)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
 
@@ -117,6 +124,7 @@ func CreateLink(c *gin.Context) {
 	link := entity.NewUserLink(uid, s.UserUID)
 
 	link.SetSlug(f.ShareSlug)
+	link.SetPerms(f.CanComment, f.CanEdit)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
 
@@ -158,9 +166,12 @@ func CreateAlbumLink(router *gin.RouterGroup) {
 			return
 		}
 
-		if _, err := query.AlbumByUID(clean.UID(c.Param("uid"))); err != nil {
+		if a, err := query.AlbumByUID(clean.UID(c.Param("uid"))); err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
 		}
 
 		CreateLink(c)
@@ -187,6 +198,14 @@ func UpdateAlbumLink(router *gin.RouterGroup) {
 			return
 		}
 
+		if a, err := query.AlbumByUID(clean.UID(c.Param("uid"))); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
+		}
+
 		UpdateLink(c)
 	})
 }
@@ -210,6 +229,14 @@ func DeleteAlbumLink(router *gin.RouterGroup) {
 			return
 		}
 
+		if a, err := query.AlbumByUID(clean.UID(c.Param("uid"))); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		} else if !s.CanAccessAlbum(&a) {
+			AbortForbidden(c)
+			return
+		}
+
 		DeleteLink(c)
 	})
 }
@@ -237,6 +264,9 @@ func GetAlbumLinks(router *gin.RouterGroup) {
 		if err != nil {
 			AbortAlbumNotFound(c)
 			return
+		} else if !s.CanAccessAlbum(&m) {
+			AbortForbidden(c)
+			return
 		}
 
 		c.JSON(http.StatusOK, m.Links())
@@ -66,6 +66,9 @@ func findFileMarker(c *gin.Context) (file *entity.File, marker *entity.Marker, e
 	if file, err = query.FileByUID(marker.FileUID); err != nil {
 		AbortEntityNotFound(c)
 		return file, marker, fmt.Errorf("file %s %s", marker.FileUID, err)
+	} else if !s.CanAccessFile(file) {
+		AbortForbidden(c)
+		return file, marker, fmt.Errorf("file %s not accessible", marker.FileUID)
 	}
 
 	return file, marker, nil
@@ -109,6 +112,9 @@ func CreateMarker(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessFile(file) {
+			AbortForbidden(c)
+			return
 		}
 
 		// Validate form values.
@@ -160,6 +166,8 @@ func CreateMarker(router *gin.RouterGroup) {
 		} else if err := p.UpdateAndSaveTitle(); err != nil {
 			log.Errorf("faces: %s (update photo title)", err)
 		} else {
+			SaveSidecarXmp(&p)
+
 			// Publish updated photo entity.
 			PublishPhotoEvent(StatusUpdated, file.PhotoUID, c)
 		}
@@ -252,6 +260,8 @@ func UpdateMarker(router *gin.RouterGroup) {
 		} else if err := p.UpdateAndSaveTitle(); err != nil {
 			log.Errorf("faces: %s (update photo title)", err)
 		} else {
+			SaveSidecarXmp(&p)
+
 			// Notify clients.
 			PublishPhotoEvent(StatusUpdated, file.PhotoUID, c)
 		}
@@ -313,6 +323,8 @@ func ClearMarkerSubject(router *gin.RouterGroup) {
 		} else if err := p.UpdateAndSaveTitle(); err != nil {
 			log.Errorf("faces: %s (update photo title)", err)
 		} else {
+			SaveSidecarXmp(&p)
+
 			// Notify clients.
 			PublishPhotoEvent(StatusUpdated, file.PhotoUID, c)
 		}
@@ -40,6 +40,9 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&m) {
+			AbortForbidden(c)
+			return
 		}
 
 		var f form.Label
@@ -90,6 +93,8 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SaveSidecarXmp(&p)
+
 		PublishPhotoEvent(StatusUpdated, c.Param("uid"), c)
 
 		event.Success("label updated")
@@ -122,6 +127,9 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&m) {
+			AbortForbidden(c)
+			return
 		}
 
 		labelId, err := strconv.Atoi(clean.Token(c.Param("id")))
@@ -159,6 +167,8 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SaveSidecarXmp(&p)
+
 		PublishPhotoEvent(StatusUpdated, clean.UID(c.Param("uid")), c)
 
 		event.Success("label removed")
@@ -194,6 +204,9 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&m) {
+			AbortForbidden(c)
+			return
 		}
 
 		labelId, err := strconv.Atoi(clean.Token(c.Param("id")))
@@ -232,6 +245,8 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SaveSidecarXmp(&p)
+
 		PublishPhotoEvent(StatusUpdated, clean.UID(c.Param("uid")), c)
 
 		event.Success("label saved")
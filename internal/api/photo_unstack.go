@@ -44,6 +44,9 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			log.Errorf("photo: %s (unstack)", err)
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessFile(file) {
+			AbortForbidden(c)
+			return
 		}
 
 		if file.FilePrimary {
@@ -149,7 +152,7 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			}
 
 			if err := entity.UnscopedDb().Exec(`UPDATE files 
-				SET photo_id = ?, photo_uid = ?, file_name = ?, file_missing = 0
+				SET photo_id = ?, photo_uid = ?, file_name = ?, file_missing = FALSE
 				WHERE file_name = ? AND file_root = ?`,
 				newPhoto.ID, newPhoto.PhotoUID, r.RootRelName(),
 				relName, relRoot).Error; err != nil {
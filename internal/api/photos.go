@@ -35,6 +35,24 @@ func SaveSidecarYaml(photo *entity.Photo) {
 	_ = photo.SaveSidecarYaml(conf.OriginalsPath(), conf.SidecarPath())
 }
 
+// SaveSidecarXmp saves the photo metadata to an XMP sidecar file so that other applications can read it.
+func SaveSidecarXmp(photo *entity.Photo) {
+	if photo == nil {
+		log.Debugf("api: photo is nil (update xmp)")
+		return
+	}
+
+	conf := get.Config()
+
+	// Check if writing XMP sidecar files is enabled.
+	if !conf.SidecarXmp() {
+		return
+	}
+
+	// Write photo metadata to XMP sidecar file.
+	_ = photo.SaveSidecarXmp(conf.OriginalsPath())
+}
+
 // GetPhoto returns picture details as JSON.
 //
 //	@Summary	returns picture details as JSON
@@ -58,6 +76,9 @@ func GetPhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&p) {
+			AbortForbidden(c)
+			return
 		}
 
 		c.IndentedJSON(http.StatusOK, p)
@@ -89,6 +110,9 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&m) {
+			AbortForbidden(c)
+			return
 		}
 
 		// 1) Init form with model values
@@ -125,6 +149,7 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		SaveSidecarYaml(&p)
+		SaveSidecarXmp(&p)
 
 		UpdateClientConfig()
 
@@ -195,6 +220,9 @@ func GetPhotoYaml(router *gin.RouterGroup) {
 		if err != nil {
 			c.AbortWithStatus(http.StatusNotFound)
 			return
+		} else if !s.CanAccessPhoto(&p) {
+			AbortForbidden(c)
+			return
 		}
 
 		data, err := p.Yaml()
@@ -236,6 +264,9 @@ func ApprovePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&m) {
+			AbortForbidden(c)
+			return
 		}
 
 		if err := m.Approve(); err != nil {
@@ -289,6 +320,9 @@ func PhotoPrimary(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&p) {
+			AbortForbidden(c)
+			return
 		}
 
 		c.JSON(http.StatusOK, p)
@@ -21,6 +21,17 @@ func TestGetPhoto(t *testing.T) {
 		assert.Equal(t, "200", val.String())
 	})
 
+	t.Run("Objects", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6be2lvl0y18")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "object", gjson.Get(r.Body.String(), "Files.0.Markers.0.Type").String())
+		assert.Equal(t, "car", gjson.Get(r.Body.String(), "Files.0.Markers.0.Name").String())
+		assert.Equal(t, int64(87), gjson.Get(r.Body.String(), "Files.0.Markers.0.Score").Int())
+		assert.Contains(t, gjson.Get(r.Body.String(), "Files.0.Focus").String(), "pcad9a68fa6acc5c5ba965adf6ec465ca42fd924-")
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		GetPhoto(router)
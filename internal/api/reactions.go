@@ -36,6 +36,9 @@ func LikePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&m) {
+			AbortForbidden(c)
+			return
 		}
 
 		if get.Config().Experimental() && acl.Rules.Allow(acl.ResourcePhotos, s.UserRole(), acl.ActionReact) {
@@ -52,6 +55,7 @@ func LikePhoto(router *gin.RouterGroup) {
 			}
 
 			SaveSidecarYaml(&m)
+			SaveSidecarXmp(&m)
 			PublishPhotoEvent(StatusUpdated, id, c)
 		}
 
@@ -83,6 +87,9 @@ func DislikePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !s.CanAccessPhoto(&m) {
+			AbortForbidden(c)
+			return
 		}
 
 		if get.Config().Experimental() && acl.Rules.Allow(acl.ResourcePhotos, s.UserRole(), acl.ActionReact) {
@@ -99,6 +106,7 @@ func DislikePhoto(router *gin.RouterGroup) {
 			}
 
 			SaveSidecarYaml(&m)
+			SaveSidecarXmp(&m)
 			PublishPhotoEvent(StatusUpdated, id, c)
 		}
 
@@ -7,7 +7,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 
+	"github.com/photoprism/photoprism/internal/auth/ldap/ldaptest"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
 	"github.com/photoprism/photoprism/pkg/i18n"
@@ -77,6 +79,40 @@ func TestCreateSession(t *testing.T) {
 		assert.Equal(t, "admin", userName)
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("Ldap", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+
+		srv, err := ldaptest.NewServer(ldaptest.Entries...)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer srv.Close()
+
+		conf.Options().LDAPUri = srv.URI()
+		conf.Options().LDAPBindDN = "cn=reader,dc=example,dc=com"
+		conf.Options().LDAPBindPassword = "reader-secret"
+		conf.Options().LDAPBaseDN = "ou=people,dc=example,dc=com"
+		conf.Options().LDAPRoles = "admins=admin;family=guest"
+
+		entity.LdapClient = conf.LDAP()
+
+		defer func() {
+			conf.Options().LDAPUri = ""
+			entity.LdapClient = nil
+		}()
+
+		CreateSession(router)
+
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "dave", "password": "dave-secret"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "dave", gjson.Get(r.Body.String(), "user.Name").String())
+		assert.Equal(t, "ldap", gjson.Get(r.Body.String(), "provider").String())
+		assert.Equal(t, "guest", gjson.Get(r.Body.String(), "user.Role").String())
+	})
 	t.Run("BadRequest", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		conf.SetAuthMode(config.AuthModePasswd)
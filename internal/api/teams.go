@@ -0,0 +1,404 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/i18n"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// findTeam returns the team specified in the request, or aborts with an error if it was not found.
+func findTeam(c *gin.Context) *entity.Team {
+	uid := clean.UID(c.Param("uid"))
+
+	if !rnd.IsUID(uid, entity.TeamUID) {
+		AbortEntityNotFound(c)
+		return nil
+	} else if m := entity.FindTeam(uid); m != nil {
+		return m
+	}
+
+	AbortEntityNotFound(c)
+
+	return nil
+}
+
+// teamsDisabled checks if teams cannot be managed, e.g. because authentication is disabled.
+func teamsDisabled(c *gin.Context) bool {
+	if conf := get.Config(); conf.Public() || conf.Demo() || conf.DisableSettings() {
+		AbortForbidden(c)
+		return true
+	}
+
+	return false
+}
+
+// SearchTeams returns all teams as JSON.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams [get]
+func SearchTeams(router *gin.RouterGroup) {
+	router.GET("/teams", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindTeams())
+	})
+}
+
+// GetTeam returns a team as JSON.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid} [get]
+func GetTeam(router *gin.RouterGroup) {
+	router.GET("/teams/:uid", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if m := findTeam(c); m != nil {
+			c.JSON(http.StatusOK, m)
+		}
+	})
+}
+
+// CreateTeam creates a new team and returns it as JSON.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams [post]
+func CreateTeam(router *gin.RouterGroup) {
+	router.POST("/teams", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Team
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewTeam("", s.UserUID).SetValuesFromForm(f)
+
+		if err := m.Create(); err != nil {
+			log.Errorf("team: %s", clean.Error(err))
+			AbortBadRequest(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// UpdateTeam updates the team name and description.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid} [put]
+func UpdateTeam(router *gin.RouterGroup) {
+	router.PUT("/teams/:uid", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := findTeam(c)
+
+		if m == nil {
+			return
+		}
+
+		// Init form with model values.
+		f := form.Team{TeamName: m.TeamName, TeamDescription: m.TeamDescription}
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.SetValuesFromForm(f).Save(); err != nil {
+			log.Errorf("team: %s", clean.Error(err))
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteTeam deletes a team together with its members and shares.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid} [delete]
+func DeleteTeam(router *gin.RouterGroup) {
+	router.DELETE("/teams/:uid", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := findTeam(c)
+
+		if m == nil {
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GetTeamMembers returns the members of a team as JSON.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid}/members [get]
+func GetTeamMembers(router *gin.RouterGroup) {
+	router.GET("/teams/:uid/members", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if m := findTeam(c); m != nil {
+			c.JSON(http.StatusOK, m.Members())
+		}
+	})
+}
+
+// AddTeamMember adds a user to a team and returns the members as JSON.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid}/members [post]
+func AddTeamMember(router *gin.RouterGroup) {
+	router.POST("/teams/:uid/members", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := findTeam(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.TeamMember
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var user *entity.User
+
+		if f.UserUID != "" {
+			user = entity.FindUserByUID(clean.UID(f.UserUID))
+		} else {
+			user = entity.FindUserByName(f.UserName)
+		}
+
+		if user == nil || user.IsDeleted() {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		} else if err := m.AddMember(user); err != nil {
+			log.Errorf("team: %s", clean.Error(err))
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Members())
+	})
+}
+
+// RemoveTeamMember removes a user from a team and returns the remaining members as JSON.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid}/members/{user} [delete]
+func RemoveTeamMember(router *gin.RouterGroup) {
+	router.DELETE("/teams/:uid/members/:user", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := findTeam(c)
+
+		if m == nil {
+			return
+		}
+
+		user := entity.FindUserByUID(clean.UID(c.Param("user")))
+
+		if user == nil || !m.HasMember(user.UserUID) {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		} else if err := m.RemoveMember(user); err != nil {
+			log.Errorf("team: %s", clean.Error(err))
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Members())
+	})
+}
+
+// GetTeamShares returns the albums and folders shared with a team as JSON.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid}/shares [get]
+func GetTeamShares(router *gin.RouterGroup) {
+	router.GET("/teams/:uid/shares", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if m := findTeam(c); m != nil {
+			c.JSON(http.StatusOK, m.Shares())
+		}
+	})
+}
+
+// ShareWithTeam shares an album or folder with a team at the specified permission level.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid}/shares [post]
+func ShareWithTeam(router *gin.RouterGroup) {
+	router.POST("/teams/:uid/shares", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionShare)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := findTeam(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.TeamShare
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		uid := clean.UID(f.UID)
+		perm := entity.TeamPerm(f.Perm)
+
+		if perm == entity.PermDefault {
+			AbortBadRequest(c)
+			return
+		} else if !rnd.IsUID(uid, entity.AlbumUID) {
+			AbortAlbumNotFound(c)
+			return
+		} else if err := m.Share(uid, perm); err != nil {
+			log.Errorf("team: %s", clean.Error(err))
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Shares())
+	})
+}
+
+// UnshareWithTeam stops sharing an album or folder with a team.
+//
+//	@Tags	Teams
+//	@Router	/api/v1/teams/{uid}/shares/{share} [delete]
+func UnshareWithTeam(router *gin.RouterGroup) {
+	router.DELETE("/teams/:uid/shares/:share", func(c *gin.Context) {
+		if teamsDisabled(c) {
+			return
+		}
+
+		s := Auth(c, acl.ResourceTeams, acl.ActionShare)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := findTeam(c)
+
+		if m == nil {
+			return
+		}
+
+		if err := m.Unshare(clean.UID(c.Param("share"))); err != nil {
+			log.Errorf("team: %s", clean.Error(err))
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Shares())
+	})
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestTeams(t *testing.T) {
+	t.Run("Public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchTeams(router)
+		r := PerformRequest(app, "GET", "/api/v1/teams")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Visitor", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		CreateTeam(router)
+
+		sess := entity.SessionFixtures.Get("visitor")
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams", `{"Name": "Visitor Team"}`, sess.AuthToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+
+		SearchTeams(router)
+		GetTeam(router)
+		CreateTeam(router)
+		UpdateTeam(router)
+		DeleteTeam(router)
+		GetTeamMembers(router)
+		AddTeamMember(router)
+		RemoveTeamMember(router)
+		GetTeamShares(router)
+		ShareWithTeam(router)
+		UnshareWithTeam(router)
+
+		sessId := AuthenticateAdmin(app, router)
+
+		// Create team.
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams", `{"Name": "Photo Club", "Description": "Our photo club"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		assert.Equal(t, "Photo Club", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "photo-club", gjson.Get(r.Body.String(), "Slug").String())
+
+		// Team names must be unique.
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams", `{"Name": "Photo Club"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		// Update team.
+		r = AuthenticatedRequestWithBody(app, "PUT", "/api/v1/teams/"+uid, `{"Description": "Changed"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Photo Club", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "Changed", gjson.Get(r.Body.String(), "Description").String())
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/teams", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), uid)
+
+		// Add and remove members.
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams/"+uid+"/members", `{"UserName": "guest"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "guest", gjson.Get(r.Body.String(), "0.Name").String())
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams/"+uid+"/members", `{"UserName": "unknown"}`, sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams/"+uid+"/members", `{"UserUID": "uqxc08w3d0ej2283"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Parse(r.Body.String()).Array(), 2)
+
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/teams/"+uid+"/members/uqxc08w3d0ej2283", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Parse(r.Body.String()).Array(), 1)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/teams/"+uid+"/members", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Parse(r.Body.String()).Array(), 1)
+
+		// Share and unshare albums.
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams/"+uid+"/shares", `{"UID": "as6sg6bxpogaaba7", "Perm": "comment"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "as6sg6bxpogaaba7", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Equal(t, int64(entity.TeamPerm("comment")), gjson.Get(r.Body.String(), "0.Perm").Int())
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams/"+uid+"/shares", `{"UID": "as6sg6bxpogaaba7", "Perm": "owner"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/teams/"+uid+"/shares", `{"UID": "as6sg6bxpogaxxxx", "Perm": "view"}`, sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		assert.True(t, entity.FindUserShares("usg73p55zwgr1gbq").Contains("as6sg6bxpogaaba7"))
+
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/teams/"+uid+"/shares/as6sg6bxpogaaba7", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/teams/"+uid+"/shares", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "[]", r.Body.String())
+
+		// Delete team.
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/teams/"+uid, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/teams/"+uid, sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo/track"
+	"github.com/photoprism/photoprism/pkg/i18n"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// GetTracks returns the GPX, KML, and GeoJSON tracklogs that can be used for geotagging.
+//
+//	@Summary	returns the tracklogs that can be used for geotagging
+//	@Id			GetTracks
+//	@Tags		Places
+//	@Produce	json
+//	@Success	200				{object}	[]photoprism.TrackInfo
+//	@Failure	401,403,429,500	{object}	i18n.Response
+//	@Router		/api/v1/tracks [get]
+func GetTracks(router *gin.RouterGroup) {
+	router.GET("/tracks", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		_, infos, err := get.Geotag().Tracks()
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		if infos == nil {
+			infos = []photoprism.TrackInfo{}
+		}
+
+		c.JSON(http.StatusOK, infos)
+	})
+}
+
+// UploadTracks adds GPX, KML, and GeoJSON tracklogs that can be used for geotagging.
+//
+//	@Summary	uploads GPX, KML, and GeoJSON tracklogs
+//	@Id			UploadTracks
+//	@Tags		Places
+//	@Accept		multipart/form-data
+//	@Produce	json
+//	@Success	200					{object}	[]photoprism.TrackInfo
+//	@Failure	400,401,403,429,500	{object}	i18n.Response
+//	@Param		files				formData	file	true	"one or more tracklog files"
+//	@Router		/api/v1/tracks [post]
+func UploadTracks(router *gin.RouterGroup) {
+	router.POST("/tracks", func(c *gin.Context) {
+		conf := get.Config()
+
+		if conf.ReadOnly() || conf.DisablePlaces() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		s := Auth(c, acl.ResourcePlaces, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		// Parse upload form.
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			event.AuditErr([]string{ClientIP(c), "session %s", "upload tracks", "%s"}, s.RefID, err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		files := f.File["files"]
+
+		if len(files) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		tempDir := filepath.Join(conf.TempPath(), "tracks_"+rnd.Base36(8))
+
+		defer os.RemoveAll(tempDir)
+
+		infos := make([]photoprism.TrackInfo, 0, len(files))
+
+		for _, file := range files {
+			baseName := clean.FileName(filepath.Base(file.Filename))
+
+			if !track.Supported(baseName) {
+				event.AuditWarn([]string{ClientIP(c), "session %s", "upload tracks", "%s not supported"}, s.RefID, clean.Log(file.Filename))
+				Abort(c, http.StatusBadRequest, i18n.ErrUnsupportedFormat)
+				return
+			} else if limit := conf.OriginalsByteLimit(); limit > 0 && file.Size > limit {
+				event.AuditWarn([]string{ClientIP(c), "session %s", "upload tracks", "file size exceeded"}, s.RefID)
+				Abort(c, http.StatusBadRequest, i18n.ErrFileTooLarge)
+				return
+			}
+
+			tempName := filepath.Join(tempDir, baseName)
+
+			if err = c.SaveUploadedFile(file, tempName); err != nil {
+				event.AuditErr([]string{ClientIP(c), "session %s", "upload tracks", "failed to save %s"}, s.RefID, clean.Log(baseName))
+				Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+				return
+			}
+
+			// Validate and store tracklog.
+			info, importErr := get.Geotag().Import(tempName, baseName)
+
+			if importErr != nil {
+				event.AuditWarn([]string{ClientIP(c), "session %s", "upload tracks", "%s in %s"}, s.RefID, importErr, clean.Log(baseName))
+				Abort(c, http.StatusBadRequest, i18n.ErrUnsupportedFormat)
+				return
+			}
+
+			infos = append(infos, info)
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "upload tracks", "%d files", authn.Succeeded}, s.RefID, len(infos))
+
+		c.JSON(http.StatusOK, infos)
+	})
+}
+
+// GeotagPhotos assigns coordinates from the uploaded tracklogs to pictures without location.
+// If the "dryRun" flag is set, the matches are only reported without updating the index.
+//
+//	@Summary	assigns coordinates from the uploaded tracklogs to pictures without location
+//	@Id			GeotagPhotos
+//	@Tags		Places, Photos
+//	@Produce	json
+//	@Success	200					{object}	[]photoprism.GeotagResult
+//	@Failure	400,401,403,429,500	{object}	i18n.Response
+//	@Param		geotag				body		form.Geotag	true	"geotagging options"
+//	@Router		/api/v1/tracks/geotag [post]
+func GeotagPhotos(router *gin.RouterGroup) {
+	router.POST("/tracks/geotag", func(c *gin.Context) {
+		conf := get.Config()
+
+		if conf.DisablePlaces() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		s := Auth(c, acl.ResourcePlaces, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Geotag
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		w := get.Geotag()
+
+		tracks, _, err := w.Tracks()
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		results, err := w.Start(tracks, photoprism.GeotagOptions{MaxGap: f.Gap(), DryRun: f.DryRun})
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		if results == nil {
+			results = []photoprism.GeotagResult{}
+		}
+
+		if !f.DryRun && len(results) > 0 {
+			UpdateClientConfig()
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
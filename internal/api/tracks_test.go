@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/pkg/header"
+)
+
+// trackUploadRequest returns a multipart request for uploading the specified tracklog.
+func trackUploadRequest(t *testing.T, fileName string, data []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("files", fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = part.Write(data)
+	_ = writer.Close()
+
+	req, _ := http.NewRequest("POST", "/api/v1/tracks", body)
+	req.Header.Set(header.ContentType, writer.FormDataContentType())
+
+	return req
+}
+
+func TestGetTracks(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTracks(router)
+		r := PerformRequest(app, "GET", "/api/v1/tracks")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+	})
+}
+
+func TestUploadTracks(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UploadTracks(router)
+
+		data, err := os.ReadFile("../../pkg/geo/track/testdata/track.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, trackUploadRequest(t, "walk.gpx", data))
+
+		defer os.Remove(filepath.Join(conf.TracksPath(), "walk.gpx"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "walk.gpx", gjson.Get(w.Body.String(), "0.FileName").String())
+		assert.Equal(t, "Berlin Walk", gjson.Get(w.Body.String(), "0.Name").String())
+		assert.Equal(t, int64(6), gjson.Get(w.Body.String(), "0.Points").Int())
+		assert.FileExists(t, filepath.Join(conf.TracksPath(), "walk.gpx"))
+	})
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadTracks(router)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, trackUploadRequest(t, "notes.txt", []byte("hello")))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("InvalidTrack", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UploadTracks(router)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, trackUploadRequest(t, "invalid.gpx", []byte("<gpx></gpx>")))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.NoFileExists(t, filepath.Join(conf.TracksPath(), "invalid.gpx"))
+	})
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadTracks(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/tracks", "{foo:123}")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGeotagPhotos(t *testing.T) {
+	t.Run("DryRun", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UploadTracks(router)
+		GeotagPhotos(router)
+
+		data, err := os.ReadFile("../../pkg/geo/track/testdata/track.kml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, trackUploadRequest(t, "walk.kml", data))
+
+		defer os.Remove(filepath.Join(conf.TracksPath(), "walk.kml"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/tracks/geotag", `{"maxGap": 300, "dryRun": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+	})
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GeotagPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/tracks/geotag", "{foo:123}")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,288 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/header"
+	"github.com/photoprism/photoprism/pkg/i18n"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// GetUserPasskeys returns the passkeys registered for the specified user as JSON.
+//
+//	@Tags	Users
+//	@Router	/api/v1/users/{uid}/passkeys [get]
+func GetUserPasskeys(router *gin.RouterGroup) {
+	router.GET("/users/:uid/passkeys", func(c *gin.Context) {
+		// Check authentication and authorization.
+		_, user, authErr := checkUserPasskeyAuth(c, acl.ActionView)
+
+		if authErr != nil {
+			return
+		}
+
+		c.JSON(http.StatusOK, user.Passkeys())
+	})
+}
+
+// RegisterUserPasskey checks the user password and returns the options for creating a new passkey.
+//
+//	@Tags	Users
+//	@Router	/api/v1/users/{uid}/passkeys/register [post]
+func RegisterUserPasskey(router *gin.RouterGroup) {
+	router.POST("/users/:uid/passkeys/register", func(c *gin.Context) {
+		// Check authentication and authorization.
+		s, user, authErr := checkUserPasskeyAuth(c, acl.ActionCreate)
+
+		if authErr != nil {
+			return
+		}
+
+		var frm form.Passkey
+
+		// Validate request form values.
+		if err := c.BindJSON(&frm); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrInvalidPassword)
+			return
+		}
+
+		// Get client IP address for logs and rate limiting checks.
+		clientIp := ClientIP(c)
+
+		// Check request rate limit.
+		r := limiter.Login.Request(clientIp)
+
+		if r.Reject() {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		// Check user password and abort if invalid.
+		if code, msg, err := checkUserPasscodePassword(c, user, frm.Password); err != nil {
+			event.AuditErr([]string{clientIp, "session %s", authn.Users, user.UserName, authn.ErrPasskeyCreateFailed.Error(), strings.ToLower(clean.Error(err))}, s.RefID)
+			Abort(c, code, msg)
+			return
+		}
+
+		// Return the reserved request rate limit tokens after successful authentication.
+		r.Success()
+
+		// Create options, excluding passkeys that have already been registered.
+		opt, err := entity.WebAuthn.CreationOptions(user.UserUID, user.Username(), user.DisplayName, user.Passkeys().Descriptors())
+
+		if err != nil {
+			event.AuditErr([]string{clientIp, "session %s", authn.Users, user.UserName, authn.ErrPasskeyCreateFailed.Error(), clean.Error(err)}, s.RefID)
+			Abort(c, http.StatusInternalServerError, i18n.ErrUnexpected)
+			return
+		}
+
+		c.JSON(http.StatusOK, opt)
+	})
+}
+
+// CreateUserPasskey verifies the response of the authenticator and saves the new passkey.
+//
+//	@Tags	Users
+//	@Router	/api/v1/users/{uid}/passkeys [post]
+func CreateUserPasskey(router *gin.RouterGroup) {
+	router.POST("/users/:uid/passkeys", func(c *gin.Context) {
+		// Check authentication and authorization.
+		s, user, authErr := checkUserPasskeyAuth(c, acl.ActionCreate)
+
+		if authErr != nil {
+			return
+		}
+
+		var frm form.Passkey
+
+		// Validate request form values.
+		if err := c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if !frm.HasCredential() {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Verify the response of the authenticator, which must include a valid challenge.
+		cred, err := entity.WebAuthn.VerifyRegistration(user.UserUID, *frm.Credential)
+
+		if err != nil {
+			event.AuditErr([]string{ClientIP(c), "session %s", authn.Users, user.UserName, authn.ErrPasskeyCreateFailed.Error(), clean.Error(err)}, s.RefID)
+			AbortBadRequest(c)
+			return
+		}
+
+		// Save new passkey.
+		passkey := entity.NewPasskey(user.UserUID, frm.Name, cred)
+
+		if err = passkey.Create(); err != nil {
+			event.AuditErr([]string{ClientIP(c), "session %s", authn.Users, user.UserName, authn.ErrPasskeyCreateFailed.Error(), clean.Error(err)}, s.RefID)
+			Abort(c, http.StatusConflict, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", authn.Users, user.UserName, authn.Passkey, "%s", authn.Created}, s.RefID, passkey.String())
+
+		// Clear session cache.
+		s.ClearCache()
+
+		c.JSON(http.StatusOK, passkey)
+	})
+}
+
+// DeleteUserPasskey removes a passkey so that it can no longer be used to log in.
+//
+//	@Tags	Users
+//	@Router	/api/v1/users/{uid}/passkeys/{passkey} [delete]
+func DeleteUserPasskey(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/passkeys/:passkey", func(c *gin.Context) {
+		// Check authentication and authorization.
+		s, user, authErr := checkUserPasskeyAuth(c, acl.ActionDelete)
+
+		if authErr != nil {
+			return
+		}
+
+		passkey := entity.FindPasskey(clean.UID(c.Param("passkey")))
+
+		// Users can only remove their own passkeys.
+		if passkey == nil || passkey.UserUID != user.UserUID {
+			event.AuditWarn([]string{ClientIP(c), "session %s", authn.Users, user.UserName, authn.ErrPasskeyNotFound.Error()}, s.RefID)
+			Abort(c, http.StatusNotFound, i18n.ErrNotFound)
+			return
+		} else if err := passkey.Delete(); err != nil {
+			event.AuditErr([]string{ClientIP(c), "session %s", authn.Users, user.UserName, authn.ErrPasskeyDeleteFailed.Error(), clean.Error(err)}, s.RefID)
+			Abort(c, http.StatusInternalServerError, i18n.ErrDeleteFailed)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", authn.Users, user.UserName, authn.Passkey, "%s", authn.Deleted}, s.RefID, passkey.String())
+
+		// Clear session cache.
+		s.ClearCache()
+
+		c.JSON(http.StatusOK, user.Passkeys())
+	})
+}
+
+// AssertUserPasskey returns the options for logging in with a passkey. The response of the
+// authenticator must then be submitted to the session endpoint, either as a second factor
+// together with the user password, or on its own to log in without a password.
+//
+//	@Tags	Users
+//	@Router	/api/v1/users/{uid}/passkeys/assert [post]
+func AssertUserPasskey(router *gin.RouterGroup) {
+	router.POST("/users/:uid/passkeys/assert", func(c *gin.Context) {
+		conf := get.Config()
+
+		// Prevent caching of API response.
+		c.Header(header.CacheControl, header.CacheControlNoStore)
+
+		// Passkeys cannot be used if authentication is disabled.
+		if conf.Public() {
+			Abort(c, http.StatusForbidden, i18n.ErrPublic)
+			return
+		} else if entity.WebAuthn == nil {
+			Abort(c, http.StatusForbidden, i18n.ErrUnsupported)
+			return
+		}
+
+		// Check request rate limit. Since the options do not authenticate the client, the
+		// reserved token is not returned, so that usernames cannot be probed at a high rate.
+		if r := limiter.Login.Request(ClientIP(c)); r.Reject() {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		// Find user by uid or name.
+		var user *entity.User
+
+		if id := clean.UID(c.Param("uid")); rnd.IsUID(id, entity.UserUID) {
+			user = entity.FindUserByUID(id)
+		} else if name := clean.Username(c.Param("uid")); name != "" {
+			user = entity.FindUserByName(name)
+		}
+
+		var userUid string
+		var allow = entity.Passkeys{}
+
+		if user != nil && user.CanLogIn() {
+			userUid = user.UserUID
+			allow = user.Passkeys()
+		}
+
+		// Options are also returned if the account does not exist or cannot log in, in which case the list of
+		// allowed credentials is empty. Since the list is not empty for accounts with passkeys, the response
+		// reveals whether an account has registered passkeys, which is why the request rate is limited.
+		opt, err := entity.WebAuthn.RequestOptions(userUid, allow.Descriptors())
+
+		if err != nil {
+			log.Errorf("passkey: %s", clean.Error(err))
+			Abort(c, http.StatusInternalServerError, i18n.ErrUnexpected)
+			return
+		}
+
+		c.JSON(http.StatusOK, opt)
+	})
+}
+
+// checkUserPasskeyAuth checks authentication and authorization for the passkey management endpoints.
+func checkUserPasskeyAuth(c *gin.Context, action acl.Permission) (*entity.Session, *entity.User, error) {
+	conf := get.Config()
+
+	// Prevent caching of API response.
+	c.Header(header.CacheControl, header.CacheControlNoStore)
+
+	// Passkeys cannot be managed without authentication and settings enabled.
+	if conf.Public() || conf.DisableSettings() {
+		Abort(c, http.StatusForbidden, i18n.ErrPublic)
+		return nil, nil, authn.ErrPasskeyNotSupported
+	} else if entity.WebAuthn == nil {
+		Abort(c, http.StatusForbidden, i18n.ErrUnsupported)
+		return nil, nil, authn.ErrPasskeyNotSupported
+	}
+
+	// Check limit for failed auth requests (max. 10 per minute).
+	if limiter.Login.Reject(ClientIP(c)) {
+		limiter.AbortJSON(c)
+		return nil, nil, authn.ErrRateLimitExceeded
+	}
+
+	// Get session.
+	s := Auth(c, acl.ResourcePasskeys, action)
+
+	if s.Abort(c) {
+		return s, nil, authn.ErrUnauthorized
+	}
+
+	uid := clean.UID(c.Param("uid"))
+
+	// Get user from session.
+	user := s.User()
+
+	// Users can only manage the passkeys of their own account.
+	if user.UserUID != uid || !user.CanLogIn() {
+		AbortForbidden(c)
+		return s, nil, authn.ErrUnauthorized
+	}
+
+	// Check if the user's authentication provider supports passkeys.
+	if !user.Provider().SupportsPasscodeAuthentication() {
+		Abort(c, http.StatusForbidden, i18n.ErrUnsupported)
+		return s, nil, authn.ErrPasskeyNotSupported
+	}
+
+	return s, user, nil
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/auth/webauthn"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+)
+
+func TestUserPasskeys(t *testing.T) {
+	t.Run("PublicMode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserPasskeys(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/passkeys")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetUserPasskeys(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/passkeys")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("UsersDontMatch", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetUserPasskeys(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxc08w3d0ej2283/passkeys", sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		RegisterUserPasskey(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/passkeys/register", form.AsJson(form.Passkey{Password: "wrong"}), sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetUserPasskeys(router)
+		RegisterUserPasskey(router)
+		CreateUserPasskey(router)
+		DeleteUserPasskey(router)
+		AssertUserPasskey(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+
+		a, err := webauthn.NewAuthenticator()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Get registration options.
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/passkeys/register", form.AsJson(form.Passkey{Password: "Alice123!"}), sessId)
+
+		if !assert.Equal(t, http.StatusOK, r.Code) {
+			t.FailNow()
+		}
+
+		var creationOpt webauthn.CreationOptions
+
+		if err = json.Unmarshal(r.Body.Bytes(), &creationOpt); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.WebAuthn.ID, creationOpt.RP.ID)
+		assert.Equal(t, "alice", creationOpt.User.Name)
+
+		// Register passkey.
+		att, err := a.Create(entity.WebAuthn.Origin, &creationOpt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/passkeys", form.AsJson(form.Passkey{Name: "My Phone", Credential: &att}), sessId)
+
+		if !assert.Equal(t, http.StatusOK, r.Code) {
+			t.FailNow()
+		}
+
+		passkeyUid := gjson.Get(r.Body.String(), "UID").String()
+		assert.Equal(t, "My Phone", gjson.Get(r.Body.String(), "Name").String())
+		assert.False(t, gjson.Get(r.Body.String(), "PublicKey").Exists())
+
+		// Challenges can only be used once.
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/passkeys", form.AsJson(form.Passkey{Credential: &att}), sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/passkeys", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, passkeyUid, gjson.Get(r.Body.String(), "0.UID").String())
+
+		// Log in without a password.
+		r = PerformRequest(app, "POST", "/api/v1/users/alice/passkeys/assert")
+
+		if !assert.Equal(t, http.StatusOK, r.Code) {
+			t.FailNow()
+		}
+
+		var requestOpt webauthn.RequestOptions
+
+		if err = json.Unmarshal(r.Body.Bytes(), &requestOpt); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, requestOpt.AllowCredentials, 1)
+
+		assertion, err := a.Get(entity.WebAuthn.Origin, &requestOpt, "uqxetse3cy5eo9z2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", form.AsJson(form.Login{Passkey: &assertion}))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "alice", gjson.Get(r.Body.String(), "user.Name").String())
+		assert.Equal(t, "local", gjson.Get(r.Body.String(), "provider").String())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "access_token").String())
+
+		// Assertions cannot be replayed.
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", form.AsJson(form.Login{Passkey: &assertion}))
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		// Delete passkey.
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/users/uqxetse3cy5eo9z2/passkeys/"+passkeyUid, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "[]", r.Body.String())
+
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/users/uqxetse3cy5eo9z2/passkeys/"+passkeyUid, sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("UnknownUser", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		AssertUserPasskey(router)
+
+		r := PerformRequest(app, "POST", "/api/v1/users/xxx/passkeys/assert")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "challenge").Exists())
+		assert.Len(t, gjson.Get(r.Body.String(), "allowCredentials").Array(), 0)
+	})
+	t.Run("RateLimit", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		AssertUserPasskey(router)
+
+		// Each request uses a token, even if it succeeds.
+		login := limiter.Login
+		limiter.Login = limiter.NewLimit(0.001, 3)
+		defer func() { limiter.Login = login }()
+
+		assert.Equal(t, http.StatusOK, PerformRequest(app, "POST", "/api/v1/users/xxx/passkeys/assert").Code)
+		assert.Equal(t, http.StatusOK, PerformRequest(app, "POST", "/api/v1/users/yyy/passkeys/assert").Code)
+		assert.Equal(t, http.StatusOK, PerformRequest(app, "POST", "/api/v1/users/alice/passkeys/assert").Code)
+		assert.Equal(t, http.StatusTooManyRequests, PerformRequest(app, "POST", "/api/v1/users/alice/passkeys/assert").Code)
+	})
+}
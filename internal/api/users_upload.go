@@ -98,38 +98,9 @@ func UploadUserFiles(router *gin.RouterGroup) {
 		}
 
 		// Check if uploaded file is safe.
-		if !conf.UploadNSFW() {
-			nd := get.NsfwDetector()
-
-			containsNSFW := false
-
-			for _, filename := range uploads {
-				labels, err := nd.File(filename)
-
-				if err != nil {
-					log.Debug(err)
-					continue
-				}
-
-				if labels.IsSafe() {
-					continue
-				}
-
-				log.Infof("nsfw: %s might be offensive", clean.Log(filename))
-
-				containsNSFW = true
-			}
-
-			if containsNSFW {
-				for _, filename := range uploads {
-					if err := os.Remove(filename); err != nil {
-						log.Errorf("nsfw: could not delete %s", clean.Log(filename))
-					}
-				}
-
-				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
-				return
-			}
+		if !conf.UploadNSFW() && deleteOffensiveUploads(uploads) {
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
 		}
 
 		elapsed := int(time.Since(start).Seconds())
@@ -258,3 +229,39 @@ func ProcessUserUpload(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
 	})
 }
+
+// deleteOffensiveUploads checks the uploaded files for offensive content and deletes all of them if any was found.
+func deleteOffensiveUploads(uploads []string) bool {
+	nd := get.NsfwDetector()
+
+	containsNSFW := false
+
+	for _, filename := range uploads {
+		labels, err := nd.File(filename)
+
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
+
+		if labels.IsSafe() {
+			continue
+		}
+
+		log.Infof("nsfw: %s might be offensive", clean.Log(filename))
+
+		containsNSFW = true
+	}
+
+	if !containsNSFW {
+		return false
+	}
+
+	for _, filename := range uploads {
+		if err := os.Remove(filename); err != nil {
+			log.Errorf("nsfw: could not delete %s", clean.Log(filename))
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// GetVideoPreview returns an animated preview clip, a sprite sheet, or a WebVTT thumbnail track of a video.
+//
+//	@Summary	returns an animated preview clip, a sprite sheet, or a WebVTT thumbnail track of a video
+//	@Id			GetVideoPreview
+//	@Produce	video/mp4,image/jpeg,text/vtt
+//	@Tags		Images, Videos
+//	@Failure	400,403,404	{object}	i18n.Response
+//	@Param		hash		path		string	true	"SHA1 video file hash"
+//	@Param		token		path		string	true	"user-specific security token provided with session or 'public' when running PhotoPrism in public mode"
+//	@Param		type		path		string	true	"preview type"	Enums(clip, sprite, vtt)
+//	@Router		/api/v1/previews/{hash}/{token}/{type} [get]
+func GetVideoPreview(router *gin.RouterGroup) {
+	router.GET("/previews/:hash/:token/:type", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		conf := get.Config()
+		fileHash := clean.Token(c.Param("hash"))
+		previewType := thumb.VideoPreview(clean.Token(c.Param("type")))
+
+		if !previewType.Valid() {
+			log.Errorf("video: invalid preview type %s", clean.Log(previewType.String()))
+			AbortBadRequest(c)
+			return
+		}
+
+		// Return existing previews straight away.
+		if fileName, err := previewType.FileName(fileHash, conf.ThumbCachePath()); err != nil {
+			log.Errorf("video: %s", err)
+			AbortBadRequest(c)
+			return
+		} else if fs.FileExistsNotEmpty(fileName) {
+			sendVideoPreview(c, fileName, previewType)
+			return
+		}
+
+		// Check if previews may be created on demand.
+		if !conf.ThumbVideo() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		f, err := query.FileByHash(fileHash)
+
+		if err != nil {
+			log.Errorf("video: requested file not found (%s)", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if !f.FileVideo {
+			f, err = query.VideoByPhotoUID(f.PhotoUID)
+
+			if err != nil {
+				log.Errorf("video: no playable file found (%s)", err)
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		if f.FileError != "" {
+			log.Errorf("video: file has error %s", f.FileError)
+			AbortEntityNotFound(c)
+			return
+		} else if f.FileHash == "" {
+			log.Errorf("video: file hash missing in index")
+			AbortEntityNotFound(c)
+			return
+		} else if f.MediaType == entity.MediaLive {
+			log.Debugf("video: cannot create preview for live photo %s", clean.Log(f.FileName))
+			AbortEntityNotFound(c)
+			return
+		}
+
+		fileName, err := previewType.FileName(f.FileHash, conf.ThumbCachePath())
+
+		if err != nil {
+			log.Errorf("video: %s", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Create previews if they don't exist yet.
+		if !fs.FileExistsNotEmpty(fileName) {
+			mediaFile, mediaErr := photoprism.NewMediaFile(photoprism.FileName(f.FileRoot, f.FileName))
+
+			if mediaErr != nil {
+				// Set missing flag so that the file doesn't show up in search results anymore.
+				logErr("video", f.Update("FileMissing", true))
+				log.Errorf("video: file %s is missing", clean.Log(f.FileName))
+				AbortEntityNotFound(c)
+				return
+			}
+
+			if previewErr := mediaFile.GenerateVideoPreviews(conf.ThumbCachePath(), false); previewErr != nil {
+				log.Errorf("video: %s", previewErr)
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		if !fs.FileExistsNotEmpty(fileName) {
+			log.Debugf("video: %s preview of %s not found", previewType, clean.Log(f.FileName))
+			AbortEntityNotFound(c)
+			return
+		}
+
+		sendVideoPreview(c, fileName, previewType)
+	})
+}
+
+// sendVideoPreview sends a video preview file with the matching content type and cache headers.
+func sendVideoPreview(c *gin.Context, fileName string, previewType thumb.VideoPreview) {
+	// Add content type and HTTP cache headers.
+	AddContentTypeHeader(c, previewType.ContentType())
+	AddImmutableCacheHeader(c)
+
+	// Return requested content.
+	c.File(fileName)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestGetVideoPreview(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetVideoPreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/previews/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/xxx/clip")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoPreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/previews/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoPreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/previews/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/sprite")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().ThumbVideo = true
+		defer func() { conf.Options().ThumbVideo = false }()
+		GetVideoPreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/previews/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/vtt")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
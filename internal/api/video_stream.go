@@ -0,0 +1,136 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/header"
+)
+
+// GetVideoStream returns an adaptive streaming playlist or media segment of a video.
+// Playlists and segments are created in the background when the main playlist is first
+// requested, in which case 202 Accepted is returned until they are ready.
+//
+//	@Summary		returns an adaptive streaming playlist or media segment of a video
+//	@Id				GetVideoStream
+//	@Produce		application/vnd.apple.mpegurl,application/dash+xml,video/iso.segment,video/mp4
+//	@Tags			Files, Videos
+//	@Failure		403,404	{object}	i18n.Response
+//	@Param			thumb	path		string	true	"SHA1 video file hash"
+//	@Param			token	path		string	true	"user-specific security token provided with session"
+//	@Param			format	path		string	true	"streaming format, e.g. hls or dash"
+//	@Param			name	path		string	true	"playlist or segment file name, e.g. master.m3u8"
+//	@Router			/api/v1/videos/{hash}/{token}/{format}/{name} [get]
+func GetVideoStream(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/:format/:name", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		// Get app config.
+		conf := get.Config()
+
+		fileHash := clean.Token(c.Param("hash"))
+		format := ffmpeg.FindStreamFormat(clean.Token(c.Param("format")))
+		name := clean.FileName(c.Param("name"))
+
+		// Check if streaming is enabled for the requested format.
+		if !conf.FFmpegStreamingAllowed(format) {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		// Only playlists and segments created by FFmpeg may be requested.
+		contentType := ffmpeg.StreamContentType(name)
+
+		if contentType == "" || name != filepath.Base(name) {
+			log.Errorf("video: invalid stream file name %s", clean.Log(name))
+			AbortBadRequest(c)
+			return
+		}
+
+		f, err := query.FileByHash(fileHash)
+
+		if err != nil {
+			log.Errorf("video: requested file not found (%s)", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if !f.FileVideo {
+			f, err = query.VideoByPhotoUID(f.PhotoUID)
+
+			if err != nil {
+				log.Errorf("video: no playable file found (%s)", err)
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		if f.FileError != "" {
+			log.Errorf("video: file has error %s", f.FileError)
+			AbortEntityNotFound(c)
+			return
+		} else if f.FileHash == "" {
+			log.Errorf("video: file hash missing in index")
+			AbortEntityNotFound(c)
+			return
+		} else if f.MediaType == entity.MediaLive {
+			// Embedded videos are short, so they can be streamed with the standard video endpoint.
+			log.Debugf("video: cannot create %s stream for live photo %s", format, clean.Log(f.FileName))
+			AbortEntityNotFound(c)
+			return
+		}
+
+		streamDir := conf.VideoStreamPath(f.FileHash, format)
+		fileName := filepath.Join(streamDir, name)
+
+		// Create playlists and segments in the background if they don't exist yet.
+		if !fs.FileExists(filepath.Join(streamDir, format.PlaylistName())) {
+			mediaFile, mediaErr := photoprism.NewMediaFile(photoprism.FileName(f.FileRoot, f.FileName))
+
+			if mediaErr != nil {
+				// Set missing flag so that the file doesn't show up in search results anymore.
+				logErr("video", f.Update("FileMissing", true))
+				log.Errorf("video: file %s is missing", clean.Log(f.FileName))
+				AbortEntityNotFound(c)
+				return
+			}
+
+			if streamErr := get.Convert().QueueStream(mediaFile, streamDir, format, conf.FFmpegEncoder()); streamErr != nil {
+				log.Errorf("video: failed to create %s stream for %s", format, clean.Log(f.FileName))
+				AbortEntityNotFound(c)
+				return
+			}
+
+			// Ask the client to try again once the stream has been created.
+			c.Header(header.CacheControl, header.CacheControlNoStore)
+			c.Header(header.RetryAfter, "5")
+			c.AbortWithStatus(http.StatusAccepted)
+			return
+		}
+
+		if !fs.FileExists(fileName) {
+			log.Debugf("video: %s stream file %s not found", format, clean.Log(name))
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Add content type and HTTP cache headers.
+		AddContentTypeHeader(c, contentType)
+		AddVideoCacheHeader(c, conf.CdnVideo())
+
+		// Return requested content, optionally limited to a byte range.
+		c.File(fileName)
+	})
+}
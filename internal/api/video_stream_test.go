@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestGetVideoStream(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/xxx/hls/master.m3u8")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideo(router)
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/master.m3u8")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().FFmpegStreaming = "hls"
+		defer func() { conf.Options().FFmpegStreaming = "" }()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/master.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().FFmpegStreaming = "hls"
+		defer func() { conf.Options().FFmpegStreaming = "" }()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/index.html")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -17,6 +17,12 @@ var wsTimeout = 90 * time.Second
 // wsSubPerm specifies the permissions required to subscribe to a channel.
 var wsSubscribePerms = acl.Permissions{acl.ActionSubscribe}
 
+// wsSubscribeAllPerms specifies the permissions required to receive all events of a channel.
+var wsSubscribeAllPerms = acl.Permissions{acl.AccessAll, acl.ActionSubscribe}
+
+// wsSubscribeSharedPerms specifies the permissions required to receive events about shared content.
+var wsSubscribeSharedPerms = acl.Permissions{acl.AccessShared, acl.ActionSubscribe}
+
 // wsAuth maps connection IDs to specific users and session IDs.
 var wsAuth = struct {
 	sid   map[string]string
@@ -10,6 +10,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/auth/acl"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/internal/event"
 )
 
@@ -53,6 +54,7 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 		"lenses.*",
 		"countries.*",
 		"albums.*",
+		"comments.*",
 		"labels.*",
 		"subjects.*",
 		"people.*",
@@ -88,8 +90,8 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 		case msg := <-e.Receiver:
 			wsAuth.mutex.RLock()
 
-			sid := wsAuth.sid[connId] // Session ID.
-			// rid := wsAuth.rid[connId]  // Session RefID.
+			sid := wsAuth.sid[connId]  // Session ID.
+			rid := wsAuth.rid[connId]  // Session RefID.
 			user := entity.UnknownUser // User.
 
 			if hit, ok := wsAuth.user[connId]; ok {
@@ -105,8 +107,15 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 			// Send the message only to authorized recipients.
 			switch len(ch) {
 			case 2:
-				// Send to everyone who is allowed to subscribe.
-				if res := acl.Resource(ch[0]); acl.Events.AllowAll(res, user.AclRole(), wsSubscribePerms) {
+				res := acl.Resource(ch[0])
+
+				if acl.ChannelComments.Equal(ch[0]) && !acl.Events.AllowAll(res, user.AclRole(), wsSubscribeAllPerms) {
+					// Send comments only to sessions with access to the shared content.
+					if acl.Events.AllowAll(res, user.AclRole(), wsSubscribeSharedPerms) && wsSharedComments(rid, msg.Fields) {
+						wsSendMessage(ev, msg.Fields, ws, writeMutex)
+					}
+				} else if acl.Events.AllowAll(res, user.AclRole(), wsSubscribePerms) {
+					// Send to everyone who is allowed to subscribe.
 					wsSendMessage(ev, msg.Fields, ws, writeMutex)
 				}
 			case 4:
@@ -122,3 +131,32 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 		}
 	}
 }
+
+// wsSharedComments checks if the session with the specified RefID has access to the commented content.
+func wsSharedComments(rid string, data event.Data) bool {
+	comments, ok := data["entities"].(entity.Comments)
+
+	if !ok || len(comments) == 0 {
+		return false
+	}
+
+	s := entity.FindSessionByRefID(rid)
+
+	if s == nil {
+		return false
+	}
+
+	shared := s.SharedUIDs()
+
+	for _, c := range comments {
+		if c.AlbumUID != "" && s.HasShare(c.AlbumUID) {
+			continue
+		} else if c.PhotoUID != "" && (s.HasShare(c.PhotoUID) || query.PhotoShareUID(c.PhotoUID, shared) != "") {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
@@ -54,6 +54,9 @@ func ZipCreate(router *gin.RouterGroup) {
 		if f.Empty() {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !s.CanAccessPhotos(f.Photos) || !canAccessAlbums(s, f.Albums) {
+			AbortForbidden(c)
+			return
 		}
 
 		// Configure file selection based on user settings.
@@ -71,7 +74,7 @@ func ZipCreate(router *gin.RouterGroup) {
 		if err != nil {
 			Error(c, http.StatusBadRequest, err, i18n.ErrZipFailed)
 			return
-		} else if len(files) == 0 {
+		} else if files = s.AccessibleFiles(files); len(files) == 0 {
 			Abort(c, http.StatusNotFound, i18n.ErrNoFilesForDownload)
 			return
 		}
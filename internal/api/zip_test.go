@@ -6,6 +6,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestZip(t *testing.T) {
@@ -37,3 +40,31 @@ func TestZip(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestZip_UserLibrary(t *testing.T) {
+	app, router, conf := NewApiTest()
+
+	conf.SetAuthMode(config.AuthModePasswd)
+	defer conf.SetAuthMode(config.AuthModePublic)
+
+	entity.LibraryMode = entity.LibraryUser
+	defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+	ZipCreate(router)
+
+	// Bob is not a super admin and does not own the fixture pictures.
+	authToken := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+	t.Run("Photos", func(t *testing.T) {
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/zip", `{"photos": ["ps6sg6be2lvl0y12", "ps6sg6be2lvl0y11"]}`, authToken)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Albums", func(t *testing.T) {
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/zip", `{"albums": ["as6sg6bxpogaaba8"]}`, authToken)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Files", func(t *testing.T) {
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/zip", `{"files": ["fs6sg6bw45bnlqdw"]}`, authToken)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -30,6 +30,7 @@ const (
 	ActionDelete    Permission = "delete"
 	ActionRate      Permission = "rate"
 	ActionReact     Permission = "react"
+	ActionComment   Permission = "comment"
 	ActionSubscribe Permission = "subscribe"
 	ActionManage    Permission = "manage"
 	ActionManageOwn Permission = "manage_own"
@@ -44,6 +45,7 @@ const (
 	ResourceVideos    Resource = "videos"
 	ResourceFavorites Resource = "favorites"
 	ResourceAlbums    Resource = "albums"
+	ResourceComments  Resource = "comments"
 	ResourceMoments   Resource = "moments"
 	ResourceCalendar  Resource = "calendar"
 	ResourcePeople    Resource = "people"
@@ -52,9 +54,11 @@ const (
 	ResourceConfig    Resource = "config"
 	ResourceSettings  Resource = "settings"
 	ResourcePasscode  Resource = "passcode"
+	ResourcePasskeys  Resource = "passkeys"
 	ResourcePassword  Resource = "password"
 	ResourceServices  Resource = "services"
 	ResourceUsers     Resource = "users"
+	ResourceTeams     Resource = "teams"
 	ResourceSessions  Resource = "sessions"
 	ResourceLogs      Resource = "logs"
 	ResourceWebDAV    Resource = "webdav"
@@ -80,6 +84,7 @@ const (
 	ChannelLenses    Resource = "lenses"
 	ChannelCountries Resource = "countries"
 	ChannelAlbums    Resource = "albums"
+	ChannelComments  Resource = "comments"
 	ChannelLabels    Resource = "labels"
 	ChannelSubjects  Resource = "subjects"
 	ChannelPeople    Resource = "people"
@@ -15,4 +15,9 @@ var Events = ACL{
 		RoleGuest:   GrantSubscribeOwn,
 		RoleVisitor: GrantSubscribeOwn,
 	},
+	ChannelComments: Roles{
+		RoleAdmin:   GrantFullAccess,
+		RoleGuest:   GrantSubscribeShared,
+		RoleVisitor: GrantSubscribeShared,
+	},
 }
@@ -19,6 +19,7 @@ var (
 		ActionShare:     true,
 		ActionRate:      true,
 		ActionReact:     true,
+		ActionComment:   true,
 		ActionManage:    true,
 		ActionSubscribe: true,
 	}
@@ -94,6 +95,13 @@ var (
 		ActionDownload: true,
 		ActionReact:    true,
 	}
+	GrantCommentShared = Grant{
+		AccessShared:  true,
+		AccessOwn:     true,
+		ActionView:    true,
+		ActionDelete:  true,
+		ActionComment: true,
+	}
 	GrantSearchShared = Grant{
 		AccessShared:   true,
 		ActionSearch:   true,
@@ -109,6 +117,10 @@ var (
 		AccessOwn:       true,
 		ActionSubscribe: true,
 	}
+	GrantSubscribeShared = Grant{
+		AccessShared:    true,
+		ActionSubscribe: true,
+	}
 	GrantSubscribeAll = Grant{
 		AccessAll:       true,
 		ActionSubscribe: true,
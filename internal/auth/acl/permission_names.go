@@ -0,0 +1,25 @@
+package acl
+
+// PermissionNames contains a list of all specified permissions.
+var PermissionNames = Permissions{
+	FullAccess,
+	AccessShared,
+	AccessLibrary,
+	AccessPrivate,
+	AccessOwn,
+	AccessAll,
+	ActionSearch,
+	ActionView,
+	ActionUpload,
+	ActionCreate,
+	ActionUpdate,
+	ActionDownload,
+	ActionShare,
+	ActionDelete,
+	ActionRate,
+	ActionReact,
+	ActionComment,
+	ActionSubscribe,
+	ActionManage,
+	ActionManageOwn,
+}
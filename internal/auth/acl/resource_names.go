@@ -9,6 +9,7 @@ var ResourceNames = []Resource{
 	ResourceVideos,
 	ResourceFavorites,
 	ResourceAlbums,
+	ResourceComments,
 	ResourceMoments,
 	ResourceCalendar,
 	ResourcePeople,
@@ -17,9 +18,11 @@ var ResourceNames = []Resource{
 	ResourceConfig,
 	ResourceSettings,
 	ResourcePasscode,
+	ResourcePasskeys,
 	ResourcePassword,
 	ResourceServices,
 	ResourceUsers,
+	ResourceTeams,
 	ResourceSessions,
 	ResourceLogs,
 	ResourceWebDAV,
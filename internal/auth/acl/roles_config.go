@@ -0,0 +1,209 @@
+package acl
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// RoleConfig specifies the permissions of a custom role by resource name, e.g. as loaded from a "roles.yml" file.
+// Resources that are not specified inherit the permissions of the base role, if any, or the "default" permissions.
+// Built-in roles like "guest" can also be configured to override their permissions for specific resources.
+type RoleConfig struct {
+	Extends string              `yaml:"Extends,omitempty" json:"Extends,omitempty"`
+	Grants  map[string][]string `yaml:"Grants,omitempty" json:"Grants,omitempty"`
+}
+
+// RoleConfigs maps user role names to their config.
+type RoleConfigs map[string]RoleConfig
+
+// Load reads the role configs from a YAML file.
+func (c *RoleConfigs) Load(fileName string) error {
+	if fileName == "" {
+		return fmt.Errorf("no roles filename provided")
+	} else if !fs.FileExists(fileName) {
+		return fmt.Errorf("roles file not found: %s", clean.Log(fileName))
+	}
+
+	yamlConfig, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(yamlConfig, c)
+}
+
+// Names returns the sorted role names.
+func (c RoleConfigs) Names() []string {
+	result := make([]string, 0, len(c))
+
+	for name := range c {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Validate checks the role names, base roles, resources and permissions.
+func (c RoleConfigs) Validate() error {
+	for _, name := range c.Names() {
+		role := Role(name)
+
+		switch {
+		case name == "" || clean.Role(name) != name:
+			return fmt.Errorf("invalid role name %s", clean.LogQuote(name))
+		case role == RoleAdmin || role == RoleClient || role == RoleDefault:
+			return fmt.Errorf("role %s cannot be changed", clean.LogQuote(name))
+		}
+
+		conf := c[name]
+
+		// Only registered roles can be extended.
+		if conf.Extends != "" && (UserRoles[conf.Extends] == "" || Role(conf.Extends) == role) {
+			return fmt.Errorf("role %s cannot extend %s", clean.LogQuote(name), clean.LogQuote(conf.Extends))
+		}
+
+		for resource, perms := range conf.Grants {
+			if ParseResource(resource) == "" {
+				return fmt.Errorf("role %s has unknown resource %s", clean.LogQuote(name), clean.LogQuote(resource))
+			}
+
+			for _, perm := range perms {
+				if ParsePermission(perm) == "" {
+					return fmt.Errorf("role %s has unknown %s permission %s", clean.LogQuote(name), resource, clean.LogQuote(perm))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Extend returns a copy of the ACL that includes the permissions of the configured roles.
+// Existing roles that are configured are overridden for the specified resources only.
+func (c RoleConfigs) Extend(acl ACL) (ACL, error) {
+	if err := c.Validate(); err != nil {
+		return acl, err
+	}
+
+	result := make(ACL, len(acl))
+
+	for resource, roles := range acl {
+		result[resource] = make(Roles, len(roles))
+
+		for role, grant := range roles {
+			result[resource][role] = grant
+		}
+	}
+
+	for _, name := range c.Names() {
+		role := Role(name)
+		conf := c[name]
+		grants := make(map[Resource]Grant, len(conf.Grants))
+
+		for resource, perms := range conf.Grants {
+			grants[ParseResource(resource)] = NewGrant(perms)
+		}
+
+		// Make sure all resources with explicit grants are included in the result.
+		for resource := range grants {
+			if _, ok := result[resource]; !ok {
+				result[resource] = Roles{}
+			}
+		}
+
+		_, builtIn := UserRoles[name]
+		defaultGrant, hasDefault := grants[ResourceDefault]
+
+		for resource, roles := range result {
+			if grant, ok := grants[resource]; ok {
+				roles[role] = grant
+			} else if grant, ok = acl[resource][Role(conf.Extends)]; ok && conf.Extends != "" {
+				roles[role] = grant
+			} else if hasDefault && !builtIn {
+				roles[role] = defaultGrant
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Apply validates the role configs, extends the global access control lists, and registers the configured roles.
+func (c RoleConfigs) Apply() error {
+	rules, err := c.Extend(Rules)
+
+	if err != nil {
+		return err
+	}
+
+	// Custom roles may only inherit event subscriptions from their base role.
+	inherited := make(RoleConfigs, len(c))
+
+	for name, conf := range c {
+		inherited[name] = RoleConfig{Extends: conf.Extends}
+	}
+
+	events, err := inherited.Extend(Events)
+
+	if err != nil {
+		return err
+	}
+
+	Rules = rules
+	Events = events
+
+	for _, name := range c.Names() {
+		UserRoles[name] = Role(name)
+	}
+
+	return nil
+}
+
+// NewGrant returns a new Grant with the specified permission names.
+func NewGrant(perms []string) Grant {
+	result := make(Grant, len(perms))
+
+	for _, s := range perms {
+		if perm := ParsePermission(s); perm != "" {
+			result[perm] = true
+		}
+	}
+
+	return result
+}
+
+// ParseResource returns the resource matching the specified name, or an empty string if it is unknown.
+func ParseResource(s string) Resource {
+	s = strings.TrimSpace(s)
+
+	for _, resource := range ResourceNames {
+		if resource.Equal(s) {
+			return resource
+		}
+	}
+
+	return ""
+}
+
+// ParsePermission returns the permission matching the specified name, or an empty string if it is unknown.
+func ParsePermission(s string) Permission {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "_", " "))
+
+	for _, perm := range PermissionNames {
+		if perm.Equal(s) {
+			return perm
+		}
+	}
+
+	return ""
+}
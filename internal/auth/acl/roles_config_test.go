@@ -0,0 +1,134 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleConfigs_Load(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		roles := RoleConfigs{}
+
+		if err := roles.Load("testdata/roles.yml"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"editor", "guest", "uploader"}, roles.Names())
+		assert.Equal(t, "guest", roles["editor"].Extends)
+		assert.Len(t, roles["editor"].Grants, 3)
+		assert.NoError(t, roles.Validate())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		roles := RoleConfigs{}
+		assert.Error(t, roles.Load("testdata/missing.yml"))
+		assert.Error(t, roles.Load(""))
+	})
+}
+
+func TestRoleConfigs_Validate(t *testing.T) {
+	t.Run("Admin", func(t *testing.T) {
+		assert.Error(t, RoleConfigs{"admin": {}}.Validate())
+	})
+	t.Run("Client", func(t *testing.T) {
+		assert.Error(t, RoleConfigs{"client": {}}.Validate())
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		assert.Error(t, RoleConfigs{"Editor": {}}.Validate())
+		assert.Error(t, RoleConfigs{"": {}}.Validate())
+	})
+	t.Run("UnknownBase", func(t *testing.T) {
+		assert.Error(t, RoleConfigs{"editor": {Extends: "manager"}}.Validate())
+	})
+	t.Run("CustomBase", func(t *testing.T) {
+		assert.Error(t, RoleConfigs{"editor": {Extends: "guest"}, "reviewer": {Extends: "editor"}}.Validate())
+	})
+	t.Run("UnknownResource", func(t *testing.T) {
+		assert.Error(t, RoleConfigs{"editor": {Grants: map[string][]string{"foo": {"view"}}}}.Validate())
+	})
+	t.Run("UnknownPermission", func(t *testing.T) {
+		assert.Error(t, RoleConfigs{"editor": {Grants: map[string][]string{"photos": {"fly"}}}}.Validate())
+	})
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, RoleConfigs{"editor": {Extends: "guest", Grants: map[string][]string{"Photos": {"full access", "view"}}}}.Validate())
+	})
+}
+
+func TestRoleConfigs_Extend(t *testing.T) {
+	roles := RoleConfigs{}
+
+	if err := roles.Load("testdata/roles.yml"); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := roles.Extend(Rules)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	editor := Role("editor")
+	uploader := Role("uploader")
+
+	t.Run("Editor", func(t *testing.T) {
+		assert.True(t, rules.Allow(ResourcePhotos, editor, AccessLibrary))
+		assert.True(t, rules.Allow(ResourcePhotos, editor, ActionUpdate))
+		assert.False(t, rules.Allow(ResourcePhotos, editor, ActionDelete))
+		assert.True(t, rules.Allow(ResourceLabels, editor, ActionUpdate))
+		assert.True(t, rules.Allow(ResourcePasscode, editor, ActionCreate))
+		assert.False(t, rules.Allow(ResourceUsers, editor, AccessAll))
+	})
+	t.Run("Uploader", func(t *testing.T) {
+		assert.True(t, rules.Allow(ResourceFiles, uploader, ActionUpload))
+		assert.True(t, rules.Allow(ResourcePhotos, uploader, ActionUpload))
+		assert.False(t, rules.Allow(ResourcePhotos, uploader, AccessLibrary))
+		assert.True(t, rules.Allow(ResourceSettings, uploader, ActionView))
+		assert.False(t, rules.Allow(ResourceSettings, uploader, ActionUpdate))
+	})
+	t.Run("Guest", func(t *testing.T) {
+		assert.False(t, rules.Allow(ResourcePlaces, RoleGuest, ActionReact))
+		assert.True(t, rules.Allow(ResourcePlaces, RoleGuest, ActionView))
+		assert.True(t, rules.Allow(ResourcePhotos, RoleGuest, ActionReact))
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		assert.True(t, Rules.Allow(ResourcePlaces, RoleGuest, ActionReact))
+		assert.False(t, Rules.Allow(ResourcePhotos, editor, ActionView))
+		assert.False(t, Rules.Allow(ResourceVideos, editor, ActionView))
+		assert.Len(t, GrantDefaults, 4)
+	})
+}
+
+func TestRoleConfigs_Apply(t *testing.T) {
+	rules, events := Rules, Events
+
+	defer func() {
+		Rules, Events = rules, events
+		delete(UserRoles, "reviewer")
+	}()
+
+	roles := RoleConfigs{"reviewer": {Extends: "visitor", Grants: map[string][]string{"comments": {"access_shared", "view", "comment"}}}}
+
+	assert.NoError(t, roles.Apply())
+	assert.Equal(t, Role("reviewer"), UserRoles["reviewer"])
+	assert.True(t, Rules.Allow(ResourceComments, "reviewer", ActionComment))
+	assert.False(t, Rules.Allow(ResourceComments, "reviewer", ActionDelete))
+	assert.True(t, Rules.Allow(ResourcePhotos, "reviewer", ActionView))
+	assert.True(t, Events.Allow(ChannelComments, "reviewer", ActionSubscribe))
+	assert.False(t, Events.Allow(ChannelAudit, "reviewer", ActionSubscribe))
+
+	assert.Error(t, RoleConfigs{"admin": {}}.Apply())
+}
+
+func TestParsePermission(t *testing.T) {
+	assert.Equal(t, AccessLibrary, ParsePermission("access_library"))
+	assert.Equal(t, AccessLibrary, ParsePermission("Access Library"))
+	assert.Equal(t, ActionView, ParsePermission(" view "))
+	assert.Equal(t, Permission(""), ParsePermission("fly"))
+}
+
+func TestParseResource(t *testing.T) {
+	assert.Equal(t, ResourcePhotos, ParseResource("photos"))
+	assert.Equal(t, ResourceWebDAV, ParseResource("WebDAV"))
+	assert.Equal(t, ResourceDefault, ParseResource("default"))
+	assert.Equal(t, Resource(""), ParseResource("foo"))
+}
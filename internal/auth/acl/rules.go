@@ -22,6 +22,12 @@ var Rules = ACL{
 		RoleClient: GrantFullAccess,
 	},
 	ResourceAlbums: GrantDefaults,
+	ResourceComments: Roles{
+		RoleAdmin:   GrantFullAccess,
+		RoleGuest:   GrantCommentShared,
+		RoleVisitor: GrantCommentShared,
+		RoleClient:  GrantFullAccess,
+	},
 	ResourceMoments: Roles{
 		RoleAdmin:   GrantFullAccess,
 		RoleGuest:   GrantSearchShared,
@@ -66,6 +72,10 @@ var Rules = ACL{
 		RoleAdmin: GrantFullAccess,
 		RoleGuest: GrantConfigureOwn,
 	},
+	ResourcePasskeys: Roles{
+		RoleAdmin: GrantFullAccess,
+		RoleGuest: GrantManageOwn,
+	},
 	ResourcePassword: Roles{
 		RoleAdmin: GrantFullAccess,
 		RoleGuest: GrantUpdateOwn,
@@ -75,6 +85,9 @@ var Rules = ACL{
 		RoleGuest:  GrantViewUpdateOwn,
 		RoleClient: GrantViewOwn,
 	},
+	ResourceTeams: Roles{
+		RoleAdmin: GrantFullAccess,
+	},
 	ResourceSessions: Roles{
 		RoleAdmin:   GrantFullAccess,
 		RoleDefault: GrantOwn,
@@ -31,6 +31,7 @@ var (
 		ActionDelete:    true,
 		ActionRate:      true,
 		ActionReact:     true,
+		ActionComment:   true,
 		ActionManage:    true,
 		ActionManageOwn: true,
 	}
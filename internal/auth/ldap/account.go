@@ -0,0 +1,89 @@
+package ldap
+
+import (
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Attributes that are requested when searching for user accounts.
+var (
+	UsernameAttributes = []string{"sAMAccountName", "uid", "userPrincipalName"}
+	NameAttributes     = []string{"displayName", "cn"}
+	EmailAttributes    = []string{"mail"}
+	GroupAttributes    = []string{"memberOf"}
+)
+
+// Account represents a user account in the directory.
+type Account struct {
+	DN       string
+	Username string
+	Name     string
+	Email    string
+	Groups   []string
+}
+
+// NewAccount creates a new account from a directory entry and the username used to log in.
+func NewAccount(entry *ldap.Entry, login string) *Account {
+	if entry == nil {
+		return nil
+	}
+
+	result := &Account{
+		DN:       entry.DN,
+		Username: clean.Username(firstValue(entry, UsernameAttributes)),
+		Name:     clean.Name(firstValue(entry, NameAttributes)),
+		Email:    clean.Email(firstValue(entry, EmailAttributes)),
+	}
+
+	// Use the username provided at login if the directory entry has none.
+	if result.Username == "" {
+		result.Username = clean.Username(login)
+	}
+
+	for _, attr := range GroupAttributes {
+		result.Groups = append(result.Groups, entry.GetEqualFoldAttributeValues(attr)...)
+	}
+
+	return result
+}
+
+// Attributes returns the names of the attributes to request.
+func Attributes() (result []string) {
+	result = make([]string, 0, len(UsernameAttributes)+len(NameAttributes)+len(EmailAttributes)+len(GroupAttributes))
+	result = append(result, UsernameAttributes...)
+	result = append(result, NameAttributes...)
+	result = append(result, EmailAttributes...)
+	result = append(result, GroupAttributes...)
+	return result
+}
+
+// GroupName returns the common name (CN) of a group specified by DN, or the name as it is otherwise.
+func GroupName(group string) string {
+	dn, err := ldap.ParseDN(group)
+
+	if err != nil || len(dn.RDNs) == 0 {
+		return group
+	}
+
+	for _, attr := range dn.RDNs[0].Attributes {
+		if strings.EqualFold(attr.Type, "cn") {
+			return attr.Value
+		}
+	}
+
+	return group
+}
+
+// firstValue returns the first non-empty value of the specified attributes.
+func firstValue(entry *ldap.Entry, attributes []string) string {
+	for _, attr := range attributes {
+		if val := strings.TrimSpace(entry.GetEqualFoldAttributeValue(attr)); val != "" {
+			return val
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,45 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccount(t *testing.T) {
+	t.Run("ActiveDirectory", func(t *testing.T) {
+		entry := ldap.NewEntry("CN=Jane Doe,OU=Users,DC=corp,DC=example,DC=com", map[string][]string{
+			"sAMAccountName":    {"JDoe"},
+			"userPrincipalName": {"jdoe@corp.example.com"},
+			"cn":                {"Jane Doe"},
+			"mail":              {"Jane.Doe@example.com"},
+			"memberOf":          {"CN=Photographers,OU=Groups,DC=corp,DC=example,DC=com"},
+		})
+
+		account := NewAccount(entry, "jdoe@corp.example.com")
+
+		assert.Equal(t, "CN=Jane Doe,OU=Users,DC=corp,DC=example,DC=com", account.DN)
+		assert.Equal(t, "jdoe", account.Username)
+		assert.Equal(t, "Jane Doe", account.Name)
+		assert.Equal(t, "jane.doe@example.com", account.Email)
+		assert.Equal(t, []string{"CN=Photographers,OU=Groups,DC=corp,DC=example,DC=com"}, account.Groups)
+	})
+	t.Run("NoAttributes", func(t *testing.T) {
+		account := NewAccount(ldap.NewEntry("uid=jens,dc=example,dc=com", nil), "Jens")
+
+		assert.Equal(t, "jens", account.Username)
+		assert.Equal(t, "", account.Name)
+		assert.Equal(t, "", account.Email)
+		assert.Empty(t, account.Groups)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.Nil(t, NewAccount(nil, "jens"))
+	})
+}
+
+func TestGroupName(t *testing.T) {
+	assert.Equal(t, "Admins", GroupName("CN=Admins,OU=Groups,DC=example,DC=com"))
+	assert.Equal(t, "ou=groups,dc=example,dc=com", GroupName("ou=groups,dc=example,dc=com"))
+	assert.Equal(t, "admins", GroupName("admins"))
+}
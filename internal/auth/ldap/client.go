@@ -0,0 +1,165 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// DefaultUserFilter matches user accounts by their username, with "{username}" as placeholder.
+const DefaultUserFilter = "(&(objectClass=person)(|(sAMAccountName={username})(uid={username})(userPrincipalName={username})))"
+
+// DefaultTimeout is the default timeout for connecting to the directory server and searching it.
+const DefaultTimeout = 10 * time.Second
+
+// Config represents the LDAP directory server settings.
+type Config struct {
+	URI          string        // Server URI, e.g. ldaps://ldap.example.com.
+	Insecure     bool          // Skip TLS certificate verification.
+	BindDN       string        // DN of the service account used to search the directory, anonymous if empty.
+	BindPassword string        // Password of the service account.
+	BaseDN       string        // Base DN of the user accounts, e.g. dc=example,dc=com.
+	UserFilter   string        // Search filter with "{username}" as placeholder.
+	Roles        Roles         // Maps directory groups to user roles.
+	DefaultRole  acl.Role      // Role of users without a matching group.
+	Timeout      time.Duration // Connection and search timeout.
+}
+
+// Client represents an LDAP or Active Directory client for authenticating users.
+type Client struct {
+	conf Config
+}
+
+// NewClient returns a new LDAP client with the specified config.
+func NewClient(conf Config) *Client {
+	if conf.UserFilter == "" {
+		conf.UserFilter = DefaultUserFilter
+	}
+
+	if conf.Timeout <= 0 {
+		conf.Timeout = DefaultTimeout
+	}
+
+	return &Client{conf: conf}
+}
+
+// Config returns the client config.
+func (c *Client) Config() Config {
+	return c.conf
+}
+
+// Authenticate searches the directory for the specified user and verifies the password by binding as this user.
+func (c *Client) Authenticate(username, password string) (*Account, error) {
+	username = clean.Username(username)
+
+	// Empty passwords must be rejected to prevent unauthenticated binds, which may succeed.
+	if username == "" {
+		return nil, authn.ErrUsernameRequired
+	} else if password == "" {
+		return nil, authn.ErrPasswordRequired
+	}
+
+	conn, err := c.connect()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Find the user account in the directory.
+	entry, err := c.find(conn, username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify password.
+	if err = conn.Bind(entry.DN, password); ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil, authn.ErrInvalidPassword
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to bind as %s (%w)", clean.Log(entry.DN), err)
+	}
+
+	return NewAccount(entry, username), nil
+}
+
+// Role returns the user role based on the groups of the specified account.
+func (c *Client) Role(account *Account) acl.Role {
+	if account == nil {
+		return acl.RoleNone
+	} else if role := c.conf.Roles.Role(account.Groups); role != acl.RoleNone {
+		return role
+	}
+
+	return c.conf.DefaultRole
+}
+
+// connect connects to the directory server and binds as service account, if configured.
+func (c *Client) connect() (*ldap.Conn, error) {
+	if c.conf.URI == "" {
+		return nil, authn.ErrInvalidProviderConfiguration
+	}
+
+	conn, err := ldap.DialURL(
+		c.conf.URI,
+		ldap.DialWithDialer(&net.Dialer{Timeout: c.conf.Timeout}),
+		ldap.DialWithTLSConfig(&tls.Config{InsecureSkipVerify: c.conf.Insecure}), //nolint:gosec // can be enabled for self-signed certificates
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s (%w)", clean.Log(c.conf.URI), err)
+	}
+
+	conn.SetTimeout(c.conf.Timeout)
+
+	if c.conf.BindDN == "" {
+		return conn, nil
+	} else if err = conn.Bind(c.conf.BindDN, c.conf.BindPassword); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to bind as %s (%w)", clean.Log(c.conf.BindDN), err)
+	}
+
+	return conn, nil
+}
+
+// find searches the directory for exactly one user account matching the username.
+func (c *Client) find(conn *ldap.Conn, username string) (*ldap.Entry, error) {
+	req := ldap.NewSearchRequest(
+		c.conf.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		int(c.conf.Timeout.Seconds()),
+		false,
+		strings.ReplaceAll(c.conf.UserFilter, "{username}", ldap.EscapeFilter(username)),
+		Attributes(),
+		nil,
+	)
+
+	res, err := conn.Search(req)
+
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, errors.New("username is ambiguous")
+	} else if err != nil {
+		return nil, fmt.Errorf("search failed (%w)", err)
+	}
+
+	switch len(res.Entries) {
+	case 0:
+		return nil, authn.ErrAccountNotFound
+	case 1:
+		return res.Entries[0], nil
+	default:
+		return nil, errors.New("username is ambiguous")
+	}
+}
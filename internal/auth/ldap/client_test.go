@@ -0,0 +1,122 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap/ldaptest"
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+func TestClient_Authenticate(t *testing.T) {
+	srv, err := ldaptest.NewServer(ldaptest.Entries...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer srv.Close()
+
+	c := NewClient(Config{
+		URI:          srv.URI(),
+		BindDN:       "cn=reader,dc=example,dc=com",
+		BindPassword: "reader-secret",
+		BaseDN:       "ou=people,dc=example,dc=com",
+		Roles:        ParseRoles("admins=admin;family=guest"),
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		account, authErr := c.Authenticate("carol", "carol-secret")
+
+		if authErr != nil {
+			t.Fatal(authErr)
+		}
+
+		assert.Equal(t, "uid=carol,ou=people,dc=example,dc=com", account.DN)
+		assert.Equal(t, "carol", account.Username)
+		assert.Equal(t, "Carol Example", account.Name)
+		assert.Equal(t, "carol@example.com", account.Email)
+		assert.Len(t, account.Groups, 2)
+		assert.Equal(t, acl.RoleAdmin, c.Role(account))
+	})
+	t.Run("Guest", func(t *testing.T) {
+		account, authErr := c.Authenticate("DAVE", "dave-secret")
+
+		if authErr != nil {
+			t.Fatal(authErr)
+		}
+
+		assert.Equal(t, "dave", account.Username)
+		assert.Equal(t, "Dave Example", account.Name)
+		assert.Equal(t, acl.RoleGuest, c.Role(account))
+	})
+	t.Run("NoGroup", func(t *testing.T) {
+		account, authErr := c.Authenticate("mallory", "mallory-secret")
+
+		if authErr != nil {
+			t.Fatal(authErr)
+		}
+
+		assert.Equal(t, acl.RoleNone, c.Role(account))
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		account, authErr := c.Authenticate("carol", "wrong")
+
+		assert.Nil(t, account)
+		assert.ErrorIs(t, authErr, authn.ErrInvalidPassword)
+	})
+	t.Run("PasswordRequired", func(t *testing.T) {
+		account, authErr := c.Authenticate("carol", "")
+
+		assert.Nil(t, account)
+		assert.ErrorIs(t, authErr, authn.ErrPasswordRequired)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		account, authErr := c.Authenticate("eve", "secret")
+
+		assert.Nil(t, account)
+		assert.ErrorIs(t, authErr, authn.ErrAccountNotFound)
+	})
+	t.Run("FilterInjection", func(t *testing.T) {
+		account, authErr := c.Authenticate("*", "carol-secret")
+
+		assert.Nil(t, account)
+		assert.Error(t, authErr)
+	})
+	t.Run("InvalidBindPassword", func(t *testing.T) {
+		conf := c.Config()
+		conf.BindPassword = "wrong"
+
+		account, authErr := NewClient(conf).Authenticate("carol", "carol-secret")
+
+		assert.Nil(t, account)
+		assert.Error(t, authErr)
+	})
+	t.Run("DefaultRole", func(t *testing.T) {
+		conf := c.Config()
+		conf.DefaultRole = acl.RoleVisitor
+
+		account, authErr := NewClient(conf).Authenticate("mallory", "mallory-secret")
+
+		if authErr != nil {
+			t.Fatal(authErr)
+		}
+
+		assert.Equal(t, acl.RoleVisitor, NewClient(conf).Role(account))
+	})
+	t.Run("NoURI", func(t *testing.T) {
+		account, authErr := NewClient(Config{}).Authenticate("carol", "carol-secret")
+
+		assert.Nil(t, account)
+		assert.ErrorIs(t, authErr, authn.ErrInvalidProviderConfiguration)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	c := NewClient(Config{URI: "ldap://localhost"})
+
+	assert.Equal(t, DefaultUserFilter, c.Config().UserFilter)
+	assert.Equal(t, DefaultTimeout, c.Config().Timeout)
+}
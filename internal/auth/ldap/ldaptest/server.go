@@ -0,0 +1,273 @@
+/*
+Package ldaptest provides a minimal in-process LDAP server for testing. It must only be imported by tests.
+*/
+package ldaptest
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Entry represents a directory entry of the Server.
+type Entry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// Entries contains example directory entries for testing.
+var Entries = []Entry{
+	{
+		DN:       "cn=reader,dc=example,dc=com",
+		Password: "reader-secret",
+	},
+	{
+		DN:       "uid=carol,ou=people,dc=example,dc=com",
+		Password: "carol-secret",
+		Attributes: map[string][]string{
+			"objectClass": {"person", "inetOrgPerson"},
+			"uid":         {"carol"},
+			"cn":          {"Carol"},
+			"displayName": {"Carol Example"},
+			"mail":        {"carol@example.com"},
+			"memberOf":    {"cn=admins,ou=groups,dc=example,dc=com", "cn=family,ou=groups,dc=example,dc=com"},
+		},
+	},
+	{
+		DN:       "uid=dave,ou=people,dc=example,dc=com",
+		Password: "dave-secret",
+		Attributes: map[string][]string{
+			"objectClass": {"person", "inetOrgPerson"},
+			"uid":         {"dave"},
+			"cn":          {"Dave Example"},
+			"mail":        {"dave@example.com"},
+			"memberOf":    {"cn=family,ou=groups,dc=example,dc=com"},
+		},
+	},
+	{
+		DN:       "uid=mallory,ou=people,dc=example,dc=com",
+		Password: "mallory-secret",
+		Attributes: map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"mallory"},
+		},
+	},
+}
+
+// Server represents a minimal in-process LDAP server, which supports
+// simple bind and search operations on a static list of entries.
+type Server struct {
+	Entries  []Entry
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a new test server on a random local port.
+func NewServer(entries ...Entry) (*Server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{Entries: entries, listener: l}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// URI returns the server URI, e.g. for the client config.
+func (s *Server) URI() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+// serve accepts new connections until the listener is closed.
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+
+		if errors.Is(err, net.ErrClosed) {
+			return
+		} else if err != nil {
+			continue
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle processes the requests of a client connection.
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		req, err := ber.ReadPacket(conn)
+
+		if err != nil || len(req.Children) < 2 {
+			return
+		}
+
+		id := req.Children[0].Value
+		op := req.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			var code uint16 = ldap.LDAPResultInvalidCredentials
+
+			if len(op.Children) < 3 {
+				code = ldap.LDAPResultProtocolError
+			} else if name, password := op.Children[1].Data.String(), op.Children[2].Data.String(); name == "" && password == "" {
+				// Anonymous bind.
+				code = ldap.LDAPResultSuccess
+			} else if e := s.entry(name); e != nil && e.Password != "" && e.Password == password {
+				code = ldap.LDAPResultSuccess
+			}
+
+			s.write(conn, id, ldap.ApplicationBindResponse, code)
+		case ldap.ApplicationSearchRequest:
+			if len(op.Children) < 8 {
+				s.write(conn, id, ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError)
+				continue
+			}
+
+			base := strings.ToLower(op.Children[0].Data.String())
+
+			for i := range s.Entries {
+				e := &s.Entries[i]
+
+				if !strings.HasSuffix(strings.ToLower(e.DN), base) || !e.match(op.Children[6]) {
+					continue
+				}
+
+				res := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+
+				entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+				entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, ""))
+
+				attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+
+				for name, values := range e.Attributes {
+					attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+					attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+
+					vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+
+					for _, val := range values {
+						vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, val, ""))
+					}
+
+					attr.AppendChild(vals)
+					attrs.AppendChild(attr)
+				}
+
+				entry.AppendChild(attrs)
+				res.AppendChild(entry)
+
+				if _, err = conn.Write(res.Bytes()); err != nil {
+					return
+				}
+			}
+
+			s.write(conn, id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+		case ldap.ApplicationUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+// write sends an LDAP result message.
+func (s *Server) write(conn net.Conn, id interface{}, op ber.Tag, code uint16) {
+	res := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, op, nil, "")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+
+	res.AppendChild(result)
+
+	_, _ = conn.Write(res.Bytes())
+}
+
+// entry returns the entry with the specified DN, or nil if it was not found.
+func (s *Server) entry(dn string) *Entry {
+	for i := range s.Entries {
+		if strings.EqualFold(s.Entries[i].DN, dn) {
+			return &s.Entries[i]
+		}
+	}
+
+	return nil
+}
+
+// values returns the values of the specified attribute.
+func (e *Entry) values(name string) []string {
+	for attr, values := range e.Attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+// match checks if the entry matches a search filter. Only "and", "or", "not",
+// "equality match" and "present" filters are supported.
+func (e *Entry) match(filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, f := range filter.Children {
+			if !e.match(f) {
+				return false
+			}
+		}
+
+		return true
+	case ldap.FilterOr:
+		for _, f := range filter.Children {
+			if e.match(f) {
+				return true
+			}
+		}
+
+		return false
+	case ldap.FilterNot:
+		return len(filter.Children) == 1 && !e.match(filter.Children[0])
+	case ldap.FilterEqualityMatch:
+		if len(filter.Children) != 2 {
+			return false
+		}
+
+		for _, val := range e.values(filter.Children[0].Data.String()) {
+			if strings.EqualFold(val, filter.Children[1].Data.String()) {
+				return true
+			}
+		}
+
+		return false
+	case ldap.FilterPresent:
+		return len(e.values(filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
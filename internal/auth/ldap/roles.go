@@ -0,0 +1,102 @@
+package ldap
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// BuiltInRoles lists the built-in user roles that can be assigned based on directory groups, highest priority first.
+var BuiltInRoles = []acl.Role{acl.RoleAdmin, acl.RoleGuest, acl.RoleVisitor}
+
+// RolePriority returns the user roles that can be assigned based on directory groups, highest priority first.
+// Custom roles, e.g. as configured in a "roles.yml" file, follow the built-in roles in alphabetical order.
+func RolePriority() []acl.Role {
+	result := slices.Clone(BuiltInRoles)
+
+	var custom []acl.Role
+
+	for _, role := range acl.UserRoles {
+		if role == acl.RoleNone || slices.Contains(BuiltInRoles, role) {
+			continue
+		}
+
+		custom = append(custom, role)
+	}
+
+	slices.Sort(custom)
+
+	return append(result, custom...)
+}
+
+// Roles maps lowercase directory group names or DNs to user roles.
+type Roles map[string]acl.Role
+
+// ParseRoles parses a group to role mapping, e.g. "admins=admin;family=guest".
+func ParseRoles(s string) Roles {
+	result := make(Roles)
+
+	for _, val := range strings.Split(s, ";") {
+		// Split at the last equal sign, so that groups can also be specified by DN.
+		i := strings.LastIndex(val, "=")
+
+		if i < 1 {
+			continue
+		}
+
+		group := strings.ToLower(strings.TrimSpace(val[:i]))
+		role := acl.UserRoles[clean.Role(val[i+1:])]
+
+		if group == "" || role == acl.RoleNone {
+			continue
+		}
+
+		result[group] = role
+	}
+
+	return result
+}
+
+// Role returns the role with the highest priority that matches one of the specified groups, or RoleNone if none matches.
+func (r Roles) Role(groups []string) acl.Role {
+	if len(r) == 0 || len(groups) == 0 {
+		return acl.RoleNone
+	}
+
+	matches := make(map[acl.Role]bool)
+
+	for _, group := range groups {
+		if role, ok := r[strings.ToLower(group)]; ok {
+			matches[role] = true
+		} else if role, ok = r[strings.ToLower(GroupName(group))]; ok {
+			matches[role] = true
+		}
+	}
+
+	for _, role := range RolePriority() {
+		if matches[role] {
+			return role
+		}
+	}
+
+	return acl.RoleNone
+}
+
+// String returns the group to role mapping as string.
+func (r Roles) String() string {
+	if len(r) == 0 {
+		return ""
+	}
+
+	result := make([]string, 0, len(r))
+
+	for group, role := range r {
+		result = append(result, group+"="+role.String())
+	}
+
+	slices.Sort(result)
+
+	return strings.Join(result, ";")
+}
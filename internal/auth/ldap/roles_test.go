@@ -0,0 +1,73 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+)
+
+func TestParseRoles(t *testing.T) {
+	t.Run("Groups", func(t *testing.T) {
+		r := ParseRoles(" Admins=admin; family = guest;;invalid;foo=bar")
+
+		assert.Equal(t, Roles{"admins": acl.RoleAdmin, "family": acl.RoleGuest}, r)
+		assert.Equal(t, "admins=admin;family=guest", r.String())
+	})
+	t.Run("DN", func(t *testing.T) {
+		r := ParseRoles("cn=admins,ou=groups,dc=example,dc=com=admin")
+
+		assert.Equal(t, Roles{"cn=admins,ou=groups,dc=example,dc=com": acl.RoleAdmin}, r)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, Roles{}, ParseRoles(""))
+		assert.Equal(t, "", ParseRoles("").String())
+	})
+}
+
+func TestRolePriority(t *testing.T) {
+	t.Run("BuiltIn", func(t *testing.T) {
+		assert.Equal(t, []acl.Role{acl.RoleAdmin, acl.RoleGuest, acl.RoleVisitor}, RolePriority())
+	})
+	t.Run("Custom", func(t *testing.T) {
+		acl.UserRoles["family"] = "family"
+		acl.UserRoles["editor"] = "editor"
+
+		defer func() {
+			delete(acl.UserRoles, "family")
+			delete(acl.UserRoles, "editor")
+		}()
+
+		assert.Equal(t, []acl.Role{acl.RoleAdmin, acl.RoleGuest, acl.RoleVisitor, "editor", "family"}, RolePriority())
+	})
+}
+
+func TestRoles_Role(t *testing.T) {
+	r := ParseRoles("admins=admin;family=guest;cn=friends,ou=groups,dc=example,dc=com=visitor")
+
+	assert.Equal(t, acl.RoleAdmin, r.Role([]string{"cn=family,ou=groups,dc=example,dc=com", "CN=Admins,OU=Groups,DC=example,DC=com"}))
+	assert.Equal(t, acl.RoleGuest, r.Role([]string{"family"}))
+	assert.Equal(t, acl.RoleVisitor, r.Role([]string{"cn=friends,ou=groups,dc=example,dc=com"}))
+	assert.Equal(t, acl.RoleNone, r.Role([]string{"cn=friends,ou=other,dc=example,dc=com"}))
+	assert.Equal(t, acl.RoleNone, r.Role(nil))
+	assert.Equal(t, acl.RoleNone, Roles{}.Role([]string{"admins"}))
+}
+
+func TestRoles_RoleCustom(t *testing.T) {
+	acl.UserRoles["family"] = "family"
+	acl.UserRoles["editor"] = "editor"
+
+	defer func() {
+		delete(acl.UserRoles, "family")
+		delete(acl.UserRoles, "editor")
+	}()
+
+	r := ParseRoles("admins=admin;friends=guest;family=family;editors=editor")
+
+	assert.Equal(t, acl.Role("family"), r["family"])
+	assert.Equal(t, acl.RoleAdmin, r.Role([]string{"family", "admins"}))
+	assert.Equal(t, acl.RoleGuest, r.Role([]string{"family", "friends"}))
+	assert.Equal(t, acl.Role("editor"), r.Role([]string{"family", "editors"}))
+	assert.Equal(t, acl.Role("family"), r.Role([]string{"family"}))
+}
@@ -0,0 +1,105 @@
+package webauthn
+
+// Assertion represents the JSON-encoded result of navigator.credentials.get().
+type Assertion struct {
+	ID       string            `json:"id"`
+	RawID    string            `json:"rawId"`
+	Type     string            `json:"type"`
+	Response AssertionResponse `json:"response"`
+}
+
+// AssertionResponse represents the authenticator response to an authentication request.
+type AssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// CredentialID returns the base64url-encoded ID of the credential used, or an empty string if it is invalid.
+func (a *Assertion) CredentialID() string {
+	if a == nil {
+		return ""
+	}
+
+	id := a.RawID
+
+	if id == "" {
+		id = a.ID
+	}
+
+	b, err := Decode(id)
+
+	if err != nil || len(b) == 0 {
+		return ""
+	}
+
+	return Encode(b)
+}
+
+// VerifyAssertion verifies the assertion with the public key of the credential registered for the specified user,
+// and returns the new signature counter. User verification, e.g. with a PIN or biometrics, can be required
+// for passwordless authentication.
+func (rp *RelyingParty) VerifyAssertion(userUid string, a Assertion, publicKey []byte, signCount uint32, userVerification bool) (uint32, error) {
+	if a.Type != CredentialType {
+		return signCount, ErrInvalidCredential
+	}
+
+	clientData, clientDataHash, err := ParseClientData(a.Response.ClientDataJSON)
+
+	if err != nil {
+		return signCount, err
+	} else if err = rp.verifyClientData(clientData, CeremonyGet, userUid); err != nil {
+		return signCount, err
+	}
+
+	rawAuthData, err := Decode(a.Response.AuthenticatorData)
+
+	if err != nil {
+		return signCount, ErrInvalidAuthData
+	}
+
+	authData, err := ParseAuthenticatorData(rawAuthData)
+
+	if err != nil {
+		return signCount, err
+	} else if err = rp.verifyAuthData(authData, userVerification); err != nil {
+		return signCount, err
+	}
+
+	// The user handle is optional, but must match the user if provided.
+	if a.Response.UserHandle != "" {
+		if handle, decodeErr := Decode(a.Response.UserHandle); decodeErr != nil || string(handle) != userUid {
+			return signCount, ErrInvalidUserHandle
+		}
+	}
+
+	key, err := ParsePublicKey(publicKey)
+
+	if err != nil {
+		return signCount, err
+	}
+
+	sig, err := Decode(a.Response.Signature)
+
+	if err != nil {
+		return signCount, ErrInvalidSignature
+	}
+
+	// The signature covers the authenticator data followed by the hash of the client data.
+	signed := make([]byte, 0, len(rawAuthData)+len(clientDataHash))
+	signed = append(signed, rawAuthData...)
+	signed = append(signed, clientDataHash...)
+
+	if err = key.Verify(signed, sig); err != nil {
+		return signCount, err
+	}
+
+	// Authenticators that do not implement a counter always return zero, otherwise
+	// a counter that did not increase indicates that the authenticator may have been cloned.
+	if (authData.SignCount > 0 || signCount > 0) && authData.SignCount <= signCount {
+		return signCount, ErrSignCount
+	}
+
+	return authData.SignCount, nil
+}
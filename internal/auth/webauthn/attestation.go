@@ -0,0 +1,97 @@
+package webauthn
+
+import (
+	"bytes"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Attestation represents the JSON-encoded result of navigator.credentials.create().
+type Attestation struct {
+	ID       string              `json:"id"`
+	RawID    string              `json:"rawId"`
+	Type     string              `json:"type"`
+	Response AttestationResponse `json:"response"`
+}
+
+// AttestationResponse represents the authenticator response to a registration request.
+type AttestationResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON"`
+	AttestationObject string   `json:"attestationObject"`
+	Transports        []string `json:"transports,omitempty"`
+}
+
+// attestationObject represents the CBOR-encoded attestation object, see https://www.w3.org/TR/webauthn-2/#attestation-object.
+type attestationObject struct {
+	Fmt      string                 `codec:"fmt"`
+	AuthData []byte                 `codec:"authData"`
+	AttStmt  map[string]interface{} `codec:"attStmt"`
+}
+
+// Credential represents a newly registered passkey.
+type Credential struct {
+	ID           []byte
+	PublicKey    []byte
+	Alg          int64
+	AAGUID       []byte
+	SignCount    uint32
+	Transports   []string
+	UserVerified bool
+}
+
+// VerifyRegistration verifies the attestation returned by the authenticator and returns the new credential.
+// Attestation statements are not verified, since the relying party does not request them.
+func (rp *RelyingParty) VerifyRegistration(userUid string, a Attestation) (*Credential, error) {
+	if a.Type != CredentialType {
+		return nil, ErrInvalidCredential
+	}
+
+	clientData, _, err := ParseClientData(a.Response.ClientDataJSON)
+
+	if err != nil {
+		return nil, err
+	} else if err = rp.verifyClientData(clientData, CeremonyCreate, userUid); err != nil {
+		return nil, err
+	}
+
+	var obj attestationObject
+
+	if raw, decodeErr := Decode(a.Response.AttestationObject); decodeErr != nil || len(raw) == 0 {
+		return nil, ErrInvalidAttestation
+	} else if decodeErr = codec.NewDecoderBytes(raw, cbor).Decode(&obj); decodeErr != nil {
+		return nil, ErrInvalidAttestation
+	}
+
+	authData, err := ParseAuthenticatorData(obj.AuthData)
+
+	if err != nil {
+		return nil, err
+	} else if err = rp.verifyAuthData(authData, false); err != nil {
+		return nil, err
+	} else if len(authData.CredentialID) == 0 || len(authData.PublicKey) == 0 {
+		return nil, ErrInvalidAttestation
+	}
+
+	// Make sure the credential ID matches the attested credential.
+	if a.RawID != "" {
+		if rawId, decodeErr := Decode(a.RawID); decodeErr != nil || !bytes.Equal(rawId, authData.CredentialID) {
+			return nil, ErrInvalidCredential
+		}
+	}
+
+	key, err := ParsePublicKey(authData.PublicKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:           authData.CredentialID,
+		PublicKey:    authData.PublicKey,
+		Alg:          key.Alg,
+		AAGUID:       authData.AAGUID,
+		SignCount:    authData.SignCount,
+		Transports:   a.Response.Transports,
+		UserVerified: authData.UserVerified(),
+	}, nil
+}
@@ -0,0 +1,149 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Authenticator implements a software authenticator with an ES256 key, e.g. for testing.
+type Authenticator struct {
+	CredentialID []byte
+	SignCount    uint32
+	UserVerified bool
+	key          *ecdsa.PrivateKey
+}
+
+// NewAuthenticator returns a new software authenticator with a random credential ID and key.
+func NewAuthenticator() (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 16)
+
+	if _, err = rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	return &Authenticator{CredentialID: id, UserVerified: true, key: key}, nil
+}
+
+// PublicKey returns the COSE-encoded public key of the authenticator.
+func (a *Authenticator) PublicKey() []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+
+	var b []byte
+
+	_ = codec.NewEncoderBytes(&b, cbor).Encode(map[int64]interface{}{
+		coseKty: ktyEC2,
+		coseAlg: AlgES256,
+		coseCrv: crvP256,
+		coseX:   x,
+		coseY:   y,
+	})
+
+	return b
+}
+
+// Create returns a new attestation for the specified origin and creation options.
+func (a *Authenticator) Create(origin string, opt *CreationOptions) (result Attestation, err error) {
+	clientData, err := a.clientData(CeremonyCreate, opt.Challenge, origin)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Append the attested credential data with an empty AAGUID.
+	authData := a.authData(opt.RP.ID, FlagAttestedCredentialData)
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.CredentialID)))
+	authData = append(authData, a.CredentialID...)
+	authData = append(authData, a.PublicKey()...)
+
+	var obj []byte
+
+	if err = codec.NewEncoderBytes(&obj, cbor).Encode(attestationObject{
+		Fmt:      "none",
+		AuthData: authData,
+		AttStmt:  map[string]interface{}{},
+	}); err != nil {
+		return result, err
+	}
+
+	return Attestation{
+		ID:    Encode(a.CredentialID),
+		RawID: Encode(a.CredentialID),
+		Type:  CredentialType,
+		Response: AttestationResponse{
+			ClientDataJSON:    Encode(clientData),
+			AttestationObject: Encode(obj),
+			Transports:        []string{"internal"},
+		},
+	}, nil
+}
+
+// Get returns a new assertion for the specified origin, request options, and user.
+func (a *Authenticator) Get(origin string, opt *RequestOptions, userUid string) (result Assertion, err error) {
+	clientData, err := a.clientData(CeremonyGet, opt.Challenge, origin)
+
+	if err != nil {
+		return result, err
+	}
+
+	a.SignCount++
+
+	authData := a.authData(opt.RPID, 0)
+	clientDataHash := sha256.Sum256(clientData)
+	hash := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key, hash[:])
+
+	if err != nil {
+		return result, err
+	}
+
+	return Assertion{
+		ID:    Encode(a.CredentialID),
+		RawID: Encode(a.CredentialID),
+		Type:  CredentialType,
+		Response: AssertionResponse{
+			ClientDataJSON:    Encode(clientData),
+			AuthenticatorData: Encode(authData),
+			Signature:         Encode(sig),
+			UserHandle:        UserHandle(userUid),
+		},
+	}, nil
+}
+
+// clientData returns the JSON-encoded client data for the specified ceremony.
+func (a *Authenticator) clientData(ceremony, challenge, origin string) ([]byte, error) {
+	return json.Marshal(ClientData{Type: ceremony, Challenge: challenge, Origin: origin})
+}
+
+// authData returns the authenticator data for the specified relying party ID and flags.
+func (a *Authenticator) authData(rpId string, flags byte) []byte {
+	hash := sha256.Sum256([]byte(rpId))
+
+	flags |= FlagUserPresent
+
+	if a.UserVerified {
+		flags |= FlagUserVerified
+	}
+
+	b := append([]byte{}, hash[:]...)
+	b = append(b, flags)
+
+	return binary.BigEndian.AppendUint32(b, a.SignCount)
+}
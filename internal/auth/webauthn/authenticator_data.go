@@ -0,0 +1,102 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Authenticator data flags, see https://www.w3.org/TR/webauthn-2/#flags.
+const (
+	FlagUserPresent            byte = 0x01
+	FlagUserVerified           byte = 0x04
+	FlagAttestedCredentialData byte = 0x40
+	FlagExtensionData          byte = 0x80
+)
+
+// AuthenticatorData represents the data returned by the authenticator,
+// see https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data.
+type AuthenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+// ParseAuthenticatorData parses the binary authenticator data, including the attested credential data, if any.
+func ParseAuthenticatorData(b []byte) (*AuthenticatorData, error) {
+	if len(b) < 37 {
+		return nil, ErrInvalidAuthData
+	}
+
+	data := &AuthenticatorData{
+		RPIDHash:  b[:32],
+		Flags:     b[32],
+		SignCount: binary.BigEndian.Uint32(b[33:37]),
+	}
+
+	if data.Flags&FlagAttestedCredentialData == 0 {
+		return data, nil
+	}
+
+	// The attested credential data consists of the AAGUID, the
+	// length-prefixed credential ID, and the COSE-encoded public key.
+	rest := b[37:]
+
+	if len(rest) < 18 {
+		return nil, ErrInvalidAuthData
+	}
+
+	data.AAGUID = rest[:16]
+	n := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+
+	if n == 0 || len(rest) <= n {
+		return nil, ErrInvalidAuthData
+	}
+
+	data.CredentialID = rest[:n]
+	rest = rest[n:]
+
+	// Decode the public key to find out where it ends, as extension data may follow.
+	var key interface{}
+
+	dec := codec.NewDecoderBytes(rest, cbor)
+
+	if err := dec.Decode(&key); err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	data.PublicKey = rest[:dec.NumBytesRead()]
+
+	return data, nil
+}
+
+// UserPresent checks if the authenticator has confirmed that the user is present.
+func (data *AuthenticatorData) UserPresent() bool {
+	return data.Flags&FlagUserPresent != 0
+}
+
+// UserVerified checks if the authenticator has verified the user, e.g. with a PIN or biometrics.
+func (data *AuthenticatorData) UserVerified() bool {
+	return data.Flags&FlagUserVerified != 0
+}
+
+// verifyAuthData checks the relying party ID hash and the user presence and verification flags.
+func (rp *RelyingParty) verifyAuthData(data *AuthenticatorData, userVerification bool) error {
+	hash := sha256.Sum256([]byte(rp.ID))
+
+	if !bytes.Equal(data.RPIDHash, hash[:]) {
+		return ErrInvalidRelyingParty
+	} else if !data.UserPresent() {
+		return ErrUserNotPresent
+	} else if userVerification && !data.UserVerified() {
+		return ErrUserNotVerified
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package webauthn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAuthenticatorData(t *testing.T) {
+	a, err := NewAuthenticator()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Assertion", func(t *testing.T) {
+		data, err := ParseAuthenticatorData(a.authData("example.com", 0))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, data.UserPresent())
+		assert.True(t, data.UserVerified())
+		assert.Empty(t, data.CredentialID)
+	})
+	t.Run("ExtensionData", func(t *testing.T) {
+		b := a.authData("example.com", FlagAttestedCredentialData|FlagExtensionData)
+		b = append(b, make([]byte, 16)...)
+		b = append(b, 0, byte(len(a.CredentialID)))
+		b = append(b, a.CredentialID...)
+		b = append(b, a.PublicKey()...)
+		b = append(b, 0xa0)
+
+		data, err := ParseAuthenticatorData(b)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, a.CredentialID, data.CredentialID)
+		assert.Equal(t, a.PublicKey(), data.PublicKey)
+	})
+	t.Run("TooShort", func(t *testing.T) {
+		_, err := ParseAuthenticatorData(make([]byte, 36))
+		assert.ErrorIs(t, err, ErrInvalidAuthData)
+	})
+	t.Run("InvalidCredentialLength", func(t *testing.T) {
+		b := a.authData("example.com", FlagAttestedCredentialData)
+		b = append(b, make([]byte, 16)...)
+		b = append(b, 0xff, 0xff, 1, 2, 3)
+
+		_, err := ParseAuthenticatorData(b)
+		assert.ErrorIs(t, err, ErrInvalidAuthData)
+	})
+}
@@ -0,0 +1,59 @@
+package webauthn
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+// ChallengeSize is the number of random bytes in a challenge.
+const ChallengeSize = 32
+
+// challenge represents a pending registration or authentication ceremony.
+type challenge struct {
+	Ceremony string
+	UserUID  string
+}
+
+var challenges = gc.New(DefaultTimeout, time.Minute)
+var challengeMutex = sync.Mutex{}
+
+// NewChallenge creates a random challenge for the specified ceremony and user,
+// and caches it until it has been used or expires.
+func NewChallenge(ceremony, userUid string, expires time.Duration) (string, error) {
+	b := make([]byte, ChallengeSize)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	s := Encode(b)
+
+	challenges.Set(s, challenge{Ceremony: ceremony, UserUID: userUid}, expires)
+
+	return s, nil
+}
+
+// useChallenge returns and removes a pending challenge so that it cannot be used again.
+func useChallenge(s string) (challenge, bool) {
+	if s == "" {
+		return challenge{}, false
+	}
+
+	challengeMutex.Lock()
+	defer challengeMutex.Unlock()
+
+	v, found := challenges.Get(s)
+
+	if !found {
+		return challenge{}, false
+	}
+
+	challenges.Delete(s)
+
+	c, ok := v.(challenge)
+
+	return c, ok
+}
@@ -0,0 +1,45 @@
+package webauthn
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"strings"
+)
+
+// ClientData represents the data that the browser passes to the authenticator,
+// see https://www.w3.org/TR/webauthn-2/#dictionary-client-data.
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+}
+
+// ParseClientData decodes the base64url-encoded client data JSON and returns it together with its SHA-256 hash.
+func ParseClientData(s string) (data ClientData, hash []byte, err error) {
+	b, err := Decode(s)
+
+	if err != nil || len(b) == 0 {
+		return data, nil, ErrInvalidClientData
+	} else if err = json.Unmarshal(b, &data); err != nil {
+		return data, nil, ErrInvalidClientData
+	}
+
+	sum := sha256.Sum256(b)
+
+	return data, sum[:], nil
+}
+
+// verifyClientData checks the ceremony type, challenge, and origin of the client data.
+// Challenges that are not bound to a user, e.g. for logins with discoverable credentials, are accepted for any user.
+func (rp *RelyingParty) verifyClientData(data ClientData, ceremony, userUid string) error {
+	if data.Type != ceremony {
+		return ErrInvalidCeremony
+	} else if c, ok := useChallenge(data.Challenge); !ok || c.Ceremony != ceremony || c.UserUID != "" && c.UserUID != userUid {
+		return ErrInvalidChallenge
+	} else if data.CrossOrigin || !strings.EqualFold(strings.TrimRight(data.Origin, "/"), rp.Origin) {
+		return ErrInvalidOrigin
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package webauthn
+
+import "errors"
+
+// Error messages returned by the registration and authentication ceremonies.
+var (
+	ErrInvalidCredential    = errors.New("invalid credential")
+	ErrInvalidClientData    = errors.New("invalid client data")
+	ErrInvalidCeremony      = errors.New("invalid ceremony type")
+	ErrInvalidChallenge     = errors.New("invalid or expired challenge")
+	ErrInvalidOrigin        = errors.New("origin does not match")
+	ErrInvalidRelyingParty  = errors.New("relying party does not match")
+	ErrInvalidAuthData      = errors.New("invalid authenticator data")
+	ErrInvalidAttestation   = errors.New("invalid attestation")
+	ErrInvalidPublicKey     = errors.New("invalid public key")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrInvalidUserHandle    = errors.New("user handle does not match")
+	ErrUnsupportedAlgorithm = errors.New("unsupported public key algorithm")
+	ErrUserNotPresent       = errors.New("user not present")
+	ErrUserNotVerified      = errors.New("user not verified")
+	ErrSignCount            = errors.New("signature counter did not increase")
+)
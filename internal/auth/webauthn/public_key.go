@@ -0,0 +1,140 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/ugorji/go/codec"
+)
+
+// COSE algorithm identifiers, see https://www.iana.org/assignments/cose/cose.xhtml#algorithms.
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// Algorithms lists the supported public key algorithms in order of preference.
+var Algorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// COSE key types and parameters, see https://www.rfc-editor.org/rfc/rfc8152#section-13.
+const (
+	coseKty    int64 = 1
+	coseAlg    int64 = 3
+	coseCrv    int64 = -1
+	coseX      int64 = -2
+	coseY      int64 = -3
+	coseRsaN   int64 = -1
+	coseRsaE   int64 = -2
+	ktyOKP     int64 = 1
+	ktyEC2     int64 = 2
+	ktyRSA     int64 = 3
+	crvP256    int64 = 1
+	crvEd25519 int64 = 6
+)
+
+// PublicKey represents a credential public key.
+type PublicKey struct {
+	Alg int64
+	key crypto.PublicKey
+}
+
+// ParsePublicKey parses a COSE-encoded credential public key.
+func ParsePublicKey(b []byte) (*PublicKey, error) {
+	var m map[int64]interface{}
+
+	if len(b) == 0 {
+		return nil, ErrInvalidPublicKey
+	} else if err := codec.NewDecoderBytes(b, cbor).Decode(&m); err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	kty, _ := m[coseKty].(int64)
+	alg, _ := m[coseAlg].(int64)
+
+	switch {
+	case kty == ktyEC2 && alg == AlgES256:
+		crv, _ := m[coseCrv].(int64)
+		x, _ := m[coseX].([]byte)
+		y, _ := m[coseY].([]byte)
+
+		if crv != crvP256 || len(x) != 32 || len(y) != 32 {
+			return nil, ErrInvalidPublicKey
+		}
+
+		// Make sure the point is on the curve.
+		point := append(append([]byte{4}, x...), y...)
+
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, ErrInvalidPublicKey
+		}
+
+		return &PublicKey{Alg: alg, key: &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}}, nil
+	case kty == ktyOKP && alg == AlgEdDSA:
+		crv, _ := m[coseCrv].(int64)
+		x, _ := m[coseX].([]byte)
+
+		if crv != crvEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, ErrInvalidPublicKey
+		}
+
+		return &PublicKey{Alg: alg, key: ed25519.PublicKey(x)}, nil
+	case kty == ktyRSA && alg == AlgRS256:
+		n, _ := m[coseRsaN].([]byte)
+		e, _ := m[coseRsaE].([]byte)
+
+		if len(e) == 0 || len(e) > 4 {
+			return nil, ErrInvalidPublicKey
+		}
+
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+		if key.N.BitLen() < 2048 || key.E < 3 {
+			return nil, ErrInvalidPublicKey
+		}
+
+		return &PublicKey{Alg: alg, key: key}, nil
+	}
+
+	return nil, ErrUnsupportedAlgorithm
+}
+
+// Verify checks the signature of the specified data.
+func (k *PublicKey) Verify(data, sig []byte) error {
+	if k == nil {
+		return ErrInvalidPublicKey
+	}
+
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(data)
+
+		if ecdsa.VerifyASN1(key, hash[:], sig) {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if ed25519.Verify(key, data, sig) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(data)
+
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig) == nil {
+			return nil
+		}
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+
+	return ErrInvalidSignature
+}
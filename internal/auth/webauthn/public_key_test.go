@@ -0,0 +1,86 @@
+package webauthn
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ugorji/go/codec"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	t.Run("ES256", func(t *testing.T) {
+		a, err := NewAuthenticator()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := ParsePublicKey(a.PublicKey())
+
+		assert.NoError(t, err)
+		assert.Equal(t, AlgES256, key.Alg)
+	})
+	t.Run("EdDSA", func(t *testing.T) {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var b []byte
+
+		if err = codec.NewEncoderBytes(&b, cbor).Encode(map[int64]interface{}{
+			coseKty: ktyOKP,
+			coseAlg: AlgEdDSA,
+			coseCrv: crvEd25519,
+			coseX:   []byte(pub),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := ParsePublicKey(b)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, AlgEdDSA, key.Alg)
+		assert.NoError(t, key.Verify([]byte("data"), ed25519.Sign(priv, []byte("data"))))
+		assert.ErrorIs(t, key.Verify([]byte("other"), ed25519.Sign(priv, []byte("data"))), ErrInvalidSignature)
+	})
+	t.Run("NotOnCurve", func(t *testing.T) {
+		var b []byte
+
+		if err := codec.NewEncoderBytes(&b, cbor).Encode(map[int64]interface{}{
+			coseKty: ktyEC2,
+			coseAlg: AlgES256,
+			coseCrv: crvP256,
+			coseX:   make([]byte, 32),
+			coseY:   make([]byte, 32),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := ParsePublicKey(b)
+		assert.ErrorIs(t, err, ErrInvalidPublicKey)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		var b []byte
+
+		if err := codec.NewEncoderBytes(&b, cbor).Encode(map[int64]interface{}{
+			coseKty: ktyEC2,
+			coseAlg: int64(-35),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := ParsePublicKey(b)
+		assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParsePublicKey(nil)
+		assert.ErrorIs(t, err, ErrInvalidPublicKey)
+	})
+}
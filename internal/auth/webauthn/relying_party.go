@@ -0,0 +1,151 @@
+package webauthn
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the time users have to complete a registration or authentication ceremony.
+const DefaultTimeout = 5 * time.Minute
+
+// User verification requirements, see https://www.w3.org/TR/webauthn-2/#enum-userVerificationRequirement.
+const (
+	UserVerificationRequired    = "required"
+	UserVerificationPreferred   = "preferred"
+	UserVerificationDiscouraged = "discouraged"
+)
+
+// RelyingParty represents the site with which users register passkeys and to which they authenticate.
+type RelyingParty struct {
+	ID      string        // Relying party ID, i.e. the domain name of the site.
+	Name    string        // Human-readable site name shown by the browser.
+	Origin  string        // Expected request origin, e.g. https://photos.example.com.
+	Timeout time.Duration // Time users have to complete a ceremony.
+}
+
+// NewRelyingParty returns a new relying party with the specified ID, name, and origin.
+func NewRelyingParty(id, name, origin string) *RelyingParty {
+	return &RelyingParty{
+		ID:      strings.ToLower(id),
+		Name:    name,
+		Origin:  strings.TrimRight(origin, "/"),
+		Timeout: DefaultTimeout,
+	}
+}
+
+// RelyingPartyEntity represents the relying party in the credential creation options.
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity represents the user account in the credential creation options.
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialParameters specifies a public key algorithm supported by the relying party.
+type CredentialParameters struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// CredentialDescriptor identifies a registered credential.
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// AuthenticatorSelection specifies the requirements for authenticators that can be registered.
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions represents the options for navigator.credentials.create() with base64url-encoded binary values.
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions represents the options for navigator.credentials.get() with base64url-encoded binary values.
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// UserHandle returns the user handle for the specified user UID.
+func UserHandle(userUid string) string {
+	return Encode([]byte(userUid))
+}
+
+// CreationOptions returns the options for registering a new passkey and caches the challenge.
+func (rp *RelyingParty) CreationOptions(userUid, userName, displayName string, exclude []CredentialDescriptor) (*CreationOptions, error) {
+	c, err := NewChallenge(CeremonyCreate, userUid, rp.Timeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]CredentialParameters, len(Algorithms))
+
+	for i, alg := range Algorithms {
+		params[i] = CredentialParameters{Type: CredentialType, Alg: alg}
+	}
+
+	if exclude == nil {
+		exclude = []CredentialDescriptor{}
+	}
+
+	if displayName == "" {
+		displayName = userName
+	}
+
+	// Attestation statements are not requested, since the authenticator models are not restricted.
+	return &CreationOptions{
+		Challenge:          c,
+		RP:                 RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User:               UserEntity{ID: UserHandle(userUid), Name: userName, DisplayName: displayName},
+		PubKeyCredParams:   params,
+		Timeout:            rp.Timeout.Milliseconds(),
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: UserVerificationPreferred,
+		},
+		Attestation: "none",
+	}, nil
+}
+
+// RequestOptions returns the options for authenticating with a passkey and caches the challenge.
+func (rp *RelyingParty) RequestOptions(userUid string, allow []CredentialDescriptor) (*RequestOptions, error) {
+	c, err := NewChallenge(CeremonyGet, userUid, rp.Timeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if allow == nil {
+		allow = []CredentialDescriptor{}
+	}
+
+	return &RequestOptions{
+		Challenge:        c,
+		Timeout:          rp.Timeout.Milliseconds(),
+		RPID:             rp.ID,
+		AllowCredentials: allow,
+		UserVerification: UserVerificationPreferred,
+	}, nil
+}
@@ -0,0 +1,195 @@
+package webauthn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testOrigin  = "https://photos.example.com"
+	testUserUid = "uqxetse3cy5eo9z2"
+)
+
+func TestNewRelyingParty(t *testing.T) {
+	rp := NewRelyingParty("Photos.Example.com", "PhotoPrism", testOrigin+"/")
+
+	assert.Equal(t, "photos.example.com", rp.ID)
+	assert.Equal(t, "PhotoPrism", rp.Name)
+	assert.Equal(t, testOrigin, rp.Origin)
+	assert.Equal(t, DefaultTimeout, rp.Timeout)
+}
+
+func TestRelyingParty_CreationOptions(t *testing.T) {
+	rp := NewRelyingParty("photos.example.com", "PhotoPrism", testOrigin)
+
+	opt, err := rp.CreationOptions(testUserUid, "alice", "", []CredentialDescriptor{{Type: CredentialType, ID: "AQID"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, opt.Challenge, 43)
+	assert.Equal(t, "photos.example.com", opt.RP.ID)
+	assert.Equal(t, UserHandle(testUserUid), opt.User.ID)
+	assert.Equal(t, "alice", opt.User.DisplayName)
+	assert.Len(t, opt.PubKeyCredParams, len(Algorithms))
+	assert.Len(t, opt.ExcludeCredentials, 1)
+	assert.Equal(t, int64(300000), opt.Timeout)
+	assert.Equal(t, "none", opt.Attestation)
+}
+
+func TestRelyingParty_VerifyRegistration(t *testing.T) {
+	rp := NewRelyingParty("photos.example.com", "PhotoPrism", testOrigin)
+
+	t.Run("Success", func(t *testing.T) {
+		a, err := NewAuthenticator()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		opt, err := rp.CreationOptions(testUserUid, "alice", "Alice", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		att, err := a.Create(testOrigin, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cred, err := rp.VerifyRegistration(testUserUid, att)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, a.CredentialID, cred.ID)
+		assert.Equal(t, a.PublicKey(), cred.PublicKey)
+		assert.Equal(t, AlgES256, cred.Alg)
+		assert.Equal(t, []string{"internal"}, cred.Transports)
+		assert.True(t, cred.UserVerified)
+
+		// Challenges can only be used once.
+		_, err = rp.VerifyRegistration(testUserUid, att)
+		assert.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+	t.Run("WrongUser", func(t *testing.T) {
+		a, _ := NewAuthenticator()
+		opt, _ := rp.CreationOptions(testUserUid, "alice", "Alice", nil)
+		att, _ := a.Create(testOrigin, opt)
+
+		_, err := rp.VerifyRegistration("uqxc08w3d0ej2283", att)
+		assert.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+	t.Run("WrongOrigin", func(t *testing.T) {
+		a, _ := NewAuthenticator()
+		opt, _ := rp.CreationOptions(testUserUid, "alice", "Alice", nil)
+		att, _ := a.Create("https://evil.example.com", opt)
+
+		_, err := rp.VerifyRegistration(testUserUid, att)
+		assert.ErrorIs(t, err, ErrInvalidOrigin)
+	})
+	t.Run("WrongRelyingParty", func(t *testing.T) {
+		a, _ := NewAuthenticator()
+		opt, _ := rp.CreationOptions(testUserUid, "alice", "Alice", nil)
+		opt.RP.ID = "example.com"
+		att, _ := a.Create(testOrigin, opt)
+
+		_, err := rp.VerifyRegistration(testUserUid, att)
+		assert.ErrorIs(t, err, ErrInvalidRelyingParty)
+	})
+	t.Run("WrongCeremony", func(t *testing.T) {
+		a, _ := NewAuthenticator()
+		opt, _ := rp.RequestOptions(testUserUid, nil)
+		att, _ := a.Create(testOrigin, &CreationOptions{Challenge: opt.Challenge, RP: RelyingPartyEntity{ID: rp.ID}})
+
+		_, err := rp.VerifyRegistration(testUserUid, att)
+		assert.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+}
+
+func TestRelyingParty_VerifyAssertion(t *testing.T) {
+	rp := NewRelyingParty("photos.example.com", "PhotoPrism", testOrigin)
+
+	a, err := NewAuthenticator()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		opt, err := rp.RequestOptions(testUserUid, []CredentialDescriptor{{Type: CredentialType, ID: Encode(a.CredentialID)}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos.example.com", opt.RPID)
+		assert.Len(t, opt.AllowCredentials, 1)
+
+		res, err := a.Get(testOrigin, opt, testUserUid)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Encode(a.CredentialID), res.CredentialID())
+
+		signCount, err := rp.VerifyAssertion(testUserUid, res, a.PublicKey(), 0, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), signCount)
+	})
+	t.Run("SignCount", func(t *testing.T) {
+		opt, _ := rp.RequestOptions(testUserUid, nil)
+		res, _ := a.Get(testOrigin, opt, testUserUid)
+
+		_, err := rp.VerifyAssertion(testUserUid, res, a.PublicKey(), 5, true)
+		assert.ErrorIs(t, err, ErrSignCount)
+	})
+	t.Run("WrongKey", func(t *testing.T) {
+		other, _ := NewAuthenticator()
+		opt, _ := rp.RequestOptions(testUserUid, nil)
+		res, _ := a.Get(testOrigin, opt, testUserUid)
+
+		_, err := rp.VerifyAssertion(testUserUid, res, other.PublicKey(), 0, true)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+	t.Run("WrongUserHandle", func(t *testing.T) {
+		opt, _ := rp.RequestOptions(testUserUid, nil)
+		res, _ := a.Get(testOrigin, opt, "uqxc08w3d0ej2283")
+
+		_, err := rp.VerifyAssertion(testUserUid, res, a.PublicKey(), 0, true)
+		assert.ErrorIs(t, err, ErrInvalidUserHandle)
+	})
+	t.Run("UserNotVerified", func(t *testing.T) {
+		a.UserVerified = false
+		defer func() { a.UserVerified = true }()
+
+		opt, _ := rp.RequestOptions(testUserUid, nil)
+		res, _ := a.Get(testOrigin, opt, testUserUid)
+
+		_, err := rp.VerifyAssertion(testUserUid, res, a.PublicKey(), 0, true)
+		assert.ErrorIs(t, err, ErrUserNotVerified)
+
+		opt, _ = rp.RequestOptions(testUserUid, nil)
+		res, _ = a.Get(testOrigin, opt, testUserUid)
+
+		_, err = rp.VerifyAssertion(testUserUid, res, a.PublicKey(), 0, false)
+		assert.NoError(t, err)
+	})
+	t.Run("InvalidChallenge", func(t *testing.T) {
+		res, _ := a.Get(testOrigin, &RequestOptions{Challenge: "AAAA", RPID: rp.ID}, testUserUid)
+
+		_, err := rp.VerifyAssertion(testUserUid, res, a.PublicKey(), 0, true)
+		assert.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		_, err := rp.VerifyAssertion(testUserUid, Assertion{Type: "password"}, a.PublicKey(), 0, true)
+		assert.ErrorIs(t, err, ErrInvalidCredential)
+	})
+}
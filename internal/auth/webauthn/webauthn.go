@@ -0,0 +1,71 @@
+/*
+Package webauthn implements the WebAuthn registration and authentication ceremonies for passkey login.
+
+Copyright (c) 2018 - 2024 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package webauthn
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+)
+
+// CredentialType is the only public key credential type defined by the WebAuthn specification.
+const CredentialType = "public-key"
+
+// Ceremony types as reported in the client data.
+const (
+	CeremonyCreate = "webauthn.create"
+	CeremonyGet    = "webauthn.get"
+)
+
+// cbor is the codec handle for decoding attestation objects and public keys.
+var cbor = newCborHandle()
+
+// newCborHandle returns a new CBOR codec handle that decodes all integers as signed values
+// and encodes maps with sorted keys.
+func newCborHandle() *codec.CborHandle {
+	h := &codec.CborHandle{}
+	h.SignedInteger = true
+	h.Canonical = true
+
+	return h
+}
+
+// Encode returns the unpadded base64url encoding of the specified bytes.
+func Encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode decodes a base64url string, with or without padding.
+func Decode(s string) ([]byte, error) {
+	s = strings.TrimRight(strings.TrimSpace(s), "=")
+
+	// Some clients use standard base64 encoding instead.
+	if strings.ContainsAny(s, "+/") {
+		return base64.RawStdEncoding.DecodeString(s)
+	}
+
+	return base64.RawURLEncoding.DecodeString(s)
+}
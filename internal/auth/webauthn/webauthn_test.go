@@ -0,0 +1,34 @@
+package webauthn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "", Encode(nil))
+	assert.Equal(t, "_-8", Encode([]byte{0xff, 0xef}))
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("URL", func(t *testing.T) {
+		b, err := Decode("_-8")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xef}, b)
+	})
+	t.Run("Padding", func(t *testing.T) {
+		b, err := Decode("_-8=")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xef}, b)
+	})
+	t.Run("Standard", func(t *testing.T) {
+		b, err := Decode("/+8=")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xef}, b)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Decode("#")
+		assert.Error(t, err)
+	})
+}
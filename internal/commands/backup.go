@@ -17,7 +17,8 @@ import (
 const backupDescription = `A custom filename for the database backup (or - to send the backup to stdout) can optionally be passed as argument.
    The --database flag can be omitted in this case. When using Docker, please run the docker command with the -T flag
    to prevent log messages from being sent to stdout. If nothing else is specified, the database and album backup paths
-   will be automatically determined based on the current configuration.`
+   will be automatically determined based on the current configuration. Backups in the portable jsonl format can be
+   restored with any supported database driver, e.g. to move an instance from SQLite to MariaDB.`
 
 // BackupCommand configures the command name, flags, and action.
 var BackupCommand = cli.Command{
@@ -27,6 +28,9 @@ var BackupCommand = cli.Command{
 	ArgsUsage:   "[filename]",
 	Flags:       backupFlags,
 	Action:      backupAction,
+	Subcommands: []cli.Command{
+		BackupVerifyCommand,
+	},
 }
 
 var backupFlags = []cli.Flag{
@@ -50,6 +54,18 @@ var backupFlags = []cli.Flag{
 		Name:  "database-path, index-path",
 		Usage: "custom database backup `PATH`",
 	},
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "database backup `FORMAT` (sql, jsonl), based on the file extension if not specified",
+	},
+	cli.BoolFlag{
+		Name:  "originals, o",
+		Usage: "create an incremental backup of originals and sidecar files, copying only new and changed files",
+	},
+	cli.StringFlag{
+		Name:  "originals-path",
+		Usage: "custom originals backup `PATH` or WebDAV URL",
+	},
 	cli.IntFlag{
 		Name:  "retain, r",
 		Usage: "`NUMBER` of database backups to keep (-1 to keep all)",
@@ -65,10 +81,13 @@ func backupAction(ctx *cli.Context) error {
 	backupDatabase := ctx.Bool("database") || fileName != "" || databasePath != ""
 	albumsPath := ctx.String("albums-path")
 	backupAlbums := ctx.Bool("albums") || albumsPath != ""
+	originalsPath := ctx.String("originals-path")
+	backupOriginals := ctx.Bool("originals") || originalsPath != ""
+	format := backup.Format(fileName, ctx.String("format"))
 	force := ctx.Bool("force")
 	retain := ctx.Int("retain")
 
-	if !backupDatabase && !backupAlbums {
+	if !backupDatabase && !backupAlbums && !backupOriginals {
 		return cli.ShowSubcommandHelp(ctx)
 	}
 
@@ -96,11 +115,11 @@ func backupAction(ctx *cli.Context) error {
 				databasePath = conf.BackupDatabasePath()
 			}
 
-			backupFile := time.Now().UTC().Format("2006-01-02") + ".sql"
+			backupFile := time.Now().UTC().Format("2006-01-02") + "." + format
 			fileName = filepath.Join(databasePath, backupFile)
 		}
 
-		if err = backup.Database(databasePath, fileName, fileName == "-", force, retain); err != nil {
+		if err = backup.Database(databasePath, fileName, format, fileName == "-", force, retain); err != nil {
 			return fmt.Errorf("failed to create database backup: %w", err)
 		}
 	}
@@ -121,6 +140,18 @@ func backupAction(ctx *cli.Context) error {
 		}
 	}
 
+	if backupOriginals {
+		if originalsPath == "" {
+			originalsPath = conf.BackupOriginalsPath()
+		}
+
+		if result, backupErr := backup.Originals(originalsPath, force, retain); backupErr != nil {
+			return fmt.Errorf("failed to back up originals: %w", backupErr)
+		} else {
+			log.Infof("backup: uploaded %s", english.Plural(result.Uploaded, "new file", "new files"))
+		}
+	}
+
 	elapsed := time.Since(start)
 
 	log.Infof("completed in %s", elapsed)
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism/backup"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// BackupVerifyCommand configures the command name, flags, and action.
+var BackupVerifyCommand = cli.Command{
+	Name:      "verify",
+	Usage:     "Re-hashes the files in an originals backup and compares them with the index",
+	ArgsUsage: "[manifest]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "originals-path",
+			Usage: "custom originals backup `PATH` or WebDAV URL",
+		},
+	},
+	Action: backupVerifyAction,
+}
+
+// backupVerifyAction verifies the latest or specified originals backup.
+func backupVerifyAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		originalsPath := ctx.String("originals-path")
+
+		if originalsPath == "" {
+			originalsPath = conf.BackupOriginalsPath()
+		}
+
+		result, err := backup.VerifyOriginals(originalsPath, ctx.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		for _, name := range result.Missing {
+			log.Errorf("backup: %s is missing", clean.Log(name))
+		}
+
+		for _, name := range result.Corrupted {
+			log.Errorf("backup: %s is corrupted", clean.Log(name))
+		}
+
+		for _, name := range result.Outdated {
+			log.Warnf("backup: %s has changed or is not backed up yet", clean.Log(name))
+		}
+
+		if len(result.Missing) > 0 || len(result.Corrupted) > 0 {
+			return fmt.Errorf("backup verification failed, %d missing and %d corrupted files", len(result.Missing), len(result.Corrupted))
+		}
+
+		return nil
+	})
+}
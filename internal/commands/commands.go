@@ -46,6 +46,9 @@ var PhotoPrism = []cli.Command{
 	StatusCommand,
 	IndexCommand,
 	FindCommand,
+	DuplicatesCommand,
+	TimeShiftCommand,
+	GeotagCommand,
 	ImportCommand,
 	CopyCommand,
 	FacesCommands,
@@ -64,6 +67,7 @@ var PhotoPrism = []cli.Command{
 	PasswdCommand,
 	UsersCommands,
 	ClientsCommands,
+	TeamsCommands,
 	AuthCommands,
 	ShowCommands,
 	VersionCommand,
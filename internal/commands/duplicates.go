@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/search"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/media/phash"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// DuplicatesCommand configures the command name, flags, and action.
+var DuplicatesCommand = cli.Command{
+	Name:  "duplicates",
+	Usage: "Finds visually near-identical pictures such as bursts, re-saved files, and resized copies",
+	Flags: append(report.CliFlags,
+		cli.IntFlag{
+			Name:  "distance, d",
+			Usage: "maximum perceptual hash `DISTANCE` in bits for pictures to be considered similar",
+			Value: phash.DefaultDistance,
+		},
+		cli.BoolFlag{
+			Name:  "stack, s",
+			Usage: "stack similar pictures so that the files of each group are shown as one picture",
+		},
+	),
+	Action: duplicatesAction,
+}
+
+// duplicatesAction finds and optionally stacks visually similar pictures.
+func duplicatesAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		maxDist := ctx.Int("distance")
+
+		if maxDist < 0 || maxDist > phash.MaxDistance {
+			return fmt.Errorf("distance must be between 0 and %d", phash.MaxDistance)
+		}
+
+		groups, err := search.SimilarGroups(maxDist)
+
+		if err != nil {
+			return err
+		}
+
+		cols := []string{"Group", "Photo UID", "File Name", "Resolution", "Distance"}
+		rows := make([][]string, 0, len(groups)*2)
+
+		for i, group := range groups {
+			for _, f := range group {
+				rows = append(rows, []string{
+					strconv.Itoa(i + 1),
+					f.PhotoUID,
+					f.FileName,
+					fmt.Sprintf("%dx%d", f.FileWidth, f.FileHeight),
+					strconv.Itoa(f.Distance),
+				})
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(result)
+
+		if !ctx.Bool("stack") {
+			log.Infof("found %s of similar pictures", english.Plural(len(groups), "group", "groups"))
+			return nil
+		}
+
+		// Stack similar pictures, keeping the one with the best quality.
+		stacked := 0
+
+		for _, group := range groups {
+			photos := make(entity.Photos, 0, len(group))
+
+			for _, f := range group {
+				if p := entity.FindPhoto(entity.Photo{PhotoUID: f.PhotoUID}); p != nil {
+					photos = append(photos, *p)
+				}
+			}
+
+			if original, merged, mergeErr := entity.MergePhotos(photos); mergeErr != nil {
+				log.Errorf("duplicates: %s (stack %s)", mergeErr, clean.Log(group[0].PhotoUID))
+			} else if len(merged) > 0 {
+				log.Infof("duplicates: stacked %s with %s", clean.Log(original.PhotoUID), english.Plural(len(merged), "similar picture", "similar pictures"))
+				stacked += len(merged)
+			}
+		}
+
+		if stacked > 0 {
+			if err = entity.UpdateCounts(); err != nil {
+				log.Warnf("duplicates: %s (update counts)", err)
+			}
+		}
+
+		log.Infof("stacked %s", english.Plural(stacked, "similar picture", "similar pictures"))
+
+		return nil
+	})
+}
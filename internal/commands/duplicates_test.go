@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+func TestDuplicatesCommand(t *testing.T) {
+	t.Run("Csv", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"duplicates", "--csv"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = DuplicatesCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Group;Photo UID;File Name;Resolution;Distance")
+	})
+	t.Run("InvalidDistance", func(t *testing.T) {
+		ctx := NewTestContext([]string{"duplicates", "--distance", "65"})
+
+		err := DuplicatesCommand.Run(ctx)
+
+		assert.Error(t, err)
+	})
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
@@ -10,12 +11,14 @@ import (
 	"github.com/manifoldco/promptui"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/ai/face"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt/report"
 )
 
 // FacesCommands configures the command name, flags, and action.
@@ -31,12 +34,16 @@ var FacesCommands = cli.Command{
 		{
 			Name:  "audit",
 			Usage: "Scans the index for issues",
-			Flags: []cli.Flag{
+			Flags: append(report.CliFlags,
 				cli.BoolFlag{
 					Name:  "fix, f",
 					Usage: "fix discovered issues",
 				},
-			},
+				cli.BoolFlag{
+					Name:  "report, r",
+					Usage: "show quality metrics of face clusters, e.g. to tune clustering",
+				},
+			),
 			Action: facesAuditAction,
 		},
 		{
@@ -64,6 +71,29 @@ var FacesCommands = cli.Command{
 					Name:  "force, f",
 					Usage: "update all faces",
 				},
+				cli.StringFlag{
+					Name:  "algorithm, a",
+					Usage: fmt.Sprintf("clustering `ALGORITHM` (%s)", strings.Join(photoprism.ClusterAlgorithms, ", ")),
+					Value: photoprism.ClusterDBSCAN,
+				},
+				cli.IntFlag{
+					Name:  "core",
+					Usage: "min `NUMBER` of samples forming a cluster core (dbscan, optics)",
+					Value: face.ClusterCore,
+				},
+				cli.Float64Flag{
+					Name:  "dist",
+					Usage: "similarity `DISTANCE` threshold of samples forming a cluster core (dbscan, optics)",
+					Value: face.ClusterDist,
+				},
+				cli.Float64Flag{
+					Name:  "xi",
+					Usage: "steepness `THRESHOLD` for extracting clusters (optics)",
+				},
+				cli.IntFlag{
+					Name:  "clusters, k",
+					Usage: "`NUMBER` of clusters, estimated if not specified (kmeans)",
+				},
 			},
 			Action: facesUpdateAction,
 		},
@@ -125,12 +155,31 @@ func facesAuditAction(ctx *cli.Context) error {
 
 	if err := w.Audit(ctx.Bool("fix")); err != nil {
 		return err
-	} else {
-		elapsed := time.Since(start)
+	}
 
-		log.Infof("completed in %s", elapsed)
+	// Show face cluster quality metrics?
+	if ctx.Bool("report") {
+		faces, err := w.Report()
+
+		if err != nil {
+			return err
+		}
+
+		rows, cols := faces.Table()
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(result)
+
+		log.Infof("faces: %s, %d with collisions, average cohesion %.4f", english.Plural(len(faces), "cluster", "clusters"), faces.Collisions(), faces.Cohesion())
 	}
 
+	log.Infof("completed in %s", time.Since(start))
+
 	return nil
 }
 
@@ -299,7 +348,16 @@ func facesUpdateAction(ctx *cli.Context) error {
 	defer conf.Shutdown()
 
 	opt := photoprism.FacesOptions{
-		Force: ctx.Bool("force"),
+		Force:     ctx.Bool("force"),
+		Algorithm: ctx.String("algorithm"),
+		Core:      ctx.Int("core"),
+		Dist:      ctx.Float64("dist"),
+		Xi:        ctx.Float64("xi"),
+		Clusters:  ctx.Int("clusters"),
+	}
+
+	if !opt.ValidAlgorithm() {
+		return fmt.Errorf("unknown clustering algorithm %s", clean.Log(opt.Algorithm))
 	}
 
 	w := get.Faces()
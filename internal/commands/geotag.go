@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// GeotagCommand configures the command name, flags, and action.
+var GeotagCommand = cli.Command{
+	Name:      "geotag",
+	Usage:     "Assigns coordinates from GPX, KML, and GeoJSON tracklogs to pictures without location",
+	ArgsUsage: "[track file]...",
+	Description: "Track files passed as arguments are added to the storage folder, so they are also used for geotagging\n" +
+		"   in the future. Pictures are only matched if the time between the two nearest track points does not\n" +
+		"   exceed the maximum gap. Use --dry-run to see which pictures would be updated.",
+	Flags: append(report.CliFlags,
+		cli.DurationFlag{
+			Name:  "max-gap, g",
+			Usage: "maximum time `GAP` between two track points, e.g. 10m (defaults to the track-max-gap config value)",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only report matching pictures without updating the index",
+		},
+	),
+	Action: geotagAction,
+}
+
+// geotagAction assigns coordinates from tracklogs to pictures without location.
+func geotagAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		w := get.Geotag()
+
+		// Add tracklogs passed as arguments.
+		for _, fileName := range ctx.Args() {
+			info, err := w.Import(fileName, filepath.Base(fileName))
+
+			if err != nil {
+				return fmt.Errorf("%s in %s", err, clean.Log(fileName))
+			}
+
+			log.Infof("geotag: added %s with %s", clean.Log(info.FileName), english.Plural(info.Points, "point", "points"))
+		}
+
+		tracks, _, err := w.Tracks()
+
+		if err != nil {
+			return err
+		}
+
+		opt := w.DefaultOptions()
+		opt.DryRun = ctx.Bool("dry-run")
+
+		if gap := ctx.Duration("max-gap"); gap > 0 {
+			opt.MaxGap = gap
+		}
+
+		results, err := w.Start(tracks, opt)
+
+		if err != nil {
+			return err
+		}
+
+		cols := []string{"Photo UID", "Name", "Taken At", "Latitude", "Longitude", "Altitude", "Track", "Updated"}
+		rows := make([][]string, 0, len(results))
+
+		for _, r := range results {
+			rows = append(rows, []string{
+				r.PhotoUID,
+				r.PhotoName,
+				r.TakenAt.Format(time.DateTime),
+				fmt.Sprintf("%f", r.Lat),
+				fmt.Sprintf("%f", r.Lng),
+				fmt.Sprintf("%d m", r.Altitude),
+				r.Track,
+				report.Bool(r.Updated, report.Yes, report.No),
+			})
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(result)
+
+		return nil
+	})
+}
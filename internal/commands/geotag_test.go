@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+func TestGeotagCommand(t *testing.T) {
+	t.Run("DryRun", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"geotag", "--csv", "--dry-run", "--max-gap", "5m", "../../pkg/geo/track/testdata/track.gpx"})
+
+		defer os.Remove(filepath.Join(get.Config().TracksPath(), "track.gpx"))
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = GeotagCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Photo UID;Name;Taken At;Latitude;Longitude;Altitude;Track;Updated")
+	})
+	t.Run("InvalidTrack", func(t *testing.T) {
+		ctx := NewTestContext([]string{"geotag", "geotag_test.go"})
+
+		err := GeotagCommand.Run(ctx)
+
+		assert.Error(t, err)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/internal/service/hub/places"
 )
 
 // PlacesCommands configures the command name, flags, and action.
@@ -51,8 +52,8 @@ func placesUpdateAction(ctx *cli.Context) error {
 	// Force update of all locations?
 	force := ctx.Bool("force")
 
-	// Show info in case the force option is used without support.
-	if force && !conf.Sponsor() && !conf.Test() {
+	// Show info in case the force option is used with the places API without support.
+	if force && conf.GeoApi() == places.ApiName && !conf.Sponsor() && !conf.Test() {
 		log.Errorf("Since updating the location details of all pictures puts a high load on our infrastructure, this option cannot be used with our Community Edition.")
 		return nil
 	}
@@ -28,6 +28,9 @@ var ConfigReports = []Report{
 	{Title: "OpenID Connect (OIDC)", NoWrap: true, Report: func(conf *config.Config) ([][]string, []string) {
 		return conf.OIDCReport()
 	}},
+	{Title: "LDAP / Active Directory", NoWrap: true, Report: func(conf *config.Config) ([][]string, []string) {
+		return conf.LDAPReport()
+	}},
 }
 
 // showConfigAction shows global config option names and values.
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"github.com/urfave/cli"
+)
+
+// Usage hints for the team management subcommands.
+const (
+	TeamNameUsage        = "team `NAME` for display in the interface"
+	TeamDescriptionUsage = "team `DESCRIPTION`"
+	TeamAddMemberUsage   = "add a member by `USERNAME` (can be specified multiple times)"
+	TeamRemoveUsage      = "remove a member by `USERNAME` (can be specified multiple times)"
+	TeamShareUsage       = "share an album or folder by `UID` with the team (can be specified multiple times)"
+	TeamPermUsage        = "permission `LEVEL` for shared albums and folders (view, react, comment, upload)"
+	TeamUnshareUsage     = "stop sharing an album or folder by `UID` with the team (can be specified multiple times)"
+)
+
+// TeamsCommands configures the team management subcommands.
+var TeamsCommands = cli.Command{
+	Name:    "teams",
+	Aliases: []string{"team"},
+	Usage:   "Team management subcommands",
+	Subcommands: []cli.Command{
+		TeamsListCommand,
+		TeamsAddCommand,
+		TeamsModCommand,
+		TeamsRemoveCommand,
+	},
+}
+
+// TeamMemberFlags specifies the add and modify team command flags.
+var TeamMemberFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "description, d",
+		Usage: TeamDescriptionUsage,
+	},
+	cli.StringSliceFlag{
+		Name:  "add, a",
+		Usage: TeamAddMemberUsage,
+	},
+	cli.StringSliceFlag{
+		Name:  "share, s",
+		Usage: TeamShareUsage,
+	},
+	cli.StringFlag{
+		Name:  "perm, p",
+		Usage: TeamPermUsage,
+		Value: "view",
+	},
+}
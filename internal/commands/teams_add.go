@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// TeamsAddCommand configures the command name, flags, and action.
+var TeamsAddCommand = cli.Command{
+	Name:      "add",
+	Usage:     "Creates a new team",
+	ArgsUsage: "[name]",
+	Flags:     TeamMemberFlags,
+	Action:    teamsAddAction,
+}
+
+// teamsAddAction creates a new team with the specified members and shares.
+func teamsAddAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		name := clean.Name(ctx.Args().First())
+
+		if name == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		} else if found := entity.FindTeam(name); found != nil {
+			return fmt.Errorf("team %s already exists", clean.LogQuote(name))
+		} else if len(ctx.StringSlice("share")) > 0 && entity.TeamPerm(ctx.String("perm")) == entity.PermDefault {
+			return fmt.Errorf("invalid permission level %s", clean.LogQuote(ctx.String("perm")))
+		}
+
+		m := entity.NewTeam("", "").SetValuesFromForm(form.Team{TeamName: name, TeamDescription: ctx.String("description")})
+
+		if err := m.Create(); err != nil {
+			return err
+		}
+
+		if err := teamsModMembersAndShares(ctx, m); err != nil {
+			return err
+		}
+
+		log.Infof("team %s has been created", m.String())
+
+		return nil
+	})
+}
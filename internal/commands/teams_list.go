@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// TeamsListCommand configures the command name, flags, and action.
+var TeamsListCommand = cli.Command{
+	Name:   "ls",
+	Usage:  "Lists teams with their members and shares",
+	Flags:  report.CliFlags,
+	Action: teamsListAction,
+}
+
+// teamsListAction lists all teams.
+func teamsListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		cols := []string{"UID", "Name", "Description", "Members", "Shares", "Created At"}
+
+		teams := entity.FindTeams()
+
+		if len(teams) == 0 {
+			log.Warnf("no teams found")
+			return nil
+		}
+
+		// Show log message.
+		log.Infof("found %s", english.Plural(len(teams), "team", "teams"))
+
+		rows := make([][]string, len(teams))
+
+		// Display report.
+		for i, team := range teams {
+			members := team.Members()
+			names := make([]string, len(members))
+
+			for j := range members {
+				names[j] = members[j].Username()
+			}
+
+			rows[i] = []string{
+				team.GetUID(),
+				team.TeamName,
+				team.TeamDescription,
+				english.OxfordWordSeries(names, "and"),
+				fmt.Sprintf("%d", len(team.Shares())),
+				team.CreatedAt.Format("2006-01-02 15:04:05"),
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// TeamsModCommand configures the command name, flags, and action.
+var TeamsModCommand = cli.Command{
+	Name:      "mod",
+	Usage:     "Changes the team name, members, and shares",
+	ArgsUsage: "[team]",
+	Flags: append(TeamMemberFlags,
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: TeamNameUsage,
+		},
+		cli.StringSliceFlag{
+			Name:  "remove, r",
+			Usage: TeamRemoveUsage,
+		},
+		cli.StringSliceFlag{
+			Name:  "unshare, u",
+			Usage: TeamUnshareUsage,
+		},
+	),
+	Action: teamsModAction,
+}
+
+// teamsModAction modifies an existing team.
+func teamsModAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := ctx.Args().First()
+
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m := entity.FindTeam(id)
+
+		if m == nil {
+			return fmt.Errorf("team %s not found", clean.LogQuote(id))
+		}
+
+		// Change name and description?
+		if ctx.IsSet("name") || ctx.IsSet("description") {
+			f := form.Team{TeamName: m.TeamName, TeamDescription: m.TeamDescription}
+
+			if ctx.IsSet("name") {
+				f.TeamName = ctx.String("name")
+			}
+
+			if ctx.IsSet("description") {
+				f.TeamDescription = ctx.String("description")
+			}
+
+			if err := m.SetValuesFromForm(f).Save(); err != nil {
+				return err
+			}
+		}
+
+		// Remove members.
+		for _, userName := range ctx.StringSlice("remove") {
+			if user := entity.FindUserByName(userName); user == nil {
+				return fmt.Errorf("user %s not found", clean.LogQuote(userName))
+			} else if err := m.RemoveMember(user); err != nil {
+				return err
+			}
+		}
+
+		// Stop sharing albums and folders.
+		for _, uid := range ctx.StringSlice("unshare") {
+			if err := m.Unshare(clean.UID(uid)); err != nil {
+				return err
+			}
+		}
+
+		if err := teamsModMembersAndShares(ctx, m); err != nil {
+			return err
+		}
+
+		log.Infof("team %s has been updated", m.String())
+
+		return nil
+	})
+}
+
+// teamsModMembersAndShares adds the members and shares specified in the command flags.
+func teamsModMembersAndShares(ctx *cli.Context, m *entity.Team) error {
+	for _, userName := range ctx.StringSlice("add") {
+		if user := entity.FindUserByName(userName); user == nil || user.IsDeleted() {
+			return fmt.Errorf("user %s not found", clean.LogQuote(userName))
+		} else if err := m.AddMember(user); err != nil {
+			return err
+		}
+	}
+
+	shares := ctx.StringSlice("share")
+
+	if len(shares) == 0 {
+		return nil
+	}
+
+	perm := entity.TeamPerm(ctx.String("perm"))
+
+	if perm == entity.PermDefault {
+		return fmt.Errorf("invalid permission level %s", clean.LogQuote(ctx.String("perm")))
+	}
+
+	for _, uid := range shares {
+		if err := m.Share(clean.UID(uid), perm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
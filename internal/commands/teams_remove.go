@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// TeamsRemoveCommand configures the command name, flags, and action.
+var TeamsRemoveCommand = cli.Command{
+	Name:      "rm",
+	Usage:     "Deletes the specified team with its members and shares",
+	ArgsUsage: "[team]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "don't ask for confirmation",
+		},
+	},
+	Action: teamsRemoveAction,
+}
+
+// teamsRemoveAction deletes a team.
+func teamsRemoveAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := ctx.Args().First()
+
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m := entity.FindTeam(id)
+
+		if m == nil {
+			return fmt.Errorf("team %s not found", clean.LogQuote(id))
+		}
+
+		if !ctx.Bool("force") {
+			actionPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Delete team %s?", m.String()),
+				IsConfirm: true,
+			}
+
+			if _, err := actionPrompt.Run(); err != nil {
+				log.Infof("team %s was not deleted", m.String())
+				return nil
+			}
+		}
+
+		if err := m.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("team %s has been deleted", m.String())
+
+		return nil
+	})
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+func TestTeamsCommands(t *testing.T) {
+	t.Run("AddInvalidPerm", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"add", "--share=as6sg6bxpogaaba7", "--perm=owner", "Invalid Team"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = TeamsAddCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.Error(t, err)
+		assert.Empty(t, output)
+	})
+	t.Run("Add", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"add", "--description=Our photo club", "--add=guest", "--add=bob", "--share=as6sg6bxpogaaba7", "--perm=comment", "Command Team"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = TeamsAddCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.NoError(t, err)
+		assert.Empty(t, output)
+	})
+	t.Run("AddTeamThatAlreadyExists", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"add", "command team"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = TeamsAddCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.Error(t, err)
+		assert.Empty(t, output)
+	})
+	t.Run("List", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"ls", "--md"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = TeamsListCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		// t.Logf(output)
+		assert.NoError(t, err)
+		assert.Contains(t, output, "| Command Team | Our photo club | bob and guest |      1 |")
+		assert.NotContains(t, output, "Invalid Team")
+	})
+	t.Run("ModNotExistingTeam", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"mod", "--name=Foo", "missing-team"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = TeamsModCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.Error(t, err)
+		assert.Empty(t, output)
+	})
+	t.Run("Mod", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"mod", "--name=Renamed Team", "--remove=bob", "--unshare=as6sg6bxpogaaba7", "--share=as6sg6bipogaaba1", "--share=as6sg6bxpogaaba8", "command-team"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = TeamsModCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.NoError(t, err)
+		assert.Empty(t, output)
+
+		// Run command with test context.
+		output = capture.Output(func() {
+			err = TeamsListCommand.Run(NewTestContext([]string{"ls", "--md"}))
+		})
+
+		// Check command output for plausibility.
+		// t.Logf(output)
+		assert.NoError(t, err)
+		assert.Contains(t, output, "| Renamed Team | Our photo club | guest   |      2 |")
+		assert.NotContains(t, output, "Command Team")
+	})
+	t.Run("Remove", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"rm", "--force", "renamed team"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = TeamsRemoveCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.NoError(t, err)
+		assert.Empty(t, output)
+
+		// Run command with test context.
+		output = capture.Output(func() {
+			err = TeamsRemoveCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.Error(t, err)
+		assert.Empty(t, output)
+	})
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// TimeShiftCommand configures the command name, flags, and action.
+var TimeShiftCommand = cli.Command{
+	Name:      "timeshift",
+	Usage:     "Corrects the date and time of pictures taken with a wrong camera clock",
+	ArgsUsage: "[photo or album uid]...",
+	Description: "Pictures can be selected by photo or album UID and/or camera serial number. The time offset is either specified\n" +
+		"   with the --offset flag, e.g. -1h30m, or derived from the correct local time of a reference photo.\n" +
+		"   Local times are updated based on the time zone at the picture location, if known.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "offset, o",
+			Usage: "time `OFFSET` to be added, e.g. 2h or -1h30m",
+		},
+		cli.StringFlag{
+			Name:  "ref",
+			Usage: "reference photo `UID` to derive the offset from",
+		},
+		cli.StringFlag{
+			Name:  "ref-time",
+			Usage: "correct local `TIME` of the reference photo, e.g. \"2024-06-01 14:30:00\"",
+		},
+		cli.StringFlag{
+			Name:  "serial, s",
+			Usage: "only pictures taken with the camera that has this `SERIAL` number",
+		},
+		cli.StringFlag{
+			Name:  "after",
+			Usage: "only pictures taken on or after this local `TIME`",
+		},
+		cli.StringFlag{
+			Name:  "before",
+			Usage: "only pictures taken on or before this local `TIME`",
+		},
+	},
+	Action: timeShiftAction,
+}
+
+// timeShiftAction corrects the date and time of multiple pictures.
+func timeShiftAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		f := form.TimeShift{
+			Offset:  ctx.String("offset"),
+			RefUID:  clean.UID(ctx.String("ref")),
+			RefTime: ctx.String("ref-time"),
+			Serial:  ctx.String("serial"),
+			After:   ctx.String("after"),
+			Before:  ctx.String("before"),
+		}
+
+		for _, arg := range ctx.Args() {
+			uid := clean.UID(arg)
+
+			switch {
+			case rnd.IsUID(uid, entity.PhotoUID):
+				f.Photos = append(f.Photos, uid)
+			case rnd.IsUID(uid, entity.AlbumUID):
+				f.Albums = append(f.Albums, uid)
+			default:
+				return fmt.Errorf("invalid photo or album uid %s", clean.Log(arg))
+			}
+		}
+
+		if f.Empty() && strings.TrimSpace(f.Serial) == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		updated, err := get.TimeShift().Start(f, nil)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("shifted date and time of %s", english.Plural(len(updated), "picture", "pictures"))
+
+		return nil
+	})
+}
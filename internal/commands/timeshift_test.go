@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeShiftCommand(t *testing.T) {
+	t.Run("Serial", func(t *testing.T) {
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"timeshift", "--serial", "123", "--offset", "1h"})
+
+		// Run command with test context.
+		err := TimeShiftCommand.Run(ctx)
+
+		assert.NoError(t, err)
+
+		// Shift back to the original time.
+		ctx = NewTestContext([]string{"timeshift", "--serial", "123", "--offset", "-1h"})
+
+		err = TimeShiftCommand.Run(ctx)
+
+		assert.NoError(t, err)
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		ctx := NewTestContext([]string{"timeshift", "--offset", "1h", "foo"})
+
+		err := TimeShiftCommand.Run(ctx)
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidOffset", func(t *testing.T) {
+		ctx := NewTestContext([]string{"timeshift", "--offset", "1 hour", "ps6sg6bexxvl0yh0"})
+
+		err := TimeShiftCommand.Run(ctx)
+
+		assert.Error(t, err)
+	})
+}
@@ -12,8 +12,8 @@ const (
 	UserNameUsage     = "full `NAME` for display in the interface"
 	UserEmailUsage    = "unique `EMAIL` address of the user"
 	UserPasswordUsage = "`PASSWORD` for local authentication (8-72 characters)"
-	UserRoleUsage     = "user account `ROLE` (admin or guest)"
-	UserAuthUsage     = "authentication `PROVIDER` (default, local, oidc or none)"
+	UserRoleUsage     = "user account `ROLE` (admin, guest, or a custom role from roles.yml)"
+	UserAuthUsage     = "authentication `PROVIDER` (default, local, oidc, ldap or none)"
 	UserAuthIDUsage   = "authentication `ID` e.g. Subject ID or Distinguished Name (DN)"
 	UserAdminUsage    = "make user super admin with full access"
 	UserNoLoginUsage  = "disable login on the web interface"
@@ -33,6 +33,7 @@ var UsersCommands = cli.Command{
 		UsersShowCommand,
 		UsersModCommand,
 		UsersRemoveCommand,
+		UsersPasskeysCommand,
 		UsersResetCommand,
 	},
 }
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// UsersPasskeysCommand configures the passkey management subcommands.
+var UsersPasskeysCommand = cli.Command{
+	Name:    "passkeys",
+	Aliases: []string{"passkey"},
+	Usage:   "Passkey management subcommands",
+	Subcommands: []cli.Command{
+		UsersPasskeysListCommand,
+		UsersPasskeysRemoveCommand,
+	},
+}
+
+// UsersPasskeysListCommand configures the command name, flags, and action.
+var UsersPasskeysListCommand = cli.Command{
+	Name:      "ls",
+	Usage:     "Lists the passkeys registered for a user account",
+	ArgsUsage: "[username]",
+	Flags:     report.CliFlags,
+	Action:    usersPasskeysListAction,
+}
+
+// UsersPasskeysRemoveCommand configures the command name, flags, and action.
+var UsersPasskeysRemoveCommand = cli.Command{
+	Name:      "rm",
+	Usage:     "Removes a passkey so that it can no longer be used to log in",
+	ArgsUsage: "[username] [passkey]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "remove all passkeys of the user",
+		},
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "don't ask for confirmation",
+		},
+	},
+	Action: usersPasskeysRemoveAction,
+}
+
+// findPasskeyUser returns the user specified by name or uid.
+func findPasskeyUser(id string) (*entity.User, error) {
+	var m *entity.User
+
+	if rnd.IsUID(id, entity.UserUID) {
+		m = entity.FindUserByUID(id)
+	} else {
+		m = entity.FindUserByName(id)
+	}
+
+	if m == nil || m.IsDeleted() {
+		return nil, fmt.Errorf("user %s not found", clean.LogQuote(id))
+	}
+
+	return m, nil
+}
+
+// usersPasskeysListAction lists the passkeys of a user.
+func usersPasskeysListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.Username(ctx.Args().First())
+
+		// Name or UID provided?
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		user, err := findPasskeyUser(id)
+
+		if err != nil {
+			return err
+		}
+
+		passkeys := user.Passkeys()
+
+		if len(passkeys) == 0 {
+			log.Warnf("no passkeys found for user %s", user.String())
+			return nil
+		}
+
+		// Show log message.
+		log.Infof("found %s", english.Plural(len(passkeys), "passkey", "passkeys"))
+
+		cols := []string{"UID", "Name", "Transports", "Last Used", "Created At"}
+		rows := make([][]string, len(passkeys))
+
+		for i, m := range passkeys {
+			rows[i] = []string{
+				m.PasskeyUID,
+				m.KeyName,
+				m.Transports,
+				report.DateTime(m.LastUsedAt),
+				report.DateTime(&m.CreatedAt),
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
+
+// usersPasskeysRemoveAction removes one or all passkeys of a user.
+func usersPasskeysRemoveAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.Username(ctx.Args().First())
+		uid := clean.UID(ctx.Args().Get(1))
+
+		// Name or UID and passkey provided?
+		if id == "" || uid == "" && !ctx.Bool("all") {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		user, err := findPasskeyUser(id)
+
+		if err != nil {
+			return err
+		}
+
+		var passkeys entity.Passkeys
+
+		if ctx.Bool("all") {
+			passkeys = user.Passkeys()
+		} else if m := entity.FindPasskey(uid); m != nil && m.UserUID == user.UserUID {
+			passkeys = entity.Passkeys{*m}
+		}
+
+		if len(passkeys) == 0 {
+			return fmt.Errorf("no matching passkeys found for user %s", user.String())
+		}
+
+		if !ctx.Bool("force") {
+			actionPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Remove %s of user %s?", english.Plural(len(passkeys), "passkey", "passkeys"), user.String()),
+				IsConfirm: true,
+			}
+
+			if _, err = actionPrompt.Run(); err != nil {
+				log.Infof("passkeys of user %s were not removed", user.String())
+				return nil
+			}
+		}
+
+		for i := range passkeys {
+			if err = passkeys[i].Delete(); err != nil {
+				return err
+			}
+
+			log.Infof("passkey %s of user %s has been removed", passkeys[i].String(), user.String())
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+func TestUsersPasskeysCommand(t *testing.T) {
+	t.Run("ListNoPasskeys", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"ls", "alice"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = UsersPasskeysListCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.NoError(t, err)
+		assert.Empty(t, output)
+	})
+	t.Run("ListUserNotFound", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"ls", "xxx"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = UsersPasskeysListCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.Error(t, err)
+		assert.Empty(t, output)
+	})
+	t.Run("RemoveNotFound", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"rm", "--force", "alice", "wsg73p55zwgr1xxx"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = UsersPasskeysRemoveCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.Error(t, err)
+		assert.Empty(t, output)
+	})
+	t.Run("RemoveAllNotFound", func(t *testing.T) {
+		var err error
+
+		// Create test context with flags and arguments.
+		ctx := NewTestContext([]string{"rm", "--force", "--all", "bob"})
+
+		// Run command with test context.
+		output := capture.Output(func() {
+			err = UsersPasskeysRemoveCommand.Run(ctx)
+		})
+
+		// Check command output for plausibility.
+		assert.Error(t, err)
+		assert.Empty(t, output)
+	})
+}
@@ -56,7 +56,7 @@ func usersResetAction(ctx *cli.Context) error {
 		db := conf.Db()
 
 		// Drop existing user management tables.
-		if err := db.DropTableIfExists(entity.User{}, entity.UserDetails{}, entity.UserSettings{}, entity.UserShare{}, entity.Passcode{}, entity.Session{}).Error; err != nil {
+		if err := db.DropTableIfExists(entity.User{}, entity.UserDetails{}, entity.UserSettings{}, entity.UserShare{}, entity.Passcode{}, entity.Passkey{}, entity.Session{}).Error; err != nil {
 			return err
 		}
 
@@ -85,6 +85,11 @@ func usersResetAction(ctx *cli.Context) error {
 			return err
 		}
 
+		// Re-create passkeys.
+		if err := db.CreateTable(entity.Passkey{}).Error; err != nil {
+			return err
+		}
+
 		// Re-create auth_sessions.
 		if err := db.CreateTable(entity.Session{}).Error; err != nil {
 			return err
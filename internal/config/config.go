@@ -50,6 +50,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/service/geonames"
 	"github.com/photoprism/photoprism/internal/service/hub"
 	"github.com/photoprism/photoprism/internal/service/hub/places"
 	"github.com/photoprism/photoprism/internal/thumb"
@@ -213,6 +214,11 @@ func (c *Config) Init() error {
 	// Load settings from the "settings.yml" config file.
 	c.initSettings()
 
+	// Load custom user roles from the "roles.yml" config file, if it exists.
+	if err := c.initRoles(); err != nil {
+		return err
+	}
+
 	// Connect to database.
 	if err := c.connectDb(); err != nil {
 		return err
@@ -261,6 +267,7 @@ func (c *Config) Propagate() {
 
 	// Set geocoding parameters.
 	places.UserAgent = c.UserAgent()
+	geonames.DataPath = c.GeoNamesPath()
 	entity.GeoApi = c.GeoApi()
 
 	// Set session cache duration.
@@ -272,6 +279,15 @@ func (c *Config) Propagate() {
 	// Set path for user assets.
 	entity.UsersPath = c.UsersPath()
 
+	// Set library mode for registered users.
+	entity.LibraryMode = c.LibraryMode()
+
+	// Set LDAP client for password authentication, if enabled.
+	entity.LdapClient = c.LDAP()
+
+	// Set WebAuthn relying party for passkey authentication, if enabled.
+	entity.WebAuthn = c.WebAuthn()
+
 	// Set API preview and download default tokens.
 	entity.PreviewToken.Set(c.PreviewToken(), entity.TokenConfig)
 	entity.DownloadToken.Set(c.DownloadToken(), entity.TokenConfig)
@@ -624,13 +640,18 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
-// GeoApi returns the preferred geocoding api (places, or none).
+// GeoApi returns the preferred geocoding api (places, geonames, or none).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
 		return ""
 	}
 
-	return "places"
+	switch api := strings.ToLower(strings.TrimSpace(c.options.GeoApi)); api {
+	case geonames.ApiName:
+		return api
+	default:
+		return places.ApiName
+	}
 }
 
 // OriginalsLimit returns the maximum size of originals in MB.
@@ -651,6 +672,44 @@ func (c *Config) OriginalsByteLimit() int64 {
 	}
 }
 
+// ShareUploadLimit returns the maximum size of files uploaded by share link visitors in MB,
+// or -1 if visitors cannot upload files.
+func (c *Config) ShareUploadLimit() int {
+	result := c.options.ShareUploadLimit
+
+	if result == 0 {
+		result = DefaultShareUploadLimit
+	} else if result < 0 {
+		return -1
+	}
+
+	// Uploaded files cannot exceed the size limit for originals.
+	if limit := c.OriginalsLimit(); limit > 0 && result > limit {
+		return limit
+	}
+
+	return result
+}
+
+// ShareUploadByteLimit returns the maximum size of files uploaded by share link visitors in bytes.
+func (c *Config) ShareUploadByteLimit() int64 {
+	if result := c.ShareUploadLimit(); result <= 0 {
+		return -1
+	} else {
+		return int64(result) * 1024 * 1024
+	}
+}
+
+// ShareUploadTotalByteLimit returns the maximum size of all files that share link visitors can upload
+// before they are imported, in bytes.
+func (c *Config) ShareUploadTotalByteLimit() int64 {
+	if result := c.ShareUploadByteLimit(); result <= 0 {
+		return -1
+	} else {
+		return result * ShareUploadTotal
+	}
+}
+
 // ResolutionLimit returns the maximum resolution of originals in megapixels (width x height).
 func (c *Config) ResolutionLimit() int {
 	result := c.options.ResolutionLimit
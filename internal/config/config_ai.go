@@ -16,6 +16,21 @@ func (c *Config) TensorFlowModelPath() string {
 	return filepath.Join(c.AssetsPath(), "nasnet")
 }
 
+// ClassifyYaml returns the filename of the optional image classification backend configuration.
+func (c *Config) ClassifyYaml() string {
+	return filepath.Join(c.ConfigPath(), "classify.yml")
+}
+
+// ClipModelPath returns the path of the CLIP model used for semantic search.
+func (c *Config) ClipModelPath() string {
+	return filepath.Join(c.AssetsPath(), "clip")
+}
+
+// ObjectsModelPath returns the path of the object detection model.
+func (c *Config) ObjectsModelPath() string {
+	return filepath.Join(c.AssetsPath(), "objects")
+}
+
 // FaceNetModelPath returns the FaceNet model path.
 func (c *Config) FaceNetModelPath() string {
 	return filepath.Join(c.AssetsPath(), "facenet")
@@ -35,3 +50,18 @@ func (c *Config) DetectNSFW() bool {
 func (c *Config) UploadNSFW() bool {
 	return c.options.UploadNSFW
 }
+
+// SemanticSearch checks if natural-language search with image embeddings is enabled.
+func (c *Config) SemanticSearch() bool {
+	return c.options.SemanticSearch && !c.DisableTensorFlow()
+}
+
+// DetectObjects checks if object detection with bounding boxes is enabled.
+func (c *Config) DetectObjects() bool {
+	return c.options.DetectObjects && !c.DisableTensorFlow()
+}
+
+// DetectPets checks if pets should be recognized and clustered like faces.
+func (c *Config) DetectPets() bool {
+	return c.options.DetectPets && !c.DisableFaces()
+}
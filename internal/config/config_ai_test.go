@@ -20,6 +20,73 @@ func TestConfig_TensorFlowModelPath(t *testing.T) {
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/assets/nasnet", path)
 }
 
+func TestConfig_ClassifyYaml(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.ClassifyYaml(), "/config/classify.yml")
+}
+
+func TestConfig_ClipModelPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.ClipModelPath(), "/assets/clip")
+}
+
+func TestConfig_SemanticSearch(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.SemanticSearch())
+
+	c.options.SemanticSearch = true
+	assert.True(t, c.SemanticSearch())
+
+	c.options.DisableTensorFlow = true
+	assert.False(t, c.SemanticSearch())
+
+	c.options.SemanticSearch = false
+	c.options.DisableTensorFlow = false
+}
+
+func TestConfig_ObjectsModelPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.ObjectsModelPath(), "/assets/objects")
+}
+
+func TestConfig_DetectObjects(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DetectObjects())
+
+	c.options.DetectObjects = true
+	assert.True(t, c.DetectObjects())
+
+	c.options.DisableTensorFlow = true
+	assert.False(t, c.DetectObjects())
+
+	c.options.DetectObjects = false
+	c.options.DisableTensorFlow = false
+}
+
+func TestConfig_DetectPets(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DetectPets())
+
+	c.options.DetectPets = true
+	assert.True(t, c.DetectPets())
+
+	c.options.DisableFaces = true
+	assert.False(t, c.DetectPets())
+
+	c.options.DisableFaces = false
+	c.options.DisableTensorFlow = true
+	assert.False(t, c.DetectPets())
+
+	c.options.DetectPets = false
+	c.options.DisableTensorFlow = false
+}
+
 func TestConfig_TensorFlowDisabled(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"net/url"
 	"path/filepath"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -99,3 +101,32 @@ func (c *Config) BackupAlbumsPath() string {
 
 	return c.BackupPath("albums")
 }
+
+// BackupOriginals checks if incremental backups of originals and sidecar files should be created based on the configured schedule.
+func (c *Config) BackupOriginals() bool {
+	if c.DisableBackups() {
+		return false
+	}
+
+	return c.options.BackupOriginals
+}
+
+// BackupOriginalsPath returns the backup path or WebDAV URL for originals and sidecar files.
+func (c *Config) BackupOriginalsPath() string {
+	if s := strings.TrimSpace(c.options.BackupOriginalsPath); s == "" {
+		return c.BackupPath("originals")
+	} else if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		return s
+	} else {
+		return fs.Abs(s)
+	}
+}
+
+// redactUrl replaces the password in a URL, if any, so that it can be displayed.
+func redactUrl(s string) string {
+	if u, err := url.Parse(s); err != nil || u.User == nil {
+		return s
+	} else {
+		return u.Redacted()
+	}
+}
@@ -45,6 +45,19 @@ const RecommendedMem = 3 * GigaByte // 3,000,000,000 Bytes
 // DefaultResolutionLimit defines the default resolution limit.
 const DefaultResolutionLimit = 150 // 150 Megapixels
 
+// DefaultShareUploadLimit defines the default size limit for files uploaded by share link visitors.
+const DefaultShareUploadLimit = 100 // 100 MB
+
+// ShareUploadFiles defines the maximum number of files share link visitors can upload before they are imported.
+const ShareUploadFiles = 100
+
+// ShareUploadTotal defines the maximum size of all files that share link visitors can upload before they are
+// imported, as a multiple of the size limit for individual files.
+const ShareUploadTotal = 10
+
+// DefaultTrackMaxGap defines the default maximum time between two track points in seconds.
+const DefaultTrackMaxGap = 600 // 10 minutes
+
 // serialName defines the name of the unique storage serial.
 const serialName = "serial"
 
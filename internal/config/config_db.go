@@ -12,6 +12,7 @@ import (
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -22,7 +23,6 @@ import (
 )
 
 // SQL Databases.
-// TODO: PostgreSQL support requires upgrading GORM, so generic column data types can be used.
 const (
 	MySQL    = "mysql"
 	MariaDB  = "mariadb"
@@ -41,6 +41,8 @@ func (c *Config) DatabaseDriver() string {
 	switch strings.ToLower(c.options.DatabaseDriver) {
 	case MySQL, MariaDB:
 		c.options.DatabaseDriver = MySQL
+	case Postgres, "postgresql", "pgsql":
+		c.options.DatabaseDriver = Postgres
 	case SQLite3, "sqlite", "sqllite", "test", "file", "":
 		c.options.DatabaseDriver = SQLite3
 	case "tidb":
@@ -148,7 +150,11 @@ func (c *Config) DatabaseHost() string {
 
 // DatabasePort the database server port.
 func (c *Config) DatabasePort() int {
-	const defaultPort = 3306
+	defaultPort := 3306
+
+	if c.DatabaseDriver() == Postgres {
+		defaultPort = 5432
+	}
 
 	if server := c.DatabaseServer(); server == "" {
 		return 0
@@ -284,7 +290,7 @@ func (c *Config) SetDbOptions() {
 	case MySQL, MariaDB:
 		c.Db().Set("gorm:table_options", "ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci")
 	case Postgres:
-		// Ignore for now.
+		// Not required as the column types are set by the dialect.
 	case SQLite3:
 		// Not required as unicode is default.
 	}
@@ -353,6 +359,15 @@ func (c *Config) checkDb(db *gorm.DB) error {
 		} else if sub := txt.UInt(v[1]); sub < 5 || sub == 5 && txt.UInt(v[2]) < 12 {
 			return fmt.Errorf("config: MariaDB %s is not supported, see https://docs.photoprism.app/getting-started/#databases", res.Value)
 		}
+	case Postgres:
+		var version string
+		if err := db.Raw("SHOW server_version_num").Row().Scan(&version); err != nil {
+			log.Warnf("config: unknown database server version (%s)", err)
+		} else if v := txt.UInt(version); v == 0 {
+			log.Warnf("config: unknown database server version")
+		} else if v < 130000 {
+			return fmt.Errorf("config: PostgreSQL %d is not supported, see https://docs.photoprism.app/getting-started/#databases", v/10000)
+		}
 	}
 
 	return nil
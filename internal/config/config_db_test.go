@@ -45,6 +45,22 @@ func TestConfig_ParseDatabaseDsn(t *testing.T) {
 	assert.Equal(t, "", c.DatabasePassword())
 }
 
+func TestConfig_ParseDatabaseDsnPostgres(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.DatabaseDsn = "postgres://foo:b@r@honeypot/baz?sslmode=disable"
+	c.options.DatabaseDriver = "postgresql"
+
+	assert.Equal(t, Postgres, c.DatabaseDriver())
+	assert.Equal(t, "honeypot", c.DatabaseServer())
+	assert.Equal(t, "honeypot", c.DatabaseHost())
+	assert.Equal(t, 5432, c.DatabasePort())
+	assert.Equal(t, "baz", c.DatabaseName())
+	assert.Equal(t, "foo", c.DatabaseUser())
+	assert.Equal(t, "b@r", c.DatabasePassword())
+	assert.Equal(t, "postgres://foo:b@r@honeypot/baz?sslmode=disable", c.DatabaseDsn())
+}
+
 func TestConfig_DatabaseServer(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -100,7 +116,7 @@ func TestConfig_DatabaseDsn(t *testing.T) {
 	c.options.DatabaseDriver = "tidb"
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db?_busy_timeout=5000", c.DatabaseDsn())
 	c.options.DatabaseDriver = "Postgres"
-	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db?_busy_timeout=5000", c.DatabaseDsn())
+	assert.Equal(t, "user=photoprism password= dbname=photoprism host=localhost port=5432 connect_timeout=15 sslmode=disable TimeZone=UTC", c.DatabaseDsn())
 	c.options.DatabaseDriver = "SQLite"
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db?_busy_timeout=5000", c.DatabaseDsn())
 	c.options.DatabaseDriver = ""
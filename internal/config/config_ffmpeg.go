@@ -72,6 +72,28 @@ func (c *Config) FFmpegMapAudio() string {
 	return c.options.FFmpegMapAudio
 }
 
+// FFmpegStreaming returns the adaptive video streaming format, if enabled.
+func (c *Config) FFmpegStreaming() ffmpeg.StreamFormat {
+	if c.DisableFFmpeg() {
+		return ffmpeg.StreamNone
+	}
+
+	return ffmpeg.FindStreamFormat(c.options.FFmpegStreaming)
+}
+
+// FFmpegStreamingAllowed checks if videos may be streamed in the specified format.
+// HLS playlists are also generated when DASH streaming is enabled.
+func (c *Config) FFmpegStreamingAllowed(format ffmpeg.StreamFormat) bool {
+	switch c.FFmpegStreaming() {
+	case ffmpeg.StreamHLS:
+		return format == ffmpeg.StreamHLS
+	case ffmpeg.StreamDASH:
+		return format == ffmpeg.StreamHLS || format == ffmpeg.StreamDASH
+	default:
+		return false
+	}
+}
+
 // FFmpegOptions returns the FFmpeg transcoding options.
 func (c *Config) FFmpegOptions(encoder ffmpeg.AvcEncoder, bitrate string) (ffmpeg.Options, error) {
 	// Transcode all other formats with FFmpeg.
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/ffmpeg"
@@ -114,3 +115,35 @@ func TestConfig_FFmpegOptions(t *testing.T) {
 	assert.Equal(t, c.FFmpegMapVideo(), opt.MapVideo)
 	assert.Equal(t, c.FFmpegMapAudio(), opt.MapAudio)
 }
+
+func TestConfig_FFmpegStreaming(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, ffmpeg.StreamNone, c.FFmpegStreaming())
+	assert.False(t, c.FFmpegStreamingAllowed(ffmpeg.StreamHLS))
+
+	c.options.FFmpegStreaming = "hls"
+	assert.Equal(t, ffmpeg.StreamHLS, c.FFmpegStreaming())
+	assert.True(t, c.FFmpegStreamingAllowed(ffmpeg.StreamHLS))
+	assert.False(t, c.FFmpegStreamingAllowed(ffmpeg.StreamDASH))
+
+	c.options.FFmpegStreaming = "dash"
+	assert.Equal(t, ffmpeg.StreamDASH, c.FFmpegStreaming())
+	assert.True(t, c.FFmpegStreamingAllowed(ffmpeg.StreamHLS))
+	assert.True(t, c.FFmpegStreamingAllowed(ffmpeg.StreamDASH))
+	assert.False(t, c.FFmpegStreamingAllowed(ffmpeg.StreamNone))
+
+	c.options.DisableFFmpeg = true
+	assert.Equal(t, ffmpeg.StreamNone, c.FFmpegStreaming())
+	assert.False(t, c.FFmpegStreamingAllowed(ffmpeg.StreamHLS))
+
+	c.options.DisableFFmpeg = false
+	c.options.FFmpegStreaming = ""
+}
+
+func TestConfig_VideoStreamPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	hash := "0b57b50fe3f6d12bbbf5f1abda3ebcc8bb5ebcee"
+
+	assert.Equal(t, filepath.Join(c.MediaFileCachePath(hash), hash+".hls"), c.VideoStreamPath(hash, ffmpeg.StreamHLS))
+	assert.Equal(t, filepath.Join(c.MediaFileCachePath(hash), hash+".dash"), c.VideoStreamPath(hash, ffmpeg.StreamDASH))
+}
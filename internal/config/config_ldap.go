@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// LDAPEnabled checks if password authentication via LDAP or Active Directory is configured and enabled.
+func (c *Config) LDAPEnabled() bool {
+	if c.options.DisableLDAP {
+		return false
+	}
+
+	return c.LDAPUri() != "" && c.LDAPBaseDN() != ""
+}
+
+// LDAPUri returns the LDAP server URI, e.g. ldaps://ldap.example.com.
+func (c *Config) LDAPUri() string {
+	uri := strings.TrimSpace(c.options.LDAPUri)
+
+	if !strings.HasPrefix(uri, "ldap://") && !strings.HasPrefix(uri, "ldaps://") {
+		return ""
+	}
+
+	return uri
+}
+
+// LDAPInsecure checks if TLS certificate verification should be skipped when connecting to the LDAP server.
+func (c *Config) LDAPInsecure() bool {
+	return c.options.LDAPInsecure
+}
+
+// LDAPBindDN returns the DN of the service account for searching the directory.
+func (c *Config) LDAPBindDN() string {
+	return strings.TrimSpace(c.options.LDAPBindDN)
+}
+
+// LDAPBindPassword returns the password of the service account for searching the directory.
+func (c *Config) LDAPBindPassword() string {
+	return c.options.LDAPBindPassword
+}
+
+// LDAPBaseDN returns the base DN of the user accounts.
+func (c *Config) LDAPBaseDN() string {
+	return strings.TrimSpace(c.options.LDAPBaseDN)
+}
+
+// LDAPFilter returns the search filter for finding user accounts, with {username} as placeholder.
+func (c *Config) LDAPFilter() string {
+	if f := strings.TrimSpace(c.options.LDAPFilter); f != "" {
+		return f
+	}
+
+	return ldap.DefaultUserFilter
+}
+
+// LDAPRoles returns the mapping of directory groups to user roles.
+func (c *Config) LDAPRoles() ldap.Roles {
+	return ldap.ParseRoles(c.options.LDAPRoles)
+}
+
+// LDAPRole returns the default role of LDAP users without a matching group.
+func (c *Config) LDAPRole() acl.Role {
+	if c.options.LDAPRole == "" {
+		return acl.RoleGuest
+	}
+
+	return acl.UserRoles[clean.Role(c.options.LDAPRole)]
+}
+
+// DisableLDAP checks if password authentication via LDAP should be disabled.
+func (c *Config) DisableLDAP() bool {
+	return c.options.DisableLDAP
+}
+
+// LDAP returns a new LDAP client for password authentication, or nil if LDAP is disabled.
+func (c *Config) LDAP() *ldap.Client {
+	if !c.LDAPEnabled() {
+		return nil
+	}
+
+	return ldap.NewClient(ldap.Config{
+		URI:          c.LDAPUri(),
+		Insecure:     c.LDAPInsecure(),
+		BindDN:       c.LDAPBindDN(),
+		BindPassword: c.LDAPBindPassword(),
+		BaseDN:       c.LDAPBaseDN(),
+		UserFilter:   c.LDAPFilter(),
+		Roles:        c.LDAPRoles(),
+		DefaultRole:  c.LDAPRole(),
+	})
+}
+
+// LDAPReport returns the LDAP config values as a table for reporting.
+func (c *Config) LDAPReport() (rows [][]string, cols []string) {
+	cols = []string{"Name", "Value"}
+
+	rows = [][]string{
+		{"ldap-uri", c.LDAPUri()},
+		{"ldap-insecure", fmt.Sprintf("%t", c.LDAPInsecure())},
+		{"ldap-bind-dn", c.LDAPBindDN()},
+		{"ldap-bind-password", strings.Repeat("*", utf8.RuneCountInString(c.LDAPBindPassword()))},
+		{"ldap-base-dn", c.LDAPBaseDN()},
+		{"ldap-filter", c.LDAPFilter()},
+		{"ldap-roles", c.LDAPRoles().String()},
+		{"ldap-role", c.LDAPRole().String()},
+		{"disable-ldap", fmt.Sprintf("%t", c.DisableLDAP())},
+	}
+
+	return rows, cols
+}
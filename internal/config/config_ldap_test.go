@@ -0,0 +1,80 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
+)
+
+func TestConfig_LDAPEnabled(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		assert.False(t, c.LDAPEnabled())
+		assert.Nil(t, c.LDAP())
+	})
+	t.Run("InvalidUri", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.LDAPUri = "https://ldap.example.com"
+		c.options.LDAPBaseDN = "dc=example,dc=com"
+		assert.Equal(t, "", c.LDAPUri())
+		assert.False(t, c.LDAPEnabled())
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.LDAPUri = "ldaps://ldap.example.com"
+		c.options.LDAPBaseDN = "dc=example,dc=com"
+		c.options.DisableLDAP = true
+		assert.True(t, c.DisableLDAP())
+		assert.False(t, c.LDAPEnabled())
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.LDAPUri = " ldaps://ldap.example.com "
+		c.options.LDAPBaseDN = "dc=example,dc=com"
+		c.options.LDAPBindDN = "cn=reader,dc=example,dc=com"
+		c.options.LDAPBindPassword = "secret"
+		c.options.LDAPRoles = "admins=admin"
+		assert.True(t, c.LDAPEnabled())
+
+		if client := c.LDAP(); assert.NotNil(t, client) {
+			conf := client.Config()
+			assert.Equal(t, "ldaps://ldap.example.com", conf.URI)
+			assert.Equal(t, "dc=example,dc=com", conf.BaseDN)
+			assert.Equal(t, "cn=reader,dc=example,dc=com", conf.BindDN)
+			assert.Equal(t, "secret", conf.BindPassword)
+			assert.Equal(t, ldap.DefaultUserFilter, conf.UserFilter)
+			assert.Equal(t, ldap.Roles{"admins": acl.RoleAdmin}, conf.Roles)
+			assert.Equal(t, acl.RoleGuest, conf.DefaultRole)
+		}
+	})
+}
+
+func TestConfig_LDAPRole(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, acl.RoleGuest, c.LDAPRole())
+	c.options.LDAPRole = "visitor"
+	assert.Equal(t, acl.RoleVisitor, c.LDAPRole())
+	c.options.LDAPRole = "none"
+	assert.Equal(t, acl.RoleNone, c.LDAPRole())
+	c.options.LDAPRole = "foo"
+	assert.Equal(t, acl.RoleNone, c.LDAPRole())
+}
+
+func TestConfig_LDAPFilter(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, ldap.DefaultUserFilter, c.LDAPFilter())
+	c.options.LDAPFilter = "(uid={username})"
+	assert.Equal(t, "(uid={username})", c.LDAPFilter())
+}
+
+func TestConfig_LDAPReport(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.LDAPBindPassword = "secret"
+
+	r, _ := c.LDAPReport()
+	assert.Len(t, r, 9)
+	assert.Equal(t, []string{"ldap-bind-password", "******"}, r[3])
+}
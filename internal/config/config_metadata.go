@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // ExifBruteForce checks if a brute-force search should be performed when no Exif headers were found.
 func (c *Config) ExifBruteForce() bool {
 	return c.options.ExifBruteForce || !c.ExifToolJson()
@@ -14,3 +16,12 @@ func (c *Config) ExifToolBin() string {
 func (c *Config) ExifToolJson() bool {
 	return !c.DisableExifTool()
 }
+
+// TrackMaxGap returns the maximum time between two track points for pictures taken in between to be geotagged.
+func (c *Config) TrackMaxGap() time.Duration {
+	if c.options.TrackMaxGap <= 0 {
+		return DefaultTrackMaxGap * time.Second
+	}
+
+	return time.Duration(c.options.TrackMaxGap) * time.Second
+}
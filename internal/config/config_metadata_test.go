@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -29,3 +30,13 @@ func TestConfig_ExifToolJson(t *testing.T) {
 	assert.Equal(t, false, c.ExifToolJson())
 	assert.Equal(t, c.DisableExifTool(), !c.ExifToolJson())
 }
+
+func TestConfig_TrackMaxGap(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 10*time.Minute, c.TrackMaxGap())
+	c.options.TrackMaxGap = 60
+	assert.Equal(t, time.Minute, c.TrackMaxGap())
+	c.options.TrackMaxGap = -1
+	assert.Equal(t, 10*time.Minute, c.TrackMaxGap())
+}
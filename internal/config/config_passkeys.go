@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/photoprism/photoprism/internal/auth/webauthn"
+)
+
+// DisablePasskeys checks if passkeys should be disabled for passwordless login and two-factor authentication.
+func (c *Config) DisablePasskeys() bool {
+	return c.options.DisablePasskeys
+}
+
+// PasskeyOrigin returns the origin from which passkey registrations and logins are accepted, e.g. https://photos.example.com.
+func (c *Config) PasskeyOrigin() string {
+	if c.SiteHttps() {
+		return "https://" + c.SiteHost()
+	}
+
+	return "http://" + c.SiteHost()
+}
+
+// WebAuthn returns the relying party for passkey authentication based on the site URL, or nil if passkeys are disabled.
+func (c *Config) WebAuthn() *webauthn.RelyingParty {
+	if c.DisablePasskeys() {
+		return nil
+	}
+
+	return webauthn.NewRelyingParty(c.SiteDomain(), c.SiteTitle(), c.PasskeyOrigin())
+}
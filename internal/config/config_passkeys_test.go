@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_DisablePasskeys(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DisablePasskeys())
+	c.options.DisablePasskeys = true
+	assert.True(t, c.DisablePasskeys())
+	assert.Nil(t, c.WebAuthn())
+	c.options.DisablePasskeys = false
+}
+
+func TestConfig_PasskeyOrigin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "http://localhost:2342", c.PasskeyOrigin())
+	c.options.SiteUrl = "https://photos.example.com/"
+	assert.Equal(t, "https://photos.example.com", c.PasskeyOrigin())
+}
+
+func TestConfig_WebAuthn(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.SiteUrl = "https://photos.example.com:8443/"
+	c.options.SiteTitle = "Family Photos"
+
+	rp := c.WebAuthn()
+
+	if rp == nil {
+		t.Fatal("relying party should not be nil")
+	}
+
+	assert.Equal(t, "photos.example.com", rp.ID)
+	assert.Equal(t, "Family Photos", rp.Name)
+	assert.Equal(t, "https://photos.example.com:8443", rp.Origin)
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// initRoles loads custom user roles from the "roles.yml" config file and adds them to the access control lists.
+func (c *Config) initRoles() error {
+	fileName := c.RolesYaml()
+
+	if !fs.FileExists(fileName) {
+		return nil
+	}
+
+	roles := acl.RoleConfigs{}
+
+	if err := roles.Load(fileName); err != nil {
+		return fmt.Errorf("config: failed to load roles from %s (%s)", clean.Log(fileName), err)
+	} else if err = roles.Apply(); err != nil {
+		return fmt.Errorf("config: invalid roles in %s (%s)", clean.Log(fileName), err)
+	}
+
+	log.Infof("config: loaded %s from %s", english.Plural(len(roles), "custom role", ""), clean.Log(fileName))
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestConfig_InitRoles(t *testing.T) {
+	rules, events := acl.Rules, acl.Events
+
+	defer func() {
+		acl.Rules, acl.Events = rules, events
+		delete(acl.UserRoles, "editor")
+	}()
+
+	c := NewConfig(CliTestContext())
+	c.options.ConfigPath = t.TempDir()
+
+	t.Run("NotFound", func(t *testing.T) {
+		assert.NoError(t, c.initRoles())
+		assert.Equal(t, acl.RoleNone, acl.UserRoles["editor"])
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		if err := os.WriteFile(c.RolesYaml(), []byte("admin:\n  Grants:\n    photos: [view]\n"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, c.initRoles())
+		assert.True(t, acl.Rules.Allow(acl.ResourcePhotos, acl.RoleAdmin, acl.ActionDelete))
+	})
+	t.Run("Success", func(t *testing.T) {
+		if err := os.WriteFile(c.RolesYaml(), []byte("editor:\n  Extends: guest\n  Grants:\n    photos: [access_library, search, view, update]\n"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, c.initRoles())
+		assert.Equal(t, acl.Role("editor"), acl.UserRoles["editor"])
+		assert.True(t, acl.Rules.Allow(acl.ResourcePhotos, "editor", acl.ActionUpdate))
+		assert.False(t, acl.Rules.Allow(acl.ResourcePhotos, "editor", acl.ActionDelete))
+	})
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -135,6 +137,13 @@ func (c *Config) CreateDirectories() error {
 		return createError(dir, err)
 	}
 
+	// Create track storage path if it doesn't exist yet.
+	if dir := c.TracksPath(); dir == "" {
+		return notFoundError("tracks")
+	} else if err := fs.MkdirAll(dir); err != nil {
+		return createError(dir, err)
+	}
+
 	// Create sidecar storage path if it doesn't exist yet.
 	if dir := c.SidecarPath(); filepath.IsAbs(dir) {
 		if err := fs.MkdirAll(dir); err != nil {
@@ -267,6 +276,11 @@ func (c *Config) SettingsYaml() string {
 	return filepath.Join(c.ConfigPath(), "settings.yml")
 }
 
+// RolesYaml returns the custom user roles YAML filename.
+func (c *Config) RolesYaml() string {
+	return filepath.Join(c.ConfigPath(), "roles.yml")
+}
+
 // SettingsYamlDefaults returns the default settings YAML filename.
 func (c *Config) SettingsYamlDefaults(settingsYml string) string {
 	if settingsYml != "" && fs.FileExists(settingsYml) {
@@ -356,6 +370,29 @@ func (c *Config) SidecarWritable() bool {
 	return !c.ReadOnly() || c.SidecarPathIsAbs()
 }
 
+// SidecarXmp checks if metadata changes should be written to XMP sidecar files in the originals folder.
+func (c *Config) SidecarXmp() bool {
+	if c.ReadOnly() {
+		return false
+	}
+
+	return c.options.SidecarXmp
+}
+
+// TracksPath returns the storage path for GPX, KML, and GeoJSON tracks used for geotagging.
+func (c *Config) TracksPath() string {
+	return filepath.Join(c.StoragePath(), "tracks")
+}
+
+// GeoNamesPath returns the folder containing the GeoNames dataset used for offline geocoding.
+func (c *Config) GeoNamesPath() string {
+	if c.options.GeoNamesPath != "" {
+		return fs.Abs(c.options.GeoNamesPath)
+	}
+
+	return filepath.Join(c.StoragePath(), "geonames")
+}
+
 // UsersPath returns the relative base path for user assets.
 func (c *Config) UsersPath() string {
 	// Set default.
@@ -366,6 +403,22 @@ func (c *Config) UsersPath() string {
 	return clean.UserPath(c.options.UsersPath)
 }
 
+// LibraryMode returns the library mode for registered users, see entity.LibraryModes.
+func (c *Config) LibraryMode() string {
+	mode := strings.ToLower(strings.TrimSpace(c.options.LibraryMode))
+
+	if !entity.LibraryModes[mode] {
+		return entity.LibraryShared
+	}
+
+	return mode
+}
+
+// UserLibraries checks if each registered user has a separate library.
+func (c *Config) UserLibraries() bool {
+	return c.LibraryMode() == entity.LibraryUser
+}
+
 // UsersOriginalsPath returns the users originals base path.
 func (c *Config) UsersOriginalsPath() string {
 	return filepath.Join(c.OriginalsPath(), c.UsersPath())
@@ -406,6 +459,21 @@ func (c *Config) UserUploadPath(userUid, token string) (string, error) {
 	return dir, nil
 }
 
+// ShareUploadPath returns the staging folder for files uploaded by visitors of the specified share link.
+func (c *Config) ShareUploadPath(linkUid, token string) (string, error) {
+	if !rnd.IsUID(linkUid, 0) {
+		return "", fmt.Errorf("invalid uid")
+	}
+
+	dir := filepath.Join(c.StoragePath(), "upload", "shares", linkUid, clean.Token(token))
+
+	if err := fs.MkdirAll(dir); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
 // TempPath returns the cached temporary directory name e.g. for uploads and downloads.
 func (c *Config) TempPath() string {
 	// Return cached value?
@@ -517,6 +585,11 @@ func (c *Config) MediaFileCachePath(hash string) string {
 	return dir
 }
 
+// VideoStreamPath returns the cache path for the adaptive streaming playlists and segments of a video.
+func (c *Config) VideoStreamPath(hash string, format ffmpeg.StreamFormat) string {
+	return filepath.Join(c.MediaFileCachePath(hash), hash+"."+format.String())
+}
+
 // ThumbCachePath returns the thumbnail storage path.
 func (c *Config) ThumbCachePath() string {
 	return filepath.Join(c.CachePath(), "thumbnails")
@@ -646,6 +719,16 @@ func (c *Config) MariadbDumpBin() string {
 	return findBin("", "mariadb-dump")
 }
 
+// PsqlBin returns the psql executable file name.
+func (c *Config) PsqlBin() string {
+	return findBin("", "psql")
+}
+
+// PgDumpBin returns the pg_dump executable file name.
+func (c *Config) PgDumpBin() string {
+	return findBin("", "pg_dump")
+}
+
 // SqliteBin returns the sqlite executable file name.
 func (c *Config) SqliteBin() string {
 	return findBin("", "sqlite3")
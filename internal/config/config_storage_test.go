@@ -51,16 +51,65 @@ func TestConfig_SidecarYaml(t *testing.T) {
 	assert.Equal(t, c.DisableBackups(), !c.SidecarYaml())
 }
 
+func TestConfig_SidecarXmp(t *testing.T) {
+	c := NewConfig(NewTestContext(nil))
+
+	assert.Equal(t, false, c.SidecarXmp())
+
+	c.options.SidecarXmp = true
+
+	assert.Equal(t, true, c.SidecarXmp())
+
+	c.options.ReadOnly = true
+
+	assert.Equal(t, false, c.SidecarXmp())
+
+	c.options.ReadOnly = false
+	c.options.SidecarXmp = false
+}
+
 func TestConfig_UsersPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Contains(t, c.UsersPath(), "users")
 }
 
+func TestConfig_LibraryMode(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "shared", c.LibraryMode())
+	assert.False(t, c.UserLibraries())
+
+	c.options.LibraryMode = " User "
+	assert.Equal(t, "user", c.LibraryMode())
+	assert.True(t, c.UserLibraries())
+
+	c.options.LibraryMode = "foo"
+	assert.Equal(t, "shared", c.LibraryMode())
+	assert.False(t, c.UserLibraries())
+
+	c.options.LibraryMode = ""
+}
+
 func TestConfig_UsersOriginalsPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Contains(t, c.UsersOriginalsPath(), "users")
 }
 
+func TestConfig_TracksPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Contains(t, c.TracksPath(), "testdata/tracks")
+}
+
+func TestConfig_GeoNamesPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.GeoNamesPath(), "testdata/geonames")
+
+	c.options.GeoNamesPath = "/srv/geonames"
+	assert.Equal(t, "/srv/geonames", c.GeoNamesPath())
+	c.options.GeoNamesPath = ""
+}
+
 func TestConfig_UsersStoragePath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Contains(t, c.UsersStoragePath(), "users")
@@ -73,6 +122,20 @@ func TestConfig_UserStoragePath(t *testing.T) {
 	assert.Contains(t, c.UserStoragePath("urjult03ceelhw6k"), "users/urjult03ceelhw6k")
 }
 
+func TestConfig_ShareUploadPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	if dir, err := c.ShareUploadPath("", ""); err == nil {
+		t.Error("error expected")
+	} else {
+		assert.Equal(t, "", dir)
+	}
+	if dir, err := c.ShareUploadPath("ss62xpryd1ob7gtf", "123"); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Contains(t, dir, "upload/shares/ss62xpryd1ob7gtf/123")
+	}
+}
+
 func TestConfig_UserUploadPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	if dir, err := c.UserUploadPath("", ""); err == nil {
@@ -472,11 +535,26 @@ func TestConfig_MariadbDumpBin(t *testing.T) {
 	assert.Contains(t, c.MariadbDumpBin(), "mariadb-dump")
 }
 
+func TestConfig_PsqlBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Contains(t, c.PsqlBin(), "psql")
+}
+
+func TestConfig_PgDumpBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Contains(t, c.PgDumpBin(), "pg_dump")
+}
+
 func TestConfig_SqliteBin(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Contains(t, c.SqliteBin(), "sqlite")
 }
 
+func TestConfig_RolesYaml(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, c.ConfigPath()+"/roles.yml", c.RolesYaml())
+}
+
 func TestConfig_SettingsYamlDefaults(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	name1 := c.SettingsYamlDefaults(c.SettingsYaml())
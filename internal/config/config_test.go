@@ -310,6 +310,10 @@ func TestConfig_AutoImport(t *testing.T) {
 func TestConfig_GeoApi(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
+	assert.Equal(t, "places", c.GeoApi())
+	c.options.GeoApi = "GeoNames"
+	assert.Equal(t, "geonames", c.GeoApi())
+	c.options.GeoApi = "foo"
 	assert.Equal(t, "places", c.GeoApi())
 	c.options.DisablePlaces = true
 	assert.Equal(t, "", c.GeoApi())
@@ -331,6 +335,22 @@ func TestConfig_OriginalsByteLimit(t *testing.T) {
 	assert.Equal(t, int64(838860800), c.OriginalsByteLimit())
 }
 
+func TestConfig_ShareUploadLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, DefaultShareUploadLimit, c.ShareUploadLimit())
+	c.options.ShareUploadLimit = -1
+	assert.Equal(t, -1, c.ShareUploadLimit())
+	assert.Equal(t, int64(-1), c.ShareUploadByteLimit())
+	assert.Equal(t, int64(-1), c.ShareUploadTotalByteLimit())
+	c.options.ShareUploadLimit = 50
+	assert.Equal(t, 50, c.ShareUploadLimit())
+	assert.Equal(t, int64(52428800), c.ShareUploadByteLimit())
+	assert.Equal(t, int64(524288000), c.ShareUploadTotalByteLimit())
+	c.options.OriginalsLimit = 20
+	assert.Equal(t, 20, c.ShareUploadLimit())
+}
+
 func TestConfig_ResolutionLimit(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
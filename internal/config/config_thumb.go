@@ -80,6 +80,11 @@ func (c *Config) ThumbUncached() bool {
 	return c.options.ThumbUncached
 }
 
+// ThumbVideo checks if animated preview clips and sprite sheets should be generated for videos.
+func (c *Config) ThumbVideo() bool {
+	return c.options.ThumbVideo && c.FFmpegEnabled()
+}
+
 // ThumbSizePrecached returns the pre-cached thumbnail size limit in pixels (720-7680).
 func (c *Config) ThumbSizePrecached() int {
 	size := c.options.ThumbSize
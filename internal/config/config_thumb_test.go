@@ -59,6 +59,16 @@ func TestConfig_ThumbSizeUncached(t *testing.T) {
 	assert.False(t, c.ThumbUncached())
 }
 
+func TestConfig_ThumbVideo(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.ThumbVideo())
+	c.options.ThumbVideo = true
+	assert.Equal(t, c.FFmpegEnabled(), c.ThumbVideo())
+	c.options.DisableFFmpeg = true
+	assert.False(t, c.ThumbVideo())
+}
+
 func TestConfig_ThumbSize(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
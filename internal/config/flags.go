@@ -8,9 +8,11 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
 	"github.com/photoprism/photoprism/internal/config/ttl"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/service/hub/places"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/authn"
 	"github.com/photoprism/photoprism/pkg/header"
@@ -108,6 +110,63 @@ var Flags = CliFlags{
 			Usage:  "disable single sign-on via OpenID Connect, even if an identity provider has been configured",
 			EnvVar: EnvVar("DISABLE_OIDC"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ldap-uri",
+			Usage:  "LDAP or Active Directory server `URI` for password authentication, e.g. ldaps://ldap.example.com",
+			Value:  "",
+			EnvVar: EnvVar("LDAP_URI"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "ldap-insecure",
+			Usage:  "skip TLS certificate verification when connecting to the LDAP server",
+			EnvVar: EnvVar("LDAP_INSECURE"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ldap-bind-dn",
+			Usage:  "`DN` of the service account for searching the LDAP directory (anonymous if empty)",
+			Value:  "",
+			EnvVar: EnvVar("LDAP_BIND_DN"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ldap-bind-password",
+			Usage:  "`PASSWORD` of the service account for searching the LDAP directory",
+			Value:  "",
+			EnvVar: EnvVar("LDAP_BIND_PASSWORD"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ldap-base-dn",
+			Usage:  "base `DN` of the LDAP user accounts, e.g. dc=example,dc=com",
+			Value:  "",
+			EnvVar: EnvVar("LDAP_BASE_DN"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ldap-filter",
+			Usage:  "LDAP search `FILTER` for finding user accounts, with {username} as placeholder",
+			Value:  ldap.DefaultUserFilter,
+			EnvVar: EnvVar("LDAP_FILTER"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ldap-roles",
+			Usage:  "maps LDAP `GROUPS` to user roles, e.g. admins=admin;family=guest",
+			Value:  "",
+			EnvVar: EnvVar("LDAP_ROLES"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ldap-role",
+			Usage:  "default user `ROLE` of LDAP users without a matching group (guest, visitor, or none to deny access)",
+			Value:  "",
+			EnvVar: EnvVar("LDAP_ROLE"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "disable-ldap",
+			Usage:  "disable password authentication via LDAP, even if a server has been configured",
+			EnvVar: EnvVar("DISABLE_LDAP"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "disable-passkeys",
+			Usage:  "disable passkeys for passwordless login and two-factor authentication",
+			EnvVar: EnvVar("DISABLE_PASSKEYS"),
+		}}, {
 		Flag: cli.Int64Flag{
 			Name:   "session-maxage",
 			Value:  DefaultSessionMaxAge,
@@ -205,12 +264,24 @@ var Flags = CliFlags{
 			Usage:  "maximum resolution of media files in `MEGAPIXELS` (1-900; -1 to disable)",
 			EnvVar: EnvVar("RESOLUTION_LIMIT"),
 		}}, {
+		Flag: cli.IntFlag{
+			Name:   "share-upload-limit",
+			Value:  DefaultShareUploadLimit,
+			Usage:  "maximum size of files uploaded by share link visitors in `MB` (-1 to disable)",
+			EnvVar: EnvVar("SHARE_UPLOAD_LIMIT"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "users-path",
 			Usage:  "relative `PATH` to create base and upload subdirectories for users",
 			Value:  "users",
 			EnvVar: EnvVar("USERS_PATH"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "library-mode",
+			Usage:  "library `MODE` for registered users, either shared by all users or a separate library for each user (shared, user)",
+			Value:  entity.LibraryShared,
+			EnvVar: EnvVar("LIBRARY_MODE"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "storage-path, s",
 			Usage:  "writable storage `PATH` for sidecar, cache, and database files",
@@ -251,6 +322,11 @@ var Flags = CliFlags{
 			Usage:  "create YAML sidecar files to back up picture metadata",
 			EnvVar: EnvVar("SIDECAR_YAML"),
 		}, DocDefault: "true"}, {
+		Flag: cli.BoolFlag{
+			Name:   "sidecar-xmp",
+			Usage:  "write metadata changes to XMP sidecar files so that other applications can read them",
+			EnvVar: EnvVar("SIDECAR_XMP"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "backup-path, ba",
 			Usage:  "custom base `PATH` for creating and restoring backups *optional*",
@@ -278,6 +354,16 @@ var Flags = CliFlags{
 			Usage:  "create YAML files to back up album metadata",
 			EnvVar: EnvVar("BACKUP_ALBUMS"),
 		}, DocDefault: "true"}, {
+		Flag: cli.BoolFlag{
+			Name:   "backup-originals",
+			Usage:  "create incremental backups of originals and sidecar files based on the configured schedule",
+			EnvVar: EnvVar("BACKUP_ORIGINALS"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "backup-originals-path",
+			Usage:  "custom backup `PATH` or WebDAV URL for originals and sidecar files *optional*",
+			EnvVar: EnvVar("BACKUP_ORIGINALS_PATH"),
+		}}, {
 		Flag: cli.IntFlag{
 			Name:   "index-workers, workers",
 			Usage:  "maximum `NUMBER` of indexing workers, default depends on the number of physical cores",
@@ -418,6 +504,23 @@ var Flags = CliFlags{
 			Usage:  "always perform a brute-force search if no Exif headers were found",
 			EnvVar: EnvVar("EXIF_BRUTEFORCE"),
 		}}, {
+		Flag: cli.IntFlag{
+			Name:   "track-max-gap",
+			Value:  DefaultTrackMaxGap,
+			Usage:  "maximum time in `SECONDS` between two track points for pictures taken in between to be geotagged",
+			EnvVar: EnvVar("TRACK_MAX_GAP"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "geo-api",
+			Value:  places.ApiName,
+			Usage:  "reverse geocoding `API` used to look up location names (places, geonames)",
+			EnvVar: EnvVar("GEO_API"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "geonames-path",
+			Usage:  "folder `PATH` containing the GeoNames cities and admin1CodesASCII.txt files for offline geocoding",
+			EnvVar: EnvVar("GEONAMES_PATH"),
+		}}, {
 		Flag: cli.BoolFlag{
 			Name:   "detect-nsfw",
 			Usage:  "flag newly added pictures as private if they might be offensive (requires TensorFlow)",
@@ -428,6 +531,21 @@ var Flags = CliFlags{
 			Usage:  "allow uploads that might be offensive (detecting unsafe content requires TensorFlow)",
 			EnvVar: EnvVar("UPLOAD_NSFW"),
 		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "semantic-search",
+			Usage:  "enable natural-language search with locally computed image embeddings (requires TensorFlow and a CLIP model)",
+			EnvVar: EnvVar("SEMANTIC_SEARCH"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "detect-objects",
+			Usage:  "detect objects with bounding boxes, e.g. to search for them and crop thumbnails (requires TensorFlow and an object detection model)",
+			EnvVar: EnvVar("DETECT_OBJECTS"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "detect-pets",
+			Usage:  "recognize dogs and cats so they can be named like people (requires TensorFlow, a CLIP model, and an object detection model)",
+			EnvVar: EnvVar("DETECT_PETS"),
+		}}, {
 		Flag: cli.BoolFlag{
 			Name:   "upload-allow",
 			Usage:  "allow these file types for web uploads (comma-separated list of extensions; leave blank to allow all)",
@@ -662,13 +780,13 @@ var Flags = CliFlags{
 		}}, {
 		Flag: cli.StringFlag{
 			Name:   "database-driver, db",
-			Usage:  "database `DRIVER` (sqlite, mysql)",
+			Usage:  "database `DRIVER` (sqlite, mysql, postgres)",
 			Value:  "sqlite",
 			EnvVar: EnvVar("DATABASE_DRIVER"),
 		}}, {
 		Flag: cli.StringFlag{
 			Name:   "database-dsn, dsn",
-			Usage:  "database connection `DSN` (sqlite file, optional for mysql)",
+			Usage:  "database connection `DSN` (sqlite file, optional for mysql and postgres)",
 			EnvVar: EnvVar("DATABASE_DSN"),
 		}}, {
 		Flag: cli.StringFlag{
@@ -745,6 +863,12 @@ var Flags = CliFlags{
 			Value:  ffmpeg.MapAudioDefault,
 			EnvVar: EnvVar("FFMPEG_MAP_AUDIO"),
 		}, DocDefault: fmt.Sprintf("`%s`", ffmpeg.MapAudioDefault)}, {
+		Flag: cli.StringFlag{
+			Name:   "ffmpeg-streaming",
+			Usage:  "adaptive video streaming `FORMAT` (none, hls, dash)",
+			Value:  "none",
+			EnvVar: EnvVar("FFMPEG_STREAMING"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "exiftool-bin",
 			Usage:  "ExifTool `COMMAND` for extracting metadata",
@@ -869,6 +993,11 @@ var Flags = CliFlags{
 			Usage:  "generate missing thumbnails on demand (high memory and cpu usage)",
 			EnvVar: EnvVar("THUMB_UNCACHED"),
 		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "thumb-video",
+			Usage:  "generate animated preview clips and sprite sheets for videos (high cpu usage)",
+			EnvVar: EnvVar("THUMB_VIDEO"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "jpeg-quality, q",
 			Usage:  "higher values increase the image `QUALITY` and file size (25-100)",
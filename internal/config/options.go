@@ -44,6 +44,16 @@ type Options struct {
 	OIDCRole               string        `yaml:"-" json:"-" flag:"oidc-role"`
 	OIDCWebDAV             bool          `yaml:"OIDCWebDAV" json:"-" flag:"oidc-webdav"`
 	DisableOIDC            bool          `yaml:"DisableOIDC" json:"DisableOIDC" flag:"disable-oidc"`
+	LDAPUri                string        `yaml:"LDAPUri" json:"-" flag:"ldap-uri"`
+	LDAPInsecure           bool          `yaml:"LDAPInsecure" json:"-" flag:"ldap-insecure"`
+	LDAPBindDN             string        `yaml:"LDAPBindDN" json:"-" flag:"ldap-bind-dn"`
+	LDAPBindPassword       string        `yaml:"LDAPBindPassword" json:"-" flag:"ldap-bind-password"`
+	LDAPBaseDN             string        `yaml:"LDAPBaseDN" json:"-" flag:"ldap-base-dn"`
+	LDAPFilter             string        `yaml:"LDAPFilter" json:"-" flag:"ldap-filter"`
+	LDAPRoles              string        `yaml:"LDAPRoles" json:"-" flag:"ldap-roles"`
+	LDAPRole               string        `yaml:"LDAPRole" json:"-" flag:"ldap-role"`
+	DisableLDAP            bool          `yaml:"DisableLDAP" json:"DisableLDAP" flag:"disable-ldap"`
+	DisablePasskeys        bool          `yaml:"DisablePasskeys" json:"DisablePasskeys" flag:"disable-passkeys"`
 	SessionMaxAge          int64         `yaml:"SessionMaxAge" json:"-" flag:"session-maxage"`
 	SessionTimeout         int64         `yaml:"SessionTimeout" json:"-" flag:"session-timeout"`
 	SessionCache           int64         `yaml:"SessionCache" json:"-" flag:"session-cache"`
@@ -60,7 +70,9 @@ type Options struct {
 	OriginalsPath          string        `yaml:"OriginalsPath" json:"-" flag:"originals-path"`
 	OriginalsLimit         int           `yaml:"OriginalsLimit" json:"OriginalsLimit" flag:"originals-limit"`
 	ResolutionLimit        int           `yaml:"ResolutionLimit" json:"ResolutionLimit" flag:"resolution-limit"`
+	ShareUploadLimit       int           `yaml:"ShareUploadLimit" json:"ShareUploadLimit" flag:"share-upload-limit"`
 	UsersPath              string        `yaml:"UsersPath" json:"-" flag:"users-path"`
+	LibraryMode            string        `yaml:"LibraryMode" json:"LibraryMode" flag:"library-mode"`
 	StoragePath            string        `yaml:"StoragePath" json:"-" flag:"storage-path"`
 	ImportPath             string        `yaml:"ImportPath" json:"-" flag:"import-path"`
 	ImportDest             string        `yaml:"ImportDest" json:"-" flag:"import-dest"`
@@ -70,11 +82,14 @@ type Options struct {
 	CustomAssetsPath       string        `yaml:"-" json:"-" flag:"custom-assets-path"`
 	SidecarPath            string        `yaml:"SidecarPath" json:"-" flag:"sidecar-path"`
 	SidecarYaml            bool          `yaml:"SidecarYaml" json:"SidecarYaml" flag:"sidecar-yaml" default:"true"`
+	SidecarXmp             bool          `yaml:"SidecarXmp" json:"SidecarXmp" flag:"sidecar-xmp"`
 	BackupPath             string        `yaml:"BackupPath" json:"-" flag:"backup-path"`
 	BackupSchedule         string        `yaml:"BackupSchedule" json:"BackupSchedule" flag:"backup-schedule"`
 	BackupRetain           int           `yaml:"BackupRetain" json:"BackupRetain" flag:"backup-retain"`
 	BackupDatabase         bool          `yaml:"BackupDatabase" json:"BackupDatabase" flag:"backup-database" default:"true"`
 	BackupAlbums           bool          `yaml:"BackupAlbums" json:"BackupAlbums" flag:"backup-albums" default:"true"`
+	BackupOriginals        bool          `yaml:"BackupOriginals" json:"BackupOriginals" flag:"backup-originals"`
+	BackupOriginalsPath    string        `yaml:"BackupOriginalsPath" json:"-" flag:"backup-originals-path"`
 	IndexWorkers           int           `yaml:"IndexWorkers" json:"IndexWorkers" flag:"index-workers"`
 	IndexSchedule          string        `yaml:"IndexSchedule" json:"IndexSchedule" flag:"index-schedule"`
 	WakeupInterval         time.Duration `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
@@ -103,8 +118,14 @@ type Options struct {
 	DisableRaw             bool          `yaml:"DisableRaw" json:"DisableRaw" flag:"disable-raw"`
 	RawPresets             bool          `yaml:"RawPresets" json:"RawPresets" flag:"raw-presets"`
 	ExifBruteForce         bool          `yaml:"ExifBruteForce" json:"ExifBruteForce" flag:"exif-bruteforce"`
+	TrackMaxGap            int           `yaml:"TrackMaxGap" json:"TrackMaxGap" flag:"track-max-gap"`
+	GeoApi                 string        `yaml:"GeoApi" json:"GeoApi" flag:"geo-api"`
+	GeoNamesPath           string        `yaml:"GeoNamesPath" json:"-" flag:"geonames-path"`
 	DetectNSFW             bool          `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW             bool          `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
+	SemanticSearch         bool          `yaml:"SemanticSearch" json:"SemanticSearch" flag:"semantic-search"`
+	DetectObjects          bool          `yaml:"DetectObjects" json:"DetectObjects" flag:"detect-objects"`
+	DetectPets             bool          `yaml:"DetectPets" json:"DetectPets" flag:"detect-pets"`
 	DefaultLocale          string        `yaml:"DefaultLocale" json:"DefaultLocale" flag:"default-locale"`
 	DefaultTimezone        string        `yaml:"DefaultTimezone" json:"DefaultTimezone" flag:"default-timezone"`
 	DefaultTheme           string        `yaml:"DefaultTheme" json:"DefaultTheme" flag:"default-theme"`
@@ -159,6 +180,7 @@ type Options struct {
 	FFmpegBitrate          int           `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
 	FFmpegMapVideo         string        `yaml:"FFmpegMapVideo" json:"FFmpegMapVideo" flag:"ffmpeg-map-video"`
 	FFmpegMapAudio         string        `yaml:"FFmpegMapAudio" json:"FFmpegMapAudio" flag:"ffmpeg-map-audio"`
+	FFmpegStreaming        string        `yaml:"FFmpegStreaming" json:"FFmpegStreaming" flag:"ffmpeg-streaming"`
 	ExifToolBin            string        `yaml:"ExifToolBin" json:"-" flag:"exiftool-bin"`
 	SipsBin                string        `yaml:"SipsBin" json:"-" flag:"sips-bin"`
 	SipsExclude            string        `yaml:"SipsExclude" json:"-" flag:"sips-exclude"`
@@ -181,6 +203,7 @@ type Options struct {
 	ThumbSize              int           `yaml:"ThumbSize" json:"ThumbSize" flag:"thumb-size"`
 	ThumbSizeUncached      int           `yaml:"ThumbSizeUncached" json:"ThumbSizeUncached" flag:"thumb-size-uncached"`
 	ThumbUncached          bool          `yaml:"ThumbUncached" json:"ThumbUncached" flag:"thumb-uncached"`
+	ThumbVideo             bool          `yaml:"ThumbVideo" json:"ThumbVideo" flag:"thumb-video"`
 	JpegQuality            int           `yaml:"JpegQuality" json:"JpegQuality" flag:"jpeg-quality"`
 	JpegSize               int           `yaml:"JpegSize" json:"JpegSize" flag:"jpeg-size"`
 	PngSize                int           `yaml:"PngSize" json:"PngSize" flag:"png-size"`
@@ -31,6 +31,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"session-maxage", fmt.Sprintf("%d", c.SessionMaxAge())},
 		{"session-timeout", fmt.Sprintf("%d", c.SessionTimeout())},
 		{"session-cache", fmt.Sprintf("%d", c.SessionCache())},
+		{"disable-passkeys", fmt.Sprintf("%t", c.DisablePasskeys())},
 
 		// Logging.
 		{"log-level", c.LogLevel().String()},
@@ -56,7 +57,9 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"originals-path", c.OriginalsPath()},
 		{"originals-limit", fmt.Sprintf("%d", c.OriginalsLimit())},
 		{"resolution-limit", fmt.Sprintf("%d", c.ResolutionLimit())},
+		{"share-upload-limit", fmt.Sprintf("%d", c.ShareUploadLimit())},
 		{"users-path", c.UsersPath()},
+		{"library-mode", c.LibraryMode()},
 		{"users-originals-path", c.UsersOriginalsPath()},
 
 		// Storage.
@@ -78,6 +81,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		// Sidecar Files.
 		{"sidecar-path", c.SidecarPath()},
 		{"sidecar-yaml", fmt.Sprintf("%t", c.SidecarYaml())},
+		{"sidecar-xmp", fmt.Sprintf("%t", c.SidecarXmp())},
 
 		// Backups.
 		{"backup-path", c.BackupBasePath()},
@@ -87,6 +91,8 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"backup-database-path", c.BackupDatabasePath()},
 		{"backup-albums", fmt.Sprintf("%t", c.BackupAlbums())},
 		{"backup-albums-path", c.BackupAlbumsPath()},
+		{"backup-originals", fmt.Sprintf("%t", c.BackupOriginals())},
+		{"backup-originals-path", redactUrl(c.BackupOriginalsPath())},
 
 		// Indexing.
 		{"index-workers", fmt.Sprintf("%d", c.IndexWorkers())},
@@ -122,12 +128,21 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		// Format Flags.
 		{"raw-presets", fmt.Sprintf("%t", c.RawPresets())},
 		{"exif-bruteforce", fmt.Sprintf("%t", c.ExifBruteForce())},
+		{"track-max-gap", c.TrackMaxGap().String()},
+		{"geo-api", c.GeoApi()},
+		{"geonames-path", c.GeoNamesPath()},
 
 		// TensorFlow.
 		{"detect-nsfw", fmt.Sprintf("%t", c.DetectNSFW())},
 		{"upload-nsfw", fmt.Sprintf("%t", c.UploadNSFW())},
+		{"semantic-search", fmt.Sprintf("%t", c.SemanticSearch())},
+		{"detect-objects", fmt.Sprintf("%t", c.DetectObjects())},
+		{"detect-pets", fmt.Sprintf("%t", c.DetectPets())},
 		{"tensorflow-version", c.TensorFlowVersion()},
 		{"tensorflow-model-path", c.TensorFlowModelPath()},
+		{"classify-yaml", c.ClassifyYaml()},
+		{"clip-model-path", c.ClipModelPath()},
+		{"objects-model-path", c.ObjectsModelPath()},
 
 		// Customization.
 		{"default-locale", c.DefaultLocale()},
@@ -199,6 +214,8 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"database-conns-idle", fmt.Sprintf("%d", c.DatabaseConnsIdle())},
 		{"mariadb-bin", c.MariadbBin()},
 		{"mariadb-dump-bin", c.MariadbDumpBin()},
+		{"psql-bin", c.PsqlBin()},
+		{"pg-dump-bin", c.PgDumpBin()},
 
 		// File Converters.
 		{"ffmpeg-bin", c.FFmpegBin()},
@@ -207,6 +224,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"ffmpeg-bitrate", fmt.Sprintf("%d", c.FFmpegBitrate())},
 		{"ffmpeg-map-video", c.FFmpegMapVideo()},
 		{"ffmpeg-map-audio", c.FFmpegMapAudio()},
+		{"ffmpeg-streaming", c.FFmpegStreaming().String()},
 		{"exiftool-bin", c.ExifToolBin()},
 		{"sips-bin", c.SipsBin()},
 		{"sips-exclude", c.SipsExclude()},
@@ -232,6 +250,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"thumb-size", fmt.Sprintf("%d", c.ThumbSizePrecached())},
 		{"thumb-size-uncached", fmt.Sprintf("%d", c.ThumbSizeUncached())},
 		{"thumb-uncached", fmt.Sprintf("%t", c.ThumbUncached())},
+		{"thumb-video", fmt.Sprintf("%t", c.ThumbVideo())},
 		{"jpeg-quality", fmt.Sprintf("%d", c.JpegQuality())},
 		{"jpeg-size", fmt.Sprintf("%d", c.JpegSize())},
 		{"png-size", fmt.Sprintf("%d", c.PngSize())},
@@ -237,6 +237,7 @@ func CliTestContext() *cli.Context {
 	globalSet.String("storage-path", config.StoragePath, "doc")
 	globalSet.String("sidecar-path", config.SidecarPath, "doc")
 	globalSet.Bool("sidecar-yaml", config.SidecarYaml, "doc")
+	globalSet.Bool("sidecar-xmp", config.SidecarXmp, "doc")
 	globalSet.String("assets-path", config.AssetsPath, "doc")
 	globalSet.String("originals-path", config.OriginalsPath, "doc")
 	globalSet.String("import-path", config.OriginalsPath, "doc")
@@ -271,6 +272,7 @@ func CliTestContext() *cli.Context {
 	LogErr(c.Set("storage-path", config.StoragePath))
 	LogErr(c.Set("sidecar-path", config.SidecarPath))
 	LogErr(c.Set("sidecar-yaml", fmt.Sprintf("%t", config.SidecarYaml)))
+	LogErr(c.Set("sidecar-xmp", fmt.Sprintf("%t", config.SidecarXmp)))
 	LogErr(c.Set("assets-path", config.AssetsPath))
 	LogErr(c.Set("originals-path", config.OriginalsPath))
 	LogErr(c.Set("import-path", config.ImportPath))
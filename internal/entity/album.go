@@ -26,6 +26,7 @@ const (
 	AlbumMoment = "moment"
 	AlbumMonth  = "month"
 	AlbumState  = "state"
+	AlbumSmart  = "smart"
 )
 
 type Albums []Album
@@ -56,6 +57,7 @@ type Album struct {
 	AlbumPrivate     bool        `json:"Private" yaml:"Private,omitempty"`
 	Thumb            string      `gorm:"type:VARBINARY(128);index;default:'';" json:"Thumb" yaml:"Thumb,omitempty"`
 	ThumbSrc         string      `gorm:"type:VARBINARY(8);default:'';" json:"ThumbSrc,omitempty" yaml:"ThumbSrc,omitempty"`
+	CachedCount      int         `gorm:"default:0;" json:"-" yaml:"-"`
 	CreatedBy        string      `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
 	CreatedAt        time.Time   `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
 	UpdatedAt        time.Time   `json:"UpdatedAt" yaml:"UpdatedAt,omitempty"`
@@ -145,6 +147,43 @@ func AddPhotoToUserAlbums(photoUid string, albums []string, userUid string) (err
 	return err
 }
 
+// AddPhotoToAlbumsForReview flags a photo for review and adds it to existing albums as hidden entries
+// that become visible once the photo has been approved, e.g. for files uploaded by link visitors.
+func AddPhotoToAlbumsForReview(photoUid string, albums []string) (err error) {
+	if !rnd.IsUID(photoUid, PhotoUID) {
+		return fmt.Errorf("album: can not add invalid photo uid %s", clean.Log(photoUid))
+	}
+
+	// Flag photo for review.
+	if err = UnscopedDb().Model(&Photo{}).
+		Where("photo_uid = ? AND photo_quality >= 0", photoUid).
+		UpdateColumn("photo_quality", 0).Error; err != nil {
+		return err
+	}
+
+	for _, albumUid := range albums {
+		if !rnd.IsUID(albumUid, AlbumUID) {
+			log.Debugf("album: cannot add photo uid %s for review because album uid %s is invalid", clean.Log(photoUid), clean.Log(albumUid))
+			continue
+		}
+
+		entry := PhotoAlbum{}
+
+		// Keep entries that are already visible.
+		if findErr := Db().Where("photo_uid = ? AND album_uid = ?", photoUid, albumUid).First(&entry).Error; findErr == nil && !entry.Hidden {
+			continue
+		}
+
+		entry = PhotoAlbum{AlbumUID: albumUid, PhotoUID: photoUid, Hidden: true, Review: true}
+
+		if err = entry.Save(); err != nil {
+			log.Errorf("album: %s (add photo %s for review)", err.Error(), photoUid)
+		}
+	}
+
+	return err
+}
+
 // NewAlbum creates a new album of the given type.
 func NewAlbum(albumTitle, albumType string) *Album {
 	return NewUserAlbum(albumTitle, albumType, OwnerUnknown)
@@ -174,6 +213,36 @@ func NewUserAlbum(albumTitle, albumType, userUid string) *Album {
 	return result
 }
 
+// NewSmartAlbum creates a new smart album owned by a user that contains all pictures
+// matching the search filter, e.g. "label:dog country:de year:2023".
+func NewSmartAlbum(albumTitle, albumFilter, userUid string) (*Album, error) {
+	albumFilter, err := SmartAlbumFilter(albumFilter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewUserAlbum(albumTitle, AlbumSmart, userUid)
+	result.AlbumFilter = albumFilter
+
+	return result, nil
+}
+
+// SmartAlbumFilter validates a smart album search filter and returns it in normalized form.
+func SmartAlbumFilter(albumFilter string) (string, error) {
+	f := form.SearchPhotos{}
+
+	if err := form.Unserialize(&f, albumFilter); err != nil {
+		return "", fmt.Errorf("invalid smart album filter (%s)", err)
+	} else if f.Scope != "" || f.Filter != "" || f.Album != "" {
+		return "", fmt.Errorf("smart album filter must not refer to other albums")
+	} else if albumFilter = f.Serialize(); albumFilter == "" {
+		return "", fmt.Errorf("smart album filter must not be empty")
+	}
+
+	return albumFilter, nil
+}
+
 // NewFolderAlbum creates a new folder album.
 func NewFolderAlbum(albumTitle, albumPath, albumFilter string) *Album {
 	albumSlug := txt.Slug(albumPath)
@@ -464,6 +533,11 @@ func (m *Album) IsState() bool {
 	return m.AlbumType == AlbumState
 }
 
+// IsSmart tests if the album is a smart album created from a search filter.
+func (m *Album) IsSmart() bool {
+	return m.AlbumType == AlbumSmart
+}
+
 // IsDefault tests if the album is a regular album.
 func (m *Album) IsDefault() bool {
 	return m.AlbumType == AlbumManual
@@ -481,7 +555,7 @@ func (m *Album) SetTitle(title string) *Album {
 
 	m.AlbumTitle = title
 
-	if m.AlbumType == AlbumManual || m.AlbumSlug == "" {
+	if m.AlbumType == AlbumManual || m.AlbumType == AlbumSmart || m.AlbumSlug == "" {
 		if len(m.AlbumTitle) < txt.ClipSlug {
 			m.AlbumSlug = txt.Slug(m.AlbumTitle)
 		} else {
@@ -608,6 +682,15 @@ func (m *Album) SaveForm(f form.Album) error {
 		return err
 	}
 
+	// Smart albums require a valid search filter.
+	if m.IsSmart() {
+		if albumFilter, err := SmartAlbumFilter(m.AlbumFilter); err != nil {
+			return err
+		} else {
+			m.AlbumFilter = albumFilter
+		}
+	}
+
 	if f.AlbumCategory != "" {
 		m.AlbumCategory = txt.Clip(txt.Title(f.AlbumCategory), txt.ClipCategory)
 	}
@@ -799,7 +882,8 @@ func (m *Album) ZipName() string {
 
 // AddPhotos adds photos to an existing album.
 func (m *Album) AddPhotos(photos PhotosInterface) (added PhotoAlbums) {
-	if !m.HasID() {
+	// Smart album contents are defined by their search filter.
+	if !m.HasID() || m.IsSmart() {
 		return added
 	}
 
@@ -830,7 +914,8 @@ func (m *Album) AddPhotos(photos PhotosInterface) (added PhotoAlbums) {
 
 // RemovePhotos removes photos from an album.
 func (m *Album) RemovePhotos(UIDs []string) (removed PhotoAlbums) {
-	if !m.HasID() {
+	// Smart album contents are defined by their search filter.
+	if !m.HasID() || m.IsSmart() {
 		return removed
 	}
 
@@ -115,6 +115,76 @@ func TestAddPhotoToAlbums(t *testing.T) {
 	})
 }
 
+func TestAddPhotoToAlbumsForReview(t *testing.T) {
+	t.Run("Approve", func(t *testing.T) {
+		photo := Photo{PhotoQuality: 4}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := AddPhotoToAlbumsForReview(photo.PhotoUID, []string{"as6sg6bitoga0005"}); err != nil {
+			t.Fatal(err)
+		}
+
+		entry := PhotoAlbum{}
+
+		if err := Db().Where("album_uid = ? AND photo_uid = ?", "as6sg6bitoga0005", photo.PhotoUID).First(&entry).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, entry.Hidden)
+		assert.True(t, entry.Review)
+
+		found := FindPhoto(photo)
+
+		if found == nil {
+			t.Fatal("photo not found")
+		}
+
+		assert.Equal(t, 0, found.PhotoQuality)
+		assert.False(t, found.Approved())
+
+		if err := found.Approve(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := Db().Where("album_uid = ? AND photo_uid = ?", "as6sg6bitoga0005", photo.PhotoUID).First(&entry).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, entry.Hidden)
+		assert.False(t, entry.Review)
+	})
+	t.Run("KeepVisibleEntry", func(t *testing.T) {
+		photo := Photo{PhotoQuality: 4}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := AddPhotoToAlbums(photo.PhotoUID, []string{"as6sg6bitoga0005"}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := AddPhotoToAlbumsForReview(photo.PhotoUID, []string{"as6sg6bitoga0005"}); err != nil {
+			t.Fatal(err)
+		}
+
+		entry := PhotoAlbum{}
+
+		if err := Db().Where("album_uid = ? AND photo_uid = ?", "as6sg6bitoga0005", photo.PhotoUID).First(&entry).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, entry.Hidden)
+		assert.False(t, entry.Review)
+	})
+	t.Run("InvalidPhotoUID", func(t *testing.T) {
+		assert.Error(t, AddPhotoToAlbumsForReview("xxx", []string{"as6sg6bitoga0005"}))
+	})
+}
+
 func TestAddPhotoToUserAlbums(t *testing.T) {
 	t.Run("AddToExistingAlbum", func(t *testing.T) {
 		err := AddPhotoToUserAlbums("ps6sg6bexxvl0yh0", []string{"as6sg6bitoga0004"}, "uqxetse3cy5eo9z2")
@@ -201,6 +271,56 @@ func TestNewFolderAlbum(t *testing.T) {
 	})
 }
 
+func TestNewSmartAlbum(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		album, err := NewSmartAlbum("Dogs in Germany", "label:dog country:de year:2023", "uqxetse3cy5eo9z2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Dogs in Germany", album.AlbumTitle)
+		assert.Equal(t, "dogs-in-germany", album.AlbumSlug)
+		assert.Equal(t, AlbumSmart, album.AlbumType)
+		assert.Equal(t, "uqxetse3cy5eo9z2", album.CreatedBy)
+		assert.Equal(t, "label:dog country:de year:2023", album.AlbumFilter)
+		assert.True(t, album.IsSmart())
+		assert.False(t, album.IsDefault())
+	})
+	t.Run("InvalidFilter", func(t *testing.T) {
+		album, err := NewSmartAlbum("Dogs", "foo:bar", "uqxetse3cy5eo9z2")
+		assert.Error(t, err)
+		assert.Nil(t, album)
+	})
+	t.Run("EmptyFilter", func(t *testing.T) {
+		album, err := NewSmartAlbum("Dogs", "", "uqxetse3cy5eo9z2")
+		assert.Error(t, err)
+		assert.Nil(t, album)
+	})
+}
+
+func TestSmartAlbumFilter(t *testing.T) {
+	t.Run("Normalize", func(t *testing.T) {
+		result, err := SmartAlbumFilter("country:de   label:dog")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "label:dog country:de", result)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := SmartAlbumFilter("foo:bar")
+		assert.Error(t, err)
+	})
+	t.Run("Nested", func(t *testing.T) {
+		for _, albumFilter := range []string{"album:berlin", "scope:as6sg6bxpogaaba9", "s:as6sg6bxpogaaba9", "filter:label:dog", "label:dog album:as6sg6bxpogaaba9"} {
+			_, err := SmartAlbumFilter(albumFilter)
+			assert.Error(t, err, albumFilter)
+		}
+	})
+}
+
 func TestNewMomentsAlbum(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		album := NewMomentsAlbum("Dogs", "dogs", "label:dog")
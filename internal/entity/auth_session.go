@@ -464,9 +464,9 @@ func (m *Session) Method() authn.MethodType {
 	return authn.Method(m.AuthMethod)
 }
 
-// Is2FA checks if 2-Factor Authentication (2FA) was used to log in.
+// Is2FA checks if 2-Factor Authentication (2FA) was used to log in, which includes passkeys with user verification.
 func (m *Session) Is2FA() bool {
-	return m.Method().Is(authn.Method2FA)
+	return m.Method().Is(authn.Method2FA) || m.Method().Is(authn.MethodPasskey)
 }
 
 // SetMethod sets a custom authentication method.
@@ -766,6 +766,28 @@ func (m *Session) HasShare(uid string) bool {
 	}
 }
 
+// SharePerm returns the permissions granted for the specified shared uid.
+func (m *Session) SharePerm(uid string) uint {
+	if user := m.User(); user.IsRegistered() {
+		return user.SharePerm(uid)
+	} else if data := m.Data(); data == nil {
+		return PermDefault
+	} else {
+		return data.SharePerm(uid)
+	}
+}
+
+// ShareLink returns a valid share link for the specified uid that grants the permission, or nil if none was found.
+func (m *Session) ShareLink(uid string, perm uint) *Link {
+	if user := m.User(); user.IsRegistered() {
+		return user.ShareLink(uid, perm)
+	} else if data := m.Data(); data == nil {
+		return nil
+	} else {
+		return data.ShareLink(uid, perm)
+	}
+}
+
 // SharedUIDs returns shared entity UIDs.
 func (m *Session) SharedUIDs() UIDs {
 	if user := m.User(); user.IsRegistered() {
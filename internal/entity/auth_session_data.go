@@ -70,6 +70,38 @@ func (data *SessionData) RedeemToken(token string) (n int) {
 	return n
 }
 
+// SharePerm returns the permissions granted by the redeemed share links for the specified uid.
+func (data SessionData) SharePerm(uid string) (perm uint) {
+	if !data.HasShare(uid) {
+		return PermDefault
+	}
+
+	for _, token := range data.Tokens {
+		for _, link := range FindValidLinks(token, uid) {
+			perm |= link.Perm
+		}
+	}
+
+	return perm
+}
+
+// ShareLink returns the first valid share link for the specified uid that grants the permission, or nil if none was found.
+func (data SessionData) ShareLink(uid string, perm uint) *Link {
+	if !data.HasShare(uid) {
+		return nil
+	}
+
+	for _, token := range data.Tokens {
+		for _, link := range FindValidLinks(token, uid) {
+			if link.Perm&perm != 0 {
+				return &link
+			}
+		}
+	}
+
+	return nil
+}
+
 // NoShares checks if the session has no shares yet.
 func (data SessionData) NoShares() bool {
 	return len(data.Shares) == 0
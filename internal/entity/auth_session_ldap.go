@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/header"
+	"github.com/photoprism/photoprism/pkg/i18n"
+)
+
+// LdapClient authenticates users against an LDAP or Active Directory server, if configured.
+var LdapClient *ldap.Client
+
+// AuthLdap authenticates against the configured LDAP directory with the specified username and password.
+// User accounts are created on first login, and their name, email and role are updated on subsequent logins.
+func AuthLdap(user *User, f form.Login, s *Session, c *gin.Context) (result *User, provider authn.ProviderType, method authn.MethodType, err error) {
+	// Set defaults.
+	result = user
+	provider = authn.ProviderNone
+	method = authn.MethodUndefined
+
+	// Get client IP from request context.
+	clientIp := header.ClientIP(c)
+
+	// Get sanitized username from login form.
+	username := f.CleanUsername()
+
+	// Reports a failed login attempt.
+	failed := func(message string) (*User, authn.ProviderType, authn.MethodType, error) {
+		if s != nil {
+			event.AuditWarn([]string{clientIp, "session %s", "login as %s", "ldap", message}, s.RefID, clean.LogQuote(username))
+			event.LoginError(clientIp, "api", username, s.UserAgent, message)
+			s.Status = http.StatusUnauthorized
+		}
+
+		return result, provider, method, i18n.Error(i18n.ErrInvalidCredentials)
+	}
+
+	if LdapClient == nil {
+		return failed(authn.ErrAuthenticationDisabled.Error())
+	}
+
+	// Verify username and password.
+	account, authErr := LdapClient.Authenticate(username, f.Password)
+
+	if authErr != nil {
+		return failed(authErr.Error())
+	}
+
+	// Find existing account by DN if it was not found by name.
+	if result == nil {
+		result = FindUser(User{AuthProvider: authn.ProviderLDAP.String(), AuthID: account.DN})
+	}
+
+	// Check user role based on the directory groups.
+	role := LdapClient.Role(account)
+
+	if role == acl.RoleNone {
+		return failed(authn.ErrUnauthorized.Error())
+	}
+
+	if result == nil {
+		// Create new user account.
+		newUser := LdapUser(account.Username, account.DN)
+
+		if newUser.UserName == "" {
+			return failed(authn.ErrUsernameRequired.Error())
+		} else if found := FindUserByName(newUser.UserName); found != nil {
+			return failed(authn.ErrAccountAlreadyExists.Error())
+		}
+
+		newUser.SetRole(role.String())
+		newUser.CanLogin = true
+		newUser.SetDisplayName(account.Name, SrcLDAP)
+		newUser.UserEmail = account.Email
+
+		if createErr := newUser.Create(); createErr != nil {
+			return failed(authn.ErrAccountCreateFailed.Error() + " (" + createErr.Error() + ")")
+		}
+
+		result = &newUser
+
+		event.AuditInfo([]string{clientIp, "login as %s", "ldap", "account created"}, clean.LogQuote(username))
+	} else if !result.HasProvider(authn.ProviderLDAP) {
+		return failed(authn.ErrInvalidUser.Error())
+	} else if result.AuthID != "" && !strings.EqualFold(result.AuthID, account.DN) {
+		return failed(authn.ErrUserDoesNotMatch.Error())
+	} else {
+		// Update existing user account, as the role may have changed in the directory.
+		result.AuthID = account.DN
+		result.SetDisplayName(account.Name, SrcLDAP)
+
+		if account.Email != "" {
+			result.UserEmail = account.Email
+		}
+
+		if !result.SuperAdmin {
+			result.SetRole(role.String())
+		}
+
+		if !result.CanLogIn() {
+			return failed(authn.ErrAccountDisabled.Error())
+		} else if saveErr := result.Save(); saveErr != nil {
+			return failed(authn.ErrAccountUpdateFailed.Error() + " (" + saveErr.Error() + ")")
+		}
+	}
+
+	provider = authn.ProviderLDAP
+
+	// Check two-factor authentication, if enabled.
+	if method, err = AuthPasscode(result, f, s, c); err != nil {
+		return result, provider, method, err
+	}
+
+	if s != nil {
+		event.AuditInfo([]string{clientIp, "session %s", "login as %s", "ldap", authn.Succeeded}, s.RefID, clean.LogQuote(username))
+		event.LoginInfo(clientIp, "api", username, s.UserAgent)
+	}
+
+	return result, provider, method, nil
+}
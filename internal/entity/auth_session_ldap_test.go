@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
+	"github.com/photoprism/photoprism/internal/auth/ldap/ldaptest"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+func TestAuthLdap(t *testing.T) {
+	srv, err := ldaptest.NewServer(ldaptest.Entries...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer srv.Close()
+
+	LdapClient = ldap.NewClient(ldap.Config{
+		URI:          srv.URI(),
+		BindDN:       "cn=reader,dc=example,dc=com",
+		BindPassword: "reader-secret",
+		BaseDN:       "ou=people,dc=example,dc=com",
+		Roles:        ldap.ParseRoles("admins=admin;family=guest"),
+	})
+
+	defer func() { LdapClient = nil }()
+
+	login := func(frm form.Login) (*User, authn.ProviderType, authn.MethodType, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/session", form.AsReader(frm))
+		c.Request.RemoteAddr = "1.2.3.4"
+
+		return Auth(frm, NewSession(0, 0), c)
+	}
+
+	t.Run("CreateAccount", func(t *testing.T) {
+		user, provider, method, authErr := login(form.Login{Username: "carol", Password: "carol-secret"})
+
+		if authErr != nil {
+			t.Fatal(authErr)
+		}
+
+		assert.Equal(t, authn.ProviderLDAP, provider)
+		assert.Equal(t, authn.MethodDefault, method)
+
+		if found := FindUserByName("carol"); assert.NotNil(t, found) {
+			assert.Equal(t, user.UserUID, found.UserUID)
+			assert.Equal(t, authn.ProviderLDAP.String(), found.AuthProvider)
+			assert.Equal(t, "uid=carol,ou=people,dc=example,dc=com", found.AuthID)
+			assert.Equal(t, "Carol Example", found.DisplayName)
+			assert.Equal(t, "carol@example.com", found.UserEmail)
+			assert.Equal(t, acl.RoleAdmin, found.AclRole())
+			assert.True(t, found.CanLogIn())
+		}
+	})
+	t.Run("SyncAccount", func(t *testing.T) {
+		// Change user role and email, which should be restored based on the directory entry.
+		user := FindUserByName("dave")
+
+		if user == nil {
+			if _, _, _, authErr := login(form.Login{Username: "dave", Password: "dave-secret"}); authErr != nil {
+				t.Fatal(authErr)
+			}
+
+			user = FindUserByName("dave")
+		}
+
+		if user == nil {
+			t.Fatal("user should not be nil")
+		}
+
+		assert.NoError(t, user.Updates(Map{"UserRole": acl.RoleVisitor.String(), "UserEmail": "dave@old.example.com"}))
+
+		if _, provider, _, authErr := login(form.Login{Username: "dave", Password: "dave-secret"}); authErr != nil {
+			t.Fatal(authErr)
+		} else {
+			assert.Equal(t, authn.ProviderLDAP, provider)
+		}
+
+		if found := FindUserByName("dave"); assert.NotNil(t, found) {
+			assert.Equal(t, acl.RoleGuest, found.AclRole())
+			assert.Equal(t, "dave@example.com", found.UserEmail)
+			assert.Equal(t, "Dave Example", found.DisplayName)
+		}
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		_, provider, _, authErr := login(form.Login{Username: "carol", Password: "wrong"})
+
+		assert.Error(t, authErr)
+		assert.Equal(t, authn.ProviderNone, provider)
+	})
+	t.Run("NoRole", func(t *testing.T) {
+		user, _, _, authErr := login(form.Login{Username: "mallory", Password: "mallory-secret"})
+
+		assert.Error(t, authErr)
+		assert.Nil(t, user)
+		assert.Nil(t, FindUserByName("mallory"))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		user, _, _, authErr := login(form.Login{Username: "eve", Password: "eve-secret"})
+
+		assert.Error(t, authErr)
+		assert.Nil(t, user)
+	})
+	t.Run("LocalAccount", func(t *testing.T) {
+		_, provider, _, authErr := login(form.Login{Username: "alice", Password: "Alice123!"})
+
+		assert.NoError(t, authErr)
+		assert.Equal(t, authn.ProviderLocal, provider)
+	})
+}
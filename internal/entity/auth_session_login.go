@@ -26,8 +26,13 @@ var Auth = func(f form.Login, s *Session, c *gin.Context) (user *User, provider
 	// Find registered user account.
 	user = FindUserByName(nameName)
 
-	// Try local authentication.
-	provider, method, err = AuthLocal(user, f, s, c)
+	// Authenticate against the LDAP directory if configured, unless it is a local account or an app password was provided.
+	if LdapClient != nil && (user == nil || user.HasProvider(authn.ProviderLDAP)) && !rnd.IsAppPassword(f.Password, true) {
+		user, provider, method, err = AuthLdap(user, f, s, c)
+	} else {
+		// Try local authentication.
+		provider, method, err = AuthLocal(user, f, s, c)
+	}
 
 	if err != nil {
 		return user, provider, method, err
@@ -192,8 +197,39 @@ func AuthLocal(user *User, f form.Login, s *Session, c *gin.Context) (provider a
 	provider = authn.ProviderLocal
 
 	// Check two-factor authentication, if enabled.
+	if method, err = AuthPasscode(user, f, s, c); err != nil {
+		return provider, method, err
+	}
+
+	if s != nil {
+		event.AuditInfo([]string{clientIp, "session %s", "login as %s", authn.Succeeded}, s.RefID, clean.LogQuote(username))
+		event.LoginInfo(clientIp, "api", username, s.UserAgent)
+	}
+
+	return provider, method, nil
+}
+
+// AuthPasscode checks the two-factor authentication passcode, if enabled for the user, and returns the authentication method.
+func AuthPasscode(user *User, f form.Login, s *Session, c *gin.Context) (method authn.MethodType, err error) {
+	// Get client IP from request context.
+	clientIp := header.ClientIP(c)
+
+	// Get sanitized username from login form.
+	username := f.CleanUsername()
+
 	if method = user.Method(); method.Is(authn.Method2FA) {
-		if code := f.Passcode(); code == "" {
+		// A registered passkey can be used instead of a verification code.
+		if f.HasPasskey() {
+			if _, passkeyErr := user.VerifyPasskey(f.Passkey); passkeyErr != nil {
+				if s != nil {
+					event.AuditErr([]string{clientIp, "session %s", "login as %s", authn.Passkey, passkeyErr.Error()}, s.RefID, clean.LogQuote(username))
+					event.LoginError(clientIp, "api", username, s.UserAgent, passkeyErr.Error())
+					s.Status = http.StatusUnauthorized
+				}
+
+				return method, authn.ErrInvalidPasskey
+			}
+		} else if code := f.Passcode(); code == "" {
 			err = authn.ErrPasscodeRequired
 
 			if s != nil {
@@ -201,7 +237,7 @@ func AuthLocal(user *User, f form.Login, s *Session, c *gin.Context) (provider a
 				s.Status = http.StatusUnauthorized
 			}
 
-			return provider, method, err
+			return method, err
 		} else if valid, _, codeErr := user.VerifyPasscode(code); codeErr != nil {
 			if s != nil {
 				event.AuditWarn([]string{clientIp, "session %s", "login as %s", codeErr.Error()}, s.RefID, clean.LogQuote(username))
@@ -209,7 +245,7 @@ func AuthLocal(user *User, f form.Login, s *Session, c *gin.Context) (provider a
 				s.Status = http.StatusUnauthorized
 			}
 
-			return provider, method, codeErr
+			return method, codeErr
 		} else if !valid {
 			err = authn.ErrInvalidPasscode
 
@@ -219,18 +255,13 @@ func AuthLocal(user *User, f form.Login, s *Session, c *gin.Context) (provider a
 				s.Status = http.StatusUnauthorized
 			}
 
-			return provider, method, err
+			return method, err
 		}
 	} else if method == authn.MethodUndefined {
 		method = authn.MethodDefault
 	}
 
-	if s != nil {
-		event.AuditInfo([]string{clientIp, "session %s", "login as %s", authn.Succeeded}, s.RefID, clean.LogQuote(username))
-		event.LoginInfo(clientIp, "api", username, s.UserAgent)
-	}
-
-	return provider, method, nil
+	return method, nil
 }
 
 // LogIn performs authentication checks against the specified login form.
@@ -263,6 +294,23 @@ func (m *Session) LogIn(f form.Login, c *gin.Context) (err error) {
 
 		m.SetUser(user)
 		m.SetGrantType(authn.GrantPassword)
+	} else if f.HasPasskey() {
+		// Log in with a passkey instead of username and password.
+		if m.IsRegistered() {
+			m.Regenerate()
+		}
+
+		user, provider, method, err = AuthPasskey(f, m, c)
+
+		m.SetProvider(provider)
+		m.SetMethod(method)
+
+		if err != nil {
+			return err
+		}
+
+		m.SetUser(user)
+		m.SetGrantType(authn.GrantPasskey)
 	}
 
 	// Try to redeem link share token, if provided.
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/webauthn"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/header"
+	"github.com/photoprism/photoprism/pkg/i18n"
+)
+
+// WebAuthn verifies passkey registrations and logins, or is nil if passkeys are disabled.
+var WebAuthn *webauthn.RelyingParty
+
+// AuthPasskey authenticates a user with a passkey instead of a username and password.
+// Passwordless logins require the authenticator to verify the user, e.g. with a PIN or biometrics.
+func AuthPasskey(f form.Login, s *Session, c *gin.Context) (user *User, provider authn.ProviderType, method authn.MethodType, err error) {
+	// Set defaults.
+	provider = authn.ProviderNone
+	method = authn.MethodUndefined
+
+	// Get client IP from request context.
+	clientIp := header.ClientIP(c)
+
+	// Reports a failed login attempt.
+	failed := func(username, message string) (*User, authn.ProviderType, authn.MethodType, error) {
+		if s != nil {
+			event.AuditWarn([]string{clientIp, "session %s", "login as %s", authn.Passkey, message}, s.RefID, clean.LogQuote(username))
+			event.LoginError(clientIp, "api", username, s.UserAgent, message)
+			s.Status = http.StatusUnauthorized
+		}
+
+		return user, provider, method, i18n.Error(i18n.ErrInvalidCredentials)
+	}
+
+	if WebAuthn == nil {
+		return failed("", authn.ErrPasskeyNotSupported.Error())
+	}
+
+	// Find the passkey and the user it belongs to.
+	passkey := FindPasskeyByKeyID(f.Passkey.CredentialID())
+
+	if passkey == nil {
+		return failed("", authn.ErrPasskeyNotFound.Error())
+	} else if user = FindUserByUID(passkey.UserUID); user == nil {
+		return failed("", authn.ErrAccountNotFound.Error())
+	}
+
+	username := user.Username()
+
+	// Passwordless login is only available for accounts with local authentication.
+	if !user.CanLogIn() {
+		return failed(username, authn.ErrAccountDisabled.Error())
+	} else if !user.Provider().IsDefault() && !user.Provider().IsLocal() {
+		return failed(username, authn.ErrAuthenticationDisabled.Error())
+	} else if verifyErr := passkey.Verify(*f.Passkey, true); verifyErr != nil {
+		return failed(username, verifyErr.Error())
+	}
+
+	provider = authn.ProviderLocal
+	method = authn.MethodPasskey
+
+	// Update login timestamp.
+	user.UpdateLoginTime()
+
+	if s != nil {
+		event.AuditInfo([]string{clientIp, "session %s", "login as %s", authn.Passkey, authn.Succeeded}, s.RefID, clean.LogQuote(username))
+		event.LoginInfo(clientIp, "api", username, s.UserAgent)
+	}
+
+	return user, provider, method, nil
+}
@@ -1039,3 +1039,35 @@ func TestSession_HttpStatus(t *testing.T) {
 	alice := FindSessionByRefID("sessxkkcabcd")
 	assert.Equal(t, 200, alice.HttpStatus())
 }
+
+func TestSession_SharePerm(t *testing.T) {
+	t.Run("Visitor", func(t *testing.T) {
+		m := FindSessionByRefID(SessionFixtures.Get("visitor").RefID)
+
+		if m == nil {
+			t.Fatal("session not found")
+		}
+
+		assert.Equal(t, PermComment|PermEdit, m.SharePerm("as6sg6bxpogaaba8"))
+		assert.Equal(t, PermDefault, m.SharePerm("as6sg6bxpogaaba7"))
+	})
+}
+
+func TestSession_ShareLink(t *testing.T) {
+	t.Run("Visitor", func(t *testing.T) {
+		m := FindSessionByRefID(SessionFixtures.Get("visitor").RefID)
+
+		if m == nil {
+			t.Fatal("session not found")
+		}
+
+		if link := m.ShareLink("as6sg6bxpogaaba8", PermEdit); link == nil {
+			t.Fatal("link expected")
+		} else {
+			assert.Equal(t, "1jxf3jfn2k", link.LinkToken)
+		}
+
+		assert.Nil(t, m.ShareLink("as6sg6bxpogaaba8", PermUpload))
+		assert.Nil(t, m.ShareLink("as6sg6bxpogaaba7", PermEdit))
+	})
+}
@@ -0,0 +1,320 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// TeamUID is the unique ID prefix.
+const (
+	TeamUID = byte('t')
+)
+
+// TeamPerms maps the permission levels at which albums and folders can be shared with a team.
+var TeamPerms = map[string]uint{
+	"view":    PermView,
+	"react":   PermView | PermReact,
+	"comment": PermView | PermReact | PermComment,
+	"upload":  PermView | PermReact | PermComment | PermUpload,
+}
+
+// TeamPerm returns the permissions for the specified level name, or PermDefault if it is unknown.
+func TeamPerm(level string) uint {
+	return TeamPerms[strings.ToLower(strings.TrimSpace(level))]
+}
+
+// Teams represents a list of teams.
+type Teams []Team
+
+// Team represents a group of users with whom albums and folders can be shared.
+type Team struct {
+	TeamUID         string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	TeamSlug        string    `gorm:"type:VARBINARY(160);unique_index;" json:"Slug" yaml:"Slug"`
+	TeamName        string    `gorm:"size:160;" json:"Name" yaml:"Name"`
+	TeamDescription string    `gorm:"size:2048;" json:"Description" yaml:"Description,omitempty"`
+	CreatedBy       string    `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
+	CreatedAt       time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt       time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Team) TableName() string {
+	return "auth_teams"
+}
+
+// NewTeam returns a new team with the specified name.
+func NewTeam(name, createdBy string) *Team {
+	m := &Team{CreatedBy: createdBy}
+	m.SetName(name)
+
+	return m
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Team) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.TeamUID, TeamUID) {
+		return nil
+	}
+
+	m.TeamUID = rnd.GenerateUID(TeamUID)
+
+	return scope.SetColumn("TeamUID", m.TeamUID)
+}
+
+// FindTeam returns the team with the specified UID or name, or nil if it was not found.
+func FindTeam(id string) *Team {
+	m := &Team{}
+
+	if rnd.IsUID(id, TeamUID) {
+		if err := UnscopedDb().First(m, "team_uid = ?", id).Error; err != nil {
+			return nil
+		}
+	} else if slug := txt.Slug(id); slug == "" {
+		return nil
+	} else if err := UnscopedDb().First(m, "team_slug = ?", slug).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindTeams returns all teams sorted by name.
+func FindTeams() (result Teams) {
+	result = Teams{}
+
+	if err := UnscopedDb().Order("team_name, team_uid").Find(&result).Error; err != nil {
+		log.Errorf("team: %s (find)", err)
+	}
+
+	return result
+}
+
+// GetUID returns the team uid string.
+func (m *Team) GetUID() string {
+	return m.TeamUID
+}
+
+// HasUID tests if the team has a valid uid.
+func (m *Team) HasUID() bool {
+	return rnd.IsUID(m.TeamUID, TeamUID)
+}
+
+// String returns the team name or uid for use in logs and reports.
+func (m *Team) String() string {
+	if m == nil {
+		return report.NotAssigned
+	} else if m.TeamName != "" {
+		return clean.Log(m.TeamName)
+	} else if m.HasUID() {
+		return m.GetUID()
+	}
+
+	return report.NotAssigned
+}
+
+// SetName changes the team name and slug.
+func (m *Team) SetName(name string) *Team {
+	if name = clean.Name(name); name == "" {
+		return m
+	}
+
+	m.TeamName = txt.Clip(name, txt.ClipName)
+	m.TeamSlug = txt.Slug(name)
+
+	return m
+}
+
+// SetValuesFromForm updates the team with the specified form values.
+func (m *Team) SetValuesFromForm(f form.Team) *Team {
+	m.SetName(f.TeamName)
+	m.TeamDescription = txt.Clip(f.TeamDescription, txt.ClipLongText)
+
+	return m
+}
+
+// Validate checks the team name and makes sure it is unique.
+func (m *Team) Validate() error {
+	if m.TeamName == "" || m.TeamSlug == "" {
+		return fmt.Errorf("team name is missing")
+	}
+
+	if found := FindTeam(m.TeamSlug); found != nil && found.TeamUID != m.TeamUID {
+		return fmt.Errorf("team %s already exists", clean.LogQuote(m.TeamName))
+	}
+
+	return nil
+}
+
+// Create inserts a new team into the database.
+func (m *Team) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Create(m).Error
+}
+
+// Save updates the team in the database.
+func (m *Team) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Save(m).Error
+}
+
+// Delete removes the team together with its members and shares.
+func (m *Team) Delete() error {
+	if !m.HasUID() {
+		return fmt.Errorf("invalid team uid")
+	}
+
+	if err := UnscopedDb().Delete(TeamUser{}, "team_uid = ?", m.TeamUID).Error; err != nil {
+		return err
+	} else if err = UnscopedDb().Delete(AlbumUser{}, "team_uid = ?", m.TeamUID).Error; err != nil {
+		return err
+	}
+
+	if err := UnscopedDb().Delete(m).Error; err != nil {
+		return err
+	}
+
+	FlushSessionCache()
+
+	return nil
+}
+
+// Members returns the users who are members of the team.
+func (m *Team) Members() (result Users) {
+	result = Users{}
+
+	if !m.HasUID() {
+		return result
+	}
+
+	if err := UnscopedDb().
+		Where("user_uid IN (SELECT user_uid FROM auth_teams_users WHERE team_uid = ?)", m.TeamUID).
+		Order("user_name").Find(&result).Error; err != nil {
+		log.Errorf("team: %s (find members)", err)
+	}
+
+	return result
+}
+
+// HasMember checks if the specified user is a member of the team.
+func (m *Team) HasMember(userUid string) bool {
+	if !m.HasUID() || userUid == "" {
+		return false
+	}
+
+	return FindTeamUser(m.TeamUID, userUid) != nil
+}
+
+// AddMember adds the specified user to the team.
+func (m *Team) AddMember(user *User) error {
+	if !m.HasUID() {
+		return fmt.Errorf("invalid team uid")
+	} else if user == nil || !user.IsRegistered() {
+		return fmt.Errorf("invalid user")
+	} else if m.HasMember(user.UserUID) {
+		return nil
+	}
+
+	if err := NewTeamUser(m.TeamUID, user.UserUID).Create(); err != nil {
+		return err
+	}
+
+	event.AuditInfo([]string{"team %s", "user %s", "member added"}, m.String(), user.String())
+
+	// Make sure the shares of active sessions are updated.
+	FlushSessionCache()
+
+	return nil
+}
+
+// RemoveMember removes the specified user from the team.
+func (m *Team) RemoveMember(user *User) error {
+	if !m.HasUID() {
+		return fmt.Errorf("invalid team uid")
+	} else if user == nil || user.UserUID == "" {
+		return fmt.Errorf("invalid user")
+	}
+
+	if err := UnscopedDb().Delete(TeamUser{}, "team_uid = ? AND user_uid = ?", m.TeamUID, user.UserUID).Error; err != nil {
+		return err
+	}
+
+	event.AuditInfo([]string{"team %s", "user %s", "member removed"}, m.String(), user.String())
+
+	FlushSessionCache()
+
+	return nil
+}
+
+// Shares returns the albums and folders shared with the team.
+func (m *Team) Shares() (result []AlbumUser) {
+	result = []AlbumUser{}
+
+	if !m.HasUID() {
+		return result
+	}
+
+	if err := UnscopedDb().Where("team_uid = ?", m.TeamUID).Order("uid").Find(&result).Error; err != nil {
+		log.Errorf("team: %s (find shares)", err)
+	}
+
+	return result
+}
+
+// Share shares the album or folder with the specified UID with the team.
+// Team grants use the team UID as user UID since both are part of the primary key.
+func (m *Team) Share(albumUid string, perm uint) error {
+	if !m.HasUID() {
+		return fmt.Errorf("invalid team uid")
+	} else if perm == PermDefault || perm == PermNone {
+		return fmt.Errorf("invalid permissions")
+	}
+
+	album := FindAlbum(Album{AlbumUID: albumUid})
+
+	if album == nil {
+		return fmt.Errorf("album %s not found", clean.Log(albumUid))
+	}
+
+	if err := NewAlbumUser(album.AlbumUID, m.TeamUID, m.TeamUID, perm).Save(); err != nil {
+		return err
+	}
+
+	event.AuditInfo([]string{"team %s", "album %s", "shared"}, m.String(), album.AlbumUID)
+
+	FlushSessionCache()
+
+	return nil
+}
+
+// Unshare stops sharing the album or folder with the specified UID with the team.
+func (m *Team) Unshare(albumUid string) error {
+	if !m.HasUID() {
+		return fmt.Errorf("invalid team uid")
+	}
+
+	if err := UnscopedDb().Delete(AlbumUser{}, "uid = ? AND team_uid = ?", albumUid, m.TeamUID).Error; err != nil {
+		return err
+	}
+
+	event.AuditInfo([]string{"team %s", "album %s", "unshared"}, m.String(), clean.Log(albumUid))
+
+	FlushSessionCache()
+
+	return nil
+}
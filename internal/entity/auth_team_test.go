@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestTeamPerm(t *testing.T) {
+	assert.Equal(t, PermView, TeamPerm("view"))
+	assert.Equal(t, PermView|PermReact|PermComment, TeamPerm(" Comment "))
+	assert.Equal(t, PermUpload, TeamPerm("upload")&PermUpload)
+	assert.Equal(t, PermDefault, TeamPerm("edit"))
+	assert.Equal(t, PermDefault, TeamPerm("owner"))
+}
+
+func TestNewTeam(t *testing.T) {
+	m := NewTeam("Photo Club  ", "uqxetse3cy5eo9z2")
+
+	assert.Equal(t, "Photo Club", m.TeamName)
+	assert.Equal(t, "photo-club", m.TeamSlug)
+	assert.Equal(t, "uqxetse3cy5eo9z2", m.CreatedBy)
+	assert.False(t, m.HasUID())
+}
+
+func TestTeam_Create(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewTeam("Create Team", "")
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.HasUID())
+
+		if found := FindTeam(m.TeamUID); assert.NotNil(t, found) {
+			assert.Equal(t, "Create Team", found.TeamName)
+		}
+
+		if found := FindTeam("create team"); assert.NotNil(t, found) {
+			assert.Equal(t, m.TeamUID, found.TeamUID)
+		}
+
+		assert.Error(t, NewTeam("Create Team", "").Create())
+		assert.NoError(t, m.Delete())
+		assert.Nil(t, FindTeam(m.TeamUID))
+	})
+	t.Run("NoName", func(t *testing.T) {
+		assert.Error(t, NewTeam("", "").Create())
+	})
+}
+
+func TestTeam_SetValuesFromForm(t *testing.T) {
+	m := NewTeam("Form Team", "").SetValuesFromForm(form.Team{TeamName: "Renamed Team", TeamDescription: "Our photo club"})
+
+	assert.Equal(t, "Renamed Team", m.TeamName)
+	assert.Equal(t, "renamed-team", m.TeamSlug)
+	assert.Equal(t, "Our photo club", m.TeamDescription)
+}
+
+func TestTeam_Members(t *testing.T) {
+	m := NewTeam("Member Team", "")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	guest := UserFixtures.Pointer("guest")
+	bob := UserFixtures.Pointer("bob")
+
+	assert.NoError(t, m.AddMember(guest))
+	assert.NoError(t, m.AddMember(guest))
+	assert.NoError(t, m.AddMember(bob))
+	assert.Error(t, m.AddMember(&User{}))
+	assert.True(t, m.HasMember(guest.UserUID))
+
+	if members := m.Members(); assert.Len(t, members, 2) {
+		assert.Equal(t, "bob", members[0].UserName)
+		assert.Equal(t, "guest", members[1].UserName)
+	}
+
+	assert.NoError(t, m.RemoveMember(bob))
+	assert.False(t, m.HasMember(bob.UserUID))
+	assert.Len(t, m.Members(), 1)
+}
+
+func TestTeam_Share(t *testing.T) {
+	m := NewTeam("Share Team", "")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	guest := UserFixtures.Pointer("guest")
+	album := AlbumFixtures.Pointer("christmas2030")
+	folder := AlbumFixtures.Pointer("april-1990")
+
+	assert.NoError(t, m.AddMember(guest))
+	assert.NoError(t, m.Share(album.AlbumUID, TeamPerm("comment")))
+	assert.NoError(t, m.Share(folder.AlbumUID, TeamPerm("view")))
+	assert.Error(t, m.Share("as6sg6bxpogaxxxx", TeamPerm("view")))
+	assert.Error(t, m.Share(album.AlbumUID, PermDefault))
+	assert.Len(t, m.Shares(), 2)
+
+	t.Run("UserShares", func(t *testing.T) {
+		shares := FindUserShares(guest.UserUID)
+
+		assert.True(t, shares.Contains(album.AlbumUID))
+		assert.True(t, shares.Contains(folder.AlbumUID))
+		assert.Equal(t, PermComment, shares.Perm(album.AlbumUID)&PermComment)
+		assert.Equal(t, uint(0), shares.Perm(folder.AlbumUID)&PermComment)
+		assert.False(t, FindUserShares(UserFixtures.Pointer("bob").UserUID).Contains(album.AlbumUID))
+	})
+	t.Run("Unshare", func(t *testing.T) {
+		assert.NoError(t, m.Unshare(folder.AlbumUID))
+		assert.False(t, FindUserShares(guest.UserUID).Contains(folder.AlbumUID))
+		assert.True(t, FindUserShares(guest.UserUID).Contains(album.AlbumUID))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, m.Delete())
+		assert.False(t, FindUserShares(guest.UserUID).Contains(album.AlbumUID))
+		assert.Nil(t, FindTeamUser(m.TeamUID, guest.UserUID))
+	})
+}
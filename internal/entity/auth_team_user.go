@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+)
+
+// TeamUser represents the membership of a user in a team.
+type TeamUser struct {
+	TeamUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"TeamUID" yaml:"TeamUID"`
+	UserUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"UserUID" yaml:"UserUID"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the database table name.
+func (TeamUser) TableName() string {
+	return "auth_teams_users"
+}
+
+// NewTeamUser creates a new entity model.
+func NewTeamUser(teamUid, userUid string) *TeamUser {
+	return &TeamUser{
+		TeamUID: teamUid,
+		UserUID: userUid,
+	}
+}
+
+// Create inserts a new record into the database.
+func (m *TeamUser) Create() error {
+	return Db().Create(m).Error
+}
+
+// FindTeamUser returns the matching record or nil if it was not found.
+func FindTeamUser(teamUid, userUid string) *TeamUser {
+	m := &TeamUser{}
+
+	if err := UnscopedDb().First(m, "team_uid = ? AND user_uid = ?", teamUid, userUid).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindTeamShares returns the albums and folders shared with the teams of the specified user.
+func FindTeamShares(userUid string) UserShares {
+	var grants []AlbumUser
+
+	if err := UnscopedDb().
+		Where("team_uid <> '' AND perm <> ? AND team_uid IN (SELECT team_uid FROM auth_teams_users WHERE user_uid = ?)", PermNone, userUid).
+		Find(&grants).Error; err != nil {
+		log.Errorf("team: %s (find shares)", err)
+		return UserShares{}
+	}
+
+	result := make(UserShares, len(grants))
+
+	for i, grant := range grants {
+		result[i] = UserShare{UserUID: userUid, ShareUID: grant.UID, Perm: grant.Perm}
+	}
+
+	return result
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 
 	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/webauthn"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/authn"
@@ -628,7 +629,7 @@ func (m *User) SetAuthID(id, issuer string) *User {
 	// Make sure other users do not use the same identifier.
 	if m.HasUID() && m.AuthProvider != "" {
 		if err := UnscopedDb().Model(&User{}).
-			Where("user_uid <> ? AND auth_provider = ? AND auth_id = ? AND super_admin = 0", m.UserUID, m.AuthProvider, m.AuthID).
+			Where("user_uid <> ? AND auth_provider = ? AND auth_id = ? AND super_admin = FALSE", m.UserUID, m.AuthProvider, m.AuthID).
 			Updates(map[string]interface{}{"auth_id": "", "auth_provider": authn.ProviderNone}).Error; err != nil {
 			event.AuditErr([]string{"user %s", "failed to resolve auth id conflicts", "%s"}, m.RefID, err)
 		}
@@ -673,6 +674,15 @@ func (m *User) Passcode(t authn.KeyType) *Passcode {
 	return FindPasscode(Passcode{UID: m.GetUID(), KeyType: t.String()})
 }
 
+// Passkeys returns the passkeys registered for the user.
+func (m *User) Passkeys() Passkeys {
+	if m == nil || m.UserUID == "" {
+		return Passkeys{}
+	}
+
+	return FindPasskeys(m.UserUID)
+}
+
 // Username returns the user's login name as sanitized string.
 func (m *User) Username() string {
 	return clean.Username(m.UserName)
@@ -1038,6 +1048,23 @@ func (m *User) VerifyPasscode(code string) (valid bool, passcode *Passcode, err
 	return valid, passcode, err
 }
 
+// VerifyPasskey checks if the specified assertion was signed with one of the user's passkeys.
+func (m *User) VerifyPasskey(a *webauthn.Assertion) (passkey *Passkey, err error) {
+	if m == nil {
+		err = errors.New("user is nil")
+	} else if WebAuthn == nil {
+		err = authn.ErrPasskeyNotSupported
+	} else if a == nil {
+		err = authn.ErrPasskeyRequired
+	} else if passkey = FindPasskeyByKeyID(a.CredentialID()); passkey == nil || passkey.UserUID != m.UserUID {
+		passkey, err = nil, authn.ErrPasskeyNotFound
+	} else {
+		err = passkey.Verify(*a, false)
+	}
+
+	return passkey, err
+}
+
 // ActivatePasscode activates two-factor authentication with a passcode.
 func (m *User) ActivatePasscode() (passcode *Passcode, err error) {
 	if m == nil {
@@ -1215,6 +1242,32 @@ func (m *User) HasShare(uid string) bool {
 	return m.UserShares.Contains(uid)
 }
 
+// SharePerm returns the permissions granted for the specified shared uid.
+func (m *User) SharePerm(uid string) uint {
+	if !m.HasShare(uid) {
+		return PermDefault
+	}
+
+	return m.UserShares.Perm(uid)
+}
+
+// ShareLink returns the valid share link for the specified uid that grants the permission, or nil if none was found.
+func (m *User) ShareLink(uid string, perm uint) *Link {
+	if !m.HasShare(uid) {
+		return nil
+	}
+
+	for _, share := range m.UserShares {
+		if share.ShareUID != uid || share.LinkUID == "" || share.Perm&perm == 0 {
+			continue
+		} else if link := FindLink(share.LinkUID); link != nil && !link.Expired() {
+			return link
+		}
+	}
+
+	return nil
+}
+
 // SharedUIDs returns shared entity UIDs.
 func (m *User) SharedUIDs() UIDs {
 	if m.IsRegistered() && m.NoShares() {
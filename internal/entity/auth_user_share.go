@@ -61,6 +61,17 @@ func (m UserShares) Contains(uid string) bool {
 	return false
 }
 
+// Perm returns the combined permissions for the specified shared uid.
+func (m UserShares) Perm(uid string) (perm uint) {
+	for _, share := range m {
+		if share.ShareUID == uid {
+			perm |= share.Perm
+		}
+	}
+
+	return perm
+}
+
 // UserShare represents content shared with a user.
 type UserShare struct {
 	UserUID   string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"-" yaml:"UserUID"`
@@ -124,7 +135,8 @@ func FindUserShares(userUid string) UserShares {
 		return nil
 	}
 
-	return found
+	// Add albums and folders shared with the user's teams.
+	return append(found, FindTeamShares(userUid)...)
 }
 
 // HasID tests if the entity has a valid uid.
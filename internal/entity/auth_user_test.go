@@ -834,6 +834,31 @@ func TestUser_AclRole(t *testing.T) {
 		assert.False(t, p.IsAdmin())
 		assert.False(t, p.IsVisitor())
 	})
+	t.Run("CustomRole", func(t *testing.T) {
+		rules, events := acl.Rules, acl.Events
+
+		defer func() {
+			acl.Rules, acl.Events = rules, events
+			delete(acl.UserRoles, "editor")
+		}()
+
+		p := User{ID: 8, UserUID: "u000000000000008", UserName: "Hanna", DisplayName: ""}
+		p.SetRole("editor")
+		assert.Equal(t, acl.RoleNone, p.AclRole())
+
+		roles := acl.RoleConfigs{"editor": {Extends: "guest", Grants: map[string][]string{"photos": {"access_library", "search", "view", "update"}}}}
+
+		if err := roles.Apply(); err != nil {
+			t.Fatal(err)
+		}
+
+		p.SetRole("editor")
+		assert.Equal(t, acl.Role("editor"), p.AclRole())
+		assert.False(t, p.IsAdmin())
+		assert.False(t, p.IsVisitor())
+		assert.False(t, p.HasSharedAccessOnly(acl.ResourcePhotos))
+		assert.True(t, p.HasSharedAccessOnly(acl.ResourceAlbums))
+	})
 }
 
 func TestUser_Validate(t *testing.T) {
@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	CommentUID = byte('k')
+)
+
+type Comments []Comment
+
+// Comment represents a text comment on a photo or album.
+type Comment struct {
+	CommentUID  string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	PhotoUID    string     `gorm:"type:VARBINARY(42);index;default:'';" json:"PhotoUID,omitempty" yaml:"PhotoUID,omitempty"`
+	AlbumUID    string     `gorm:"type:VARBINARY(42);index;default:'';" json:"AlbumUID,omitempty" yaml:"AlbumUID,omitempty"`
+	CommentText string     `gorm:"type:VARCHAR(2048);" json:"Text" yaml:"Text"`
+	AuthorName  string     `gorm:"size:160;" json:"AuthorName" yaml:"AuthorName,omitempty"`
+	CreatedBy   string     `gorm:"type:VARBINARY(42);index;default:'';" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
+	CreatedAt   time.Time  `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+	UpdatedAt   time.Time  `json:"UpdatedAt" yaml:"UpdatedAt,omitempty"`
+	DeletedAt   *time.Time `sql:"index" json:"-" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Comment) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUnique(m.CommentUID, CommentUID) {
+		return nil
+	}
+
+	return scope.SetColumn("CommentUID", rnd.GenerateUID(CommentUID))
+}
+
+// NewPhotoComment returns a new comment on the specified photo.
+func NewPhotoComment(photoUid, text, authorName, createdBy string) *Comment {
+	return NewComment(photoUid, "", text, authorName, createdBy)
+}
+
+// NewAlbumComment returns a new comment on the specified album.
+func NewAlbumComment(albumUid, text, authorName, createdBy string) *Comment {
+	return NewComment("", albumUid, text, authorName, createdBy)
+}
+
+// NewComment returns a new comment entity.
+func NewComment(photoUid, albumUid, text, authorName, createdBy string) *Comment {
+	return &Comment{
+		CommentUID:  rnd.GenerateUID(CommentUID),
+		PhotoUID:    photoUid,
+		AlbumUID:    albumUid,
+		CommentText: txt.Clip(text, txt.ClipText),
+		AuthorName:  clean.Name(authorName),
+		CreatedBy:   createdBy,
+	}
+}
+
+// FindComment returns the comment with the specified uid or nil if it was not found.
+func FindComment(uid string) *Comment {
+	if !rnd.IsUID(uid, CommentUID) {
+		return nil
+	}
+
+	result := &Comment{}
+
+	if err := Db().Where("comment_uid = ?", uid).First(result).Error; err != nil {
+		return nil
+	}
+
+	return result
+}
+
+// Create inserts a new comment into the database.
+func (m *Comment) Create() error {
+	if m.Invalid() {
+		return fmt.Errorf("invalid comment")
+	}
+
+	return Db().Create(m).Error
+}
+
+// Delete marks the comment as deleted.
+func (m *Comment) Delete() error {
+	if m.CommentUID == "" {
+		return fmt.Errorf("empty comment uid")
+	}
+
+	return Db().Delete(m).Error
+}
+
+// Invalid checks if the comment text or the commented entity are missing.
+func (m *Comment) Invalid() bool {
+	return m.CommentText == "" || m.PhotoUID == "" && m.AlbumUID == ""
+}
+
+// ShareUID returns the uid of the commented album or photo.
+func (m *Comment) ShareUID() string {
+	if m.AlbumUID != "" {
+		return m.AlbumUID
+	}
+
+	return m.PhotoUID
+}
+
+// String returns the comment uid for logging.
+func (m *Comment) String() string {
+	if m == nil {
+		return "Comment<nil>"
+	}
+
+	return clean.Log(m.CommentUID)
+}
@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+type CommentMap map[string]Comment
+
+// Get returns the fixture with the specified name.
+func (m CommentMap) Get(name string) Comment {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Comment{}
+}
+
+// Pointer returns a pointer to the fixture with the specified name.
+func (m CommentMap) Pointer(name string) *Comment {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Comment{}
+}
+
+var CommentFixtures = CommentMap{
+	"PhotoAlice": {
+		CommentUID:  "ks6sg6bxpogaab01",
+		PhotoUID:    PhotoFixtures.Get("Photo01").PhotoUID,
+		CommentText: "What a beautiful place!",
+		AuthorName:  "Alice",
+		CreatedBy:   UserFixtures.Get("alice").UserUID,
+		CreatedAt:   time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:   time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"AlbumAlice": {
+		CommentUID:  "ks6sg6bxpogaab02",
+		AlbumUID:    AlbumFixtures.Get("holiday-2030").AlbumUID,
+		CommentText: "Thanks for sharing!",
+		AuthorName:  "Alice",
+		CreatedBy:   UserFixtures.Get("alice").UserUID,
+		CreatedAt:   time.Date(2020, 3, 7, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:   time.Date(2020, 3, 7, 2, 6, 51, 0, time.UTC),
+	},
+	"AlbumBob": {
+		CommentUID:  "ks6sg6bxpogaab03",
+		AlbumUID:    AlbumFixtures.Get("holiday-2030").AlbumUID,
+		CommentText: "See you next year.",
+		AuthorName:  "Bob",
+		CreatedBy:   UserFixtures.Get("bob").UserUID,
+		CreatedAt:   time.Date(2020, 3, 8, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:   time.Date(2020, 3, 8, 2, 6, 51, 0, time.UTC),
+	},
+}
+
+// CreateCommentFixtures inserts known entities into the database for testing.
+func CreateCommentFixtures() {
+	for _, entity := range CommentFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestNewComment(t *testing.T) {
+	t.Run("Photo", func(t *testing.T) {
+		m := NewPhotoComment("ps6sg6be2lvl0yh7", "Hello", "  Jane  Doe ", "uqxetse3cy5eo9z2")
+		assert.True(t, rnd.IsUID(m.CommentUID, CommentUID))
+		assert.Equal(t, "ps6sg6be2lvl0yh7", m.PhotoUID)
+		assert.Equal(t, "", m.AlbumUID)
+		assert.Equal(t, "Hello", m.CommentText)
+		assert.Equal(t, "Jane Doe", m.AuthorName)
+		assert.Equal(t, "ps6sg6be2lvl0yh7", m.ShareUID())
+		assert.False(t, m.Invalid())
+	})
+	t.Run("Album", func(t *testing.T) {
+		m := NewAlbumComment("as6sg6bxpogaaba8", "Hello", "", "")
+		assert.Equal(t, "as6sg6bxpogaaba8", m.AlbumUID)
+		assert.Equal(t, "as6sg6bxpogaaba8", m.ShareUID())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		m := NewComment("", "", "Hello", "", "")
+		assert.True(t, m.Invalid())
+		assert.Error(t, m.Create())
+	})
+}
+
+func TestComment_Create(t *testing.T) {
+	m := NewAlbumComment("as6sg6bxpogaaba9", "Nice pictures!", "Bob", "uqxc08w3d0ej2283")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindComment(m.CommentUID)
+
+	if found == nil {
+		t.Fatal("comment not found")
+	}
+
+	assert.Equal(t, "Nice pictures!", found.CommentText)
+
+	if err := found.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindComment(m.CommentUID))
+}
+
+func TestFindComment(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		m := FindComment(CommentFixtures.Get("PhotoAlice").CommentUID)
+
+		if m == nil {
+			t.Fatal("comment not found")
+		}
+
+		assert.Equal(t, "What a beautiful place!", m.CommentText)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Nil(t, FindComment("as6sg6bxpogaaba9"))
+	})
+}
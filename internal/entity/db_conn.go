@@ -13,6 +13,7 @@ import (
 // Supported test databases.
 const (
 	MySQL           = "mysql"
+	Postgres        = "postgres"
 	SQLite3         = "sqlite3"
 	SQLiteTestDB    = ".test.db"
 	SQLiteMemoryDSN = ":memory:?cache=shared"
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+)
+
+// postgresTypes maps the MySQL column types used in struct tags to their PostgreSQL equivalents.
+// Since text columns are compared case-insensitively with MariaDB and SQLite, e.g. when
+// using LIKE, they are created with the "citext" type, while binary strings remain case-sensitive.
+var postgresTypes = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(?i)^VARBINARY\b`), "VARCHAR"},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)?BLOB\b`), "BYTEA"},
+	{regexp.MustCompile(`(?i)^((TINY|MEDIUM|LONG)?TEXT|VARCHAR(\(\d+\))?)`), "CITEXT"},
+	{regexp.MustCompile(`(?i)^DATETIME\b`), "TIMESTAMP"},
+	{regexp.MustCompile(`(?i)^DOUBLE\b`), "DOUBLE PRECISION"},
+}
+
+// postgresBase is the type of the default PostgreSQL dialect.
+var postgresBase reflect.Type
+
+// postgresDialect extends the default PostgreSQL dialect so that the column types
+// used in struct tags, e.g. VARBINARY, can be used with all supported databases.
+type postgresDialect struct {
+	gorm.Dialect
+}
+
+func init() {
+	if base, ok := gorm.GetDialect(Postgres); ok {
+		postgresBase = reflect.TypeOf(base).Elem()
+		gorm.RegisterDialect(Postgres, &postgresDialect{})
+	}
+}
+
+// SetDB sets the database connection. Since gorm creates a new
+// dialect for each connection, the default dialect is created here.
+func (d *postgresDialect) SetDB(db gorm.SQLCommon) {
+	if d.Dialect == nil {
+		d.Dialect = reflect.New(postgresBase).Interface().(gorm.Dialect)
+	}
+
+	d.Dialect.SetDB(db)
+}
+
+// DataTypeOf returns the PostgreSQL column type of a struct field.
+func (d *postgresDialect) DataTypeOf(field *gorm.StructField) string {
+	sqlType := d.Dialect.DataTypeOf(field)
+
+	for _, t := range postgresTypes {
+		if t.re.MatchString(sqlType) {
+			return t.re.ReplaceAllString(sqlType, t.repl)
+		}
+	}
+
+	return sqlType
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresDialect_DataTypeOf(t *testing.T) {
+	base, ok := gorm.GetDialect(Postgres)
+
+	if !ok {
+		t.Fatal("postgres dialect not registered")
+	}
+
+	assert.IsType(t, &postgresDialect{}, base)
+
+	d := &postgresDialect{}
+	d.SetDB(nil)
+
+	assert.Equal(t, Postgres, d.GetName())
+
+	fields := Db().NewScope(&Photo{}).GetModelStruct().StructFields
+	types := make(map[string]string, len(fields))
+
+	for _, f := range fields {
+		if f.IsNormal {
+			types[f.DBName] = d.DataTypeOf(f)
+		}
+	}
+
+	assert.Equal(t, "VARCHAR(42)", types["photo_uid"])
+	assert.Equal(t, "TIMESTAMP", types["taken_at"])
+	assert.Equal(t, "DOUBLE PRECISION", types["photo_lat"])
+	assert.Equal(t, "boolean", types["photo_favorite"])
+	assert.Equal(t, "CITEXT", types["photo_title"])
+}
@@ -32,5 +32,7 @@ func ResetTestFixtures() {
 
 	CreateTestFixtures()
 
+	Entities.UpdateSequences(Db())
+
 	log.Debugf("migrate: recreated test fixtures [%s]", time.Since(start))
 }
@@ -41,6 +41,8 @@ func InitDb(opt migrate.Options) {
 
 	CreateDefaultFixtures()
 
+	Entities.UpdateSequences(Db())
+
 	ready()
 
 	log.Debugf("migrate: completed in %s", time.Since(start))
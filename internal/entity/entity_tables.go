@@ -20,6 +20,7 @@ var Entities = Tables{
 	Error{}.TableName():             &Error{},
 	Password{}.TableName():          &Password{},
 	Passcode{}.TableName():          &Passcode{},
+	Passkey{}.TableName():           &Passkey{},
 	User{}.TableName():              &User{},
 	UserDetails{}.TableName():       &UserDetails{},
 	UserSettings{}.TableName():      &UserSettings{},
@@ -47,12 +48,16 @@ var Entities = Tables{
 	PhotoLabel{}.TableName():        &PhotoLabel{},
 	Keyword{}.TableName():           &Keyword{},
 	PhotoKeyword{}.TableName():      &PhotoKeyword{},
+	PhotoEmbedding{}.TableName():    &PhotoEmbedding{},
 	Link{}.TableName():              &Link{},
 	Subject{}.TableName():           &Subject{},
 	Face{}.TableName():              &Face{},
 	Marker{}.TableName():            &Marker{},
 	Reaction{}.TableName():          &Reaction{},
+	Comment{}.TableName():           &Comment{},
 	UserShare{}.TableName():         &UserShare{},
+	Team{}.TableName():              &Team{},
+	TeamUser{}.TableName():          &TeamUser{},
 }
 
 // WaitForMigration waits for the database migration to be successful and returns an error otherwise.
@@ -93,7 +98,7 @@ func (list Tables) Truncate(db *gorm.DB) {
 	}()
 
 	for name = range list {
-		if err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE 1", name)).Error; err == nil {
+		if err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE 1 = 1", name)).Error; err == nil {
 			// log.Debugf("entity: removed all data from %s", name)
 			break
 		} else if err.Error() != "record not found" {
@@ -102,6 +107,20 @@ func (list Tables) Truncate(db *gorm.DB) {
 	}
 }
 
+// UpdateSequences sets the next auto-increment values based on the existing IDs. This is required
+// with PostgreSQL, as sequences are not updated when rows are inserted with explicit IDs, e.g. fixtures.
+func (list Tables) UpdateSequences(db *gorm.DB) {
+	if db.Dialect().GetName() != Postgres {
+		return
+	}
+
+	for name := range list {
+		if err := db.Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %[1]s", name)).Error; err != nil {
+			log.Tracef("migrate: %s in %s (update sequence)", err, clean.Log(name))
+		}
+	}
+}
+
 // Migrate migrates all database tables of registered entities.
 func (list Tables) Migrate(db *gorm.DB, opt migrate.Options) {
 	var name string
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/pet"
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
@@ -57,6 +58,28 @@ func NewFace(subjUID, faceSrc string, embeddings face.Embeddings) *Face {
 	return result
 }
 
+// NewPetFace returns a new face cluster for recognizing a pet.
+func NewPetFace(subjUID, faceSrc string, embeddings face.Embeddings) *Face {
+	result := NewFace(subjUID, faceSrc, embeddings)
+	result.FaceKind = int(face.PetFace)
+
+	return result
+}
+
+// Pet tests if the face cluster belongs to a pet.
+func (m *Face) Pet() bool {
+	return m.FaceKind == int(face.PetFace)
+}
+
+// MarkerType returns the type of markers that can be matched with this face.
+func (m *Face) MarkerType() string {
+	if m.Pet() {
+		return MarkerPet
+	}
+
+	return MarkerFace
+}
+
 // MatchId returns a compound id for matching.
 func (m *Face) MatchId(f Face) string {
 	if m.ID == "" || f.ID == "" {
@@ -147,6 +170,12 @@ func (m *Face) Match(embeddings face.Embeddings) (match bool, dist float64) {
 		return false, dist
 	}
 
+	matchDist := face.MatchDist
+
+	if m.Pet() {
+		matchDist = pet.MatchDist
+	}
+
 	// Calculate the smallest distance to embeddings.
 	for _, e := range embeddings {
 		if d := e.Dist(faceEmbedding); d < dist || dist < 0 {
@@ -159,7 +188,7 @@ func (m *Face) Match(embeddings face.Embeddings) (match bool, dist float64) {
 	case dist < 0:
 		// Should never happen.
 		return false, dist
-	case dist > (m.SampleRadius + face.MatchDist):
+	case dist > (m.SampleRadius + matchDist):
 		// Too far.
 		return false, dist
 	case m.CollisionRadius > 0.1 && dist > m.CollisionRadius:
@@ -171,6 +200,19 @@ func (m *Face) Match(embeddings face.Embeddings) (match bool, dist float64) {
 	return true, dist
 }
 
+// MatchCollision tests if another face cluster with a different subject matches this face.
+func (m *Face) MatchCollision(f Face) (collision bool, dist float64) {
+	if m.ID == "" || f.ID == "" || m.ID == f.ID || m.Pet() != f.Pet() {
+		return false, -1
+	}
+
+	if matched, dist := m.Match(face.Embeddings{f.Embedding()}); !matched || m.SubjUID == f.SubjUID {
+		return false, dist
+	} else {
+		return true, dist
+	}
+}
+
 // ResolveCollision resolves a collision with a different subject's face.
 func (m *Face) ResolveCollision(embeddings face.Embeddings) (resolved bool, err error) {
 	if m.SubjUID == "" {
@@ -228,7 +270,7 @@ func (m *Face) ReviseMatches() (revised Markers, err error) {
 
 	var matches Markers
 
-	if err := Db().Where("face_id = ?", m.ID).Where("marker_type = ?", MarkerFace).
+	if err := Db().Where("face_id = ?", m.ID).Where("marker_type = ?", m.MarkerType()).
 		Find(&matches).Error; err != nil {
 		log.Debugf("faces: found no matching markers for conflict resolution (%s)", err)
 		return revised, err
@@ -253,7 +295,7 @@ func (m *Face) MatchMarkers(faceIds []string) error {
 	var markers Markers
 
 	err := Db().
-		Where("marker_invalid = 0 AND marker_type = ? AND face_id IN (?)", MarkerFace, faceIds).
+		Where("marker_invalid = FALSE AND marker_type = ? AND face_id IN (?)", m.MarkerType(), faceIds).
 		Find(&markers).Error
 
 	if err != nil {
@@ -288,7 +330,7 @@ func (m *Face) SetSubjectUID(subjUid string) (err error) {
 		Where("face_id = ?", m.ID).
 		Where("subj_src = ?", SrcAuto).
 		Where("subj_uid <> ?", m.SubjUID).
-		Where("marker_invalid = 0").
+		Where("marker_invalid = FALSE").
 		UpdateColumns(Map{"subj_uid": m.SubjUID, "marker_review": false}).Error; err != nil {
 		return err
 	}
@@ -439,7 +481,7 @@ func ValidFaceCount(fileUid string) (c int) {
 
 	if err := Db().Model(Marker{}).
 		Where("file_uid = ? AND marker_type = ?", fileUid, MarkerFace).
-		Where("marker_invalid = 0").
+		Where("marker_invalid = FALSE").
 		Count(&c).Error; err != nil {
 		log.Errorf("file: %s (count faces)", err)
 		return 0
@@ -141,6 +141,72 @@ func TestNewFace(t *testing.T) {
 	})
 }
 
+func TestNewPetFace(t *testing.T) {
+	e := face.RandomEmbedding()
+
+	r := NewPetFace("", SrcAuto, face.Embeddings{e})
+
+	assert.True(t, r.Pet())
+	assert.True(t, r.SkipMatching())
+	assert.Equal(t, MarkerPet, r.MarkerType())
+	assert.Equal(t, int(face.PetFace), r.FaceKind)
+
+	if ok, dist := r.Match(face.Embeddings{e}); assert.True(t, ok) {
+		assert.Equal(t, 0.0, dist)
+	}
+
+	regular := NewFace("", SrcAuto, face.Embeddings{e})
+
+	assert.False(t, regular.Pet())
+	assert.Equal(t, MarkerFace, regular.MarkerType())
+
+	t.Run("MarkerMismatch", func(t *testing.T) {
+		m := MarkerFixtures.Get("1000003-4")
+
+		updated, err := m.SetFace(r, 0)
+
+		assert.Error(t, err)
+		assert.False(t, updated)
+	})
+}
+
+func TestFace_MatchCollision(t *testing.T) {
+	e := face.RandomEmbedding()
+
+	f1 := NewFace("js6sg6b1qekk9jx8", SrcAuto, face.Embeddings{e})
+	similar := make(face.Embedding, len(e))
+
+	for i := range e {
+		similar[i] = face.RandomFloat64(e[i], 0.0001)
+	}
+
+	f2 := NewFace("jqu0xs11qekk9jx8", SrcAuto, face.Embeddings{similar})
+	f3 := NewFace("js6sg6b1qekk9jx8", SrcAuto, face.Embeddings{e, face.RandomEmbedding(), face.RandomEmbedding()})
+
+	t.Run("DifferentSubjects", func(t *testing.T) {
+		collision, dist := f1.MatchCollision(*f2)
+
+		assert.True(t, collision)
+		assert.GreaterOrEqual(t, dist, 0.0)
+	})
+	t.Run("SameSubject", func(t *testing.T) {
+		collision, _ := f1.MatchCollision(*f3)
+
+		assert.False(t, collision)
+	})
+	t.Run("SameFace", func(t *testing.T) {
+		collision, dist := f1.MatchCollision(*f1)
+
+		assert.False(t, collision)
+		assert.Equal(t, -1.0, dist)
+	})
+	t.Run("Pet", func(t *testing.T) {
+		collision, _ := f1.MatchCollision(*NewPetFace("", SrcAuto, face.Embeddings{e}))
+
+		assert.False(t, collision)
+	})
+}
+
 func TestFace_MatchId(t *testing.T) {
 	t.Run("A123-B456", func(t *testing.T) {
 		f1 := Face{ID: "A123"}
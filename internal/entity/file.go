@@ -15,7 +15,10 @@ import (
 	"github.com/ulule/deepcopier"
 
 	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/ai/pet"
 	"github.com/photoprism/photoprism/internal/config/customize"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/media"
@@ -78,6 +81,7 @@ type File struct {
 	FileColors         string        `gorm:"type:VARBINARY(18);" json:"Colors" yaml:"Colors,omitempty"`
 	FileLuminance      string        `gorm:"type:VARBINARY(18);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff           int           `json:"Diff" yaml:"Diff,omitempty"`
+	FilePHash          string        `gorm:"column:file_phash;type:VARBINARY(16);index;default:'';" json:"PHash" yaml:"PHash,omitempty"`
 	FileChroma         int16         `json:"Chroma" yaml:"Chroma,omitempty"`
 	FileSoftware       string        `gorm:"type:VARCHAR(64)" json:"Software" yaml:"Software,omitempty"`
 	FileError          string        `gorm:"type:VARBINARY(512);index;" json:"Error" yaml:"Error,omitempty"`
@@ -149,6 +153,18 @@ func (m File) RegenerateIndex() {
 		Log("files", "regenerate time_index",
 			Db().Exec("UPDATE files SET time_index = CASE WHEN media_id IS NOT NULL AND photo_taken_at IS NOT NULL THEN ((100000000000000 - strftime('%Y%m%d%H%M%S', photo_taken_at)) || '-' || media_id) ELSE NULL END WHERE ?",
 				updateWhere).Error)
+	case Postgres:
+		Log("files", "regenerate photo_taken_at",
+			Db().Exec("UPDATE files SET photo_taken_at = (SELECT p.taken_at_local FROM ? p WHERE p.id = photo_id) WHERE ?",
+				gorm.Expr(photosTable), updateWhere).Error)
+
+		Log("files", "regenerate media_id",
+			Db().Exec("UPDATE files SET media_id = CASE WHEN file_missing = FALSE AND deleted_at IS NULL THEN ((10000000000 - photo_id) || '-' || (1 + CAST(file_sidecar AS INT) - CAST(file_primary AS INT)) || '-' || file_uid) ELSE NULL END WHERE ?",
+				updateWhere).Error)
+
+		Log("files", "regenerate time_index",
+			Db().Exec("UPDATE files SET time_index = CASE WHEN media_id IS NOT NULL AND photo_taken_at IS NOT NULL THEN ((100000000000000 - CAST(TO_CHAR(photo_taken_at, 'YYYYMMDDHH24MISS') AS BIGINT)) || '-' || media_id) ELSE NULL END WHERE ?",
+				updateWhere).Error)
 	default:
 		log.Warnf("sql: unsupported dialect %s", DbDialect())
 	}
@@ -169,7 +185,7 @@ func FirstFileByHash(fileHash string) (File, error) {
 func PrimaryFile(photoUid string) (*File, error) {
 	file := File{}
 
-	res := Db().Unscoped().First(&file, "file_primary = 1 AND photo_uid = ?", photoUid)
+	res := Db().Unscoped().First(&file, "file_primary = TRUE AND photo_uid = ?", photoUid)
 
 	return &file, res.Error
 }
@@ -366,14 +382,14 @@ func (m *File) Purge() error {
 	m.FileMissing = true
 	m.FilePrimary = false
 	m.DeletedAt = &deletedAt
-	return UnscopedDb().Exec("UPDATE files SET file_missing = 1, file_primary = 0, deleted_at = ? WHERE id = ?", &deletedAt, m.ID).Error
+	return UnscopedDb().Exec("UPDATE files SET file_missing = TRUE, file_primary = FALSE, deleted_at = ? WHERE id = ?", &deletedAt, m.ID).Error
 }
 
 // Found restores a previously purged file.
 func (m *File) Found() error {
 	m.FileMissing = false
 	m.DeletedAt = nil
-	return UnscopedDb().Exec("UPDATE files SET file_missing = 0, deleted_at = NULL WHERE id = ?", m.ID).Error
+	return UnscopedDb().Exec("UPDATE files SET file_missing = FALSE, deleted_at = NULL WHERE id = ?", m.ID).Error
 }
 
 // AllFilesMissing returns true, if all files for the photo of this file are missing.
@@ -381,7 +397,7 @@ func (m *File) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND file_missing = 0", m.PhotoID).
+		Where("photo_id = ? AND file_missing = FALSE", m.PhotoID).
 		Count(&count).Error; err != nil {
 		log.Errorf("file: %s", err.Error())
 	}
@@ -459,7 +475,7 @@ func (m *File) UpdateVideoInfos() error {
 
 	if err := deepcopier.Copy(&dimensions).From(m); err != nil {
 		return err
-	} else if err = Db().Model(File{}).Where("photo_id = ? AND file_video = 1 AND file_width <= 0", m.PhotoID).Updates(dimensions).Error; err != nil {
+	} else if err = Db().Model(File{}).Where("photo_id = ? AND file_video = TRUE AND file_width <= 0", m.PhotoID).Updates(dimensions).Error; err != nil {
 		return err
 	}
 
@@ -469,7 +485,7 @@ func (m *File) UpdateVideoInfos() error {
 
 	if err := deepcopier.Copy(&appearance).From(m); err != nil {
 		return err
-	} else if err = Db().Model(File{}).Where("photo_id = ? AND file_video = 1", m.PhotoID).Updates(appearance).Error; err != nil {
+	} else if err = Db().Model(File{}).Where("photo_id = ? AND file_video = TRUE", m.PhotoID).Updates(appearance).Error; err != nil {
 		return err
 	}
 
@@ -758,9 +774,125 @@ func (m *File) AddFace(f face.Face, subjUid string) {
 		return
 	}
 
+	markers := m.Markers()
+
 	// Append marker if it doesn't conflict with existing marker.
-	if markers := m.Markers(); !markers.Contains(*marker) {
+	if existing := markers.Overlapping(*marker); existing == nil {
 		markers.AppendWithEmbedding(*marker)
+	} else if existing.MarkerSrc == SrcXmp && existing.Embeddings().Empty() {
+		// Add embeddings to face markers created from image regions so that they can be clustered.
+		existing.SetEmbeddings(f.Embeddings)
+		existing.LandmarksJSON = marker.LandmarksJSON
+		existing.Score = marker.Score
+
+		if existing.Unsaved() {
+			return
+		} else if err := existing.Updates(Map{"EmbeddingsJSON": existing.EmbeddingsJSON, "LandmarksJSON": existing.LandmarksJSON, "Score": existing.Score}); err != nil {
+			log.Errorf("faces: %s (update marker embeddings)", err)
+		}
+	}
+}
+
+// AddSubjectMarker adds a face marker for the named subject, e.g. from the image regions
+// in XMP metadata. If a face marker already exists at this position, its name is updated instead.
+func (m *File) AddSubjectMarker(area crop.Area, name, src string) {
+	if name = clean.Name(name); name == "" {
+		return
+	}
+
+	// Create new marker from area.
+	marker := NewMarker(*m, area, "", src, MarkerFace, int(area.W*float32(m.FileWidth)), 100)
+
+	// Failed creating new marker?
+	if marker == nil {
+		return
+	}
+
+	markers := m.Markers()
+	existing := markers.Overlapping(*marker)
+
+	// Update the name of an existing marker at the same position, if any.
+	switch {
+	case existing == nil:
+		// Add new marker.
+		marker.MarkerName = name
+		marker.SubjSrc = src
+		marker.Subject()
+
+		markers.Append(*marker)
+	case existing.MarkerType != MarkerFace || existing.MarkerInvalid:
+		// Ignore regions that overlap markers which are no valid faces.
+	case existing.Unsaved():
+		if SrcPriority[src] >= SrcPriority[existing.SubjSrc] {
+			existing.MarkerName = name
+			existing.SubjSrc = src
+			existing.SubjUID = ""
+			existing.Subject()
+		}
+	default:
+		if changed, err := existing.SetName(name, src); err != nil {
+			log.Errorf("faces: %s (update marker name)", err)
+		} else if !changed {
+			// Name did not change.
+		} else if err = existing.Save(); err != nil {
+			log.Errorf("faces: %s (update marker)", err)
+		}
+	}
+}
+
+// AddObjects adds object markers to the file.
+func (m *File) AddObjects(objects object.Objects) {
+	objects.Sort()
+
+	markers := m.Markers()
+
+	for _, o := range objects {
+		// Create new marker from object.
+		marker := NewObjectMarker(o, *m)
+
+		// Failed creating new marker?
+		if marker == nil {
+			continue
+		}
+
+		// Append marker if it doesn't conflict with existing marker.
+		if existing := markers.Overlapping(*marker); existing == nil {
+			markers.Append(*marker)
+		}
+	}
+}
+
+// AddPets adds pet markers to the file.
+func (m *File) AddPets(pets pet.Pets) {
+	markers := m.Markers()
+
+	for _, p := range pets {
+		// Create new marker from pet.
+		marker := NewPetMarker(p, *m)
+
+		// Failed creating new marker?
+		if marker == nil {
+			continue
+		}
+
+		// Append marker if it doesn't conflict with existing marker.
+		if existing := markers.Overlapping(*marker); existing == nil {
+			markers.Append(*marker)
+		}
+	}
+}
+
+// FocusArea returns a square crop area centered on the main object, if any.
+func (m *File) FocusArea() crop.Area {
+	return m.Markers().Focus(m.FileAspectRatio)
+}
+
+// FocusThumb returns the thumbnail name of the main object area, if any.
+func (m *File) FocusThumb() string {
+	if area := m.FocusArea(); area.Empty() {
+		return ""
+	} else {
+		return area.Thumb(m.FileHash)
 	}
 }
 
@@ -42,6 +42,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Colors         string        `json:",omitempty"`
 		Luminance      string        `json:",omitempty"`
 		Diff           int           `json:",omitempty"`
+		PHash          string        `json:",omitempty"`
 		Chroma         int16         `json:",omitempty"`
 		HDR            bool          `json:",omitempty"`
 		Watermark      bool          `json:",omitempty"`
@@ -54,6 +55,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		UpdatedIn      int64         `json:",omitempty"`
 		DeletedAt      *time.Time    `json:",omitempty"`
 		Markers        *Markers      `json:",omitempty"`
+		Focus          string        `json:",omitempty"`
 	}{
 		UID:            m.FileUID,
 		PhotoUID:       m.PhotoUID,
@@ -89,6 +91,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Colors:         m.FileColors,
 		Luminance:      m.FileLuminance,
 		Diff:           m.FileDiff,
+		PHash:          m.FilePHash,
 		Chroma:         m.FileChroma,
 		HDR:            m.FileHDR,
 		Watermark:      m.FileWatermark,
@@ -101,5 +104,6 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		UpdatedIn:      m.UpdatedIn,
 		DeletedAt:      m.DeletedAt,
 		Markers:        m.Markers(),
+		Focus:          m.FocusThumb(),
 	})
 }
@@ -1,13 +1,17 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/ai/pet"
 	"github.com/photoprism/photoprism/internal/config/customize"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/media/colors"
@@ -499,6 +503,103 @@ func TestFile_Undelete(t *testing.T) {
 	})
 }
 
+func TestFile_AddSubjectMarker(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		file := &File{FileUID: "fs6sg6bp4sjk3kx1", FileHash: "446b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileName: "RegionsTest", PhotoID: 1000003, FilePrimary: true}
+
+		file.AddSubjectMarker(crop.NewArea("face", 0.4, 0.25, 0.2, 0.3), "Ada Region", SrcXmp)
+
+		markers := *file.Markers()
+
+		assert.Len(t, markers, 1)
+		assert.Equal(t, "Ada Region", markers[0].MarkerName)
+		assert.Equal(t, SrcXmp, markers[0].MarkerSrc)
+		assert.Equal(t, SrcXmp, markers[0].SubjSrc)
+		assert.Equal(t, MarkerFace, markers[0].MarkerType)
+		assert.NotEmpty(t, markers[0].SubjUID)
+
+		// Overlapping regions must not create additional markers.
+		file.AddSubjectMarker(crop.NewArea("face", 0.41, 0.26, 0.2, 0.3), "Bob Region", SrcXmp)
+
+		markers = *file.Markers()
+
+		assert.Len(t, markers, 1)
+		assert.Equal(t, "Bob Region", markers[0].MarkerName)
+
+		// Names with a lower priority must not replace existing names.
+		file.AddSubjectMarker(crop.NewArea("face", 0.41, 0.26, 0.2, 0.3), "Ada Region", SrcImage)
+
+		markers = *file.Markers()
+
+		assert.Len(t, markers, 1)
+		assert.Equal(t, "Bob Region", markers[0].MarkerName)
+	})
+	t.Run("EmptyName", func(t *testing.T) {
+		file := &File{FileUID: "fs6sg6bp4sjk3kx2", FileHash: "546b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileName: "RegionsTest", PhotoID: 1000003, FilePrimary: true}
+
+		file.AddSubjectMarker(crop.NewArea("face", 0.4, 0.25, 0.2, 0.3), " ", SrcXmp)
+
+		assert.Len(t, *file.Markers(), 0)
+	})
+}
+
+func TestFile_AddPets(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		file := &File{FileUID: "fs6sg6bp4sjk3pe1", FileHash: "646b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileHeight: 480, FileAspectRatio: 1.5, FileName: "PetsTest", PhotoID: 1000003, FilePrimary: true}
+
+		file.AddObjects(object.Objects{{Name: "dog", Score: 90, Area: crop.NewArea("dog", 0.1, 0.2, 0.3, 0.4), Size: 216}})
+
+		pets := pet.Pets{
+			{Name: "dog", Score: 90, Area: crop.NewArea("dog", 0.1, 0.2, 0.3, 0.4), Size: 216, Embedding: face.RandomEmbedding()},
+			{Name: "dog", Score: 80, Area: crop.NewArea("dog", 0.11, 0.21, 0.3, 0.4), Size: 216, Embedding: face.RandomEmbedding()},
+			{Name: "cat", Score: 70, Area: crop.NewArea("cat", 0.6, 0.2, 0.2, 0.3), Size: 144, Embedding: face.RandomEmbedding()},
+		}
+
+		file.AddPets(pets)
+
+		markers := file.Markers()
+
+		// The second dog overlaps the first and must be skipped, while the object marker is kept.
+		assert.Equal(t, 3, len(*markers))
+		assert.Equal(t, 1, len(markers.Objects()))
+		assert.Equal(t, 2, len(markers.Pets()))
+		assert.Equal(t, 0, markers.ValidFaceCount())
+	})
+}
+
+func TestFile_AddObjects(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		file := &File{FileUID: "fs6sg6bp4sjk3ob1", FileHash: "446b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileHeight: 480, FileAspectRatio: 1.5, FileName: "ObjectsTest", PhotoID: 1000003, FilePrimary: true}
+
+		objects := object.Objects{
+			{Name: "car", Score: 70, Area: crop.NewArea("car", 0.1, 0.2, 0.3, 0.4), Size: 216},
+			{Name: "person", Score: 95, Area: crop.NewArea("person", 0.6, 0.2, 0.1, 0.5), Size: 72},
+			{Name: "truck", Score: 60, Area: crop.NewArea("truck", 0.11, 0.21, 0.3, 0.4), Size: 216},
+		}
+
+		file.AddObjects(objects)
+
+		markers := file.Markers()
+
+		// The truck overlaps the car and must be skipped.
+		assert.Equal(t, 2, len(*markers))
+		assert.Equal(t, "person", (*markers)[0].MarkerName)
+		assert.Equal(t, "car", (*markers)[1].MarkerName)
+		assert.Equal(t, 2, len(markers.Objects()))
+		assert.False(t, file.FocusArea().Empty())
+		assert.True(t, strings.HasPrefix(file.FocusThumb(), file.FileHash+"-"))
+	})
+	t.Run("NoObjects", func(t *testing.T) {
+		file := &File{FileUID: "fs6sg6bp4sjk3ob2", FileHash: "546b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileHeight: 480, FileAspectRatio: 1.5, FileName: "ObjectsTest", PhotoID: 1000003}
+
+		file.AddObjects(object.Objects{})
+
+		assert.Empty(t, *file.Markers())
+		assert.True(t, file.FocusArea().Empty())
+		assert.Equal(t, "", file.FocusThumb())
+	})
+}
+
 func TestFile_AddFaces(t *testing.T) {
 	t.Run("Primary", func(t *testing.T) {
 		file := &File{FileUID: "fs6sg6bp4sjk3kdn", FileHash: "346b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileName: "FacesTest", PhotoID: 1000003, FilePrimary: true}
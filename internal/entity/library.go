@@ -0,0 +1,288 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+)
+
+// Library modes for registered users.
+const (
+	LibraryShared = "shared" // All users share the same library.
+	LibraryUser   = "user"   // Each user has a separate library.
+)
+
+// LibraryModes contains the supported library modes.
+var LibraryModes = map[string]bool{
+	LibraryShared: true,
+	LibraryUser:   true,
+}
+
+// LibraryMode specifies whether registered users share the same library or each have a separate library.
+var LibraryMode = LibraryShared
+
+// UserLibraries checks if each registered user has a separate library.
+func UserLibraries() bool {
+	return LibraryMode == LibraryUser
+}
+
+// UserLibrary checks if the session user may only access their own library and content shared with them.
+func (m *Session) UserLibrary() bool {
+	if m == nil || !UserLibraries() {
+		return false
+	}
+
+	user := m.User()
+
+	return user.IsRegistered() && !user.IsSuperAdmin()
+}
+
+// UserPhotosCond returns the SQL condition and values for finding photos owned by or explicitly shared with the user,
+// including photos in albums shared with the user and the specified album and folder UIDs, e.g. from share links or teams.
+func UserPhotosCond(user *User, sharedUIDs UIDs) (cond string, values []interface{}) {
+	conds := []string{
+		"photos.created_by = ?",
+		"photos.photo_uid IN (SELECT uid FROM photos_users WHERE user_uid = ? AND perm <> ?)",
+		"photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = FALSE AND missing = FALSE AND album_uid IN " +
+			"(SELECT uid FROM albums_users WHERE user_uid = ? AND perm <> ?))",
+	}
+
+	values = []interface{}{user.UserUID, user.UserUID, PermNone, user.UserUID, PermNone}
+
+	if len(sharedUIDs) > 0 {
+		conds = append(conds,
+			"photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = FALSE AND missing = FALSE AND album_uid IN (?))",
+			"photos.photo_path IN (SELECT album_path FROM albums WHERE album_type = ? AND album_uid IN (?))")
+		values = append(values, sharedUIDs, AlbumFolder, sharedUIDs)
+	}
+
+	if basePath := user.GetBasePath(); basePath != "" {
+		conds = append(conds, "photos.photo_path = ?", "photos.photo_path LIKE ?")
+		values = append(values, basePath, basePath+"/%")
+	}
+
+	return strings.Join(conds, " OR "), values
+}
+
+// SmartAlbumCond returns the SQL condition and values for limiting the pictures in a smart album to the scope
+// of the user who created it, so that its filter cannot match content the creator is not allowed to see.
+// No condition is returned if the creator may access the whole library.
+func SmartAlbumCond(album *Album) (cond string, values []interface{}) {
+	if album == nil || !album.IsSmart() {
+		return "", nil
+	}
+
+	creator := FindUserByUID(album.CreatedBy)
+
+	if !creator.IsRegistered() {
+		// Albums without an owner, e.g. created from the command line, can only match pictures in a shared library.
+		if album.CreatedBy == "" && !UserLibraries() {
+			return "", nil
+		}
+
+		return "photos.id = 0", nil
+	} else if creator.IsSuperAdmin() {
+		return "", nil
+	} else if !UserLibraries() && !acl.Rules.DenyAll(acl.ResourcePhotos, creator.AclRole(), acl.Permissions{acl.AccessAll, acl.AccessLibrary}) {
+		return "", nil
+	}
+
+	return UserPhotosCond(creator, creator.SharedUIDs())
+}
+
+// UserAlbumsCond returns the SQL condition and values for finding albums owned by or explicitly shared with the user,
+// including the specified album UIDs, e.g. from share links or teams. Other album types, such as folders and moments,
+// are not restricted since they are generated automatically and the photos they contain are filtered separately.
+func UserAlbumsCond(user *User, sharedUIDs UIDs) (cond string, values []interface{}) {
+	cond = "albums.album_type NOT IN (?) OR albums.created_by = ? OR albums.album_uid IN (SELECT uid FROM albums_users WHERE user_uid = ? AND perm <> ?)"
+	values = []interface{}{[]string{AlbumManual, AlbumSmart}, user.UserUID, user.UserUID, PermNone}
+
+	if len(sharedUIDs) > 0 {
+		cond += " OR albums.album_uid IN (?)"
+		values = append(values, sharedUIDs)
+	}
+
+	return cond, values
+}
+
+// CanAccessPhoto checks if the session user may access the specified photo based on the library mode.
+func (m *Session) CanAccessPhoto(photo *Photo) bool {
+	if photo == nil || !photo.HasID() {
+		return false
+	} else if !m.UserLibrary() {
+		return true
+	}
+
+	user := m.User()
+
+	if photo.CreatedBy == user.UserUID {
+		return true
+	}
+
+	var count int
+
+	cond, values := UserPhotosCond(user, m.SharedUIDs())
+
+	if err := UnscopedDb().Table(Photo{}.TableName()).
+		Where("photos.id = ?", photo.ID).
+		Where(cond, values...).
+		Count(&count).Error; err != nil {
+		log.Errorf("photo: %s (check access)", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// CanAccessAlbum checks if the session user may access the specified album based on the library mode,
+// i.e. if the user has created the album or it has been shared with the user, a team, or via link.
+func (m *Session) CanAccessAlbum(album *Album) bool {
+	if !album.HasID() {
+		return false
+	} else if !m.UserLibrary() {
+		return true
+	}
+
+	user := m.User()
+
+	if album.CreatedBy == user.UserUID {
+		return true
+	}
+
+	var count int
+
+	cond, values := UserAlbumsCond(user, m.SharedUIDs())
+
+	if err := UnscopedDb().Table(Album{}.TableName()).
+		Where("albums.id = ?", album.ID).
+		Where(cond, values...).
+		Count(&count).Error; err != nil {
+		log.Errorf("album: %s (check access)", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// CanAccessFile checks if the session user may access the photo the specified file belongs to.
+func (m *Session) CanAccessFile(file *File) bool {
+	if file == nil {
+		return false
+	} else if !m.UserLibrary() {
+		return true
+	}
+
+	return m.CanAccessPhoto(&Photo{ID: file.PhotoID})
+}
+
+// CanAccessPhotos checks if the session user may access all photos with the specified UIDs based on the library mode.
+func (m *Session) CanAccessPhotos(uids []string) bool {
+	if !m.UserLibrary() || len(uids) == 0 {
+		return true
+	}
+
+	var found, allowed int
+
+	cond, values := UserPhotosCond(m.User(), m.SharedUIDs())
+
+	if err := UnscopedDb().Table(Photo{}.TableName()).
+		Where("photos.photo_uid IN (?)", uids).
+		Count(&found).Error; err != nil {
+		log.Errorf("photo: %s (check access)", err)
+		return false
+	} else if err = UnscopedDb().Table(Photo{}.TableName()).
+		Where("photos.photo_uid IN (?)", uids).
+		Where(cond, values...).
+		Count(&allowed).Error; err != nil {
+		log.Errorf("photo: %s (check access)", err)
+		return false
+	}
+
+	return allowed == found
+}
+
+// AccessiblePhotos returns the specified photos that the session user may access based on the library mode.
+func (m *Session) AccessiblePhotos(photos Photos) Photos {
+	if !m.UserLibrary() || len(photos) == 0 {
+		return photos
+	}
+
+	allowed := m.AccessiblePhotoUIDs(photos.UIDs())
+	lookup := make(map[string]bool, len(allowed))
+
+	for _, uid := range allowed {
+		lookup[uid] = true
+	}
+
+	result := make(Photos, 0, len(allowed))
+
+	for _, p := range photos {
+		if lookup[p.PhotoUID] {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+// AccessiblePhotoUIDs returns the specified photo UIDs that the session user may access based on the library mode.
+func (m *Session) AccessiblePhotoUIDs(uids []string) []string {
+	if !m.UserLibrary() || len(uids) == 0 {
+		return uids
+	}
+
+	var allowed []string
+
+	cond, values := UserPhotosCond(m.User(), m.SharedUIDs())
+
+	if err := UnscopedDb().Table(Photo{}.TableName()).
+		Where("photos.photo_uid IN (?)", uids).
+		Where(cond, values...).
+		Pluck("photos.photo_uid", &allowed).Error; err != nil {
+		log.Errorf("photo: %s (check access)", err)
+		return []string{}
+	}
+
+	return allowed
+}
+
+// AccessibleFiles returns the specified files that belong to photos the session user may access based on the library mode.
+func (m *Session) AccessibleFiles(files Files) Files {
+	if !m.UserLibrary() || len(files) == 0 {
+		return files
+	}
+
+	photoIds := make([]uint, 0, len(files))
+
+	for _, f := range files {
+		photoIds = append(photoIds, f.PhotoID)
+	}
+
+	var allowed []uint
+
+	cond, values := UserPhotosCond(m.User(), m.SharedUIDs())
+
+	if err := UnscopedDb().Table(Photo{}.TableName()).
+		Where("photos.id IN (?)", photoIds).
+		Where(cond, values...).
+		Pluck("photos.id", &allowed).Error; err != nil {
+		log.Errorf("file: %s (check access)", err)
+		return Files{}
+	}
+
+	lookup := make(map[uint]bool, len(allowed))
+
+	for _, id := range allowed {
+		lookup[id] = true
+	}
+
+	result := make(Files, 0, len(files))
+
+	for _, f := range files {
+		if lookup[f.PhotoID] {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
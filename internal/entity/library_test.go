@@ -0,0 +1,319 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestUserLibraries(t *testing.T) {
+	defer func() { LibraryMode = LibraryShared }()
+
+	assert.False(t, UserLibraries())
+	LibraryMode = LibraryUser
+	assert.True(t, UserLibraries())
+}
+
+func TestSession_UserLibrary(t *testing.T) {
+	defer func() { LibraryMode = LibraryShared }()
+
+	t.Run("Shared", func(t *testing.T) {
+		assert.False(t, SessionFixtures.Pointer("bob").UserLibrary())
+	})
+	t.Run("User", func(t *testing.T) {
+		LibraryMode = LibraryUser
+		assert.True(t, SessionFixtures.Pointer("bob").UserLibrary())
+		assert.False(t, SessionFixtures.Pointer("alice").UserLibrary())
+		assert.False(t, NewSession(0, 0).UserLibrary())
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var s *Session
+		assert.False(t, s.UserLibrary())
+	})
+}
+
+func TestSession_CanAccessPhoto(t *testing.T) {
+	defer func() { LibraryMode = LibraryShared }()
+
+	bob := SessionFixtures.Pointer("bob")
+	friend := UserFixtures.Pointer("friend")
+
+	photo := Photo{PhotoUID: rnd.GenerateUID(PhotoUID), PhotoName: "Library", OriginalName: "library", CreatedBy: friend.UserUID}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Owner", func(t *testing.T) {
+		found := PhotoUser{}
+
+		if err := Db().Where("uid = ? AND user_uid = ?", photo.PhotoUID, friend.UserUID).First(&found).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, PermAll, found.Perm)
+	})
+	t.Run("Shared", func(t *testing.T) {
+		assert.True(t, bob.CanAccessPhoto(&photo))
+		assert.True(t, bob.CanAccessPhoto(PhotoFixtures.Pointer("Photo01")))
+	})
+	t.Run("User", func(t *testing.T) {
+		LibraryMode = LibraryUser
+		assert.False(t, bob.CanAccessPhoto(&photo))
+		assert.True(t, SessionFixtures.Pointer("alice").CanAccessPhoto(&photo))
+
+		if err := NewPhotoUser(photo.PhotoUID, bob.UserUID, "", PermView).Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, bob.CanAccessPhoto(&photo))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.False(t, bob.CanAccessPhoto(nil))
+		assert.False(t, bob.CanAccessPhoto(&Photo{}))
+	})
+}
+
+func TestSession_CanAccessPhotos(t *testing.T) {
+	defer func() { LibraryMode = LibraryShared }()
+
+	bob := SessionFixtures.Pointer("bob")
+
+	photo := Photo{PhotoUID: rnd.GenerateUID(PhotoUID), PhotoName: "LibraryBatch", OriginalName: "library-batch", CreatedBy: bob.UserUID}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	other := PhotoFixtures.Pointer("Photo01")
+	photos := Photos{photo, *other}
+
+	t.Run("Shared", func(t *testing.T) {
+		assert.True(t, bob.CanAccessPhotos([]string{photo.PhotoUID, other.PhotoUID}))
+		assert.Len(t, bob.AccessiblePhotos(photos), 2)
+	})
+	t.Run("User", func(t *testing.T) {
+		LibraryMode = LibraryUser
+		assert.True(t, bob.CanAccessPhotos([]string{photo.PhotoUID}))
+		assert.True(t, bob.CanAccessPhotos([]string{photo.PhotoUID, "ps6sg6be2lvl0xxx"}))
+		assert.False(t, bob.CanAccessPhotos([]string{photo.PhotoUID, other.PhotoUID}))
+		assert.False(t, SessionFixtures.Pointer("friend").CanAccessPhotos([]string{photo.PhotoUID}))
+		assert.True(t, SessionFixtures.Pointer("alice").CanAccessPhotos([]string{photo.PhotoUID, other.PhotoUID}))
+
+		if result := bob.AccessiblePhotos(photos); assert.Len(t, result, 1) {
+			assert.Equal(t, photo.PhotoUID, result[0].PhotoUID)
+		}
+
+		assert.Empty(t, bob.AccessiblePhotos(Photos{*other}))
+	})
+}
+
+func TestSession_CanAccessFile(t *testing.T) {
+	defer func() { LibraryMode = LibraryShared }()
+
+	bob := SessionFixtures.Pointer("bob")
+	file := FileFixtures.Pointer("exampleFileName.jpg")
+
+	t.Run("Shared", func(t *testing.T) {
+		assert.True(t, bob.CanAccessFile(file))
+	})
+	t.Run("User", func(t *testing.T) {
+		LibraryMode = LibraryUser
+		assert.False(t, bob.CanAccessFile(file))
+		assert.True(t, SessionFixtures.Pointer("alice").CanAccessFile(file))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.False(t, bob.CanAccessFile(nil))
+	})
+}
+
+func TestSession_CanAccessAlbum(t *testing.T) {
+	defer func() { LibraryMode = LibraryShared }()
+
+	bob := SessionFixtures.Pointer("bob")
+	friend := UserFixtures.Pointer("friend")
+
+	album := NewUserAlbum("Library Album", AlbumManual, friend.UserUID)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer album.DeletePermanently()
+
+	t.Run("Shared", func(t *testing.T) {
+		assert.True(t, bob.CanAccessAlbum(album))
+	})
+	t.Run("User", func(t *testing.T) {
+		LibraryMode = LibraryUser
+		assert.False(t, bob.CanAccessAlbum(album))
+		assert.False(t, bob.CanAccessAlbum(AlbumFixtures.Pointer("berlin-2019")))
+		assert.True(t, bob.CanAccessAlbum(AlbumFixtures.Pointer("april-1990")))
+		assert.True(t, SessionFixtures.Pointer("alice").CanAccessAlbum(album))
+	})
+	t.Run("Owner", func(t *testing.T) {
+		LibraryMode = LibraryUser
+
+		owned := NewUserAlbum("Library Album Bob", AlbumManual, bob.UserUID)
+
+		if err := owned.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer owned.DeletePermanently()
+
+		assert.True(t, bob.CanAccessAlbum(owned))
+	})
+	t.Run("Team", func(t *testing.T) {
+		LibraryMode = LibraryUser
+
+		team := NewTeam("Library Team", "")
+
+		if err := team.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer team.Delete()
+
+		teamAlbum := NewUserAlbum("Library Team Album", AlbumManual, friend.UserUID)
+
+		if err := teamAlbum.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer teamAlbum.DeletePermanently()
+
+		user := *UserFixtures.Pointer("bob")
+		user.UserShares = nil
+		sess := NewSession(0, 0).SetUser(&user)
+
+		assert.False(t, sess.CanAccessAlbum(teamAlbum))
+		assert.NoError(t, team.AddMember(&user))
+		assert.NoError(t, team.Share(teamAlbum.AlbumUID, TeamPerm("view")))
+
+		user.UserShares = nil
+		assert.True(t, sess.CanAccessAlbum(teamAlbum))
+	})
+	t.Run("Grant", func(t *testing.T) {
+		LibraryMode = LibraryUser
+
+		if err := NewAlbumUser(album.AlbumUID, bob.UserUID, "", PermView).Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, bob.CanAccessAlbum(album))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.False(t, bob.CanAccessAlbum(nil))
+		assert.False(t, bob.CanAccessAlbum(&Album{}))
+	})
+}
+
+func TestSession_AccessibleFiles(t *testing.T) {
+	defer func() { LibraryMode = LibraryShared }()
+
+	bob := SessionFixtures.Pointer("bob")
+	friend := UserFixtures.Pointer("friend")
+
+	photo := Photo{PhotoUID: rnd.GenerateUID(PhotoUID), PhotoName: "LibraryFiles", OriginalName: "library-files", CreatedBy: friend.UserUID}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := Files{{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "library-files.jpg"}}
+
+	t.Run("Shared", func(t *testing.T) {
+		assert.Len(t, bob.AccessibleFiles(files), 1)
+		assert.Len(t, bob.AccessiblePhotoUIDs([]string{photo.PhotoUID}), 1)
+	})
+	t.Run("User", func(t *testing.T) {
+		LibraryMode = LibraryUser
+		assert.Empty(t, bob.AccessibleFiles(files))
+		assert.Empty(t, bob.AccessiblePhotoUIDs([]string{photo.PhotoUID}))
+		assert.Len(t, SessionFixtures.Pointer("alice").AccessibleFiles(files), 1)
+	})
+}
+
+func TestSmartAlbumCond(t *testing.T) {
+	defer func() { LibraryMode = LibraryShared }()
+
+	alice := UserFixtures.Pointer("alice")
+	bob := UserFixtures.Pointer("bob")
+
+	t.Run("Shared", func(t *testing.T) {
+		album, err := NewSmartAlbum("Dogs", "label:dog", bob.UserUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cond, values := SmartAlbumCond(album)
+		assert.Empty(t, cond)
+		assert.Empty(t, values)
+	})
+	t.Run("User", func(t *testing.T) {
+		LibraryMode = LibraryUser
+
+		album, err := NewSmartAlbum("Dogs", "label:dog", bob.UserUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cond, values := SmartAlbumCond(album)
+		assert.Contains(t, cond, "photos.created_by = ?")
+		assert.Equal(t, bob.UserUID, values[0])
+	})
+	t.Run("SuperAdmin", func(t *testing.T) {
+		LibraryMode = LibraryUser
+
+		album, err := NewSmartAlbum("Dogs", "label:dog", alice.UserUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cond, _ := SmartAlbumCond(album)
+		assert.Empty(t, cond)
+	})
+	t.Run("UnknownCreator", func(t *testing.T) {
+		LibraryMode = LibraryShared
+
+		album, err := NewSmartAlbum("Dogs", "label:dog", rnd.GenerateUID(UserUID))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cond, _ := SmartAlbumCond(album)
+		assert.Equal(t, "photos.id = 0", cond)
+	})
+	t.Run("NoCreator", func(t *testing.T) {
+		LibraryMode = LibraryShared
+
+		album, err := NewSmartAlbum("Dogs", "label:dog", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cond, _ := SmartAlbumCond(album)
+		assert.Empty(t, cond)
+
+		LibraryMode = LibraryUser
+
+		cond, _ = SmartAlbumCond(album)
+		assert.Equal(t, "photos.id = 0", cond)
+	})
+	t.Run("NotSmart", func(t *testing.T) {
+		LibraryMode = LibraryUser
+
+		cond, _ := SmartAlbumCond(AlbumFixtures.Pointer("berlin-2019"))
+		assert.Empty(t, cond)
+		cond, _ = SmartAlbumCond(nil)
+		assert.Empty(t, cond)
+	})
+}
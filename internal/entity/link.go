@@ -59,7 +59,9 @@ func (m *Link) BeforeCreate(scope *gorm.Scope) error {
 
 // NewLink creates a sharing link.
 func NewLink(shareUid string, canComment, canEdit bool) Link {
-	return NewUserLink(shareUid, OwnerUnknown)
+	result := NewUserLink(shareUid, OwnerUnknown)
+	result.SetPerms(canComment, canEdit)
+	return result
 }
 
 // NewUserLink creates a sharing link owned by a user.
@@ -114,6 +116,31 @@ func (m *Link) Expired() bool {
 	}
 }
 
+// CanComment checks if visitors may comment on the shared content.
+func (m *Link) CanComment() bool {
+	return m.Perm&PermComment != 0
+}
+
+// CanEdit checks if visitors may edit the shared content.
+func (m *Link) CanEdit() bool {
+	return m.Perm&PermEdit != 0
+}
+
+// SetPerms updates the visitor permissions of the link.
+func (m *Link) SetPerms(canComment, canEdit bool) {
+	if canComment {
+		m.Perm |= PermComment
+	} else {
+		m.Perm &^= PermComment
+	}
+
+	if canEdit {
+		m.Perm |= PermEdit
+	} else {
+		m.Perm &^= PermEdit
+	}
+}
+
 // SetSlug sets the URL slug of the link.
 func (m *Link) SetSlug(s string) {
 	m.ShareSlug = txt.Slug(s)
@@ -12,6 +12,21 @@ func TestNewLink(t *testing.T) {
 	assert.Equal(t, "ss6sg6bxpogaaba1", link.ShareUID)
 	assert.Equal(t, 10, len(link.LinkToken))
 	assert.Equal(t, 16, len(link.LinkUID))
+	assert.True(t, link.CanComment())
+	assert.False(t, link.CanEdit())
+}
+
+func TestLink_SetPerms(t *testing.T) {
+	link := NewLink("ss6sg6bxpogaaba1", false, false)
+	assert.Equal(t, PermDefault, link.Perm)
+
+	link.SetPerms(true, true)
+	assert.True(t, link.CanComment())
+	assert.True(t, link.CanEdit())
+
+	link.SetPerms(false, true)
+	assert.False(t, link.CanComment())
+	assert.True(t, link.CanEdit())
 }
 
 func TestLink_Expired(t *testing.T) {
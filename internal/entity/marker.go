@@ -11,6 +11,8 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/ai/pet"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/thumb/crop"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -19,8 +21,10 @@ import (
 
 const (
 	MarkerUnknown = ""
-	MarkerFace    = "face"  // MarkerType for faces (implemented).
-	MarkerLabel   = "label" // MarkerType for labels (todo).
+	MarkerFace    = "face"   // MarkerType for faces (implemented).
+	MarkerLabel   = "label"  // MarkerType for labels (todo).
+	MarkerObject  = "object" // MarkerType for detected objects (implemented).
+	MarkerPet     = "pet"    // MarkerType for pets (implemented).
 )
 
 // Marker represents an image marker point.
@@ -112,6 +116,36 @@ func NewFaceMarker(f face.Face, file File, subjUid string) *Marker {
 	return m
 }
 
+// NewObjectMarker creates a new marker for a detected object.
+func NewObjectMarker(o object.Object, file File) *Marker {
+	m := NewMarker(file, o.Area, "", SrcImage, MarkerObject, o.Size, o.Score)
+
+	// Failed creating new marker?
+	if m == nil {
+		return nil
+	}
+
+	m.MarkerName = o.Name
+
+	return m
+}
+
+// NewPetMarker creates a new marker for a detected pet.
+func NewPetMarker(p pet.Pet, file File) *Marker {
+	m := NewMarker(file, p.Area, "", SrcImage, MarkerPet, p.Size, p.Score)
+
+	// Failed creating new marker?
+	if m == nil {
+		return nil
+	}
+
+	if len(p.Embedding) > 0 {
+		m.SetEmbeddings(face.Embeddings{p.Embedding})
+	}
+
+	return m
+}
+
 // SetEmbeddings assigns new face emebddings to the marker.
 func (m *Marker) SetEmbeddings(e face.Embeddings) {
 	m.embeddings = e
@@ -224,8 +258,10 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 		return false, fmt.Errorf("face is nil")
 	}
 
-	if m.MarkerType != MarkerFace {
-		return false, fmt.Errorf("not a face marker")
+	if m.MarkerType != MarkerFace && m.MarkerType != MarkerPet {
+		return false, fmt.Errorf("not a face or pet marker")
+	} else if m.MarkerType != f.MarkerType() {
+		return false, fmt.Errorf("%s marker does not match face kind", TypeString(m.MarkerType))
 	}
 
 	// Any reason we don't want to set a new face for this marker?
@@ -313,8 +349,8 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 
 // SyncSubject maintains the marker subject relationship.
 func (m *Marker) SyncSubject(updateRelated bool) (err error) {
-	// Face marker? If not, return.
-	if m.MarkerType != MarkerFace {
+	// Face or pet marker? If not, return.
+	if m.MarkerType != MarkerFace && m.MarkerType != MarkerPet {
 		return nil
 	}
 
@@ -425,6 +461,15 @@ func (m *Marker) SubjectName() string {
 	return ""
 }
 
+// SubjType returns the type of subject that can be assigned to the marker.
+func (m *Marker) SubjType() string {
+	if m.MarkerType == MarkerPet {
+		return SubjPet
+	}
+
+	return SubjPerson
+}
+
 // Subject returns the matching subject or nil.
 func (m *Marker) Subject() (subj *Subject) {
 	if m.subject != nil {
@@ -435,7 +480,7 @@ func (m *Marker) Subject() (subj *Subject) {
 
 	// Create subject?
 	if m.SubjSrc != SrcAuto && m.MarkerName != "" && m.SubjUID == "" {
-		if subj = NewSubject(m.MarkerName, SubjPerson, m.SubjSrc); subj == nil {
+		if subj = NewSubject(m.MarkerName, m.SubjType(), m.SubjSrc); subj == nil {
 			log.Errorf("faces: marker %s has invalid subject %s", clean.Log(m.MarkerUID), clean.Log(m.MarkerName))
 			return nil
 		} else if subj = FirstOrCreateSubject(subj); subj == nil {
@@ -505,7 +550,12 @@ func (m *Marker) Face() (f *Face) {
 
 	// Add face if size
 	if m.SubjSrc != SrcAuto && m.FaceID == "" {
-		if m.Size < face.ClusterSizeThreshold || m.Score < face.ClusterScoreThreshold {
+		isPet := m.MarkerType == MarkerPet
+
+		if isPet && (m.Size < pet.SizeThreshold || m.Score < pet.ScoreThreshold) {
+			log.Debugf("faces: pet marker %s skipped adding face due to low-quality (size %d, score %d)", clean.Log(m.MarkerUID), m.Size, m.Score)
+			return nil
+		} else if !isPet && (m.Size < face.ClusterSizeThreshold || m.Score < face.ClusterScoreThreshold) {
 			log.Debugf("faces: marker %s skipped adding face due to low-quality (size %d, score %d)", clean.Log(m.MarkerUID), m.Size, m.Score)
 			return nil
 		}
@@ -513,10 +563,16 @@ func (m *Marker) Face() (f *Face) {
 		if emb := m.Embeddings(); emb.Empty() {
 			log.Warnf("faces: marker %s has no face embeddings", clean.Log(m.MarkerUID))
 			return nil
-		} else if f = NewFace(m.SubjUID, m.SubjSrc, emb); f == nil {
+		} else if isPet {
+			f = NewPetFace(m.SubjUID, m.SubjSrc, emb)
+		} else {
+			f = NewFace(m.SubjUID, m.SubjSrc, emb)
+		}
+
+		if f == nil {
 			log.Warnf("faces: failed assigning face to marker %s", clean.Log(m.MarkerUID))
 			return nil
-		} else if f.SkipMatching() {
+		} else if !isPet && f.SkipMatching() {
 			log.Infof("faces: skipped matching marker %s, embedding %s not distinct enough", clean.Log(m.MarkerUID), f.ID)
 		} else if f = FirstOrCreateFace(f); f == nil {
 			log.Warnf("faces: failed matching marker %s with subject %s", clean.Log(m.MarkerUID), SubjNames.Log(m.SubjUID))
@@ -658,6 +714,16 @@ func (m *Marker) ValidFace() bool {
 	return m.MarkerType == MarkerFace && !m.MarkerInvalid
 }
 
+// ValidObject tests if the marker is a valid object.
+func (m *Marker) ValidObject() bool {
+	return m.MarkerType == MarkerObject && !m.MarkerInvalid
+}
+
+// ValidPet tests if the marker is a valid pet.
+func (m *Marker) ValidPet() bool {
+	return m.MarkerType == MarkerPet && !m.MarkerInvalid
+}
+
 // DetectedFace tests if the marker is an automatically detected face.
 func (m *Marker) DetectedFace() bool {
 	return m.MarkerType == MarkerFace && m.MarkerSrc == SrcImage
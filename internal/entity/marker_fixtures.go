@@ -365,6 +365,22 @@ var MarkerFixtures = MarkerMap{
 		Size:           209,
 		Score:          55,
 	},
+	"object-car-1": Marker{ //Photo11
+		MarkerUID:  "ms6sg6b1ob1car01",
+		FileUID:    "fs6sg6bqhhinlplv",
+		Thumb:      "pcad9a68fa6acc5c5ba965adf6ec465ca42fd924-0780f00c808c",
+		SubjUID:    "",
+		MarkerSrc:  SrcImage,
+		MarkerType: MarkerObject,
+		MarkerName: "car",
+		X:          0.12,
+		Y:          0.24,
+		W:          0.2,
+		H:          0.14,
+		Q:          540,
+		Size:       128,
+		Score:      87,
+	},
 }
 
 // CreateMarkerFixtures inserts known entities into the database for testing.
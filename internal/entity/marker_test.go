@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/ai/pet"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/thumb/crop"
 	"github.com/stretchr/testify/assert"
@@ -59,6 +62,55 @@ func TestNewMarker(t *testing.T) {
 	assert.Equal(t, MarkerLabel, m.MarkerType)
 }
 
+func TestNewObjectMarker(t *testing.T) {
+	t.Run("Car", func(t *testing.T) {
+		o := object.Object{Name: "car", Score: 87, Area: testArea, Size: 300}
+		m := NewObjectMarker(o, FileFixtures.Get("exampleFileName.jpg"))
+
+		if m == nil {
+			t.Fatal("marker must not be nil")
+		}
+
+		assert.Equal(t, "fs6sg6bw45bnlqdw", m.FileUID)
+		assert.Equal(t, "car", m.MarkerName)
+		assert.Equal(t, MarkerObject, m.MarkerType)
+		assert.Equal(t, SrcImage, m.MarkerSrc)
+		assert.Equal(t, 87, m.Score)
+		assert.Equal(t, "", m.SubjUID)
+		assert.False(t, m.MarkerReview)
+		assert.True(t, m.ValidObject())
+		assert.False(t, m.ValidFace())
+	})
+	t.Run("NoFileHash", func(t *testing.T) {
+		assert.Nil(t, NewObjectMarker(object.Object{Name: "car", Score: 87, Area: testArea, Size: 300}, File{}))
+	})
+}
+
+func TestNewPetMarker(t *testing.T) {
+	t.Run("Dog", func(t *testing.T) {
+		p := pet.Pet{Name: "dog", Score: 90, Area: testArea, Size: 300, Embedding: face.RandomEmbedding()}
+		m := NewPetMarker(p, FileFixtures.Get("exampleFileName.jpg"))
+
+		if m == nil {
+			t.Fatal("marker must not be nil")
+		}
+
+		assert.Equal(t, "fs6sg6bw45bnlqdw", m.FileUID)
+		assert.Equal(t, "", m.MarkerName)
+		assert.Equal(t, MarkerPet, m.MarkerType)
+		assert.Equal(t, SrcImage, m.MarkerSrc)
+		assert.Equal(t, SubjPet, m.SubjType())
+		assert.Equal(t, 90, m.Score)
+		assert.Len(t, m.Embeddings(), 1)
+		assert.True(t, m.ValidPet())
+		assert.False(t, m.ValidFace())
+		assert.False(t, m.ValidObject())
+	})
+	t.Run("NoFileHash", func(t *testing.T) {
+		assert.Nil(t, NewPetMarker(pet.Pet{Name: "cat", Score: 90, Area: testArea, Size: 300}, File{}))
+	})
+}
+
 func TestMarker_SetName(t *testing.T) {
 	t.Run("InvalidName", func(t *testing.T) {
 		m := MarkerFixtures.Get("actress-a-1")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/ai/classify"
 	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -45,13 +46,18 @@ func (m Markers) Unsaved() bool {
 
 // Contains returns true if a marker at the same position already exists.
 func (m Markers) Contains(other Marker) bool {
+	return m.Overlapping(other) != nil
+}
+
+// Overlapping returns the first marker of the same type at the same position, or nil if there is none.
+func (m Markers) Overlapping(other Marker) *Marker {
 	for i := range m {
-		if m[i].OverlapPercent(other) > face.OverlapThreshold {
-			return true
+		if m[i].MarkerType == other.MarkerType && m[i].OverlapPercent(other) > face.OverlapThreshold {
+			return &m[i]
 		}
 	}
 
-	return false
+	return nil
 }
 
 // DetectedFaceCount returns the number of automatically detected face markers.
@@ -76,6 +82,48 @@ func (m Markers) ValidFaceCount() (count int) {
 	return count
 }
 
+// Objects returns the valid object markers.
+func (m Markers) Objects() (result Markers) {
+	for i := range m {
+		if m[i].ValidObject() {
+			result = append(result, m[i])
+		}
+	}
+
+	return result
+}
+
+// Pets returns the valid pet markers.
+func (m Markers) Pets() (result Markers) {
+	for i := range m {
+		if m[i].ValidPet() {
+			result = append(result, m[i])
+		}
+	}
+
+	return result
+}
+
+// Focus returns a square crop area centered on the main object, if any.
+func (m Markers) Focus(aspect float32) crop.Area {
+	var best *Marker
+
+	// Use the object with the best combination of size and score.
+	for i := range m {
+		if !m[i].ValidObject() {
+			continue
+		} else if best == nil || m[i].Q > best.Q {
+			best = &m[i]
+		}
+	}
+
+	if best == nil {
+		return crop.Area{}
+	}
+
+	return crop.Areas{crop.NewArea(best.MarkerName, best.X, best.Y, best.W, best.H)}.Focus(aspect)
+}
+
 // SubjectNames returns known subject names.
 func (m Markers) SubjectNames() (names []string) {
 	for i := range m {
@@ -189,4 +189,10 @@ var DialectMySQL = Migrations{
 		Stage:      "main",
 		Statements: []string{"ALTER TABLE photos MODIFY photo_lat DOUBLE;", "ALTER TABLE photos MODIFY photo_lng DOUBLE;"},
 	},
+	{
+		ID:         "20261018-000001",
+		Dialect:    "mysql",
+		Stage:      "main",
+		Statements: []string{"UPDATE photos SET photo_rating = 0 WHERE photo_rating IS NULL;"},
+	},
 }
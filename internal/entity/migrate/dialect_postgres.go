@@ -0,0 +1,204 @@
+package migrate
+
+// Generated code, do not edit.
+
+var DialectPostgres = Migrations{
+	{
+		ID:         "20211121-094727",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"DROP INDEX IF EXISTS uix_places_place_label;"},
+	},
+	{
+		ID:         "20211124-120008",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"DROP INDEX IF EXISTS idx_places_place_label;", "DROP INDEX IF EXISTS uix_places_label;"},
+	},
+	{
+		ID:         "20220329-030000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE files ALTER COLUMN file_projection TYPE VARCHAR(64), ALTER COLUMN file_projection DROP NOT NULL;", "ALTER TABLE files ALTER COLUMN file_color_profile TYPE VARCHAR(64), ALTER COLUMN file_color_profile DROP NOT NULL;"},
+	},
+	{
+		ID:         "20220329-040000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"DROP INDEX IF EXISTS idx_albums_album_filter;", "ALTER TABLE albums ALTER COLUMN album_filter TYPE VARCHAR(2048), ALTER COLUMN album_filter SET DEFAULT '';"},
+	},
+	{
+		ID:         "20220329-050000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_albums_album_filter ON albums (album_filter);"},
+	},
+	{
+		ID:         "20220329-060000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE photos ALTER COLUMN photo_description TYPE CITEXT;", "ALTER TABLE albums ALTER COLUMN album_caption TYPE CITEXT;", "ALTER TABLE albums ALTER COLUMN album_description TYPE CITEXT;", "ALTER TABLE albums ALTER COLUMN album_notes TYPE CITEXT;", "ALTER TABLE cameras ALTER COLUMN camera_description TYPE CITEXT;", "ALTER TABLE cameras ALTER COLUMN camera_notes TYPE CITEXT;", "ALTER TABLE countries ALTER COLUMN country_description TYPE CITEXT;", "ALTER TABLE countries ALTER COLUMN country_notes TYPE CITEXT;", "ALTER TABLE details ALTER COLUMN keywords TYPE CITEXT;", "ALTER TABLE details ALTER COLUMN notes TYPE CITEXT;", "ALTER TABLE details ALTER COLUMN subject TYPE CITEXT;", "ALTER TABLE details ALTER COLUMN artist TYPE CITEXT;", "ALTER TABLE details ALTER COLUMN copyright TYPE CITEXT;", "ALTER TABLE details ALTER COLUMN license TYPE CITEXT;", "ALTER TABLE folders ALTER COLUMN folder_description TYPE CITEXT;", "ALTER TABLE labels ALTER COLUMN label_description TYPE CITEXT;", "ALTER TABLE labels ALTER COLUMN label_notes TYPE CITEXT;", "ALTER TABLE lenses ALTER COLUMN lens_description TYPE CITEXT;", "ALTER TABLE lenses ALTER COLUMN lens_notes TYPE CITEXT;", "ALTER TABLE subjects ALTER COLUMN subj_bio TYPE CITEXT;", "ALTER TABLE subjects ALTER COLUMN subj_notes TYPE CITEXT;"},
+	},
+	{
+		ID:         "20220329-061000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_files_photo_id ON files (photo_id, file_primary);"},
+	},
+	{
+		ID:         "20220329-070000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE files ADD COLUMN IF NOT EXISTS photo_taken_at TIMESTAMP;"},
+	},
+	{
+		ID:         "20220329-071000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"UPDATE files SET photo_taken_at = photos.taken_at_local FROM photos WHERE photos.id = files.photo_id;"},
+	},
+	{
+		ID:         "20220329-080000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE files ADD COLUMN IF NOT EXISTS media_id VARCHAR(32);"},
+	},
+	{
+		ID:         "20220329-081000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_media ON files (media_id);"},
+	},
+	{
+		ID:         "20220329-083000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"UPDATE files SET media_id = CASE WHEN file_missing = FALSE AND deleted_at IS NULL THEN CONCAT((10000000000 - photo_id), '-', 1 + file_sidecar::INTEGER - file_primary::INTEGER, '-', file_uid) END;"},
+	},
+	{
+		ID:         "20220329-090000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE files ADD COLUMN IF NOT EXISTS time_index VARCHAR(64);"},
+	},
+	{
+		ID:         "20220329-091000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_timeline ON files (time_index);"},
+	},
+	{
+		ID:         "20220329-093000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"UPDATE files SET time_index = CASE WHEN file_missing = FALSE AND deleted_at IS NULL THEN CONCAT(100000000000000 - CAST(TO_CHAR(photo_taken_at, 'YYYYMMDDHH24MISS') AS BIGINT), '-', media_id) END;"},
+	},
+	{
+		ID:         "20220421-200000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_files_missing_root ON files (file_missing, file_root);"},
+	},
+	{
+		ID:         "20220521-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE photos ALTER COLUMN photo_color TYPE SMALLINT, ALTER COLUMN photo_color SET DEFAULT -1;"},
+	},
+	{
+		ID:         "20220521-000002",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE files ALTER COLUMN file_diff TYPE INTEGER, ALTER COLUMN file_diff SET DEFAULT -1;"},
+	},
+	{
+		ID:         "20220521-000003",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE files ALTER COLUMN file_chroma TYPE SMALLINT, ALTER COLUMN file_chroma SET DEFAULT -1;"},
+	},
+	{
+		ID:         "20220927-000100",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE files ALTER COLUMN time_index TYPE VARCHAR(64);"},
+	},
+	{
+		ID:         "20221002-000100",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE links DROP COLUMN IF EXISTS can_edit;", "ALTER TABLE links DROP COLUMN IF EXISTS can_comment;"},
+	},
+	{
+		ID:         "20221015-100000",
+		Dialect:    "postgres",
+		Stage:      "pre",
+		Statements: []string{"ALTER TABLE IF EXISTS accounts RENAME TO services;"},
+	},
+	{
+		ID:         "20221015-100100",
+		Dialect:    "postgres",
+		Stage:      "pre",
+		Statements: []string{"ALTER TABLE IF EXISTS files_sync RENAME COLUMN account_id TO service_id;", "ALTER TABLE IF EXISTS files_share RENAME COLUMN account_id TO service_id;"},
+	},
+	{
+		ID:         "20230102-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE albums ALTER COLUMN album_path TYPE CITEXT;"},
+	},
+	{
+		ID:         "20230211-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE files ALTER COLUMN file_colors TYPE VARCHAR(18);", "ALTER TABLE files ALTER COLUMN file_luminance TYPE VARCHAR(18);"},
+	},
+	{
+		ID:         "20230309-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"UPDATE auth_users SET auth_provider = 'local' WHERE id = 1;", "UPDATE auth_users SET auth_provider = 'none' WHERE id = -1;", "UPDATE auth_users SET auth_provider = 'token' WHERE id = -2;", "UPDATE auth_users SET auth_provider = 'default' WHERE auth_provider = '' OR auth_provider = 'password' OR auth_provider IS NULL;"},
+	},
+	{
+		ID:         "20230313-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"UPDATE auth_users SET user_role = 'contributor' WHERE user_role = 'uploader';", "UPDATE auth_sessions SET auth_provider = 'link' WHERE auth_provider = 'token';"},
+	},
+	{
+		ID:         "20240112-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"TRUNCATE auth_sessions;"},
+	},
+	{
+		ID:         "20240701-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE auth_sessions ALTER COLUMN refresh_token TYPE VARCHAR(2048);", "ALTER TABLE auth_sessions ALTER COLUMN id_token TYPE VARCHAR(2048);"},
+	},
+	{
+		ID:         "20240709-000001",
+		Dialect:    "postgres",
+		Stage:      "pre",
+		Statements: []string{"ALTER TABLE IF EXISTS auth_sessions RENAME COLUMN auth_domain TO auth_issuer;"},
+	},
+	{
+		ID:         "20240915-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"ALTER TABLE photos ALTER COLUMN photo_lat TYPE DOUBLE PRECISION;", "ALTER TABLE photos ALTER COLUMN photo_lng TYPE DOUBLE PRECISION;"},
+	},
+	{
+		ID:         "20261018-000001",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"UPDATE photos SET photo_rating = 0 WHERE photo_rating IS NULL;"},
+	},
+	{
+		ID:         "20261020-000001",
+		Dialect:    "postgres",
+		Stage:      "pre",
+		Statements: []string{"CREATE EXTENSION IF NOT EXISTS citext;"},
+	},
+}
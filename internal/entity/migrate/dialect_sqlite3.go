@@ -105,4 +105,10 @@ var DialectSQLite3 = Migrations{
 		Stage:      "pre",
 		Statements: []string{"ALTER TABLE auth_sessions RENAME COLUMN auth_domain TO auth_issuer;"},
 	},
+	{
+		ID:         "20261018-000001",
+		Dialect:    "sqlite3",
+		Stage:      "main",
+		Statements: []string{"UPDATE photos SET photo_rating = 0 WHERE photo_rating IS NULL;"},
+	},
 }
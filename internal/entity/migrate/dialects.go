@@ -4,16 +4,19 @@ import "sync"
 
 // Supported database dialects.
 const (
-	MySQL   = "mysql"
-	SQLite3 = "sqlite3"
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite3  = "sqlite3"
 )
 
 var Dialects = map[string]Migrations{
-	MySQL:   DialectMySQL,
-	SQLite3: DialectSQLite3,
+	MySQL:    DialectMySQL,
+	Postgres: DialectPostgres,
+	SQLite3:  DialectSQLite3,
 }
 
 var once = map[string]*sync.Once{
-	MySQL:   {},
-	SQLite3: {},
+	MySQL:    {},
+	Postgres: {},
+	SQLite3:  {},
 }
@@ -116,6 +116,7 @@ func gen_migrations(name string) {
 
 func main() {
 	gen_migrations("MySQL")
+	gen_migrations("Postgres")
 	gen_migrations("SQLite3")
 }
 
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS uix_places_place_label;
@@ -0,0 +1,2 @@
+ALTER TABLE files ALTER COLUMN file_projection TYPE VARCHAR(64), ALTER COLUMN file_projection DROP NOT NULL;
+ALTER TABLE files ALTER COLUMN file_color_profile TYPE VARCHAR(64), ALTER COLUMN file_color_profile DROP NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_albums_album_filter;
+ALTER TABLE albums ALTER COLUMN album_filter TYPE VARCHAR(2048), ALTER COLUMN album_filter SET DEFAULT '';
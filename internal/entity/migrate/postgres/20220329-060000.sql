@@ -0,0 +1,21 @@
+ALTER TABLE photos ALTER COLUMN photo_description TYPE CITEXT;
+ALTER TABLE albums ALTER COLUMN album_caption TYPE CITEXT;
+ALTER TABLE albums ALTER COLUMN album_description TYPE CITEXT;
+ALTER TABLE albums ALTER COLUMN album_notes TYPE CITEXT;
+ALTER TABLE cameras ALTER COLUMN camera_description TYPE CITEXT;
+ALTER TABLE cameras ALTER COLUMN camera_notes TYPE CITEXT;
+ALTER TABLE countries ALTER COLUMN country_description TYPE CITEXT;
+ALTER TABLE countries ALTER COLUMN country_notes TYPE CITEXT;
+ALTER TABLE details ALTER COLUMN keywords TYPE CITEXT;
+ALTER TABLE details ALTER COLUMN notes TYPE CITEXT;
+ALTER TABLE details ALTER COLUMN subject TYPE CITEXT;
+ALTER TABLE details ALTER COLUMN artist TYPE CITEXT;
+ALTER TABLE details ALTER COLUMN copyright TYPE CITEXT;
+ALTER TABLE details ALTER COLUMN license TYPE CITEXT;
+ALTER TABLE folders ALTER COLUMN folder_description TYPE CITEXT;
+ALTER TABLE labels ALTER COLUMN label_description TYPE CITEXT;
+ALTER TABLE labels ALTER COLUMN label_notes TYPE CITEXT;
+ALTER TABLE lenses ALTER COLUMN lens_description TYPE CITEXT;
+ALTER TABLE lenses ALTER COLUMN lens_notes TYPE CITEXT;
+ALTER TABLE subjects ALTER COLUMN subj_bio TYPE CITEXT;
+ALTER TABLE subjects ALTER COLUMN subj_notes TYPE CITEXT;
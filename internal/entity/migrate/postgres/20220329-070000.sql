@@ -0,0 +1 @@
+ALTER TABLE files ADD COLUMN IF NOT EXISTS photo_taken_at TIMESTAMP;
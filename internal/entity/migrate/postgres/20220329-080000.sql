@@ -0,0 +1 @@
+ALTER TABLE files ADD COLUMN IF NOT EXISTS media_id VARCHAR(32);
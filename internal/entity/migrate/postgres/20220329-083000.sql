@@ -0,0 +1 @@
+UPDATE files SET media_id = CASE WHEN file_missing = FALSE AND deleted_at IS NULL THEN CONCAT((10000000000 - photo_id), '-', 1 + file_sidecar::INTEGER - file_primary::INTEGER, '-', file_uid) END;
@@ -0,0 +1 @@
+ALTER TABLE files ADD COLUMN IF NOT EXISTS time_index VARCHAR(64);
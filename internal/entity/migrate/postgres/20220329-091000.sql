@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_timeline ON files (time_index);
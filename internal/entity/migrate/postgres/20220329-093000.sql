@@ -0,0 +1 @@
+UPDATE files SET time_index = CASE WHEN file_missing = FALSE AND deleted_at IS NULL THEN CONCAT(100000000000000 - CAST(TO_CHAR(photo_taken_at, 'YYYYMMDDHH24MISS') AS BIGINT), '-', media_id) END;
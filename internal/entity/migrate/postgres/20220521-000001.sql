@@ -0,0 +1 @@
+ALTER TABLE photos ALTER COLUMN photo_color TYPE SMALLINT, ALTER COLUMN photo_color SET DEFAULT -1;
@@ -0,0 +1 @@
+ALTER TABLE files ALTER COLUMN file_diff TYPE INTEGER, ALTER COLUMN file_diff SET DEFAULT -1;
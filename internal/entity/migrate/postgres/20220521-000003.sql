@@ -0,0 +1 @@
+ALTER TABLE files ALTER COLUMN file_chroma TYPE SMALLINT, ALTER COLUMN file_chroma SET DEFAULT -1;
@@ -0,0 +1 @@
+ALTER TABLE files ALTER COLUMN time_index TYPE VARCHAR(64);
@@ -0,0 +1,2 @@
+ALTER TABLE links DROP COLUMN IF EXISTS can_edit;
+ALTER TABLE links DROP COLUMN IF EXISTS can_comment;
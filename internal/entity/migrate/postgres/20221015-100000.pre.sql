@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS accounts RENAME TO services;
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS files_sync RENAME COLUMN account_id TO service_id;
+ALTER TABLE IF EXISTS files_share RENAME COLUMN account_id TO service_id;
@@ -0,0 +1 @@
+ALTER TABLE albums ALTER COLUMN album_path TYPE CITEXT;
@@ -0,0 +1,2 @@
+ALTER TABLE files ALTER COLUMN file_colors TYPE VARCHAR(18);
+ALTER TABLE files ALTER COLUMN file_luminance TYPE VARCHAR(18);
@@ -0,0 +1 @@
+TRUNCATE auth_sessions;
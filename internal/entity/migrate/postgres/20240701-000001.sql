@@ -0,0 +1,2 @@
+ALTER TABLE auth_sessions ALTER COLUMN refresh_token TYPE VARCHAR(2048);
+ALTER TABLE auth_sessions ALTER COLUMN id_token TYPE VARCHAR(2048);
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS auth_sessions RENAME COLUMN auth_domain TO auth_issuer;
@@ -0,0 +1,2 @@
+ALTER TABLE photos ALTER COLUMN photo_lat TYPE DOUBLE PRECISION;
+ALTER TABLE photos ALTER COLUMN photo_lng TYPE DOUBLE PRECISION;
@@ -0,0 +1 @@
+UPDATE photos SET photo_rating = 0 WHERE photo_rating IS NULL;
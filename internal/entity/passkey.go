@@ -0,0 +1,217 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/auth/webauthn"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// PasskeyUID is the unique ID prefix.
+const (
+	PasskeyUID = byte('w')
+)
+
+// Passkeys represents a list of passkeys.
+type Passkeys []Passkey
+
+// Passkey represents a WebAuthn credential that can be used to log in without a password or as a second factor.
+type Passkey struct {
+	PasskeyUID string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	UserUID    string     `gorm:"type:VARBINARY(42);index;default:'';" json:"UserUID" yaml:"UserUID"`
+	KeyID      string     `gorm:"type:VARBINARY(1400);unique_index;" json:"KeyID" yaml:"KeyID"`
+	KeyName    string     `gorm:"size:160;" json:"Name" yaml:"Name,omitempty"`
+	KeyAlg     int64      `json:"Alg" yaml:"Alg,omitempty"`
+	PublicKey  []byte     `gorm:"type:VARBINARY(2048);" json:"-" yaml:"-"`
+	AAGUID     string     `gorm:"type:VARBINARY(64);column:aaguid;" json:"AAGUID" yaml:"AAGUID,omitempty"`
+	Transports string     `gorm:"type:VARBINARY(255);" json:"Transports" yaml:"Transports,omitempty"`
+	SignCount  uint32     `json:"-" yaml:"-"`
+	LastUsedAt *time.Time `json:"LastUsedAt" yaml:"-"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Passkey) TableName() string {
+	return "passkeys"
+}
+
+// NewPasskey returns a new passkey for the specified user based on a verified credential.
+func NewPasskey(userUid, name string, cred *webauthn.Credential) *Passkey {
+	m := &Passkey{UserUID: userUid}
+
+	if cred != nil {
+		m.KeyID = webauthn.Encode(cred.ID)
+		m.KeyAlg = cred.Alg
+		m.PublicKey = cred.PublicKey
+		m.AAGUID = webauthn.Encode(cred.AAGUID)
+		m.Transports = strings.Join(cred.Transports, ",")
+		m.SignCount = cred.SignCount
+	}
+
+	m.SetName(name)
+
+	return m
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Passkey) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.PasskeyUID, PasskeyUID) {
+		return nil
+	}
+
+	m.PasskeyUID = rnd.GenerateUID(PasskeyUID)
+
+	return scope.SetColumn("PasskeyUID", m.PasskeyUID)
+}
+
+// FindPasskey returns the passkey with the specified UID, or nil if it was not found.
+func FindPasskey(uid string) *Passkey {
+	if !rnd.IsUID(uid, PasskeyUID) {
+		return nil
+	}
+
+	m := &Passkey{}
+
+	if err := UnscopedDb().First(m, "passkey_uid = ?", uid).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindPasskeyByKeyID returns the passkey with the specified base64url-encoded credential ID, or nil if it was not found.
+func FindPasskeyByKeyID(keyId string) *Passkey {
+	if keyId == "" {
+		return nil
+	}
+
+	m := &Passkey{}
+
+	if err := UnscopedDb().First(m, "key_id = ?", keyId).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindPasskeys returns the passkeys registered for the specified user.
+func FindPasskeys(userUid string) (result Passkeys) {
+	result = Passkeys{}
+
+	if userUid == "" {
+		return result
+	}
+
+	if err := UnscopedDb().Where("user_uid = ?", userUid).Order("created_at, passkey_uid").Find(&result).Error; err != nil {
+		log.Errorf("passkey: %s (find)", err)
+	}
+
+	return result
+}
+
+// Create inserts a new passkey into the database.
+func (m *Passkey) Create() error {
+	if m.UserUID == "" {
+		return errors.New("passkey: user uid not set")
+	} else if m.KeyID == "" || len(m.PublicKey) == 0 {
+		return authn.ErrInvalidPasskey
+	} else if FindPasskeyByKeyID(m.KeyID) != nil {
+		return errors.New("passkey: already registered")
+	}
+
+	return Db().Create(m).Error
+}
+
+// Save updates the record in the database or inserts a new record if it does not exist yet.
+func (m *Passkey) Save() error {
+	return UnscopedDb().Save(m).Error
+}
+
+// Delete deletes the passkey.
+func (m *Passkey) Delete() error {
+	if m == nil {
+		return fmt.Errorf("entity is nil")
+	} else if !rnd.IsUID(m.PasskeyUID, PasskeyUID) {
+		return fmt.Errorf("invalid passkey uid")
+	}
+
+	return UnscopedDb().Delete(m).Error
+}
+
+// Updates multiple properties in the database.
+func (m *Passkey) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).Updates(values).Error
+}
+
+// String returns the passkey name or uid for use in logs and reports.
+func (m *Passkey) String() string {
+	if m == nil {
+		return "<nil>"
+	} else if m.KeyName != "" {
+		return clean.Log(m.KeyName)
+	}
+
+	return m.PasskeyUID
+}
+
+// SetName changes the passkey name.
+func (m *Passkey) SetName(name string) *Passkey {
+	if name = clean.Name(name); name == "" {
+		name = "Passkey"
+	}
+
+	m.KeyName = txt.Clip(name, txt.ClipName)
+
+	return m
+}
+
+// Descriptor returns the credential descriptor for the WebAuthn options.
+func (m *Passkey) Descriptor() webauthn.CredentialDescriptor {
+	result := webauthn.CredentialDescriptor{Type: webauthn.CredentialType, ID: m.KeyID}
+
+	if m.Transports != "" {
+		result.Transports = strings.Split(m.Transports, ",")
+	}
+
+	return result
+}
+
+// Verify checks the assertion with the public key of the passkey and updates the signature counter.
+func (m *Passkey) Verify(a webauthn.Assertion, userVerification bool) error {
+	if WebAuthn == nil {
+		return authn.ErrPasskeyNotSupported
+	} else if a.CredentialID() != m.KeyID {
+		return authn.ErrInvalidPasskey
+	}
+
+	signCount, err := WebAuthn.VerifyAssertion(m.UserUID, a, m.PublicKey, m.SignCount, userVerification)
+
+	if err != nil {
+		return err
+	}
+
+	m.SignCount = signCount
+	m.LastUsedAt = TimeStamp()
+
+	return m.Updates(Map{"SignCount": m.SignCount, "LastUsedAt": m.LastUsedAt})
+}
+
+// Descriptors returns the credential descriptors for the WebAuthn options.
+func (m Passkeys) Descriptors() []webauthn.CredentialDescriptor {
+	result := make([]webauthn.CredentialDescriptor, len(m))
+
+	for i := range m {
+		result[i] = m[i].Descriptor()
+	}
+
+	return result
+}
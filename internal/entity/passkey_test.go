@@ -0,0 +1,190 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/webauthn"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+const testPasskeyOrigin = "http://localhost:2342"
+
+// newTestPasskey registers a new passkey for the specified user and returns it together with the authenticator.
+func newTestPasskey(t *testing.T, user *User) (*Passkey, *webauthn.Authenticator) {
+	a, err := webauthn.NewAuthenticator()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt, err := WebAuthn.CreationOptions(user.UserUID, user.UserName, user.DisplayName, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	att, err := a.Create(testPasskeyOrigin, opt)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cred, err := WebAuthn.VerifyRegistration(user.UserUID, att)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewPasskey(user.UserUID, "Security Key", cred)
+
+	if err = m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	return m, a
+}
+
+// newTestAssertion returns a signed assertion for the specified user.
+func newTestAssertion(t *testing.T, a *webauthn.Authenticator, userUid string, allow Passkeys) *webauthn.Assertion {
+	opt, err := WebAuthn.RequestOptions(userUid, allow.Descriptors())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := a.Get(testPasskeyOrigin, opt, userUid)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &result
+}
+
+func TestNewPasskey(t *testing.T) {
+	m := NewPasskey("uqxetse3cy5eo9z2", "  ", &webauthn.Credential{ID: []byte{1, 2, 3}, Alg: webauthn.AlgES256, Transports: []string{"usb", "nfc"}})
+
+	assert.Equal(t, "uqxetse3cy5eo9z2", m.UserUID)
+	assert.Equal(t, "AQID", m.KeyID)
+	assert.Equal(t, "Passkey", m.KeyName)
+	assert.Equal(t, int64(webauthn.AlgES256), m.KeyAlg)
+	assert.Equal(t, "usb,nfc", m.Transports)
+	assert.Equal(t, []string{"usb", "nfc"}, m.Descriptor().Transports)
+	assert.Error(t, m.Create())
+}
+
+func TestPasskey_Verify(t *testing.T) {
+	WebAuthn = webauthn.NewRelyingParty("localhost", "PhotoPrism", testPasskeyOrigin)
+	defer func() { WebAuthn = nil }()
+
+	user := UserFixtures.Pointer("alice")
+	m, a := newTestPasskey(t, user)
+
+	defer m.Delete()
+
+	t.Run("Success", func(t *testing.T) {
+		assert.True(t, rnd.IsUID(m.PasskeyUID, PasskeyUID))
+		assert.Equal(t, m.PasskeyUID, FindPasskey(m.PasskeyUID).PasskeyUID)
+		assert.Equal(t, m.PasskeyUID, FindPasskeyByKeyID(m.KeyID).PasskeyUID)
+		assert.Len(t, user.Passkeys(), 1)
+
+		assert.NoError(t, m.Verify(*newTestAssertion(t, a, user.UserUID, user.Passkeys()), true))
+		assert.Equal(t, uint32(1), m.SignCount)
+		assert.NotNil(t, m.LastUsedAt)
+
+		if found, err := user.VerifyPasskey(newTestAssertion(t, a, user.UserUID, nil)); assert.NoError(t, err) {
+			assert.Equal(t, m.PasskeyUID, found.PasskeyUID)
+			assert.Equal(t, uint32(2), found.SignCount)
+		}
+	})
+	t.Run("AlreadyRegistered", func(t *testing.T) {
+		dup := *m
+		dup.PasskeyUID = ""
+		assert.Error(t, dup.Create())
+	})
+	t.Run("Replay", func(t *testing.T) {
+		assertion := newTestAssertion(t, a, user.UserUID, nil)
+		assert.NoError(t, m.Verify(*assertion, true))
+		assert.Error(t, m.Verify(*assertion, true))
+	})
+	t.Run("WrongUser", func(t *testing.T) {
+		_, err := UserFixtures.Pointer("bob").VerifyPasskey(newTestAssertion(t, a, user.UserUID, nil))
+		assert.ErrorIs(t, err, authn.ErrPasskeyNotFound)
+	})
+}
+
+func TestAuthPasskey(t *testing.T) {
+	WebAuthn = webauthn.NewRelyingParty("localhost", "PhotoPrism", testPasskeyOrigin)
+	defer func() { WebAuthn = nil }()
+
+	user := UserFixtures.Pointer("alice")
+	m, a := newTestPasskey(t, user)
+
+	defer m.Delete()
+
+	t.Run("Success", func(t *testing.T) {
+		// Discoverable credentials can be used without specifying a username.
+		opt, err := WebAuthn.RequestOptions("", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertion, err := a.Get(testPasskeyOrigin, opt, user.UserUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f := form.Login{Passkey: &assertion}
+
+		authUser, provider, method, err := AuthPasskey(f, nil, nil)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, user.UserUID, authUser.UserUID)
+			assert.Equal(t, authn.ProviderLocal, provider)
+			assert.Equal(t, authn.MethodPasskey, method)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, _, _, err := AuthPasskey(form.Login{Passkey: &webauthn.Assertion{ID: "AQID"}}, nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthPasscode_Passkey(t *testing.T) {
+	WebAuthn = webauthn.NewRelyingParty("localhost", "PhotoPrism", testPasskeyOrigin)
+	defer func() { WebAuthn = nil }()
+
+	user := FindUserByName("2fa")
+
+	if user == nil {
+		t.Fatal("user not found")
+	}
+
+	m, a := newTestPasskey(t, user)
+
+	defer m.Delete()
+
+	t.Run("Required", func(t *testing.T) {
+		method, err := AuthPasscode(user, form.Login{Username: "2fa"}, nil, nil)
+		assert.Equal(t, authn.Method2FA, method)
+		assert.ErrorIs(t, err, authn.ErrPasscodeRequired)
+	})
+	t.Run("Success", func(t *testing.T) {
+		method, err := AuthPasscode(user, form.Login{Username: "2fa", Passkey: newTestAssertion(t, a, user.UserUID, user.Passkeys())}, nil, nil)
+		assert.Equal(t, authn.Method2FA, method)
+		assert.NoError(t, err)
+	})
+	t.Run("Replay", func(t *testing.T) {
+		assertion := newTestAssertion(t, a, user.UserUID, nil)
+		_, err := AuthPasscode(user, form.Login{Username: "2fa", Passkey: assertion}, nil, nil)
+		assert.NoError(t, err)
+		_, err = AuthPasscode(user, form.Login{Username: "2fa", Passkey: assertion}, nil, nil)
+		assert.ErrorIs(t, err, authn.ErrInvalidPasskey)
+	})
+}
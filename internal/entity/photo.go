@@ -56,6 +56,8 @@ type Photo struct {
 	OriginalName     string        `gorm:"type:VARBINARY(755);" json:"OriginalName" yaml:"OriginalName,omitempty"`
 	PhotoStack       int8          `json:"Stack" yaml:"Stack,omitempty"`
 	PhotoFavorite    bool          `json:"Favorite" yaml:"Favorite,omitempty"`
+	PhotoRating      int8          `json:"Rating" yaml:"Rating,omitempty"`
+	RatingSrc        string        `gorm:"type:VARBINARY(8);" json:"RatingSrc" yaml:"RatingSrc,omitempty"`
 	PhotoPrivate     bool          `json:"Private" yaml:"Private,omitempty"`
 	PhotoScan        bool          `json:"Scan" yaml:"Scan,omitempty"`
 	PhotoPanorama    bool          `json:"Panorama" yaml:"Panorama,omitempty"`
@@ -142,6 +144,7 @@ func NewUserPhoto(stackable bool, userUid string) Photo {
 // SavePhotoForm saves a model in the database using form data.
 func SavePhotoForm(model Photo, form form.Photo) error {
 	locChanged := model.PhotoLat != form.PhotoLat || model.PhotoLng != form.PhotoLng || model.PhotoCountry != form.PhotoCountry
+	ratingChanged := model.PhotoRating != form.PhotoRating
 
 	if err := deepcopier.Copy(&model).From(form); err != nil {
 		return err
@@ -160,6 +163,12 @@ func SavePhotoForm(model Photo, form form.Photo) error {
 
 	model.UpdateDateFields()
 
+	// Update star rating source if changed.
+	if ratingChanged {
+		model.PhotoRating = ClampRating(int(form.PhotoRating))
+		model.RatingSrc = SrcManual
+	}
+
 	details := model.GetDetails()
 
 	if form.Details.PhotoID == model.ID {
@@ -280,6 +289,11 @@ func (m *Photo) Create() error {
 		return err
 	}
 
+	// Record the user who owns the photo, if any.
+	if m.CreatedBy != "" {
+		FirstOrCreatePhotoUser(NewPhotoUser(m.PhotoUID, m.CreatedBy, "", PermAll))
+	}
+
 	return nil
 }
 
@@ -532,7 +546,7 @@ func (m *Photo) PreloadAlbums() {
 	q := Db().NewScope(nil).DB().
 		Table("albums").
 		Select(`albums.*`).
-		Joins("JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = ? AND pa.hidden = 0", m.PhotoUID).
+		Joins("JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = ? AND pa.hidden = FALSE", m.PhotoUID).
 		Where("albums.deleted_at IS NULL").
 		Order("albums.album_title ASC")
 
@@ -726,7 +740,7 @@ func (m *Photo) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND file_missing = 0", m.ID).
+		Where("photo_id = ? AND file_missing = FALSE", m.ID).
 		Count(&count).Error; err != nil {
 		log.Error(err)
 	}
@@ -832,6 +846,10 @@ func (m *Photo) DeletePermanently() (files Files, err error) {
 		log.Errorf("index: %s (remove albums)", logErr)
 	}
 
+	if logErr := UnscopedDb().Delete(PhotoEmbedding{}, "photo_id = ?", m.ID).Error; logErr != nil {
+		log.Errorf("index: %s (remove embedding)", logErr)
+	}
+
 	return files, UnscopedDb().Delete(m).Error
 }
 
@@ -943,6 +961,12 @@ func (m *Photo) Approve() error {
 		return err
 	}
 
+	// Show album entries that have been waiting for approval.
+	if err := UnscopedDb().Model(&PhotoAlbum{}).Where("photo_uid = ? AND review = ?", m.PhotoUID, true).
+		UpdateColumns(Map{"hidden": false, "review": false}).Error; err != nil {
+		return err
+	}
+
 	// Update precalculated photo and file counts.
 	if err := UpdateCounts(); err != nil {
 		log.Warnf("index: %s (update counts)", err)
@@ -976,7 +1000,7 @@ func (m *Photo) SetPrimary(fileUid string) (err error) {
 	if fileUid != "" {
 		// Do nothing.
 	} else if err = Db().Model(File{}).
-		Where("photo_uid = ? AND file_type IN (?) AND file_missing = 0 AND file_error = ''", m.PhotoUID, media.PreviewExpr).
+		Where("photo_uid = ? AND file_type IN (?) AND file_missing = FALSE AND file_error = ''", m.PhotoUID, media.PreviewExpr).
 		Order("file_width DESC, file_hdr DESC").Limit(1).
 		Pluck("file_uid", &files).Error; err != nil {
 		return err
@@ -13,6 +13,7 @@ type PhotoAlbum struct {
 	Order     int       `json:"Order" yaml:"Order,omitempty"`
 	Hidden    bool      `json:"Hidden" yaml:"Hidden,omitempty"`
 	Missing   bool      `json:"Missing" yaml:"Missing,omitempty"`
+	Review    bool      `json:"Review" yaml:"Review,omitempty"`
 	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
 	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
 	Photo     *Photo    `gorm:"PRELOAD:false" yaml:"-"`
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -76,6 +77,66 @@ func (m *Photo) SetTakenAt(taken, local time.Time, zone, source string) {
 	m.UpdateDateFields()
 }
 
+// ShiftTakenAt adds the specified offset to the photo date, e.g. to correct the wrong clock of a camera,
+// and re-derives the local time from the time zone at the photo location, if known.
+func (m *Photo) ShiftTakenAt(offset time.Duration, source string) error {
+	if m.TakenAt.IsZero() || m.TakenAt.Year() < 1000 {
+		return fmt.Errorf("photo %s has no date", m.String())
+	}
+
+	taken := m.TakenAt.Add(offset).UTC().Truncate(time.Second)
+
+	if taken.Year() < 1000 || taken.Year() > txt.YearMax {
+		return fmt.Errorf("shifted date of photo %s is out of range", m.String())
+	}
+
+	// Set UTC time and date source.
+	m.TakenAt = taken
+	m.TakenAtLocal = m.TakenAtLocal.Add(offset).Truncate(time.Second)
+	m.TakenSrc = source
+
+	// Apply the time zone of the photo location, if any.
+	if zone := m.GetTimeZone(); zone != time.UTC.String() && zone != m.TimeZone {
+		if m.TimeZone == "" {
+			// Local time is known, set UTC time based on the new time zone.
+			m.TimeZone = zone
+			m.TakenAt = m.GetTakenAt()
+		} else {
+			// UTC time is known, set local time based on the new time zone.
+			m.TimeZone = zone
+			m.TakenAtLocal = m.GetTakenAtLocal()
+		}
+	}
+
+	m.UpdateDateFields()
+
+	return nil
+}
+
+// SaveTakenAt writes the photo date and time zone to the database and updates the file search index.
+func (m *Photo) SaveTakenAt() error {
+	if !m.HasID() {
+		return fmt.Errorf("photo id is missing")
+	}
+
+	if err := m.Updates(Map{
+		"taken_at":       m.TakenAt,
+		"taken_at_local": m.TakenAtLocal,
+		"taken_src":      m.TakenSrc,
+		"time_zone":      m.TimeZone,
+		"photo_year":     m.PhotoYear,
+		"photo_month":    m.PhotoMonth,
+		"photo_day":      m.PhotoDay,
+	}); err != nil {
+		return err
+	}
+
+	// Regenerate file search index, which is sorted by the local time.
+	File{PhotoID: m.ID, PhotoUID: m.PhotoUID}.RegenerateIndex()
+
+	return nil
+}
+
 // TimeZoneUTC tests if the current time zone is UTC.
 func (m *Photo) TimeZoneUTC() bool {
 	return strings.EqualFold(m.TimeZone, time.UTC.String())
@@ -338,3 +338,113 @@ func TestPhoto_UpdateTimeZone(t *testing.T) {
 		assert.Equal(t, "Europe/Berlin", photo.TimeZone)
 	})
 }
+
+func TestPhoto_ShiftTakenAt(t *testing.T) {
+	t.Run("NoTimeZone", func(t *testing.T) {
+		m := Photo{ID: 1, TakenAt: time.Date(2019, 12, 31, 23, 30, 0, 0, time.UTC), TakenAtLocal: time.Date(2019, 12, 31, 23, 30, 0, 0, time.UTC), TakenSrc: SrcMeta}
+
+		assert.NoError(t, m.ShiftTakenAt(time.Hour, SrcBatch))
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, SrcBatch, m.TakenSrc)
+		assert.Equal(t, "", m.TimeZone)
+		assert.Equal(t, 2020, m.PhotoYear)
+		assert.Equal(t, 1, m.PhotoMonth)
+		assert.Equal(t, 1, m.PhotoDay)
+	})
+	t.Run("ExistingTimeZone", func(t *testing.T) {
+		m := Photo{ID: 1, TakenAt: time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC), TakenAtLocal: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC), TakenSrc: SrcMeta, TimeZone: "Europe/Berlin"}
+
+		assert.NoError(t, m.ShiftTakenAt(-90*time.Minute, SrcBatch))
+		assert.Equal(t, time.Date(2020, 6, 1, 8, 30, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2020, 6, 1, 10, 30, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+	})
+	t.Run("LocationTimeZone", func(t *testing.T) {
+		m := Photo{ID: 1, PhotoLat: 52.52, PhotoLng: 13.405, TakenAt: time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC), TakenAtLocal: time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC), TakenSrc: SrcMeta, TimeZone: "UTC"}
+
+		assert.NoError(t, m.ShiftTakenAt(time.Hour, SrcBatch))
+		assert.Equal(t, time.Date(2020, 6, 1, 11, 0, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2020, 6, 1, 13, 0, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+	})
+	t.Run("LocationLocalTime", func(t *testing.T) {
+		m := Photo{ID: 1, PhotoLat: 52.52, PhotoLng: 13.405, TakenAt: time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC), TakenAtLocal: time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC), TakenSrc: SrcMeta}
+
+		assert.NoError(t, m.ShiftTakenAt(time.Hour, SrcBatch))
+		assert.Equal(t, time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2020, 6, 1, 11, 0, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+	})
+	t.Run("NoDate", func(t *testing.T) {
+		m := Photo{ID: 1}
+		assert.Error(t, m.ShiftTakenAt(time.Hour, SrcBatch))
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		m := Photo{ID: 1, TakenAt: time.Date(1001, 1, 1, 0, 0, 0, 0, time.UTC), TakenAtLocal: time.Date(1001, 1, 1, 0, 0, 0, 0, time.UTC)}
+		assert.Error(t, m.ShiftTakenAt(-24*time.Hour*366, SrcBatch))
+		assert.Equal(t, time.Date(1001, 1, 1, 0, 0, 0, 0, time.UTC), m.TakenAt)
+	})
+}
+
+func TestPhoto_SaveTakenAt(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo19")
+		orig := PhotoFixtures.Get("Photo19")
+
+		if err := m.ShiftTakenAt(2*time.Hour, SrcBatch); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.SaveTakenAt(); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindPhoto(Photo{PhotoUID: m.PhotoUID})
+
+		if found == nil {
+			t.Fatal("photo not found")
+		}
+
+		assert.Equal(t, SrcBatch, found.TakenSrc)
+		assert.Equal(t, m.TakenAt.UTC(), found.TakenAt.UTC())
+
+		// Restore original values.
+		if err := orig.SaveTakenAt(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("FileIndex", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+		orig := PhotoFixtures.Get("Photo04")
+
+		if err := m.ShiftTakenAt(3*time.Hour, SrcBatch); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.SaveTakenAt(); err != nil {
+			t.Fatal(err)
+		}
+
+		var files Files
+
+		if err := UnscopedDb().Where("photo_id = ?", m.ID).Find(&files).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, files)
+
+		for _, f := range files {
+			assert.Equal(t, m.TakenAtLocal.UTC(), f.PhotoTakenAt.UTC())
+		}
+
+		// Restore original values.
+		if err := orig.SaveTakenAt(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("NoID", func(t *testing.T) {
+		m := Photo{}
+		assert.Error(t, m.SaveTakenAt())
+	})
+}
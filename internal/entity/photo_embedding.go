@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+)
+
+// PhotoEmbedding represents the image embedding of a picture that is used for semantic search.
+type PhotoEmbedding struct {
+	PhotoID   uint      `gorm:"primary_key;auto_increment:false" json:"-" yaml:"-"`
+	PhotoUID  string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	ModelName string    `gorm:"type:VARBINARY(64);" json:"ModelName" yaml:"ModelName,omitempty"`
+	Embedding []byte    `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"-"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (PhotoEmbedding) TableName() string {
+	return "photos_embeddings"
+}
+
+// NewPhotoEmbedding returns a new image embedding for the specified picture.
+func NewPhotoEmbedding(photoID uint, photoUID, modelName string, embedding clip.Embedding) *PhotoEmbedding {
+	return &PhotoEmbedding{
+		PhotoID:   photoID,
+		PhotoUID:  photoUID,
+		ModelName: modelName,
+		Embedding: embedding.Bytes(),
+	}
+}
+
+// FindPhotoEmbedding returns the image embedding of a picture, or nil if none exists.
+func FindPhotoEmbedding(photoID uint) *PhotoEmbedding {
+	result := PhotoEmbedding{}
+
+	if err := UnscopedDb().Where("photo_id = ?", photoID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Vector returns the decoded embedding.
+func (m *PhotoEmbedding) Vector() (clip.Embedding, error) {
+	return clip.ParseEmbedding(m.Embedding)
+}
+
+// Save inserts a new row or updates the existing row in the database.
+func (m *PhotoEmbedding) Save() error {
+	if m.PhotoID == 0 || m.PhotoUID == "" {
+		return fmt.Errorf("photo id and uid must not be empty")
+	} else if len(m.Embedding) == 0 {
+		return fmt.Errorf("embedding must not be empty")
+	}
+
+	return UnscopedDb().Save(m).Error
+}
+
+// Delete removes the embedding from the database.
+func (m *PhotoEmbedding) Delete() error {
+	return UnscopedDb().Delete(PhotoEmbedding{}, "photo_id = ?", m.PhotoID).Error
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+)
+
+func TestPhotoEmbedding_Save(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photo := PhotoFixtures.Get("Photo01")
+		vector := clip.NewEmbedding([]float32{1, 2, 3})
+
+		m := NewPhotoEmbedding(photo.ID, photo.PhotoUID, "clip", vector)
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Replace existing embedding.
+		m.ModelName = "clip-vit"
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindPhotoEmbedding(photo.ID)
+
+		if result == nil {
+			t.Fatal("embedding not found")
+		}
+
+		assert.Equal(t, photo.PhotoUID, result.PhotoUID)
+		assert.Equal(t, "clip-vit", result.ModelName)
+
+		v, err := result.Vector()
+
+		assert.NoError(t, err)
+		assert.Equal(t, vector, v)
+
+		assert.NoError(t, result.Delete())
+		assert.Nil(t, FindPhotoEmbedding(photo.ID))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Error(t, NewPhotoEmbedding(0, "", "clip", clip.Embedding{1}).Save())
+		assert.Error(t, NewPhotoEmbedding(1, "ps6sg6be2lvl0y11", "clip", nil).Save())
+	})
+}
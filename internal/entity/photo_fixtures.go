@@ -111,6 +111,8 @@ var PhotoFixtures = PhotoMap{
 		PhotoName:        "Photo01",
 		OriginalName:     "",
 		PhotoFavorite:    true,
+		PhotoRating:      4,
+		RatingSrc:        SrcXmp,
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    false,
@@ -1068,6 +1070,8 @@ var PhotoFixtures = PhotoMap{
 		PhotoName:        "Quality1FavoriteTrue",
 		OriginalName:     "",
 		PhotoFavorite:    true,
+		PhotoRating:      5,
+		RatingSrc:        SrcManual,
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    false,
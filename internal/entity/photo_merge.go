@@ -13,7 +13,7 @@ var photoMergeMutex = sync.Mutex{}
 func (m *Photo) ResolvePrimary() error {
 	var file File
 
-	if err := Db().Where("file_primary = 1 AND photo_id = ?", m.ID).
+	if err := Db().Where("file_primary = TRUE AND photo_id = ?", m.ID).
 		Order("file_width DESC, file_hdr DESC").
 		First(&file).Error; err == nil && file.ID > 0 {
 		return file.ResolvePrimary()
@@ -87,6 +87,32 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 		return Photo{}, merged, err
 	}
 
+	original, merged, err = mergePhotos(identical)
+
+	if original.ID != m.ID {
+		deleted := Now()
+		m.DeletedAt = &deleted
+		m.PhotoQuality = -1
+	}
+
+	return original, merged, err
+}
+
+// MergePhotos stacks the specified photos by moving their files to the first photo,
+// e.g. to combine visually similar pictures. The other photos are flagged as deleted.
+func MergePhotos(photos Photos) (original Photo, merged Photos, err error) {
+	photoMergeMutex.Lock()
+	defer photoMergeMutex.Unlock()
+
+	if len(photos) < 2 {
+		return Photo{}, merged, nil
+	}
+
+	return mergePhotos(photos)
+}
+
+// mergePhotos moves the files of all photos to the first photo and flags the others as deleted.
+func mergePhotos(identical Photos) (original Photo, merged Photos, err error) {
 	logResult := func(res *gorm.DB) {
 		if res.Error != nil {
 			log.Errorf("merge: %s", res.Error.Error())
@@ -103,7 +129,7 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 
 		deleted := Now()
 
-		logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = FALSE WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
 		logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", Now(), merge.ID))
 
 		switch DbDialect() {
@@ -115,6 +141,10 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
 			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
 			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+		case Postgres:
+			logResult(UnscopedDb().Exec("UPDATE photos_keywords SET photo_id = ? WHERE photo_id = ? AND keyword_id NOT IN (SELECT keyword_id FROM photos_keywords WHERE photo_id = ?)", original.ID, merge.ID, original.ID))
+			logResult(UnscopedDb().Exec("UPDATE photos_labels SET photo_id = ? WHERE photo_id = ? AND label_id NOT IN (SELECT label_id FROM photos_labels WHERE photo_id = ?)", original.ID, merge.ID, original.ID))
+			logResult(UnscopedDb().Exec("UPDATE photos_albums SET photo_uid = ? WHERE photo_uid = ? AND album_uid NOT IN (SELECT album_uid FROM photos_albums WHERE photo_uid = ?)", original.PhotoUID, merge.PhotoUID, original.PhotoUID))
 		default:
 			log.Warnf("sql: unsupported dialect %s", DbDialect())
 		}
@@ -125,12 +155,6 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 		merged = append(merged, merge)
 	}
 
-	if original.ID != m.ID {
-		deleted := Now()
-		m.DeletedAt = &deleted
-		m.PhotoQuality = -1
-	}
-
 	File{PhotoID: original.ID, PhotoUID: original.PhotoUID}.RegenerateIndex()
 
 	return original, merged, err
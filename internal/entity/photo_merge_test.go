@@ -95,3 +95,50 @@ func TestPhoto_Merge(t *testing.T) {
 		assert.Equal(t, 1000024, int(merged[0].ID))
 	})
 }
+
+func TestMergePhotos(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photos := make(Photos, 2)
+
+		for i := range photos {
+			photos[i] = NewPhoto(true)
+
+			if err := photos[i].Create(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		file := &File{PhotoID: photos[1].ID, PhotoUID: photos[1].PhotoUID, FileName: "merge-photos-test.jpg", FileRoot: RootOriginals, FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		original, merged, err := MergePhotos(photos)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, photos[0].ID, original.ID)
+		assert.Len(t, merged, 1)
+		assert.Equal(t, photos[1].ID, merged[0].ID)
+
+		found := File{}
+
+		if err = UnscopedDb().First(&found, "id = ?", file.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, photos[0].ID, found.PhotoID)
+		assert.Equal(t, photos[0].PhotoUID, found.PhotoUID)
+		assert.False(t, found.FilePrimary)
+	})
+	t.Run("Single", func(t *testing.T) {
+		original, merged, err := MergePhotos(Photos{PhotoFixtures.Get("Photo23")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(0), original.ID)
+		assert.Empty(t, merged)
+	})
+}
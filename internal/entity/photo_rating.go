@@ -0,0 +1,41 @@
+package entity
+
+// Star rating limits.
+const (
+	RatingMin = 0
+	RatingMax = 5
+)
+
+// ClampRating returns a valid star rating from 0 to 5.
+func ClampRating(rating int) int8 {
+	switch {
+	case rating < RatingMin:
+		return RatingMin
+	case rating > RatingMax:
+		return RatingMax
+	default:
+		return int8(rating)
+	}
+}
+
+// HasRating checks if the photo has a star rating.
+func (m *Photo) HasRating() bool {
+	return m.PhotoRating > RatingMin
+}
+
+// SetRating changes the star rating unless the current rating has a higher priority.
+// A rating of 0 removes an existing rating, while values outside the range are ignored.
+func (m *Photo) SetRating(rating int, source string) {
+	if rating < RatingMin || rating > RatingMax {
+		return
+	} else if rating == RatingMin && !m.HasRating() {
+		return
+	}
+
+	if SrcPriority[source] < SrcPriority[m.RatingSrc] {
+		return
+	}
+
+	m.PhotoRating = int8(rating)
+	m.RatingSrc = source
+}
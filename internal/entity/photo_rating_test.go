@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClampRating(t *testing.T) {
+	assert.Equal(t, int8(0), ClampRating(-1))
+	assert.Equal(t, int8(0), ClampRating(0))
+	assert.Equal(t, int8(3), ClampRating(3))
+	assert.Equal(t, int8(5), ClampRating(5))
+	assert.Equal(t, int8(5), ClampRating(9))
+}
+
+func TestPhoto_HasRating(t *testing.T) {
+	t.Run("False", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo03")
+		assert.False(t, m.HasRating())
+	})
+	t.Run("True", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		assert.True(t, m.HasRating())
+	})
+}
+
+func TestPhoto_SetRating(t *testing.T) {
+	t.Run("Unrated", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		assert.Equal(t, int8(4), m.PhotoRating)
+		m.SetRating(0, SrcManual)
+		assert.Equal(t, int8(0), m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+		m.SetRating(3, SrcXmp)
+		assert.Equal(t, int8(0), m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+	t.Run("UnratedLowerPriority", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		m.SetRating(0, SrcMeta)
+		assert.Equal(t, int8(4), m.PhotoRating)
+		assert.Equal(t, SrcXmp, m.RatingSrc)
+	})
+	t.Run("UnratedNoRating", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo03")
+		src := m.RatingSrc
+		m.SetRating(0, SrcXmp)
+		assert.Equal(t, int8(0), m.PhotoRating)
+		assert.Equal(t, src, m.RatingSrc)
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		m.SetRating(-1, SrcManual)
+		assert.Equal(t, int8(4), m.PhotoRating)
+	})
+	t.Run("LowerPriority", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		m.SetRating(2, SrcMeta)
+		assert.Equal(t, int8(4), m.PhotoRating)
+		assert.Equal(t, SrcXmp, m.RatingSrc)
+	})
+	t.Run("HigherPriority", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		m.SetRating(2, SrcManual)
+		assert.Equal(t, int8(2), m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+	t.Run("NoRating", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo03")
+		m.SetRating(3, SrcMeta)
+		assert.Equal(t, int8(3), m.PhotoRating)
+		assert.Equal(t, SrcMeta, m.RatingSrc)
+	})
+}
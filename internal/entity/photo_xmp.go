@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var photoXmpMutex = sync.Mutex{}
+
+// XmpFileName returns the XMP sidecar file name in the originals folder. If the photo already
+// has an XMP sidecar file, it is used so that existing metadata can be merged.
+func (m *Photo) XmpFileName(originalsPath string) (absolute, relative string) {
+	if m.HasID() {
+		f := File{}
+
+		if err := Db().Where("photo_id = ? AND file_type = ? AND file_root = ? AND file_missing = FALSE AND deleted_at IS NULL",
+			m.ID, fs.SidecarXMP, RootOriginals).Order("file_name").First(&f).Error; err == nil && f.FileName != "" {
+			return filepath.Join(originalsPath, f.FileName), f.FileName
+		}
+	}
+
+	relative = filepath.Join(m.PhotoPath, m.PhotoName) + fs.ExtXMP
+
+	return filepath.Join(originalsPath, relative), relative
+}
+
+// XmpData returns the photo metadata that should be written to XMP sidecar files.
+func (m *Photo) XmpData() meta.Data {
+	details := m.GetDetails()
+
+	data := meta.Data{
+		Title:       m.PhotoTitle,
+		Description: m.PhotoDescription,
+		Artist:      details.Artist,
+		Copyright:   details.Copyright,
+		Subject:     strings.Join(m.SubjectNames(), ", "),
+		Rating:      int(m.PhotoRating),
+		Favorite:    m.PhotoFavorite,
+	}
+
+	// Only write dates that have not been estimated.
+	if m.TakenSrc != SrcAuto && m.TakenSrc != SrcEstimate {
+		data.TakenAt = m.TakenAt
+		data.TakenAtLocal = m.TakenAtLocal
+		data.TimeZone = m.TimeZone
+	}
+
+	// Only write positions that have not been estimated.
+	if m.HasLatLng() && m.PlaceSrc != SrcAuto && m.PlaceSrc != SrcEstimate {
+		data.Lat = m.PhotoLat
+		data.Lng = m.PhotoLng
+	}
+
+	var keywords []string
+
+	for _, w := range strings.Split(details.Keywords, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			keywords = append(keywords, w)
+		}
+	}
+
+	// Only export labels that have been added manually or imported from XMP, not those
+	// that have been generated automatically, e.g. by image classification.
+	if m.HasID() {
+		var labels PhotoLabels
+
+		if err := Db().Where("photo_id = ? AND uncertainty < 100 AND label_src IN (?)", m.ID, []string{SrcManual, SrcXmp}).
+			Preload("Label").Find(&labels).Error; err != nil {
+			log.Warnf("photo: %s (find labels)", err)
+		}
+
+		for _, l := range labels {
+			if l.Label != nil && l.Label.LabelName != "" {
+				keywords = append(keywords, l.Label.LabelName)
+			}
+		}
+	}
+
+	data.Keywords = txt.UniqueWords(keywords)
+
+	return data
+}
+
+// SaveSidecarXmp writes the photo metadata to an XMP sidecar file in the originals folder,
+// merging it with existing values. The original media files are never modified.
+func (m *Photo) SaveSidecarXmp(originalsPath string) error {
+	if m == nil {
+		return fmt.Errorf("photo entity is nil - you may have found a bug")
+	} else if m.PhotoName == "" {
+		return fmt.Errorf("photo name is empty")
+	} else if m.PhotoUID == "" {
+		return fmt.Errorf("photo uid is empty")
+	}
+
+	// Get photo XMP sidecar filename.
+	fileName, relName := m.XmpFileName(originalsPath)
+
+	var action string
+
+	if fs.FileExists(fileName) {
+		action = "update"
+	} else {
+		action = "create"
+	}
+
+	data := m.XmpData()
+
+	photoXmpMutex.Lock()
+	defer photoXmpMutex.Unlock()
+
+	// Write photo metadata to XMP sidecar file.
+	if err := data.SaveXMP(fileName); err != nil {
+		log.Warnf("photo: %s (%s %s)", err, action, clean.Log(relName))
+		return err
+	} else {
+		log.Infof("photo: %sd sidecar file %s", action, clean.Log(relName))
+	}
+
+	return nil
+}
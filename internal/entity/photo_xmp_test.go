@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+func TestPhoto_XmpFileName(t *testing.T) {
+	t.Run("Existing", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		fileName, relative := m.XmpFileName("xxx")
+		assert.Equal(t, "xxx/2790/02/Photo01.xmp", fileName)
+		assert.Equal(t, "2790/02/Photo01.xmp", relative)
+	})
+	t.Run("New", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+		fileName, relative := m.XmpFileName("xxx")
+		assert.Equal(t, "xxx/Germany/bridge.xmp", fileName)
+		assert.Equal(t, "Germany/bridge.xmp", relative)
+	})
+}
+
+func TestPhoto_XmpData(t *testing.T) {
+	t.Run("Photo01", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		data := m.XmpData()
+
+		assert.Equal(t, "photo description non-photographic", data.Description)
+		assert.Equal(t, 4, data.Rating)
+		assert.True(t, data.Favorite)
+		assert.Equal(t, m.PhotoLat, data.Lat)
+		assert.Equal(t, m.PhotoLng, data.Lng)
+	})
+	t.Run("Keywords", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		// Labels generated by image classification must not be exported.
+		assert.Equal(t, meta.Keywords{"frog", "nature"}, m.XmpData().Keywords)
+
+		flower := LabelFixtures.Get("flower")
+		label := NewPhotoLabel(m.ID, flower.ID, 0, SrcManual)
+
+		if err := label.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer label.Delete()
+
+		assert.Equal(t, meta.Keywords{"flower", "frog", "nature"}, m.XmpData().Keywords)
+	})
+}
+
+func TestPhoto_SaveSidecarXmp(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		originalsPath := t.TempDir()
+		m := PhotoFixtures.Get("Photo04")
+
+		if err := os.MkdirAll(filepath.Join(originalsPath, m.PhotoPath), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.SaveSidecarXmp(originalsPath); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := meta.XMP(filepath.Join(originalsPath, "Germany/bridge.xmp"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.PhotoTitle, data.Title)
+		assert.Equal(t, m.PhotoFavorite, data.Favorite)
+		assert.Equal(t, m.TakenAt.UTC(), data.TakenAt.UTC())
+	})
+	t.Run("NoPhotoUID", func(t *testing.T) {
+		m := Photo{PhotoName: "foo"}
+		assert.Error(t, m.SaveSidecarXmp(t.TempDir()))
+	})
+}
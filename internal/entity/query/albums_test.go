@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestAlbumByUID(t *testing.T) {
@@ -110,6 +112,21 @@ func TestAlbums(t *testing.T) {
 	})
 }
 
+func TestSmartAlbums(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		results, err := SmartAlbums()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, a := range results {
+			assert.Equal(t, entity.AlbumSmart, a.AlbumType)
+			assert.NotEmpty(t, a.AlbumFilter)
+		}
+	})
+}
+
 func TestAlbumsByUID(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		results, err := AlbumsByUID([]string{"as6sg6bxpogaaba7", "as6sg6bxpogaaba8"}, false)
@@ -0,0 +1,49 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoComments returns the comments on a photo, oldest first.
+func PhotoComments(photoUid string) (results entity.Comments, err error) {
+	results = entity.Comments{}
+
+	if photoUid == "" {
+		return results, nil
+	}
+
+	err = Db().Where("photo_uid = ?", photoUid).Order("created_at, comment_uid").Find(&results).Error
+
+	return results, err
+}
+
+// AlbumComments returns the comments on an album, oldest first.
+func AlbumComments(albumUid string) (results entity.Comments, err error) {
+	results = entity.Comments{}
+
+	if albumUid == "" {
+		return results, nil
+	}
+
+	err = Db().Where("album_uid = ?", albumUid).Order("created_at, comment_uid").Find(&results).Error
+
+	return results, err
+}
+
+// PhotoShareUID returns the uid of the shared album that contains the photo,
+// or an empty string if the photo is not part of any of the specified albums.
+func PhotoShareUID(photoUid string, shared []string) string {
+	if photoUid == "" || len(shared) == 0 {
+		return ""
+	}
+
+	result := entity.PhotoAlbum{}
+
+	if err := UnscopedDb().
+		Where("photo_uid = ? AND album_uid IN (?) AND hidden = ?", photoUid, shared, false).
+		Order("album_uid").First(&result).Error; err != nil {
+		return ""
+	}
+
+	return result.AlbumUID
+}
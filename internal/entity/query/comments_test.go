@@ -0,0 +1,35 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoComments(t *testing.T) {
+	results, err := PhotoComments("ps6sg6be2lvl0yh8")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(results), 1)
+	assert.Equal(t, "What a beautiful place!", results[0].CommentText)
+}
+
+func TestAlbumComments(t *testing.T) {
+	results, err := AlbumComments("as6sg6bxpogaaba8")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(results), 2)
+	assert.Equal(t, "Thanks for sharing!", results[0].CommentText)
+}
+
+func TestPhotoShareUID(t *testing.T) {
+	assert.Equal(t, "as6sg6bxpogaaba8", PhotoShareUID("ps6sg6be2lvl0yh7", []string{"as6sg6bxpogaaba8"}))
+	assert.Equal(t, "", PhotoShareUID("ps6sg6be2lvl0yh7", []string{"as6sg6bxpogaaba7"}))
+	assert.Equal(t, "", PhotoShareUID("ps6sg6be2lvl0yh7", nil))
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/search"
+	"github.com/photoprism/photoprism/internal/entity/sortby"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/pkg/media"
 )
@@ -34,13 +37,13 @@ func UpdateAlbumDefaultCovers() (err error) {
         	GROUP BY pa.album_uid) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			) b ON b.album_uid = albums.album_uid
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
-	case SQLite3:
+	case SQLite3, Postgres:
 		res = Db().Table(entity.Album{}.TableName()).
 			UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f 
-			JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = f.photo_uid AND pa.hidden = 0 AND pa.missing = 0
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
-			WHERE f.deleted_at IS NULL AND f.file_missing = 0 AND f.file_hash <> '' AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
+			JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = f.photo_uid AND pa.hidden = FALSE AND pa.missing = FALSE
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = FALSE AND p.deleted_at IS NULL AND p.photo_quality > 0
+			WHERE f.deleted_at IS NULL AND f.file_missing = FALSE AND f.file_hash <> '' AND f.file_primary = TRUE AND f.file_error = '' AND f.file_type IN (?)
 			ORDER BY p.taken_at DESC LIMIT 1
 		) WHERE ?`, media.PreviewExpr, condition))
 	default:
@@ -81,14 +84,14 @@ func UpdateAlbumFolderCovers() (err error) {
 			GROUP BY p.photo_path) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			) b ON b.photo_path = albums.album_path
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
-	case SQLite3:
+	case SQLite3, Postgres:
 		res = Db().Table(entity.Album{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f,(
 			SELECT p.photo_path, max(p.id) AS photo_id FROM photos p
-			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			  WHERE p.photo_quality > 0 AND p.photo_private = FALSE AND p.deleted_at IS NULL
 			  GROUP BY p.photo_path
 			) b
-		WHERE f.photo_id = b.photo_id  AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
+		WHERE f.photo_id = b.photo_id  AND f.file_primary = TRUE AND f.file_error = '' AND f.file_type IN (?)
 		AND b.photo_path = albums.album_path LIMIT 1)
 		WHERE ?`, media.PreviewExpr, condition))
 	default:
@@ -129,14 +132,14 @@ func UpdateAlbumMonthCovers() (err error) {
 			GROUP BY p.photo_year, p.photo_month) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
 			) b ON b.photo_year = albums.album_year AND b.photo_month = albums.album_month
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
-	case SQLite3:
+	case SQLite3, Postgres:
 		res = Db().Table(entity.Album{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f,(
 			SELECT p.photo_year, p.photo_month, max(p.id) AS photo_id FROM photos p
-			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			  WHERE p.photo_quality > 0 AND p.photo_private = FALSE AND p.deleted_at IS NULL
 			  GROUP BY p.photo_year, p.photo_month
 			) b
-		WHERE f.photo_id = b.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
+		WHERE f.photo_id = b.photo_id AND f.file_primary = TRUE AND f.file_error = '' AND f.file_type IN (?)
 		AND b.photo_year = albums.album_year AND b.photo_month = albums.album_month LIMIT 1)
 		WHERE ?`, media.PreviewExpr, condition))
 	default:
@@ -157,6 +160,56 @@ func UpdateAlbumMonthCovers() (err error) {
 	return err
 }
 
+// UpdateAlbumSmartCovers updates smart album cover thumbs based on their search filters.
+func UpdateAlbumSmartCovers() (err error) {
+	mutex.Index.Lock()
+	defer mutex.Index.Unlock()
+
+	start := time.Now()
+
+	albums, err := SmartAlbums()
+
+	if err != nil {
+		return err
+	}
+
+	updated := 0
+
+	for _, a := range albums {
+		if a.ThumbSrc != entity.SrcAuto {
+			continue
+		}
+
+		thumb := ""
+
+		// Use the newest public picture that matches the filter.
+		if results, _, searchErr := search.Photos(form.SearchPhotos{Scope: a.AlbumUID, Count: 1, Order: sortby.Newest, Public: true}); searchErr != nil {
+			log.Warnf("covers: %s (smart album %s)", searchErr, a.AlbumUID)
+			continue
+		} else if len(results) > 0 {
+			thumb = results[0].FileHash
+		}
+
+		if thumb == a.Thumb {
+			continue
+		}
+
+		if err = UnscopedDb().Model(&entity.Album{}).Where("id = ?", a.ID).UpdateColumn("thumb", thumb).Error; err != nil {
+			return err
+		}
+
+		updated++
+	}
+
+	if updated > 0 {
+		entity.FlushAlbumCache()
+	}
+
+	log.Debugf("covers: updated %s [%s]", english.Plural(updated, "smart album", "smart albums"), time.Since(start))
+
+	return nil
+}
+
 // UpdateAlbumCovers updates album cover thumbs.
 func UpdateAlbumCovers() (err error) {
 	// Update Default Albums.
@@ -174,6 +227,11 @@ func UpdateAlbumCovers() (err error) {
 		return err
 	}
 
+	// Update Smart Albums.
+	if err = UpdateAlbumSmartCovers(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -205,12 +263,12 @@ func UpdateLabelCovers() (err error) {
 			) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?) AND f.file_missing = 0
 		) b ON b.label_id = labels.id
 		SET thumb = b.file_hash WHERE ?`, media.PreviewExpr, condition)
-	case SQLite3:
+	case SQLite3, Postgres:
 		res = Db().Table(entity.Label{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f 
 			JOIN photos_labels pl ON pl.label_id = labels.id AND pl.photo_id = f.photo_id AND pl.uncertainty < 100
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
-			WHERE f.deleted_at IS NULL AND f.file_hash <> '' AND f.file_missing = 0 AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = FALSE AND p.deleted_at IS NULL AND p.photo_quality > 0
+			WHERE f.deleted_at IS NULL AND f.file_hash <> '' AND f.file_missing = FALSE AND f.file_primary = TRUE AND f.file_error = '' AND f.file_type IN (?)
 			ORDER BY p.photo_quality DESC, pl.uncertainty ASC, p.taken_at DESC LIMIT 1
 		) WHERE ?`, media.PreviewExpr, condition))
 
@@ -219,8 +277,8 @@ func UpdateLabelCovers() (err error) {
 			SELECT f.file_hash FROM files f 
 			JOIN photos_labels pl ON pl.photo_id = f.photo_id AND pl.uncertainty < 100
 			JOIN categories c ON c.label_id = pl.label_id AND c.category_id = labels.id
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
-			WHERE f.deleted_at IS NULL AND f.file_hash <> '' AND f.file_missing = 0 AND f.file_primary = 1 AND f.file_error = '' AND f.file_type IN (?)
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = FALSE AND p.deleted_at IS NULL AND p.photo_quality > 0
+			WHERE f.deleted_at IS NULL AND f.file_hash <> '' AND f.file_missing = FALSE AND f.file_primary = TRUE AND f.file_error = '' AND f.file_type IN (?)
 			ORDER BY p.photo_quality DESC, pl.uncertainty ASC, p.taken_at DESC LIMIT 1
 			) WHERE thumb IS NULL`, media.PreviewExpr))
 
@@ -258,12 +316,12 @@ func UpdateSubjectCovers(public bool) (err error) {
 	// see https://github.com/photoprism/photoprism/issues/4238
 	// and https://github.com/photoprism/photoprism/issues/2570#issuecomment-1231690056
 	if public {
-		photosJoin = gorm.Expr("p.id = f.photo_id AND p.deleted_at IS NULL AND p.photo_private = 0")
+		photosJoin = gorm.Expr("p.id = f.photo_id AND p.deleted_at IS NULL AND p.photo_private = FALSE")
 	} else {
 		photosJoin = gorm.Expr("p.id = f.photo_id AND p.deleted_at IS NULL")
 	}
 
-	condition := gorm.Expr("subjects.subj_type = ? AND thumb_src = ?", entity.SubjPerson, entity.SrcAuto)
+	condition := gorm.Expr("subjects.subj_type IN (?, ?) AND thumb_src = ?", entity.SubjPerson, entity.SubjPet, entity.SrcAuto)
 
 	// Compose SQL update query.
 	switch DbDialect() {
@@ -281,7 +339,7 @@ func UpdateSubjectCovers(public bool) (err error) {
 			photosJoin,
 			condition,
 		)
-	case SQLite3:
+	case SQLite3, Postgres:
 		// from := gorm.Expr(fmt.Sprintf("%s m WHERE m.subj_uid = %s.subj_uid ", markerTable, subjTable))
 		res = Db().Table(entity.Subject{}.TableName()).UpdateColumn("thumb",
 			gorm.Expr(`(
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestUpdateAlbumDefaultCovers(t *testing.T) {
@@ -18,6 +20,28 @@ func TestUpdateAlbumMonthCovers(t *testing.T) {
 	assert.NoError(t, UpdateAlbumMonthCovers())
 }
 
+func TestUpdateAlbumSmartCovers(t *testing.T) {
+	album, err := entity.NewSmartAlbum("Smart Cover Test", "public:true", "")
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer album.DeletePermanently()
+
+	assert.NoError(t, UpdateAlbumSmartCovers())
+
+	result, err := AlbumByUID(album.AlbumUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, result.Thumb)
+}
+
 func TestUpdateAlbumCovers(t *testing.T) {
 	assert.NoError(t, UpdateAlbumCovers())
 }
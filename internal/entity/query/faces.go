@@ -61,6 +61,17 @@ func Faces(knownOnly, unmatchedOnly, hidden, ignored bool) (result entity.Faces,
 	return result, err
 }
 
+// PetFaces returns all visible pet clusters.
+func PetFaces() (result entity.Faces, err error) {
+	err = Db().
+		Where("face_kind = ?", int(face.PetFace)).
+		Where("face_hidden = ?", false).
+		Order("subj_uid, samples DESC").
+		Find(&result).Error
+
+	return result, err
+}
+
 // ManuallyAddedFaces returns all manually added face clusters.
 func ManuallyAddedFaces(hidden, ignored bool) (result entity.Faces, err error) {
 	stmt := Db().
@@ -87,7 +98,7 @@ func MatchFaceMarkers() (affected int64, err error) {
 
 	for _, f := range faces {
 		if res := Db().Model(&entity.Marker{}).
-			Where("marker_invalid = 0").
+			Where("marker_invalid = FALSE").
 			Where("face_id = ?", f.ID).
 			Where("subj_src = ?", entity.SrcAuto).
 			Where("subj_uid <> ?", f.SubjUID).
@@ -128,7 +139,7 @@ func CountNewFaceMarkers(size, score int) (n int) {
 
 	q := Db().Model(&entity.Markers{}).
 		Where("marker_type = ?", entity.MarkerFace).
-		Where("face_id = '' AND marker_invalid = 0 AND embeddings_json <> ''")
+		Where("face_id = '' AND marker_invalid = FALSE AND embeddings_json <> ''")
 
 	if size > 0 {
 		q = q.Where("size >= ?", size)
@@ -258,11 +269,7 @@ func ResolveFaceCollisions() (conflicts, resolved int, err error) {
 			}
 
 			// Compare face 1 with face 2.
-			if matched, dist := f1.Match(face.Embeddings{f2.Embedding()}); matched {
-				if f1.SubjUID == f2.SubjUID {
-					continue
-				}
-
+			if collision, dist := f1.MatchCollision(f2); collision {
 				conflicts++
 
 				r := f1.SampleRadius + face.MatchDist
@@ -318,8 +325,8 @@ func RemovePeopleAndFaces() (err error) {
 		return err
 	}
 
-	// Delete all faces.
-	if err = UnscopedDb().Delete(entity.Face{}).Error; err != nil {
+	// Delete all faces except pets.
+	if err = UnscopedDb().Delete(entity.Face{}, "face_kind <> ?", int(face.PetFace)).Error; err != nil {
 		return err
 	}
 
@@ -59,6 +59,19 @@ func TestFaces(t *testing.T) {
 	})
 }
 
+func TestPetFaces(t *testing.T) {
+	results, err := PetFaces()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, val := range results {
+		assert.True(t, val.Pet())
+		assert.False(t, val.FaceHidden)
+	}
+}
+
 func TestManuallyAddedFaces(t *testing.T) {
 	t.Run("Ok", func(t *testing.T) {
 		results, err := ManuallyAddedFaces(false, false)
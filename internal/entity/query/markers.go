@@ -7,6 +7,7 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/pet"
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
@@ -50,7 +51,7 @@ func Markers(limit, offset int, markerType string, embeddings, subjects bool, ma
 func UnmatchedFaceMarkers(limit, offset int, matchedBefore *time.Time) (result entity.Markers, err error) {
 	db := Db().
 		Where("marker_type = ?", entity.MarkerFace).
-		Where("marker_invalid = 0").
+		Where("marker_invalid = FALSE").
 		Where("embeddings_json <> ''")
 
 	if matchedBefore == nil {
@@ -66,6 +67,22 @@ func UnmatchedFaceMarkers(limit, offset int, matchedBefore *time.Time) (result e
 	return result, err
 }
 
+// PetMarkers returns valid pet markers with embeddings sorted by id, optionally only those that are unmatched.
+func PetMarkers(limit, offset int, unmatched bool) (result entity.Markers, err error) {
+	db := Db().
+		Where("marker_type = ?", entity.MarkerPet).
+		Where("marker_invalid = FALSE").
+		Where("embeddings_json <> ''")
+
+	if unmatched {
+		db = db.Where("matched_at IS NULL")
+	}
+
+	err = db.Order("marker_uid").Limit(limit).Offset(offset).Find(&result).Error
+
+	return result, err
+}
+
 // FaceMarkers returns all face markers sorted by id.
 func FaceMarkers(limit, offset int) (result entity.Markers, err error) {
 	err = Db().
@@ -78,12 +95,22 @@ func FaceMarkers(limit, offset int) (result entity.Markers, err error) {
 
 // Embeddings returns existing face embeddings.
 func Embeddings(single, unclustered bool, size, score int) (result face.Embeddings, err error) {
+	return markerEmbeddings(entity.MarkerFace, single, unclustered, size, score)
+}
+
+// PetEmbeddings returns existing pet embeddings.
+func PetEmbeddings(unclustered bool) (result face.Embeddings, err error) {
+	return markerEmbeddings(entity.MarkerPet, true, unclustered, pet.SizeThreshold, pet.ScoreThreshold)
+}
+
+// markerEmbeddings returns the existing embeddings of markers with the specified type.
+func markerEmbeddings(markerType string, single, unclustered bool, size, score int) (result face.Embeddings, err error) {
 	var col []string
 
 	stmt := Db().
 		Model(&entity.Marker{}).
-		Where("marker_type = ?", entity.MarkerFace).
-		Where("marker_invalid = 0").
+		Where("marker_type = ?", markerType).
+		Where("marker_invalid = FALSE").
 		Where("embeddings_json <> ''").
 		Order("marker_uid")
 
@@ -126,7 +153,7 @@ func Embeddings(single, unclustered bool, size, score int) (result face.Embeddin
 func RemoveInvalidMarkerReferences() (removed int64, err error) {
 	result := Db().
 		Model(&entity.Marker{}).
-		Where("marker_invalid = 1 AND (subj_uid <> '' OR face_id <> '')").
+		Where("marker_invalid = TRUE AND (subj_uid <> '' OR face_id <> '')").
 		UpdateColumns(entity.Map{"subj_uid": "", "face_id": "", "face_dist": -1.0, "matched_at": nil})
 
 	return result.RowsAffected, result.Error
@@ -210,6 +237,37 @@ func MarkersWithSubjectConflict() (results entity.Markers, err error) {
 	return results, err
 }
 
+// FaceMarkerStats represents the number of markers matched with a face cluster and their distance to it.
+type FaceMarkerStats struct {
+	FaceID  string
+	Markers int
+	AvgDist float64
+	MaxDist float64
+}
+
+// FaceMarkerStatsByID returns the match statistics of valid markers, with the face ID as key.
+func FaceMarkerStatsByID() (result map[string]FaceMarkerStats, err error) {
+	var rows []FaceMarkerStats
+
+	if err = Db().Model(&entity.Marker{}).
+		Select("face_id, COUNT(*) AS markers, " +
+			"COALESCE(AVG(CASE WHEN face_dist >= 0 THEN face_dist END), -1) AS avg_dist, " +
+			"COALESCE(MAX(face_dist), -1) AS max_dist").
+		Where("face_id <> '' AND marker_invalid = FALSE").
+		Group("face_id").
+		Scan(&rows).Error; err != nil {
+		return result, err
+	}
+
+	result = make(map[string]FaceMarkerStats, len(rows))
+
+	for _, row := range rows {
+		result[row.FaceID] = row
+	}
+
+	return result, nil
+}
+
 // ResetFaceMarkerMatches removes automatically added subject and face references from the markers table.
 func ResetFaceMarkerMatches() (removed int64, err error) {
 	res := Db().Model(&entity.Marker{}).
@@ -222,7 +280,7 @@ func ResetFaceMarkerMatches() (removed int64, err error) {
 // CountUnmatchedFaceMarkers counts the number of unmatched face markers in the index.
 func CountUnmatchedFaceMarkers() (n int) {
 	q := Db().Model(&entity.Markers{}).
-		Where("matched_at IS NULL AND marker_invalid = 0 AND embeddings_json <> ''").
+		Where("matched_at IS NULL AND marker_invalid = FALSE AND embeddings_json <> ''").
 		Where("marker_type = ?", entity.MarkerFace)
 
 	if err := q.Count(&n).Error; err != nil {
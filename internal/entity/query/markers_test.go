@@ -151,6 +151,20 @@ func TestEmbeddings(t *testing.T) {
 	})
 }
 
+func TestPetEmbeddings(t *testing.T) {
+	t.Run("Unclustered", func(t *testing.T) {
+		results, err := PetEmbeddings(true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, val := range results {
+			assert.IsType(t, face.Embedding{}, val)
+		}
+	})
+}
+
 func TestRemoveInvalidMarkerReferences(t *testing.T) {
 	affected, err := RemoveInvalidMarkerReferences()
 
@@ -196,6 +210,22 @@ func TestMarkersWithSubjectConflict(t *testing.T) {
 	assert.GreaterOrEqual(t, len(m), 0)
 }
 
+func TestFaceMarkerStatsByID(t *testing.T) {
+	result, err := FaceMarkerStatsByID()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, result)
+
+	for id, stats := range result {
+		assert.Equal(t, id, stats.FaceID)
+		assert.GreaterOrEqual(t, stats.Markers, 1)
+		assert.GreaterOrEqual(t, stats.MaxDist, stats.AvgDist)
+	}
+}
+
 func TestCountUnmatchedFaceMarkers(t *testing.T) {
 	n := CountUnmatchedFaceMarkers()
 
@@ -201,7 +201,7 @@ func MomentsTime(threshold int, public bool) (results Moments, err error) {
 
 	// Ignore private pictures?
 	if public {
-		stmt = stmt.Where("photo_private = 0")
+		stmt = stmt.Where("photo_private = FALSE")
 	}
 
 	stmt = stmt.Group("photos.photo_year, photos.photo_month").
@@ -223,7 +223,7 @@ func MomentsCountries(threshold int, public bool) (results Moments, err error) {
 
 	// Ignore private pictures?
 	if public {
-		stmt = stmt.Where("photo_private = 0")
+		stmt = stmt.Where("photo_private = FALSE")
 	}
 
 	stmt = stmt.Group("photo_year, photo_country").
@@ -245,7 +245,7 @@ func MomentsStates(threshold int, public bool) (results Moments, err error) {
 
 	// Ignore private pictures?
 	if public {
-		stmt = stmt.Where("photo_private = 0")
+		stmt = stmt.Where("photo_private = FALSE")
 	}
 
 	stmt = stmt.Group("p.place_country, p.place_state").
@@ -276,7 +276,7 @@ func MomentsLabels(threshold int, public bool) (results Moments, err error) {
 
 	// Ignore private pictures?
 	if public {
-		stmt = stmt.Where("photo_private = 0")
+		stmt = stmt.Where("photo_private = FALSE")
 	}
 
 	stmt = stmt.Group("l.label_slug").
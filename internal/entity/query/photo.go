@@ -77,7 +77,7 @@ func PhotoPreloadByUID(photoUID string) (photo entity.Photo, err error) {
 func MissingPhotos(limit int, offset int) (entities entity.Photos, err error) {
 	err = Db().
 		Select("photos.*").
-		Where("id NOT IN (SELECT photo_id FROM files WHERE file_missing = 0 AND file_root = '/' AND deleted_at IS NULL)").
+		Where("id NOT IN (SELECT photo_id FROM files WHERE file_missing = FALSE AND file_root = '/' AND deleted_at IS NULL)").
 		Order("photos.id").
 		Limit(limit).Offset(offset).Find(&entities).Error
 
@@ -116,6 +116,41 @@ func PhotosMetadataUpdate(limit, offset int, delay, interval time.Duration) (pho
 	return photos, err
 }
 
+// PhotosByCameraSerial returns the photos taken with the camera that has the specified serial number,
+// optionally limited to a range of local times.
+func PhotosByCameraSerial(serial string, after, before time.Time) (photos entity.Photos, err error) {
+	if serial == "" {
+		return photos, fmt.Errorf("camera serial is empty")
+	}
+
+	stmt := Db().Where("camera_serial = ?", serial)
+
+	if !after.IsZero() {
+		stmt = stmt.Where("taken_at_local >= ?", after)
+	}
+
+	if !before.IsZero() {
+		stmt = stmt.Where("taken_at_local <= ?", before)
+	}
+
+	err = stmt.Order("taken_at_local, photo_uid").Find(&photos).Error
+
+	return photos, err
+}
+
+// PhotosWithoutLocation returns photos taken in the specified time range that have no coordinates, or only
+// estimated coordinates, so that their position can be determined based on tracklogs.
+func PhotosWithoutLocation(after, before time.Time) (photos entity.Photos, err error) {
+	err = Db().
+		Where("taken_at BETWEEN ? AND ?", after, before).
+		Where("taken_src NOT IN (?)", []string{entity.SrcAuto, entity.SrcEstimate}).
+		Where("(photo_lat = 0 AND photo_lng = 0) OR place_src IN (?)", []string{entity.SrcAuto, entity.SrcEstimate, entity.SrcTrack}).
+		Order("taken_at, photo_uid").
+		Find(&photos).Error
+
+	return photos, err
+}
+
 // OrphanPhotos finds orphan index entries that may be removed.
 func OrphanPhotos() (photos entity.Photos, err error) {
 	err = UnscopedDb().
@@ -139,7 +174,7 @@ func FixPrimaries() error {
 
 	// Remove primary file flag from broken or missing files.
 	if err := UnscopedDb().Table(entity.File{}.TableName()).
-		Where("(file_error <> '' OR file_missing = 1) AND file_primary <> 0").
+		Where("(file_error <> '' OR file_missing = TRUE) AND file_primary <> FALSE").
 		UpdateColumn("file_primary", 0).Error; err != nil {
 		return err
 	}
@@ -148,7 +183,7 @@ func FixPrimaries() error {
 	if err := UnscopedDb().
 		Raw(`SELECT * FROM photos 
 			WHERE deleted_at IS NULL 
-			AND id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1)`).
+			AND id NOT IN (SELECT photo_id FROM files WHERE file_primary = TRUE)`).
 		Find(&photos).Error; err != nil {
 		return err
 	}
@@ -188,7 +223,7 @@ func FlagHiddenPhotos() (err error) {
 
 	// Find and flag hidden photos.
 	if err = Db().Table(entity.Photo{}.TableName()).
-		Where("id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND file_missing = 0 AND file_error = '' AND deleted_at IS NULL) AND photo_quality > -1").
+		Where("id NOT IN (SELECT photo_id FROM files WHERE file_primary = TRUE AND file_missing = FALSE AND file_error = '' AND deleted_at IS NULL) AND photo_quality > -1").
 		Pluck("id", &hidden).Error; err != nil {
 		// Find query failed.
 		return err
@@ -94,6 +94,66 @@ func TestPhotosMetadataUpdate(t *testing.T) {
 	assert.IsType(t, entity.Photos{}, result)
 }
 
+func TestPhotosByCameraSerial(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		result, err := PhotosByCameraSerial("123", time.Time{}, time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "ps6sg6be2lvl0y12", result[0].PhotoUID)
+	})
+	t.Run("Range", func(t *testing.T) {
+		result, err := PhotosByCameraSerial("123", time.Date(2015, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2015, 11, 1, 0, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		result, err := PhotosByCameraSerial("123", time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+	t.Run("EmptySerial", func(t *testing.T) {
+		_, err := PhotosByCameraSerial("", time.Time{}, time.Time{})
+		assert.Error(t, err)
+	})
+}
+
+func TestPhotosWithoutLocation(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		result, err := PhotosWithoutLocation(time.Date(2008, 7, 1, 9, 0, 0, 0, time.UTC), time.Date(2008, 7, 1, 11, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(result), 1)
+
+		for _, p := range result {
+			assert.True(t, p.NoLatLng() || p.PlaceSrc == entity.SrcEstimate || p.PlaceSrc == entity.SrcAuto)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := PhotosWithoutLocation(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1900, 1, 2, 0, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+}
+
 func TestOrphanPhotos(t *testing.T) {
 	result, err := OrphanPhotos()
 
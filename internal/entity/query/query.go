@@ -33,8 +33,9 @@ import (
 var log = event.Log
 
 const (
-	MySQL   = "mysql"
-	SQLite3 = "sqlite3"
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite3  = "sqlite3"
 )
 
 // Cols represents a list of database columns.
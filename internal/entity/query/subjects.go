@@ -25,7 +25,7 @@ func PeopleCount() (count int, err error) {
 	err = Db().
 		Table(entity.Subject{}.TableName()).
 		Where("deleted_at IS NULL").
-		Where("subj_hidden = 0").
+		Where("subj_hidden = FALSE").
 		Where("subj_type = ?", entity.SubjPerson).
 		Count(&count).Error
 
@@ -78,8 +78,8 @@ func CreateMarkerSubjects() (affected int64, err error) {
 
 	if err := Db().
 		Where("subj_uid = '' AND marker_name <> '' AND subj_src <> ?", entity.SrcAuto).
-		Where("marker_invalid = 0 AND marker_type = ?", entity.MarkerFace).
-		Order("marker_name").
+		Where("marker_invalid = FALSE AND marker_type IN (?)", []string{entity.MarkerFace, entity.MarkerPet}).
+		Order("marker_type, marker_name").
 		Find(&markers).Error; err != nil {
 		return affected, err
 	} else if len(markers) == 0 {
@@ -90,9 +90,9 @@ func CreateMarkerSubjects() (affected int64, err error) {
 	var subj *entity.Subject
 
 	for _, m := range markers {
-		if name == m.MarkerName && subj != nil {
+		if name == m.MarkerName && subj != nil && subj.SubjType == m.SubjType() {
 			// Do nothing.
-		} else if subj = entity.NewSubject(m.MarkerName, entity.SubjPerson, entity.SrcMarker); subj == nil {
+		} else if subj = entity.NewSubject(m.MarkerName, m.SubjType(), entity.SrcMarker); subj == nil {
 			log.Errorf("faces: invalid subject %s", clean.Log(m.MarkerName))
 			continue
 		} else if subj = entity.FirstOrCreateSubject(subj); subj == nil {
@@ -205,4 +205,63 @@ func TestAlbums(t *testing.T) {
 
 		assert.Equal(t, 2, len(result))
 	})
+	t.Run("Smart", func(t *testing.T) {
+		album, err := entity.NewSmartAlbum("Smart Search Test", "label:flower", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		album.CachedCount = 7
+
+		if err = album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer album.DeletePermanently()
+
+		f := form.SearchAlbums{
+			Query: "smart search test",
+			Type:  entity.AlbumManual,
+			Count: 10,
+		}
+
+		result, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, entity.AlbumSmart, result[0].AlbumType)
+			assert.Equal(t, 7, result[0].PhotoCount)
+		}
+	})
+}
+
+func TestUserAlbums(t *testing.T) {
+	defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+	f := form.SearchAlbums{Type: entity.AlbumManual, Count: 1000}
+	sess := entity.SessionFixtures.Pointer("bob")
+
+	shared, err := UserAlbums(f, sess)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, shared)
+
+	t.Run("UserLibrary", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryUser
+
+		albums, err := UserAlbums(f, sess)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, albums)
+	})
 }
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterObject(t *testing.T) {
+	t.Run("Car", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Object = "car"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, "ps6sg6be2lvl0y18", photos[0].PhotoUID)
+	})
+	t.Run("CarPipeBicycle", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Object = "Bicycle | CAR"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Object = "airplane"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("QueryCar", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "object:car"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+}
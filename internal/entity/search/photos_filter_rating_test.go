@@ -0,0 +1,101 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity/sortby"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterRating(t *testing.T) {
+	t.Run("5", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Rating = "5"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, r := range photos {
+			assert.Equal(t, int8(5), r.PhotoRating)
+		}
+	})
+	t.Run("3-5", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Rating = "3-5"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 2)
+
+		for _, r := range photos {
+			assert.GreaterOrEqual(t, r.PhotoRating, int8(3))
+			assert.LessOrEqual(t, r.PhotoRating, int8(5))
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Rating = "foo"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, len(photos), 2)
+	})
+	t.Run("QueryRating4-5", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "rating:4-5"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, r := range photos {
+			assert.GreaterOrEqual(t, r.PhotoRating, int8(4))
+		}
+	})
+	t.Run("OrderByRating", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Order = sortby.Rating
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(photos) < 2 {
+			t.Fatal("at least two results expected")
+		}
+
+		assert.Equal(t, int8(5), photos[0].PhotoRating)
+		assert.GreaterOrEqual(t, photos[0].PhotoRating, photos[1].PhotoRating)
+	})
+}
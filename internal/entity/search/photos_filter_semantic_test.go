@@ -0,0 +1,93 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/sortby"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterSemantic(t *testing.T) {
+	found, _, err := Photos(form.SearchPhotos{Count: 3, Primary: true, Order: sortby.Newest})
+
+	if err != nil {
+		t.Fatal(err)
+	} else if len(found) < 3 {
+		t.Fatal("at least three pictures expected")
+	}
+
+	// Add image embeddings, the second of which best matches the query.
+	vectors := []clip.Embedding{
+		clip.NewEmbedding([]float32{1, 1, 0}),
+		clip.NewEmbedding([]float32{1, 0, 0}),
+		clip.NewEmbedding([]float32{0, 0, 1}),
+	}
+
+	for i, v := range vectors {
+		if err = entity.NewPhotoEmbedding(found[i].ID, found[i].PhotoUID, "clip", v).Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer UnscopedDb().Delete(entity.PhotoEmbedding{}, "photo_id > 0")
+
+	EmbedText = func(text string) (clip.Embedding, error) {
+		return clip.NewEmbedding([]float32{1, 0.1, 0}), nil
+	}
+
+	defer func() { EmbedText = nil }()
+
+	t.Run("Relevance", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Similar: "dog on a beach", Primary: true, Order: sortby.Relevance, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 2) {
+			assert.Equal(t, found[1].PhotoUID, photos[0].PhotoUID)
+			assert.Equal(t, found[0].PhotoUID, photos[1].PhotoUID)
+		}
+	})
+	t.Run("Query", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Query: "similar:\"dog on a beach\"", Primary: true, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 2)
+	})
+	t.Run("CombinedFilter", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Similar: "dog on a beach", UID: found[0].PhotoUID, Primary: true, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, found[0].PhotoUID, photos[0].PhotoUID)
+		}
+	})
+	t.Run("NoMatches", func(t *testing.T) {
+		EmbedText = func(text string) (clip.Embedding, error) {
+			return clip.NewEmbedding([]float32{0, -1, 0}), nil
+		}
+
+		photos, _, err := Photos(form.SearchPhotos{Similar: "cat", Primary: true, Count: 10})
+
+		assert.NoError(t, err)
+		assert.Len(t, photos, 0)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		EmbedText = nil
+
+		_, _, err := Photos(form.SearchPhotos{Similar: "dog on a beach", Primary: true, Count: 10})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,132 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/media/phash"
+)
+
+func TestPhotosFilterSimilar(t *testing.T) {
+	found, _, err := Photos(form.SearchPhotos{Count: 3, Primary: true})
+
+	if err != nil {
+		t.Fatal(err)
+	} else if len(found) < 3 {
+		t.Fatal("at least three pictures expected")
+	}
+
+	// Add perceptual hashes, two of which are similar.
+	hashes := []string{"00ff00ff00ff00ff", "00ff00ff00ff00fe", "ff00ff00ff00ff00"}
+
+	for i, h := range hashes {
+		if err = UnscopedDb().Model(&entity.File{}).Where("file_uid = ?", found[i].FileUID).UpdateColumn("file_phash", h).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer UnscopedDb().Model(&entity.File{}).Where("file_phash <> ''").UpdateColumn("file_phash", "")
+
+	t.Run("Similar", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Similar: found[0].PhotoUID, Primary: true, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 2)
+	})
+	t.Run("Query", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Query: "similar:" + found[2].PhotoUID, Primary: true, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("Candidates", func(t *testing.T) {
+		h, err := phash.Parse(hashes[0])
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files, err := SimilarHashCandidates(h, phash.DefaultDistance)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, files, 2)
+
+		all, err := SimilarHashCandidates(h, phash.MaxDistance)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, all, 3)
+	})
+	t.Run("UserLibrary", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryUser
+		defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+		bob := entity.SessionFixtures.Pointer("bob")
+
+		// Users must not find pictures similar to pictures they cannot access.
+		_, err := SimilarPhotoUIDs(found[0].PhotoUID, phash.DefaultDistance, bob)
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		// Similar pictures that the user cannot access must be excluded.
+		var photo entity.Photo
+
+		if err = UnscopedDb().Where("photo_uid = ?", found[0].PhotoUID).First(&photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err = UnscopedDb().Model(&entity.Photo{}).Where("photo_uid = ?", found[0].PhotoUID).
+			UpdateColumn("created_by", bob.UserUID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		defer UnscopedDb().Model(&entity.Photo{}).Where("photo_uid = ?", found[0].PhotoUID).UpdateColumn("created_by", photo.CreatedBy)
+
+		uids, err := SimilarPhotoUIDs(found[0].PhotoUID, phash.DefaultDistance, bob)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{found[0].PhotoUID}, uids)
+
+		// Super admins can access all pictures.
+		uids, err = SimilarPhotoUIDs(found[0].PhotoUID, phash.DefaultDistance, entity.SessionFixtures.Pointer("alice"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, uids, 2)
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		_, _, err := Photos(form.SearchPhotos{Similar: "foo", Count: 10})
+		assert.Error(t, err)
+	})
+	t.Run("Groups", func(t *testing.T) {
+		groups, err := SimilarGroups(1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, groups, 1) {
+			assert.Len(t, groups[0], 2)
+			assert.Equal(t, 0, groups[0][0].Distance)
+			assert.Equal(t, 1, groups[0][1].Distance)
+		}
+	})
+}
@@ -960,3 +960,22 @@ func TestGeo(t *testing.T) {
 		assert.LessOrEqual(t, 1, len(photos))
 	})
 }
+
+func TestUserPhotosGeo(t *testing.T) {
+	t.Run("SharedFolder", func(t *testing.T) {
+		guest := *entity.UserFixtures.Pointer("guest")
+		guest.UserShares = entity.UserShares{{UserUID: guest.UserUID, ShareUID: "as6sg6bipogaaba1", Perm: entity.PermView}}
+
+		var f form.SearchPhotosGeo
+
+		results, err := UserPhotosGeo(f, entity.NewSession(0, 0).SetUser(&guest))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.NotEmpty(t, r.PhotoUID)
+		}
+	})
+}
@@ -2123,3 +2123,122 @@ func TestPhotos(t *testing.T) {
 		}
 	})
 }
+
+func TestUserPhotos(t *testing.T) {
+	defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+	var f form.SearchPhotos
+	f.Count = 1000
+
+	sess := entity.SessionFixtures.Pointer("bob")
+
+	shared, _, err := UserPhotos(f, sess)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, shared)
+
+	t.Run("UserLibrary", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryUser
+
+		photos, _, err := UserPhotos(f, sess)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The bob fixture has not uploaded any photos, and none have been shared with this account.
+		assert.Empty(t, photos)
+	})
+	t.Run("SharedFolder", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryShared
+
+		guest := *entity.UserFixtures.Pointer("guest")
+		guest.UserShares = entity.UserShares{{UserUID: guest.UserUID, ShareUID: "as6sg6bipogaaba1", Perm: entity.PermView}}
+
+		photos, _, err := UserPhotos(f, entity.NewSession(0, 0).SetUser(&guest))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := 0
+
+		for _, p := range photos {
+			if p.PhotoPath == "1990/04" {
+				found++
+			}
+		}
+
+		// Guests can see the pictures in folders shared with them.
+		assert.Greater(t, found, 0)
+	})
+	t.Run("SuperAdmin", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryUser
+
+		photos, _, err := UserPhotos(f, entity.SessionFixtures.Pointer("alice"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, len(shared))
+	})
+}
+
+func TestPhotos_SmartAlbum(t *testing.T) {
+	defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+	bobAlbum, err := entity.NewSmartAlbum("Bob's Flowers", "label:flower", entity.UserFixtures.Pointer("bob").UserUID)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = bobAlbum.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	aliceAlbum, err := entity.NewSmartAlbum("Alice's Flowers", "label:flower", entity.UserFixtures.Pointer("alice").UserUID)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = aliceAlbum.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	guestAlbum, err := entity.NewSmartAlbum("Guest Flowers", "label:flower", entity.UserFixtures.Pointer("guest").UserUID)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = guestAlbum.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(albumUid string) PhotoResults {
+		photos, _, searchErr := Photos(form.SearchPhotos{Scope: albumUid, Count: 1000})
+
+		if searchErr != nil {
+			t.Fatal(searchErr)
+		}
+
+		return photos
+	}
+
+	t.Run("Shared", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryShared
+
+		assert.NotEmpty(t, search(bobAlbum.AlbumUID))
+		assert.NotEmpty(t, search(aliceAlbum.AlbumUID))
+
+		// Guests may only see their own and shared pictures.
+		assert.Empty(t, search(guestAlbum.AlbumUID))
+	})
+	t.Run("UserLibrary", func(t *testing.T) {
+		entity.LibraryMode = entity.LibraryUser
+
+		// The bob fixture has not uploaded any photos, and none have been shared with this account.
+		assert.Empty(t, search(bobAlbum.AlbumUID))
+		assert.NotEmpty(t, search(aliceAlbum.AlbumUID))
+	})
+}
@@ -0,0 +1,129 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// EmbedText returns the embedding of a natural-language search query,
+// and is only set if semantic search is enabled.
+var EmbedText func(text string) (clip.Embedding, error)
+
+// EmbedModel is the name of the model that computes the text embeddings. Only image
+// embeddings computed by the same model can be compared with them.
+var EmbedModel = clip.ModelName
+
+// SemanticLimit is the maximum number of pictures that can match a natural-language search.
+var SemanticLimit = 1000
+
+// SemanticMinSimilarity is the minimum cosine similarity of image and text embeddings for a picture to match.
+var SemanticMinSimilarity float32 = 0.2
+
+// semanticIndex caches the embeddings index until the indexed pictures change.
+var semanticIndex = struct {
+	sync.Mutex
+	idx     *clip.Index
+	model   string
+	count   int
+	updated string
+}{}
+
+// SemanticIndex returns the nearest neighbor index of all image embeddings computed by the
+// configured model, and rebuilds it when the model or the embeddings change.
+func SemanticIndex() (*clip.Index, error) {
+	var stats struct {
+		Count   int
+		Updated string
+	}
+
+	model := EmbedModel
+
+	if err := UnscopedDb().Table(entity.PhotoEmbedding{}.TableName()).
+		Select("COUNT(*) AS count, MAX(updated_at) AS updated").
+		Where("model_name = ?", model).
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	semanticIndex.Lock()
+	defer semanticIndex.Unlock()
+
+	if semanticIndex.idx != nil && semanticIndex.model == model && semanticIndex.count == stats.Count && semanticIndex.updated == stats.Updated {
+		return semanticIndex.idx, nil
+	}
+
+	var rows []entity.PhotoEmbedding
+
+	if err := UnscopedDb().Select("photo_uid, embedding").Where("model_name = ?", model).Order("photo_id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	idx := clip.NewIndex()
+
+	for _, row := range rows {
+		if v, err := row.Vector(); err != nil {
+			log.Debugf("search: %s in embedding of %s", err, row.PhotoUID)
+		} else {
+			idx.Add(row.PhotoUID, v)
+		}
+	}
+
+	idx.Optimize()
+
+	semanticIndex.idx = idx
+	semanticIndex.model = model
+	semanticIndex.count = stats.Count
+	semanticIndex.updated = stats.Updated
+
+	return idx, nil
+}
+
+// SemanticPhotos returns the pictures that best match a natural-language description,
+// ordered by similarity.
+func SemanticPhotos(text string, limit int, minSim float32) (matches clip.Matches, err error) {
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		return matches, fmt.Errorf("empty search text")
+	} else if EmbedText == nil {
+		return matches, fmt.Errorf("semantic search is disabled")
+	}
+
+	query, err := EmbedText(text)
+
+	if err != nil {
+		return matches, err
+	}
+
+	idx, err := SemanticIndex()
+
+	if err != nil {
+		return matches, err
+	}
+
+	return idx.Search(query, limit, minSim), nil
+}
+
+// semanticOrder returns an expression that sorts pictures in the order of the specified UIDs.
+func semanticOrder(uids []string) *gorm.SqlExpr {
+	var sql strings.Builder
+
+	values := make([]interface{}, len(uids))
+
+	sql.WriteString("CASE photos.photo_uid")
+
+	for i, uid := range uids {
+		sql.WriteString(fmt.Sprintf(" WHEN ? THEN %d", i))
+		values[i] = uid
+	}
+
+	sql.WriteString(fmt.Sprintf(" ELSE %d END", len(uids)))
+
+	return gorm.Expr(sql.String(), values...)
+}
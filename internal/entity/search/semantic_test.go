@@ -0,0 +1,77 @@
+package search
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSemanticIndex(t *testing.T) {
+	photo := entity.PhotoFixtures.Get("Photo01")
+
+	before, err := SemanticIndex()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The cached index is returned if nothing has changed.
+	cached, err := SemanticIndex()
+
+	assert.NoError(t, err)
+	assert.Same(t, before, cached)
+
+	if err = entity.NewPhotoEmbedding(photo.ID, photo.PhotoUID, "clip", clip.NewEmbedding([]float32{1, 0})).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Delete(entity.PhotoEmbedding{}, "photo_id = ?", photo.ID)
+
+	after, err := SemanticIndex()
+
+	assert.NoError(t, err)
+	assert.NotSame(t, before, after)
+	assert.Equal(t, before.Len()+1, after.Len())
+
+	// Embeddings computed by other models are ignored.
+	EmbedModel = "clip-large"
+
+	defer func() { EmbedModel = clip.ModelName }()
+
+	other, err := SemanticIndex()
+
+	assert.NoError(t, err)
+	assert.NotSame(t, after, other)
+	assert.Equal(t, 0, other.Len())
+}
+
+func TestSemanticPhotos(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		_, err := SemanticPhotos("dog", 10, 0)
+		assert.Error(t, err)
+	})
+	t.Run("EmptyText", func(t *testing.T) {
+		_, err := SemanticPhotos(" ", 10, 0)
+		assert.Error(t, err)
+	})
+	t.Run("EmbedError", func(t *testing.T) {
+		EmbedText = func(text string) (clip.Embedding, error) {
+			return nil, errors.New("model not found")
+		}
+
+		defer func() { EmbedText = nil }()
+
+		_, err := SemanticPhotos("dog", 10, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestSemanticOrder(t *testing.T) {
+	expr := semanticOrder([]string{"a", "b"})
+
+	assert.NotNil(t, expr)
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/media/phash"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// SimilarFile represents a primary file with a perceptual hash.
+type SimilarFile struct {
+	PhotoID      uint
+	PhotoUID     string
+	PhotoQuality int
+	FileUID      string
+	FileName     string
+	FileRoot     string
+	FileWidth    int
+	FileHeight   int
+	FilePHash    string `gorm:"column:file_phash"`
+	Distance     int    `gorm:"-"`
+}
+
+// Hash returns the perceptual hash of the file.
+func (m SimilarFile) Hash() phash.Hash {
+	h, _ := phash.Parse(m.FilePHash)
+	return h
+}
+
+// SimilarFiles represents a list of files with a perceptual hash.
+type SimilarFiles []SimilarFile
+
+// perceptualHashesQuery returns a query for the primary files of all pictures that have a perceptual hash.
+func perceptualHashesQuery() *gorm.DB {
+	return UnscopedDb().Table(entity.File{}.TableName()).
+		Select("files.photo_id, files.photo_uid, photos.photo_quality, files.file_uid, files.file_name, files.file_root, " +
+			"files.file_width, files.file_height, files.file_phash").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = TRUE AND files.file_missing = FALSE AND files.deleted_at IS NULL").
+		Where("files.file_phash IS NOT NULL AND files.file_phash <> ''").
+		Order("files.photo_id")
+}
+
+// PerceptualHashes returns the primary files of all pictures that have a perceptual hash.
+func PerceptualHashes() (results SimilarFiles, err error) {
+	err = perceptualHashesQuery().Scan(&results).Error
+
+	return results, err
+}
+
+// SimilarHashCandidates returns the primary files whose perceptual hash may not differ from the
+// specified hash in more than maxDist bits. The hexadecimal hash is split into maxDist+1 segments,
+// of which at least one must be identical, so that only matching candidates are loaded.
+func SimilarHashCandidates(h phash.Hash, maxDist int) (results SimilarFiles, err error) {
+	hex := h.Hex()
+	segments := maxDist + 1
+
+	// Compare all hashes if the distance is too large to be split into segments.
+	if maxDist < 0 || segments > len(hex) {
+		return PerceptualHashes()
+	}
+
+	var where []string
+	var values []interface{}
+
+	for i := 0; i < segments; i++ {
+		start := i * len(hex) / segments
+		end := (i + 1) * len(hex) / segments
+
+		where = append(where, "SUBSTR(files.file_phash, ?, ?) = ?")
+		values = append(values, start+1, end-start, hex[start:end])
+	}
+
+	err = perceptualHashesQuery().Where(strings.Join(where, " OR "), values...).Scan(&results).Error
+
+	return results, err
+}
+
+// SimilarPhotoUIDs returns the UIDs of all pictures that look similar to the specified picture,
+// including the picture itself. If a session is specified, only pictures the user may access are returned.
+func SimilarPhotoUIDs(photoUID string, maxDist int, sess *entity.Session) (uids []string, err error) {
+	if rnd.InvalidUID(photoUID, entity.PhotoUID) {
+		return uids, fmt.Errorf("invalid photo uid")
+	}
+
+	var photo entity.Photo
+
+	if err = UnscopedDb().Where("photo_uid = ?", photoUID).First(&photo).Error; err != nil {
+		return uids, err
+	} else if sess != nil && !sess.CanAccessPhoto(&photo) {
+		return uids, ErrForbidden
+	}
+
+	file, err := photo.PrimaryFile()
+
+	if err != nil {
+		return uids, err
+	}
+
+	h, err := phash.Parse(file.FilePHash)
+
+	if err != nil {
+		return []string{photoUID}, nil
+	}
+
+	files, err := SimilarHashCandidates(h, maxDist)
+
+	if err != nil {
+		return uids, err
+	}
+
+	var similar entity.Photos
+
+	for _, f := range files {
+		if f.Hash().Similar(h, maxDist) {
+			similar = append(similar, entity.Photo{ID: f.PhotoID, PhotoUID: f.PhotoUID})
+		}
+	}
+
+	// Exclude pictures that the user may not access.
+	if sess != nil {
+		similar = sess.AccessiblePhotos(similar)
+	}
+
+	return similar.UIDs(), nil
+}
+
+// SimilarGroups returns groups of visually near-identical pictures, such as bursts, re-saved
+// files, and resized copies. The first file in each group has the best quality, and the
+// distance of the other files is measured relative to it.
+func SimilarGroups(maxDist int) (groups []SimilarFiles, err error) {
+	files, err := PerceptualHashes()
+
+	if err != nil {
+		return groups, err
+	}
+
+	hashes := make([]phash.Hash, len(files))
+
+	for i := range files {
+		hashes[i] = files[i].Hash()
+	}
+
+	for _, indexes := range phash.Groups(hashes, maxDist) {
+		group := make(SimilarFiles, 0, len(indexes))
+		best := 0
+
+		for i, index := range indexes {
+			group = append(group, files[index])
+
+			if f, b := files[index], files[indexes[best]]; f.PhotoQuality > b.PhotoQuality ||
+				f.PhotoQuality == b.PhotoQuality && f.FileWidth*f.FileHeight > b.FileWidth*b.FileHeight {
+				best = i
+			}
+		}
+
+		// Move the file with the best quality to the top.
+		group[0], group[best] = group[best], group[0]
+
+		for i := range group {
+			group[i].Distance = group[i].Hash().Distance(group[0].Hash())
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
@@ -16,6 +16,7 @@ const (
 	Place       = "place"
 	Moment      = "moment"
 	Favorites   = "favorites"
+	Rating      = "rating"
 	Name        = "name"
 	NameReverse = "name_reverse"
 	Title       = "title"
@@ -3,12 +3,14 @@ package sortby
 import (
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 )
 
 const (
-	MySQL   = "mysql"
-	SQLite3 = "sqlite3"
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite3  = "sqlite3"
 )
 
 // RandomExpr returns the name of the random function depending on the SQL dialect.
@@ -18,6 +20,10 @@ func RandomExpr(dialect gorm.Dialect) *gorm.SqlExpr {
 		// A seed integer can be passed as an argument, e.g. "RAND(2342)", to generate
 		// reproducible pseudo-random values, see https://mariadb.com/kb/en/rand/.
 		return gorm.Expr("RAND()")
+	case Postgres:
+		// PostgreSQL uses a session-wide seed that can be set with "SELECT setseed(0.5)",
+		// see https://www.postgresql.org/docs/current/functions-math.html#FUNCTIONS-MATH-RANDOM-TABLE.
+		return gorm.Expr("RANDOM()")
 	case SQLite3:
 		// SQLite does not support specifying a seed to generate a deterministic sequence
 		// of pseudo-random values, see https://www.sqlite.org/lang_corefunc.html#random.
@@ -18,8 +18,10 @@ const (
 	SrcImage    = classify.SrcImage    // Prio 8
 	SrcKeyword  = classify.SrcKeyword  // Prio 16
 	SrcMeta     = "meta"               // Prio 16
+	SrcTrack    = "track"              // Prio 16
 	SrcXmp      = "xmp"                // Prio 32
 	SrcManual   = "manual"             // Prio 64
+	SrcBatch    = "batch"              // Prio 64
 	SrcAdmin    = "admin"              // Prio 128
 )
 
@@ -46,7 +48,9 @@ var SrcPriority = Priorities{
 	SrcImage:    8,
 	SrcKeyword:  16,
 	SrcMeta:     16,
+	SrcTrack:    16,
 	SrcXmp:      32,
 	SrcManual:   64,
+	SrcBatch:    64,
 	SrcAdmin:    128,
 }
@@ -0,0 +1,13 @@
+package entity
+
+const (
+	SubjPet = "pet" // SubjType for pets.
+)
+
+// SubjMarkerTypes are the subject types that can be recognized and assigned to markers.
+var SubjMarkerTypes = []string{SubjPerson, SubjPet}
+
+// IsPet tests if the subject is a pet.
+func (m *Subject) IsPet() bool {
+	return m.SubjType == SubjPet
+}
@@ -23,7 +23,7 @@ func OrphanPeople() (Subjects, error) {
 	orphans := Subjects{}
 
 	err := Db().
-		Where("subj_type = ?", SubjPerson).
+		Where("subj_type IN (?)", SubjMarkerTypes).
 		Where(fmt.Sprintf("subj_uid NOT IN (SELECT DISTINCT subj_uid FROM %s)", Marker{}.TableName())).
 		Find(&orphans).Error
 
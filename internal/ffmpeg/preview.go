@@ -1,6 +1,10 @@
 package ffmpeg
 
-import "time"
+import (
+	"fmt"
+	"os/exec"
+	"time"
+)
 
 // PreviewTimeOffset returns an appropriate time offset depending on the duration for extracting a preview image.
 func PreviewTimeOffset(d time.Duration) string {
@@ -24,3 +28,85 @@ func PreviewTimeOffset(d time.Duration) string {
 
 	return result
 }
+
+// PreviewClipCommand returns the command for creating a short muted preview clip that can be played on hover.
+func PreviewClipCommand(fileName, clipName string, videoDuration, clipDuration time.Duration, opt Options) (*exec.Cmd, error) {
+	if fileName == "" {
+		return nil, fmt.Errorf("empty input filename")
+	} else if clipName == "" {
+		return nil, fmt.Errorf("empty output filename")
+	} else if clipDuration <= 0 {
+		return nil, fmt.Errorf("invalid clip duration")
+	}
+
+	// Get configured ffmpeg command name.
+	ffmpeg := opt.Bin
+
+	// Use default ffmpeg command name?
+	if ffmpeg == "" {
+		ffmpeg = DefaultBin
+	}
+
+	mapVideo := opt.MapVideo
+
+	if mapVideo == "" {
+		mapVideo = MapVideoDefault
+	}
+
+	// Preview clips are small, so they are always created with the software encoder.
+	return exec.Command(
+		ffmpeg,
+		"-ss", PreviewTimeOffset(videoDuration),
+		"-i", fileName,
+		"-t", fmt.Sprintf("%.3f", clipDuration.Seconds()),
+		"-map", mapVideo,
+		"-an",
+		"-c:v", SoftwareEncoder.String(),
+		"-preset", "veryfast",
+		"-crf", "28",
+		"-vf", opt.VideoFilter(FormatYUV420P),
+		"-r", "30",
+		"-f", "mp4",
+		"-movflags", "+faststart",
+		"-y",
+		clipName,
+	), nil
+}
+
+// SpriteCommand returns the command for creating a sprite sheet with video frames taken
+// at the specified interval in seconds, e.g. to show thumbnails while scrubbing the timeline.
+func SpriteCommand(fileName, spriteName string, interval, columns, rows, tileWidth, tileHeight int, opt Options) (*exec.Cmd, error) {
+	if fileName == "" {
+		return nil, fmt.Errorf("empty input filename")
+	} else if spriteName == "" {
+		return nil, fmt.Errorf("empty output filename")
+	} else if interval < 1 || columns < 1 || rows < 1 || tileWidth < 1 || tileHeight < 1 {
+		return nil, fmt.Errorf("invalid sprite layout")
+	}
+
+	// Get configured ffmpeg command name.
+	ffmpeg := opt.Bin
+
+	// Use default ffmpeg command name?
+	if ffmpeg == "" {
+		ffmpeg = DefaultBin
+	}
+
+	mapVideo := opt.MapVideo
+
+	if mapVideo == "" {
+		mapVideo = MapVideoDefault
+	}
+
+	return exec.Command(
+		ffmpeg,
+		"-i", fileName,
+		"-map", mapVideo,
+		"-an",
+		"-vf", fmt.Sprintf("fps=1/%d,scale=%d:%d,tile=%dx%d", interval, tileWidth, tileHeight, columns, rows),
+		"-frames:v", "1",
+		"-q:v", "5",
+		"-y",
+		spriteName,
+	), nil
+}
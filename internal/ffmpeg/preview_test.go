@@ -17,3 +17,64 @@ func TestPreviewTimeOffset(t *testing.T) {
 	assert.Equal(t, "00:01:00.000", PreviewTimeOffset(time.Hour))
 	assert.Equal(t, "00:02:30.000", PreviewTimeOffset(3*time.Hour))
 }
+
+func TestPreviewClipCommand(t *testing.T) {
+	opt := Options{
+		Bin:      "",
+		Size:     480,
+		MapVideo: MapVideoDefault,
+		MapAudio: MapAudioDefault,
+	}
+
+	t.Run("EmptyFilename", func(t *testing.T) {
+		_, err := PreviewClipCommand("", "VID123.mov_preview.mp4", time.Minute, 3*time.Second, opt)
+		assert.EqualError(t, err, "empty input filename")
+	})
+	t.Run("EmptyClipName", func(t *testing.T) {
+		_, err := PreviewClipCommand("VID123.mov", "", time.Minute, 3*time.Second, opt)
+		assert.EqualError(t, err, "empty output filename")
+	})
+	t.Run("InvalidDuration", func(t *testing.T) {
+		_, err := PreviewClipCommand("VID123.mov", "VID123.mov_preview.mp4", time.Minute, 0, opt)
+		assert.EqualError(t, err, "invalid clip duration")
+	})
+	t.Run("Success", func(t *testing.T) {
+		r, err := PreviewClipCommand("VID123.mov", "VID123.mov_preview.mp4", time.Minute, 3*time.Second, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, r.String(), "ffmpeg -ss 00:00:03.000 -i VID123.mov -t 3.000 -map 0:v:0 -an -c:v libx264 -preset veryfast -crf 28 -vf scale='if(gte(iw,ih), min(480, iw), -2):if(gte(iw,ih), -2, min(480, ih))',format=yuv420p -r 30 -f mp4 -movflags +faststart -y VID123.mov_preview.mp4")
+	})
+}
+
+func TestSpriteCommand(t *testing.T) {
+	opt := Options{
+		Bin:      "",
+		MapVideo: MapVideoDefault,
+		MapAudio: MapAudioDefault,
+	}
+
+	t.Run("EmptyFilename", func(t *testing.T) {
+		_, err := SpriteCommand("", "sprite.jpg", 1, 10, 2, 160, 90, opt)
+		assert.EqualError(t, err, "empty input filename")
+	})
+	t.Run("EmptySpriteName", func(t *testing.T) {
+		_, err := SpriteCommand("VID123.mov", "", 1, 10, 2, 160, 90, opt)
+		assert.EqualError(t, err, "empty output filename")
+	})
+	t.Run("InvalidLayout", func(t *testing.T) {
+		_, err := SpriteCommand("VID123.mov", "sprite.jpg", 0, 10, 2, 160, 90, opt)
+		assert.EqualError(t, err, "invalid sprite layout")
+	})
+	t.Run("Success", func(t *testing.T) {
+		r, err := SpriteCommand("VID123.mov", "sprite.jpg", 2, 10, 3, 160, 90, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, r.String(), "ffmpeg -i VID123.mov -map 0:v:0 -an -vf fps=1/2,scale=160:90,tile=10x3 -frames:v 1 -q:v 5 -y sprite.jpg")
+	})
+}
@@ -0,0 +1,218 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// StreamFormat represents an adaptive video streaming format.
+type StreamFormat string
+
+// String returns the streaming format as string.
+func (f StreamFormat) String() string {
+	return string(f)
+}
+
+// Supported adaptive streaming formats.
+const (
+	StreamNone StreamFormat = ""
+	StreamHLS  StreamFormat = "hls"
+	StreamDASH StreamFormat = "dash"
+)
+
+// StreamFormats maps format names to supported adaptive streaming formats.
+var StreamFormats = map[string]StreamFormat{
+	"":         StreamNone,
+	"none":     StreamNone,
+	"false":    StreamNone,
+	"off":      StreamNone,
+	"hls":      StreamHLS,
+	"m3u8":     StreamHLS,
+	"dash":     StreamDASH,
+	"mpd":      StreamDASH,
+	"mpegdash": StreamDASH,
+}
+
+// FindStreamFormat finds an adaptive streaming format by name.
+func FindStreamFormat(s string) StreamFormat {
+	if format, ok := StreamFormats[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return format
+	}
+
+	return StreamNone
+}
+
+// PlaylistName returns the file name of the main playlist or manifest.
+func (f StreamFormat) PlaylistName() string {
+	switch f {
+	case StreamHLS:
+		return "master.m3u8"
+	case StreamDASH:
+		return "manifest.mpd"
+	default:
+		return ""
+	}
+}
+
+// StreamSegment is the segment duration in seconds.
+const StreamSegment = 4
+
+// StreamRenditionsMax is the maximum number of renditions per stream.
+const StreamRenditionsMax = 4
+
+// Rendition represents a single video size and bitrate of an adaptive stream.
+type Rendition struct {
+	Size    int
+	Bitrate string
+}
+
+// Renditions represents the video renditions of an adaptive stream.
+type Renditions []Rendition
+
+// StreamContentType returns the content type of a streaming playlist or segment file,
+// or an empty string if the file type is not supported.
+func StreamContentType(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".m3u8":
+		return "application/vnd.apple.mpegurl"
+	case ".mpd":
+		return "application/dash+xml"
+	case ".m4s":
+		return "video/iso.segment"
+	case ".mp4":
+		return "video/mp4"
+	default:
+		return ""
+	}
+}
+
+// StreamCommand returns the command for segmenting a video into adaptive streaming renditions.
+func StreamCommand(fileName, streamDir string, format StreamFormat, renditions Renditions, audio bool, opt Options) (result *exec.Cmd, err error) {
+	if fileName == "" {
+		return nil, fmt.Errorf("empty input filename")
+	} else if streamDir == "" {
+		return nil, fmt.Errorf("empty output folder")
+	} else if format.PlaylistName() == "" {
+		return nil, fmt.Errorf("unsupported streaming format")
+	} else if len(renditions) == 0 {
+		return nil, fmt.Errorf("no renditions")
+	}
+
+	// Get configured ffmpeg command name.
+	ffmpeg := opt.Bin
+
+	// Use default ffmpeg command name?
+	if ffmpeg == "" {
+		ffmpeg = DefaultBin
+	}
+
+	mapVideo := opt.MapVideo
+
+	if mapVideo == "" {
+		mapVideo = MapVideoDefault
+	}
+
+	mapAudio := strings.TrimSuffix(opt.MapAudio, "?")
+
+	if mapAudio == "" {
+		mapAudio = strings.TrimSuffix(MapAudioDefault, "?")
+	}
+
+	// Split the input video and scale it once for each rendition.
+	n := len(renditions)
+	filters := make([]string, 0, n+1)
+	splits := make([]string, n)
+
+	for i := range renditions {
+		splits[i] = fmt.Sprintf("[s%d]", i)
+	}
+
+	filters = append(filters, fmt.Sprintf("[%s]split=%d%s", mapVideo, n, strings.Join(splits, "")))
+
+	for i, r := range renditions {
+		filters = append(filters, fmt.Sprintf("[s%d]%s[v%d]", i, Options{Size: r.Size}.VideoFilter(FormatYUV420P), i))
+	}
+
+	args := []string{
+		"-i", fileName,
+		"-filter_complex", strings.Join(filters, ";"),
+	}
+
+	for i := range renditions {
+		args = append(args, "-map", fmt.Sprintf("[v%d]", i))
+	}
+
+	// HLS requires an audio stream for each variant, DASH uses a single shared audio stream.
+	if audio && format == StreamHLS {
+		for range renditions {
+			args = append(args, "-map", mapAudio)
+		}
+	} else if audio {
+		args = append(args, "-map", mapAudio)
+	}
+
+	args = append(args, "-c:v", opt.Encoder.String())
+
+	for i, r := range renditions {
+		args = append(args, fmt.Sprintf("-b:v:%d", i), r.Bitrate)
+	}
+
+	// Use the same keyframe interval for all renditions so that players can switch between them.
+	args = append(args,
+		"-r", "30",
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", StreamSegment),
+	)
+
+	if audio {
+		args = append(args, "-c:a", "aac", "-ac", "2", "-b:a", "128k")
+	}
+
+	switch format {
+	case StreamHLS:
+		streams := make([]string, n)
+
+		for i := range renditions {
+			if audio {
+				streams[i] = fmt.Sprintf("v:%d,a:%d", i, i)
+			} else {
+				streams[i] = fmt.Sprintf("v:%d", i)
+			}
+		}
+
+		args = append(args,
+			"-f", "hls",
+			"-hls_time", fmt.Sprintf("%d", StreamSegment),
+			"-hls_playlist_type", "vod",
+			"-hls_segment_type", "fmp4",
+			"-hls_flags", "independent_segments",
+			"-hls_fmp4_init_filename", "init_%v.mp4",
+			"-hls_segment_filename", filepath.Join(streamDir, "stream_%v_%05d.m4s"),
+			"-master_pl_name", format.PlaylistName(),
+			"-var_stream_map", strings.Join(streams, " "),
+			"-y",
+			filepath.Join(streamDir, "stream_%v.m3u8"),
+		)
+	case StreamDASH:
+		sets := "id=0,streams=v"
+
+		if audio {
+			sets += " id=1,streams=a"
+		}
+
+		args = append(args,
+			"-f", "dash",
+			"-seg_duration", fmt.Sprintf("%d", StreamSegment),
+			"-use_template", "1",
+			"-use_timeline", "1",
+			"-init_seg_name", "init_$RepresentationID$.m4s",
+			"-media_seg_name", "chunk_$RepresentationID$_$Number%05d$.m4s",
+			"-adaptation_sets", sets,
+			"-y",
+			filepath.Join(streamDir, format.PlaylistName()),
+		)
+	}
+
+	return exec.Command(ffmpeg, args...), nil
+}
@@ -0,0 +1,87 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindStreamFormat(t *testing.T) {
+	assert.Equal(t, StreamHLS, FindStreamFormat("hls"))
+	assert.Equal(t, StreamHLS, FindStreamFormat(" HLS "))
+	assert.Equal(t, StreamDASH, FindStreamFormat("dash"))
+	assert.Equal(t, StreamNone, FindStreamFormat("off"))
+	assert.Equal(t, StreamNone, FindStreamFormat("foo"))
+}
+
+func TestStreamFormat_PlaylistName(t *testing.T) {
+	assert.Equal(t, "master.m3u8", StreamHLS.PlaylistName())
+	assert.Equal(t, "manifest.mpd", StreamDASH.PlaylistName())
+	assert.Equal(t, "", StreamNone.PlaylistName())
+}
+
+func TestStreamContentType(t *testing.T) {
+	assert.Equal(t, "application/vnd.apple.mpegurl", StreamContentType("master.m3u8"))
+	assert.Equal(t, "application/dash+xml", StreamContentType("manifest.mpd"))
+	assert.Equal(t, "video/iso.segment", StreamContentType("stream_0_00001.m4s"))
+	assert.Equal(t, "video/mp4", StreamContentType("init_0.mp4"))
+	assert.Equal(t, "", StreamContentType("passwd"))
+}
+
+func TestStreamCommand(t *testing.T) {
+	opt := Options{
+		Bin:      "",
+		Encoder:  "libx264",
+		MapVideo: MapVideoDefault,
+		MapAudio: MapAudioDefault,
+	}
+
+	renditions := Renditions{{Size: 720, Bitrate: "2M"}, {Size: 1280, Bitrate: "5M"}}
+
+	t.Run("EmptyFilename", func(t *testing.T) {
+		_, err := StreamCommand("", "stream", StreamHLS, renditions, true, opt)
+		assert.EqualError(t, err, "empty input filename")
+	})
+	t.Run("EmptyFolder", func(t *testing.T) {
+		_, err := StreamCommand("VID123.mov", "", StreamHLS, renditions, true, opt)
+		assert.EqualError(t, err, "empty output folder")
+	})
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		_, err := StreamCommand("VID123.mov", "stream", StreamNone, renditions, true, opt)
+		assert.EqualError(t, err, "unsupported streaming format")
+	})
+	t.Run("NoRenditions", func(t *testing.T) {
+		_, err := StreamCommand("VID123.mov", "stream", StreamHLS, nil, true, opt)
+		assert.EqualError(t, err, "no renditions")
+	})
+	t.Run("HLS", func(t *testing.T) {
+		r, err := StreamCommand("VID123.mov", "stream", StreamHLS, renditions, true, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, r.String(), "ffmpeg -i VID123.mov -filter_complex [0:v:0]split=2[s0][s1];[s0]scale='if(gte(iw,ih), min(720, iw), -2):if(gte(iw,ih), -2, min(720, ih))',format=yuv420p[v0];[s1]scale='if(gte(iw,ih), min(1280, iw), -2):if(gte(iw,ih), -2, min(1280, ih))',format=yuv420p[v1] -map [v0] -map [v1] -map 0:a:0 -map 0:a:0 -c:v libx264 -b:v:0 2M -b:v:1 5M -r 30 -force_key_frames expr:gte(t,n_forced*4) -c:a aac -ac 2 -b:a 128k -f hls")
+		assert.Contains(t, r.String(), "-master_pl_name master.m3u8 -var_stream_map v:0,a:0 v:1,a:1 -y stream/stream_%v.m3u8")
+	})
+	t.Run("HLSWithoutAudio", func(t *testing.T) {
+		r, err := StreamCommand("VID123.mov", "stream", StreamHLS, renditions, false, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, r.String(), "-c:a")
+		assert.Contains(t, r.String(), "-var_stream_map v:0 v:1 -y stream/stream_%v.m3u8")
+	})
+	t.Run("DASH", func(t *testing.T) {
+		r, err := StreamCommand("VID123.mov", "stream", StreamDASH, renditions, true, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, r.String(), "-map [v0] -map [v1] -map 0:a:0 -c:v libx264")
+		assert.Contains(t, r.String(), "-f dash -seg_duration 4 -use_template 1 -use_timeline 1 -init_seg_name init_$RepresentationID$.m4s -media_seg_name chunk_$RepresentationID$_$Number%05d$.m4s -adaptation_sets id=0,streams=v id=1,streams=a -y stream/manifest.mpd")
+	})
+}
@@ -0,0 +1,7 @@
+package form
+
+// Comment represents a photo or album comment form.
+type Comment struct {
+	Text       string `json:"Text"`
+	AuthorName string `json:"AuthorName"`
+}
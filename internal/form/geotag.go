@@ -0,0 +1,19 @@
+package form
+
+import "time"
+
+// Geotag represents a request to assign coordinates from uploaded tracklogs to pictures
+// without location. The maximum time gap between track points is specified in seconds.
+type Geotag struct {
+	MaxGap int  `json:"maxGap"`
+	DryRun bool `json:"dryRun"`
+}
+
+// Gap returns the maximum time gap between two track points, or zero to use the default.
+func (f *Geotag) Gap() time.Duration {
+	if f.MaxGap <= 0 {
+		return 0
+	}
+
+	return time.Duration(f.MaxGap) * time.Second
+}
@@ -0,0 +1,23 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeotag_Gap(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		f := Geotag{}
+		assert.Equal(t, time.Duration(0), f.Gap())
+	})
+	t.Run("Negative", func(t *testing.T) {
+		f := Geotag{MaxGap: -1}
+		assert.Equal(t, time.Duration(0), f.Gap())
+	})
+	t.Run("FiveMinutes", func(t *testing.T) {
+		f := Geotag{MaxGap: 300, DryRun: true}
+		assert.Equal(t, 5*time.Minute, f.Gap())
+	})
+}
@@ -1,17 +1,19 @@
 package form
 
 import (
+	"github.com/photoprism/photoprism/internal/auth/webauthn"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // Login represents a login form.
 type Login struct {
-	Username string `json:"username,omitempty"` // The local Username or LDAP user principal name (UPN).
-	Password string `json:"password,omitempty"` // The user's Password.
-	Code     string `json:"code,omitempty"`     // 2FA Verification Code (Passcodes).
-	Token    string `json:"token,omitempty"`    // Share Token.
-	Email    string `json:"email,omitempty"`    // Reserved.
+	Username string              `json:"username,omitempty"` // The local Username or LDAP user principal name (UPN).
+	Password string              `json:"password,omitempty"` // The user's Password.
+	Code     string              `json:"code,omitempty"`     // 2FA Verification Code (Passcodes).
+	Passkey  *webauthn.Assertion `json:"passkey,omitempty"`  // WebAuthn Assertion (Passkeys).
+	Token    string              `json:"token,omitempty"`    // Share Token.
+	Email    string              `json:"email,omitempty"`    // Reserved.
 }
 
 // CleanUsername returns the sanitized and normalized username.
@@ -52,6 +54,11 @@ func (f Login) Passcode() string {
 	return clean.Passcode(f.Code)
 }
 
+// HasPasskey checks if a passkey assertion has been provided.
+func (f Login) HasPasskey() bool {
+	return f.Passkey.CredentialID() != ""
+}
+
 // HasShareToken checks if a link share token has been provided.
 func (f Login) HasShareToken() bool {
 	return f.Token != ""
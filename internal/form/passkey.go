@@ -0,0 +1,23 @@
+package form
+
+import (
+	"github.com/photoprism/photoprism/internal/auth/webauthn"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Passkey represents a passkey registration form.
+type Passkey struct {
+	Name       string                `form:"name" json:"name,omitempty"`
+	Password   string                `form:"password" json:"password,omitempty"`
+	Credential *webauthn.Attestation `json:"credential,omitempty"`
+}
+
+// HasPassword checks if a password has been provided.
+func (f Passkey) HasPassword() bool {
+	return f.Password != "" && len(f.Password) <= txt.ClipPassword
+}
+
+// HasCredential checks if a new credential has been provided.
+func (f Passkey) HasCredential() bool {
+	return f.Credential != nil && f.Credential.Response.AttestationObject != ""
+}
@@ -43,6 +43,7 @@ type SearchPhotos struct {
 	Public    bool      `form:"public" notes:"Excludes private pictures"`
 	Private   bool      `form:"private" notes:"Finds private pictures"`
 	Favorite  string    `form:"favorite" example:"favorite:true favorite:false" notes:"Finds images by favorite status"`
+	Rating    string    `form:"rating" example:"rating:3-5" notes:"Star Rating (0-5)"`
 	Unsorted  bool      `form:"unsorted" notes:"Finds pictures not in an album"`
 	Near      string    `form:"near" example:"near:pqbcf5j446s0futy" notes:"Finds nearby pictures (UID)"`
 	S2        string    `form:"s2" example:"s2:4799e370ca54c8b9"  notes:"S2 Position (Cell ID)"`
@@ -61,9 +62,11 @@ type SearchPhotos struct {
 	Chroma    int16     `form:"chroma" example:"chroma:70" notes:"Chroma (0-100)"`
 	Mono      bool      `form:"mono" notes:"Finds pictures with few or no colors"`
 	Diff      uint32    `form:"diff" notes:"Differential Perceptual Hash (000000-FFFFFF)"`
+	Similar   string    `form:"similar" example:"similar:pqbcf5j446s0futy similar:\"dog on a beach\"" notes:"Finds visually similar pictures (UID) or pictures matching a description (requires semantic search)"`
 	Geo       string    `form:"geo" example:"geo:yes" notes:"Finds pictures with or without coordinates"`
 	Keywords  string    `form:"keywords" example:"keywords:\"sand&water\"" notes:"Keywords (combinable with & and |)"`
 	Label     string    `form:"label" example:"label:cat|dog" notes:"Label Names (separate with |)"`
+	Object    string    `form:"object" example:"object:car|bicycle" notes:"Detected Object Names (separate with |)"`
 	Category  string    `form:"category" example:"category:airport" notes:"Location Category"`
 	Country   string    `form:"country" example:"country:\"de|us\"" notes:"Location Country Code (separate with |)"`                                                             // Moments
 	State     string    `form:"state" example:"state:\"Baden-Württemberg\"" notes:"Location State (separate with |)"`                                                            // Moments
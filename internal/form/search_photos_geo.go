@@ -25,6 +25,7 @@ type SearchPhotosGeo struct {
 	Before    time.Time `form:"before" time_format:"2006-01-02" notes:"Finds pictures taken on or before this date"`
 	After     time.Time `form:"after" time_format:"2006-01-02" notes:"Finds pictures taken on or after this date"`
 	Favorite  string    `form:"favorite" example:"favorite:yes" notes:"Finds favorites only"`
+	Rating    string    `form:"rating" example:"rating:3-5" notes:"Star Rating (0-5)"`
 	Unsorted  bool      `form:"unsorted"`
 	Video     bool      `form:"video"`
 	Vector    bool      `form:"vector"`
@@ -161,6 +161,18 @@ func TestParseQueryString(t *testing.T) {
 		assert.Equal(t, "label:dog", form.Filter)
 		assert.Equal(t, "fooBar baz", form.Title)
 	})
+	t.Run("valid query with object", func(t *testing.T) {
+		form := &SearchPhotos{Query: "object:car|bicycle label:street"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "car|bicycle", form.Object)
+		assert.Equal(t, "street", form.Label)
+	})
 	t.Run("valid query with umlauts", func(t *testing.T) {
 		form := &SearchPhotos{Query: "title:\"tübingen\""}
 
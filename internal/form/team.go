@@ -0,0 +1,19 @@
+package form
+
+// Team represents a team create or update form.
+type Team struct {
+	TeamName        string `json:"Name"`
+	TeamDescription string `json:"Description"`
+}
+
+// TeamMember represents a form for adding users to a team.
+type TeamMember struct {
+	UserUID  string `json:"UserUID"`
+	UserName string `json:"UserName"`
+}
+
+// TeamShare represents a form for sharing albums and folders with a team.
+type TeamShare struct {
+	UID  string `json:"UID"`
+	Perm string `json:"Perm"`
+}
@@ -0,0 +1,94 @@
+package form
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TimeShift represents a request to correct the date and time of multiple pictures, e.g. if the
+// camera clock was wrong. The offset can either be specified directly or derived from a reference
+// photo and its correct local time. Pictures can be selected in the user interface or by camera
+// serial number, optionally limited to a range of local times.
+type TimeShift struct {
+	Selection
+	Offset  string `json:"offset"`
+	RefUID  string `json:"refUID"`
+	RefTime string `json:"refTime"`
+	Serial  string `json:"serial"`
+	After   string `json:"after"`
+	Before  string `json:"before"`
+}
+
+// Validate returns an error if the form values are incomplete or cannot be parsed.
+func (f *TimeShift) Validate() error {
+	f.Offset = strings.TrimSpace(f.Offset)
+	f.Serial = strings.TrimSpace(f.Serial)
+
+	if f.Empty() && f.Serial == "" {
+		return errors.New("no pictures selected")
+	}
+
+	if f.Offset != "" && (f.RefUID != "" || f.RefTime != "") {
+		return errors.New("offset and reference photo cannot be used together")
+	} else if f.Offset == "" && (f.RefUID == "" || f.RefTime == "") {
+		return errors.New("offset or reference photo and time required")
+	}
+
+	if f.Offset != "" {
+		if _, err := f.Duration(); err != nil {
+			return err
+		}
+	} else if f.RefTimeLocal().IsZero() {
+		return errors.New("invalid reference time")
+	}
+
+	if f.After != "" && f.TakenAfter().IsZero() {
+		return errors.New("invalid start time")
+	} else if f.Before != "" && f.TakenBefore().IsZero() {
+		return errors.New("invalid end time")
+	}
+
+	return nil
+}
+
+// Duration returns the time offset to be applied, e.g. "-1h30m".
+func (f *TimeShift) Duration() (time.Duration, error) {
+	if f.Offset == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(f.Offset)
+}
+
+// RefTimeLocal returns the correct local time of the reference photo.
+func (f *TimeShift) RefTimeLocal() time.Time {
+	return localTime(f.RefTime)
+}
+
+// TakenAfter returns the local time from which pictures taken with the camera should be included.
+func (f *TimeShift) TakenAfter() time.Time {
+	return localTime(f.After)
+}
+
+// TakenBefore returns the local time up to which pictures taken with the camera should be included.
+func (f *TimeShift) TakenBefore() time.Time {
+	return localTime(f.Before)
+}
+
+// localTime parses a local timestamp and returns it in UTC without changing the clock time.
+func localTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+
+	t := txt.ParseTimeUTC(s)
+
+	if t.IsZero() {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
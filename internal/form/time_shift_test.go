@@ -0,0 +1,66 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeShift_Validate(t *testing.T) {
+	t.Run("Offset", func(t *testing.T) {
+		f := TimeShift{Selection: Selection{Photos: []string{"ps6sg6be2lvl0yh7"}}, Offset: " -1h30m "}
+		assert.NoError(t, f.Validate())
+		assert.Equal(t, "-1h30m", f.Offset)
+
+		d, err := f.Duration()
+		assert.NoError(t, err)
+		assert.Equal(t, -90*time.Minute, d)
+	})
+	t.Run("Reference", func(t *testing.T) {
+		f := TimeShift{Selection: Selection{Photos: []string{"ps6sg6be2lvl0yh7"}}, RefUID: "ps6sg6be2lvl0yh7", RefTime: "2020-06-01 12:30:00"}
+		assert.NoError(t, f.Validate())
+		assert.Equal(t, time.Date(2020, 6, 1, 12, 30, 0, 0, time.UTC), f.RefTimeLocal())
+	})
+	t.Run("Serial", func(t *testing.T) {
+		f := TimeShift{Serial: "123", Offset: "2h", After: "2020-06-01", Before: "2020-06-02T18:00:00"}
+		assert.NoError(t, f.Validate())
+		assert.Equal(t, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), f.TakenAfter())
+		assert.Equal(t, time.Date(2020, 6, 2, 18, 0, 0, 0, time.UTC), f.TakenBefore())
+	})
+	t.Run("NoSelection", func(t *testing.T) {
+		f := TimeShift{Offset: "1h"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("NoOffset", func(t *testing.T) {
+		f := TimeShift{Serial: "123"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("OffsetAndReference", func(t *testing.T) {
+		f := TimeShift{Serial: "123", Offset: "1h", RefUID: "ps6sg6be2lvl0yh7", RefTime: "2020-06-01 12:30:00"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("InvalidOffset", func(t *testing.T) {
+		f := TimeShift{Serial: "123", Offset: "1 hour"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("InvalidReferenceTime", func(t *testing.T) {
+		f := TimeShift{Serial: "123", RefUID: "ps6sg6be2lvl0yh7", RefTime: "foo"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		f := TimeShift{Serial: "123", Offset: "1h", After: "foo"}
+		assert.Error(t, f.Validate())
+	})
+}
+
+func TestTimeShift_RefTimeLocal(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		f := TimeShift{}
+		assert.True(t, f.RefTimeLocal().IsZero())
+	})
+	t.Run("WithOffset", func(t *testing.T) {
+		f := TimeShift{RefTime: "2020-06-01T12:30:00+02:00"}
+		assert.Equal(t, time.Date(2020, 6, 1, 12, 30, 0, 0, time.UTC), f.RefTimeLocal())
+	})
+}
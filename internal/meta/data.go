@@ -13,6 +13,12 @@ const (
 	ImageTypeHDR = 3 // see https://exiftool.org/TagNames/Apple.html
 )
 
+// Star rating limits, see https://exiftool.org/TagNames/XMP.html#xmp.
+const (
+	RatingRejected = -1
+	RatingMax      = 5
+)
+
 // Data represents image metadata.
 type Data struct {
 	FileName         string        `meta:"FileName"`
@@ -38,6 +44,7 @@ type Data struct {
 	Subject          string        `meta:"Subject,PersonInImage,ObjectName,HierarchicalSubject,CatalogSets" xmp:"Subject"`
 	Keywords         Keywords      `meta:"Keywords"`
 	Favorite         bool          `meta:"Favorite"`
+	Rating           int           `meta:"Rating" xmp:"Rating"`
 	Notes            string        `meta:"Comment,UserComment"`
 	Artist           string        `meta:"Artist,Creator,By-line,OwnerName,Owner" xmp:"Creator"`
 	Copyright        string        `meta:"Rights,Copyright,CopyrightNotice,WebStatement" xmp:"Rights,Rights.Alt"`
@@ -71,6 +78,7 @@ type Data struct {
 	Rotation         int           `meta:"Rotation"`
 	Views            int           `meta:"-"`
 	Albums           []string      `meta:"-"`
+	Regions          Regions       `meta:"-" report:"-"`
 	Warning          string        `meta:"Warning" report:"-"`
 	Error            error         `meta:"-"`
 	json             map[string]string
@@ -225,6 +225,12 @@ func (data *Data) Exif(fileName string, fileFormat fs.Type, bruteForce bool) (er
 		}
 	}
 
+	if value, ok := data.exif["Rating"]; ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			data.Rating = SanitizeRating(i)
+		}
+	}
+
 	if value, ok := data.exif["ImageUniqueID"]; ok {
 		if id := rnd.SanitizeUUID(value); id != "" {
 			data.DocumentID = id
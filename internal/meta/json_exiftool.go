@@ -176,6 +176,14 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 		}
 	}
 
+	// Make sure the star rating is within the valid range.
+	data.Rating = SanitizeRating(data.Rating)
+
+	// Get named image regions, e.g. faces tagged in other applications.
+	if regions := exiftoolRegions(jsonValues); len(regions) > 0 {
+		data.Regions = regions
+	}
+
 	// Nanoseconds.
 	if data.TakenNs <= 0 {
 		for _, name := range exifSubSecTags {
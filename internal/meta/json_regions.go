@@ -0,0 +1,79 @@
+package meta
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// exiftoolRegions returns the image regions in the flattened Exiftool JSON output,
+// see https://exiftool.org/TagNames/MWG.html#Regions.
+func exiftoolRegions(values map[string]gjson.Result) (result Regions) {
+	list := func(key string) []gjson.Result {
+		if v, ok := values[key]; !ok {
+			return nil
+		} else if v.IsArray() {
+			return v.Array()
+		} else {
+			return []gjson.Result{v}
+		}
+	}
+
+	// Metadata Working Group (MWG) regions.
+	names := list("RegionName")
+	types := list("RegionType")
+	areaX, areaY := list("RegionAreaX"), list("RegionAreaY")
+	areaW, areaH := list("RegionAreaW"), list("RegionAreaH")
+	units := list("RegionAreaUnit")
+	dimW := float32(values["RegionAppliedToDimensionsW"].Float())
+	dimH := float32(values["RegionAppliedToDimensionsH"].Float())
+
+	for i := range names {
+		if i >= len(areaX) || i >= len(areaY) || i >= len(areaW) || i >= len(areaH) {
+			break
+		}
+
+		x, y := float32(areaX[i].Float()), float32(areaY[i].Float())
+		w, h := float32(areaW[i].Float()), float32(areaH[i].Float())
+
+		// Convert pixel values to relative coordinates.
+		if i < len(units) && units[i].String() != "" && units[i].String() != "normalized" {
+			if dimW <= 0 || dimH <= 0 {
+				continue
+			}
+
+			x, y, w, h = x/dimW, y/dimH, w/dimW, h/dimH
+		}
+
+		var regionType string
+
+		if i < len(types) {
+			regionType = types[i].String()
+		}
+
+		result = append(result, NewRegionFromCenter(names[i].String(), regionType, x, y, w, h))
+	}
+
+	// Microsoft Photo (MP) regions.
+	people := list("RegionPersonDisplayName")
+	rects := list("RegionRectangle")
+
+	for i := range people {
+		if i >= len(rects) {
+			break
+		}
+
+		rect := strings.Split(rects[i].String(), ",")
+
+		if len(rect) != 4 {
+			continue
+		}
+
+		result = append(result, NewRegion(people[i].String(), RegionFace,
+			txt.Float32(rect[0]), txt.Float32(rect[1]), txt.Float32(rect[2]), txt.Float32(rect[3])))
+	}
+
+	return result
+}
@@ -12,6 +12,25 @@ import (
 )
 
 func TestJSON(t *testing.T) {
+	t.Run("regions.json", func(t *testing.T) {
+		data, err := JSON("testdata/regions.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Jens Mander, Gopher", data.Subject)
+		assert.Len(t, data.Regions, 2)
+		assert.Equal(t, "Jens Mander", data.Regions[0].Name)
+		assert.Equal(t, RegionFace, data.Regions[0].Type)
+		assert.InEpsilon(t, 0.4, data.Regions[0].X, 0.001)
+		assert.InEpsilon(t, 0.25, data.Regions[0].Y, 0.001)
+		assert.Equal(t, "Gopher", data.Regions[1].Name)
+		assert.InEpsilon(t, 0.15, data.Regions[1].X, 0.001)
+		assert.InEpsilon(t, 0.15, data.Regions[1].Y, 0.001)
+		assert.InEpsilon(t, 0.1, data.Regions[1].W, 0.001)
+		assert.InEpsilon(t, 0.1, data.Regions[1].H, 0.001)
+	})
 	t.Run("mov.json", func(t *testing.T) {
 		data, err := JSON("testdata/mov.json", "")
 
@@ -672,6 +691,7 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "", data.LensModel)
 		assert.Equal(t, 5, data.FocalLength)
 		assert.Equal(t, 1, int(data.Orientation))
+		assert.Equal(t, 4, data.Rating)
 	})
 
 	t.Run("date.mov.json", func(t *testing.T) {
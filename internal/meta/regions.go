@@ -0,0 +1,67 @@
+package meta
+
+import (
+	"strings"
+)
+
+// Region types, see https://www.metadataworkinggroup.org/specs/.
+const (
+	RegionFace = "Face"
+	RegionPet  = "Pet"
+)
+
+// Region represents a named image area, e.g. a face tagged in Lightroom, digiKam, Picasa or Apple Photos.
+// X and Y specify the top left corner relative to the image size, W and H the relative width and height.
+type Region struct {
+	Name string
+	Type string
+	X    float32
+	Y    float32
+	W    float32
+	H    float32
+}
+
+// Regions represents a list of image regions.
+type Regions []Region
+
+// Face checks if the region contains a face.
+func (r Region) Face() bool {
+	return r.Type == "" || strings.EqualFold(r.Type, RegionFace)
+}
+
+// Valid checks if the region has a name and a valid area.
+func (r Region) Valid() bool {
+	if r.Name == "" || r.W <= 0 || r.H <= 0 || r.W > 1 || r.H > 1 {
+		return false
+	}
+
+	return r.X >= 0 && r.Y >= 0 && r.X+r.W <= 1.01 && r.Y+r.H <= 1.01
+}
+
+// NewRegion creates a new region based on its top left corner.
+func NewRegion(name, regionType string, x, y, w, h float32) Region {
+	return Region{
+		Name: SanitizeString(name),
+		Type: SanitizeString(regionType),
+		X:    x,
+		Y:    y,
+		W:    w,
+		H:    h,
+	}
+}
+
+// NewRegionFromCenter creates a new region based on its center as used by MWG.
+func NewRegionFromCenter(name, regionType string, x, y, w, h float32) Region {
+	return NewRegion(name, regionType, x-w/2, y-h/2, w, h)
+}
+
+// Faces returns all valid face regions.
+func (r Regions) Faces() (result Regions) {
+	for _, region := range r {
+		if region.Face() && region.Valid() {
+			result = append(result, region)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,23 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion_Face(t *testing.T) {
+	assert.True(t, Region{Name: "Jens", Type: "Face"}.Face())
+	assert.True(t, Region{Name: "Jens", Type: "face"}.Face())
+	assert.True(t, Region{Name: "Jens"}.Face())
+	assert.False(t, Region{Name: "Bello", Type: RegionPet}.Face())
+}
+
+func TestRegion_Valid(t *testing.T) {
+	assert.True(t, NewRegion("Jens", RegionFace, 0.1, 0.1, 0.2, 0.2).Valid())
+	assert.True(t, NewRegionFromCenter("Jens", RegionFace, 0.5, 0.5, 0.2, 0.2).Valid())
+	assert.False(t, NewRegion("", RegionFace, 0.1, 0.1, 0.2, 0.2).Valid())
+	assert.False(t, NewRegion("Jens", RegionFace, 0.1, 0.1, 0, 0.2).Valid())
+	assert.False(t, NewRegion("Jens", RegionFace, -0.1, 0.1, 0.2, 0.2).Valid())
+	assert.False(t, NewRegion("Jens", RegionFace, 0.9, 0.1, 0.2, 0.2).Valid())
+}
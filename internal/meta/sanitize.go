@@ -158,3 +158,12 @@ func SanitizeMeta(s string) string {
 
 	return s
 }
+
+// SanitizeRating returns a valid star rating from 0 to 5, -1 if the picture was rejected, or 0 otherwise.
+func SanitizeRating(rating int) int {
+	if rating < RatingRejected || rating > RatingMax {
+		return 0
+	}
+
+	return rating
+}
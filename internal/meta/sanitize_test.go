@@ -173,3 +173,19 @@ func TestSanitizeUID(t *testing.T) {
 	})
 
 }
+
+func TestSanitizeRating(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.Equal(t, 0, SanitizeRating(0))
+		assert.Equal(t, 3, SanitizeRating(3))
+		assert.Equal(t, 5, SanitizeRating(5))
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		assert.Equal(t, -1, SanitizeRating(-1))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, 0, SanitizeRating(-2))
+		assert.Equal(t, 0, SanitizeRating(6))
+		assert.Equal(t, 0, SanitizeRating(99))
+	})
+}
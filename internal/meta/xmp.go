@@ -76,6 +76,18 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.AddKeywords(doc.Keywords())
 	}
 
+	if people := doc.People(); people != "" {
+		data.Subject = people
+	}
+
+	if regions := doc.Regions(); len(regions) > 0 {
+		data.Regions = regions
+	}
+
+	if rating := doc.Rating(); rating != 0 {
+		data.Rating = rating
+	}
+
 	data.Favorite = doc.Favorite()
 
 	return nil
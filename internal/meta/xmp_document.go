@@ -37,6 +37,7 @@ type XmpDocument struct {
 			CreateDate      string `xml:"CreateDate"`                                 // 2020-01-01T17:28:23
 			MetadataDate    string `xml:"MetadataDate"`                               // 2020-01-01T17:28:23.89961...
 			Rating          string `xml:"Rating"`                                     // 4
+			RatingAttr      string `xml:"http://ns.adobe.com/xap/1.0/ Rating,attr"`   // 4
 			FStopFavorite   string `xml:"http://www.fstopapp.com/xmp/ favorite,attr"` // 1
 			Lens            string `xml:"Lens"`                                       // HUAWEI P30 Rear Main Came...
 			LensModel       string `xml:"LensModel"`                                  // HUAWEI P30 Rear Main Came...
@@ -193,12 +194,13 @@ type XmpDocument struct {
 			PersonInImage struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Bag  struct {
-					Text string `xml:",chardata" json:"text,omitempty"`
-					Li   string `xml:"li"` // Gopher
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Gopher
 				} `xml:"Bag" json:"bag,omitempty"`
 			} `xml:"PersonInImage" json:"personinimage,omitempty"`
 		} `xml:"Description" json:"description,omitempty"`
 	} `xml:"RDF" json:"rdf,omitempty"`
+	raw []byte
 }
 
 // Load parses an XMP file and populates document values with its contents.
@@ -209,6 +211,8 @@ func (doc *XmpDocument) Load(filename string) error {
 		return err
 	}
 
+	doc.raw = data
+
 	return xml.Unmarshal(data, doc)
 }
 
@@ -280,11 +284,45 @@ func (doc *XmpDocument) TakenAt(timeZone string) time.Time {
 
 // Keywords returns the XMP document keywords.
 func (doc *XmpDocument) Keywords() string {
-	s := doc.RDF.Description.Subject.Seq.Li
+	var s []string
+
+	s = append(s, doc.RDF.Description.Subject.Bag.Li...)
+	s = append(s, doc.RDF.Description.Subject.Seq.Li...)
 
 	return strings.Join(s, ", ")
 }
 
+// People returns the names of the people shown in the picture.
+func (doc *XmpDocument) People() string {
+	return SanitizeMeta(strings.Join(doc.RDF.Description.PersonInImage.Bag.Li, ", "))
+}
+
+// Rating returns the XMP star rating from 0 to 5, or -1 if the picture has been rejected.
+func (doc *XmpDocument) Rating() int {
+	value := strings.TrimSpace(doc.RDF.Description.Rating)
+
+	if value == "" {
+		value = strings.TrimSpace(doc.RDF.Description.RatingAttr)
+	}
+
+	return SanitizeRating(txt.Int(value))
+}
+
+// Regions returns the face and other image regions stored in the XMP document.
+func (doc *XmpDocument) Regions() Regions {
+	if len(doc.raw) == 0 {
+		return nil
+	}
+
+	nodes, err := parseXmpNodes(doc.raw)
+
+	if err != nil {
+		return nil
+	}
+
+	return xmpRegions(nodes)
+}
+
 // Favorite returns a favorite status in the XMP document.
 func (doc *XmpDocument) Favorite() bool {
 	fstop := doc.RDF.Description.FStopFavorite
@@ -0,0 +1,449 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XMP namespace URIs and their preferred prefixes.
+const (
+	NsX           = "adobe:ns:meta/"
+	NsRDF         = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	NsXML         = "http://www.w3.org/XML/1998/namespace"
+	NsDC          = "http://purl.org/dc/elements/1.1/"
+	NsXMP         = "http://ns.adobe.com/xap/1.0/"
+	NsPhotoshop   = "http://ns.adobe.com/photoshop/1.0/"
+	NsExif        = "http://ns.adobe.com/exif/1.0/"
+	NsIptc4xmpExt = "http://iptc.org/std/Iptc4xmpExt/2008-02-29/"
+	NsFStop       = "http://www.fstopapp.com/xmp/"
+	NsMwgRs       = "http://www.metadataworkinggroup.com/schemas/regions/"
+	NsStArea      = "http://ns.adobe.com/xmp/sType/Area#"
+	NsStDim       = "http://ns.adobe.com/xap/1.0/sType/Dimensions#"
+	NsMPRI        = "http://ns.microsoft.com/photo/1.2/t/RegionInfo#"
+	NsMPReg       = "http://ns.microsoft.com/photo/1.2/t/Region#"
+)
+
+// xmpPrefixes maps namespace URIs to the prefixes used when a namespace must be declared.
+var xmpPrefixes = map[string]string{
+	NsX:           "x",
+	NsRDF:         "rdf",
+	NsXML:         "xml",
+	NsDC:          "dc",
+	NsXMP:         "xmp",
+	NsPhotoshop:   "photoshop",
+	NsExif:        "exif",
+	NsIptc4xmpExt: "Iptc4xmpExt",
+	NsFStop:       "fstop",
+}
+
+// xmpNode represents an XML element with its raw, unresolved prefixes so that unknown
+// namespaces and the original formatting are preserved when the document is written back.
+type xmpNode struct {
+	Name     xml.Name // Name.Space contains the prefix, not the namespace URI.
+	Attr     []xml.Attr
+	Children []interface{} // *xmpNode, xml.CharData, xml.Comment, xml.ProcInst, or xml.Directive.
+	parent   *xmpNode
+}
+
+// parseXmpNodes parses an XMP document into a tree of nodes and returns the document root.
+func parseXmpNodes(data []byte) (*xmpNode, error) {
+	root := &xmpNode{}
+	current := root
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+
+	for {
+		t, err := d.RawToken()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			n := &xmpNode{Name: t.Name, Attr: append([]xml.Attr{}, t.Attr...), parent: current}
+			current.Children = append(current.Children, n)
+			current = n
+		case xml.EndElement:
+			if current.parent == nil {
+				return nil, errors.New("unexpected end element")
+			}
+
+			current = current.parent
+		case xml.CharData:
+			current.Children = append(current.Children, t.Copy())
+		case xml.Comment:
+			current.Children = append(current.Children, t.Copy())
+		case xml.ProcInst:
+			current.Children = append(current.Children, t.Copy())
+		case xml.Directive:
+			current.Children = append(current.Children, t.Copy())
+		}
+	}
+
+	if current != root {
+		return nil, errors.New("unexpected end of document")
+	}
+
+	return root, nil
+}
+
+// namespace resolves a prefix to its namespace URI based on the declarations in scope.
+func (n *xmpNode) namespace(prefix string) string {
+	if prefix == "xml" {
+		return NsXML
+	}
+
+	for e := n; e != nil; e = e.parent {
+		for _, a := range e.Attr {
+			if prefix == "" && a.Name.Space == "" && a.Name.Local == "xmlns" {
+				return a.Value
+			} else if a.Name.Space == "xmlns" && a.Name.Local == prefix {
+				return a.Value
+			}
+		}
+	}
+
+	return ""
+}
+
+// prefix returns the prefix declared for a namespace URI, if any.
+func (n *xmpNode) prefix(ns string) (string, bool) {
+	if ns == NsXML {
+		return "xml", true
+	}
+
+	for e := n; e != nil; e = e.parent {
+		for _, a := range e.Attr {
+			if a.Name.Space == "xmlns" && a.Value == ns && e.namespace(a.Name.Local) == ns {
+				return a.Name.Local, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// declare makes sure a prefix for the namespace URI is declared and returns it.
+func (n *xmpNode) declare(ns string) string {
+	if p, ok := n.prefix(ns); ok {
+		return p
+	}
+
+	p := xmpPrefixes[ns]
+
+	if p == "" {
+		p = "ns"
+	}
+
+	// Avoid conflicts with prefixes already bound to other namespaces.
+	for i, base := 1, p; n.namespace(p) != ""; i++ {
+		p = base + strings.Repeat("_", i)
+	}
+
+	n.Attr = append(n.Attr, xml.Attr{Name: xml.Name{Space: "xmlns", Local: p}, Value: ns})
+
+	return p
+}
+
+// is checks if the node is an element with the specified namespace URI and local name.
+func (n *xmpNode) is(ns, local string) bool {
+	return n.Name.Local == local && n.namespace(n.Name.Space) == ns
+}
+
+// elements returns all child elements.
+func (n *xmpNode) elements() (result []*xmpNode) {
+	for _, c := range n.Children {
+		if e, ok := c.(*xmpNode); ok {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// find returns all descendant elements with the specified namespace URI and local name.
+func (n *xmpNode) find(ns, local string) (result []*xmpNode) {
+	for _, e := range n.elements() {
+		if e.is(ns, local) {
+			result = append(result, e)
+		}
+
+		result = append(result, e.find(ns, local)...)
+	}
+
+	return result
+}
+
+// child returns the first child element with the specified namespace URI and local name, or nil if not found.
+func (n *xmpNode) child(ns, local string) *xmpNode {
+	for _, e := range n.elements() {
+		if e.is(ns, local) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// text returns the character data of the node.
+func (n *xmpNode) text() string {
+	var b strings.Builder
+
+	for _, c := range n.Children {
+		if s, ok := c.(xml.CharData); ok {
+			b.Write(s)
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// value returns a simple property value, regardless of whether it is stored as an element or attribute.
+func (n *xmpNode) value(ns, local string) string {
+	if i := n.attrIndex(ns, local); i >= 0 {
+		return strings.TrimSpace(n.Attr[i].Value)
+	} else if e := n.child(ns, local); e != nil {
+		return e.text()
+	}
+
+	return ""
+}
+
+// resource returns the node containing the properties of a struct value,
+// which may be nested in an rdf:Description element.
+func (n *xmpNode) resource() *xmpNode {
+	if desc := n.child(NsRDF, "Description"); desc != nil {
+		return desc
+	}
+
+	return n
+}
+
+// attrIndex returns the index of an attribute with the specified namespace URI and local name, or -1 if not found.
+func (n *xmpNode) attrIndex(ns, local string) int {
+	for i, a := range n.Attr {
+		if a.Name.Local == local && a.Name.Space != "xmlns" && a.Name.Space != "" && n.namespace(a.Name.Space) == ns {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// removeProperty removes a property, regardless of whether it is stored as an element or attribute.
+func (n *xmpNode) removeProperty(ns, local string) {
+	if i := n.attrIndex(ns, local); i >= 0 {
+		n.Attr = append(n.Attr[:i], n.Attr[i+1:]...)
+	}
+
+	children := n.Children[:0]
+
+	for i, c := range n.Children {
+		if e, ok := c.(*xmpNode); ok && e.is(ns, local) {
+			// Also remove the whitespace preceding the element.
+			if l := len(children); l > 0 && i > 0 {
+				if s, isText := children[l-1].(xml.CharData); isText && len(bytes.TrimSpace(s)) == 0 {
+					children = children[:l-1]
+				}
+			}
+
+			continue
+		}
+
+		children = append(children, c)
+	}
+
+	n.Children = children
+}
+
+// setAttr adds or replaces an attribute with the specified namespace URI and local name.
+func (n *xmpNode) setAttr(ns, local, value string) {
+	if i := n.attrIndex(ns, local); i >= 0 {
+		n.Attr[i].Value = value
+		return
+	}
+
+	n.Attr = append(n.Attr, xml.Attr{Name: xml.Name{Space: n.declare(ns), Local: local}, Value: value})
+}
+
+// leading returns the whitespace preceding the element.
+func (n *xmpNode) leading() string {
+	if n.parent == nil {
+		return "\n"
+	}
+
+	for i, c := range n.parent.Children {
+		if e, ok := c.(*xmpNode); !ok || e != n || i == 0 {
+			continue
+		} else if s, isText := n.parent.Children[i-1].(xml.CharData); isText && len(bytes.TrimSpace(s)) == 0 {
+			return string(s)
+		}
+	}
+
+	return "\n"
+}
+
+// childIndent returns the whitespace used to indent child elements,
+// based on the existing formatting of the document.
+func (n *xmpNode) childIndent() string {
+	for i, c := range n.Children {
+		if _, ok := c.(*xmpNode); !ok || i == 0 {
+			continue
+		} else if s, isText := n.Children[i-1].(xml.CharData); isText && len(bytes.TrimSpace(s)) == 0 {
+			return string(s)
+		}
+	}
+
+	own := n.leading()
+	unit := " "
+
+	if p := n.parent; p != nil && p.Name.Local != "" {
+		if pl := p.leading(); len(own) > len(pl) && strings.HasPrefix(own, pl) {
+			unit = own[len(pl):]
+		}
+	}
+
+	return own + unit
+}
+
+// appendChild adds a new element and returns it.
+func (n *xmpNode) appendChild(ns, local string) *xmpNode {
+	e := &xmpNode{parent: n}
+	e.Name = xml.Name{Space: n.declare(ns), Local: local}
+
+	indent := xml.CharData(n.childIndent())
+
+	// Keep the closing tag on its own line.
+	var closing xml.CharData
+
+	if l := len(n.Children); l > 0 {
+		if s, isText := n.Children[l-1].(xml.CharData); isText && len(bytes.TrimSpace(s)) == 0 {
+			closing = s
+			n.Children = n.Children[:l-1]
+		}
+	}
+
+	if closing == nil {
+		closing = xml.CharData(n.leading())
+	}
+
+	n.Children = append(n.Children, indent, e, closing)
+
+	return e
+}
+
+// setText replaces all children with the specified text.
+func (n *xmpNode) setText(s string) {
+	n.Children = []interface{}{xml.CharData(s)}
+}
+
+// write serializes the node and its children.
+func (n *xmpNode) write(b *bytes.Buffer) {
+	if n.Name.Local == "" {
+		for _, c := range n.Children {
+			writeXmpChild(b, c)
+		}
+
+		return
+	}
+
+	b.WriteByte('<')
+	b.WriteString(xmpQName(n.Name))
+
+	for _, a := range n.Attr {
+		b.WriteByte(' ')
+		b.WriteString(xmpQName(a.Name))
+		b.WriteString(`="`)
+		writeXmpEscaped(b, a.Value, true)
+		b.WriteByte('"')
+	}
+
+	if len(n.Children) == 0 {
+		b.WriteString("/>")
+		return
+	}
+
+	b.WriteByte('>')
+
+	for _, c := range n.Children {
+		writeXmpChild(b, c)
+	}
+
+	b.WriteString("</")
+	b.WriteString(xmpQName(n.Name))
+	b.WriteByte('>')
+}
+
+// writeXmpChild serializes a child node.
+func writeXmpChild(b *bytes.Buffer, c interface{}) {
+	switch c := c.(type) {
+	case *xmpNode:
+		c.write(b)
+	case xml.CharData:
+		writeXmpEscaped(b, string(c), false)
+	case xml.Comment:
+		b.WriteString("<!--")
+		b.Write(c)
+		b.WriteString("-->")
+	case xml.ProcInst:
+		b.WriteString("<?")
+		b.WriteString(c.Target)
+
+		if len(c.Inst) > 0 {
+			b.WriteByte(' ')
+			b.Write(c.Inst)
+		}
+
+		b.WriteString("?>")
+	case xml.Directive:
+		b.WriteString("<!")
+		b.Write(c)
+		b.WriteByte('>')
+	}
+}
+
+// writeXmpEscaped writes text with XML special characters escaped. Unlike xml.EscapeText,
+// it keeps line breaks in character data so that the original formatting is preserved.
+func writeXmpEscaped(b *bytes.Buffer, s string, attr bool) {
+	for _, r := range s {
+		switch r {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '"':
+			if attr {
+				b.WriteString("&quot;")
+			} else {
+				b.WriteRune(r)
+			}
+		case '\n', '\r', '\t':
+			if attr {
+				fmt.Fprintf(b, "&#x%X;", r)
+			} else {
+				b.WriteRune(r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+// xmpQName returns the qualified name with prefix.
+func xmpQName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
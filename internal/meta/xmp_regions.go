@@ -0,0 +1,80 @@
+package meta
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// xmpRegions returns the image regions stored in the MWG or Microsoft Photo (MP) format.
+func xmpRegions(doc *xmpNode) (result Regions) {
+	if doc == nil {
+		return result
+	}
+
+	// Metadata Working Group (MWG) regions as used by Lightroom, digiKam, Picasa and Apple Photos,
+	// see https://exiftool.org/TagNames/MWG.html#Regions.
+	for _, info := range doc.find(NsMwgRs, "Regions") {
+		info = info.resource()
+
+		var dimW, dimH float32
+
+		if dim := info.child(NsMwgRs, "AppliedToDimensions"); dim != nil {
+			dim = dim.resource()
+			dimW = txt.Float32(dim.value(NsStDim, "w"))
+			dimH = txt.Float32(dim.value(NsStDim, "h"))
+		}
+
+		list := info.child(NsMwgRs, "RegionList")
+
+		if list == nil {
+			continue
+		}
+
+		for _, li := range list.find(NsRDF, "li") {
+			r := li.resource()
+			area := r.child(NsMwgRs, "Area")
+
+			if area == nil {
+				continue
+			}
+
+			area = area.resource()
+
+			x := txt.Float32(area.value(NsStArea, "x"))
+			y := txt.Float32(area.value(NsStArea, "y"))
+			w := txt.Float32(area.value(NsStArea, "w"))
+			h := txt.Float32(area.value(NsStArea, "h"))
+
+			// Convert pixel values to relative coordinates.
+			if unit := area.value(NsStArea, "unit"); unit != "" && unit != "normalized" {
+				if dimW <= 0 || dimH <= 0 {
+					continue
+				}
+
+				x, y, w, h = x/dimW, y/dimH, w/dimW, h/dimH
+			}
+
+			result = append(result, NewRegionFromCenter(r.value(NsMwgRs, "Name"), r.value(NsMwgRs, "Type"), x, y, w, h))
+		}
+	}
+
+	// Microsoft Photo (MP) regions as used by Windows Live Photo Gallery,
+	// see https://exiftool.org/TagNames/Microsoft.html#MP.
+	for _, regions := range doc.find(NsMPRI, "Regions") {
+		for _, li := range regions.find(NsRDF, "li") {
+			r := li.resource()
+
+			rect := strings.Split(r.value(NsMPReg, "Rectangle"), ",")
+
+			if len(rect) != 4 {
+				continue
+			}
+
+			result = append(result, NewRegion(r.value(NsMPReg, "PersonDisplayName"), RegionFace,
+				txt.Float32(rect[0]), txt.Float32(rect[1]), txt.Float32(rect[2]), txt.Float32(rect[3])))
+		}
+	}
+
+	return result
+}
@@ -36,6 +36,7 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "HUAWEI", data.CameraMake)
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, 4, data.Rating)
 	})
 
 	t.Run("canon_eos_6d", func(t *testing.T) {
@@ -81,6 +82,43 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, true, data.Favorite)
 	})
 
+	t.Run("RatingAttr", func(t *testing.T) {
+		data, err := XMP("testdata/rating-attr.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, data.Rating)
+	})
+
+	t.Run("RegionsMwg", func(t *testing.T) {
+		data, err := XMP("testdata/regions-mwg.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, data.Regions, 3)
+		assert.Equal(t, "Jens Mander", data.Regions[0].Name)
+		assert.Equal(t, RegionFace, data.Regions[0].Type)
+		assert.InEpsilon(t, 0.4, data.Regions[0].X, 0.001)
+		assert.InEpsilon(t, 0.25, data.Regions[0].Y, 0.001)
+		assert.InEpsilon(t, 0.2, data.Regions[0].W, 0.001)
+		assert.InEpsilon(t, 0.3, data.Regions[0].H, 0.001)
+		assert.Equal(t, "Bello", data.Regions[1].Name)
+		assert.Equal(t, RegionPet, data.Regions[1].Type)
+		assert.InEpsilon(t, 0.15, data.Regions[1].X, 0.001)
+		assert.Equal(t, "Gopher", data.Regions[2].Name)
+		assert.InEpsilon(t, 0.25, data.Regions[2].H, 0.001)
+
+		faces := data.Regions.Faces()
+
+		assert.Len(t, faces, 2)
+		assert.Equal(t, "Jens Mander", faces[0].Name)
+		assert.Equal(t, "Gopher", faces[1].Name)
+	})
+
 	t.Run("DateHeic", func(t *testing.T) {
 		data, err := XMP("testdata/date.heic.xmp")
 
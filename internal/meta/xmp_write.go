@@ -0,0 +1,248 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// xmpSkeleton is used as template when a new XMP sidecar file is created.
+const xmpSkeleton = "<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n" +
+	"<x:xmpmeta xmlns:x=\"adobe:ns:meta/\" x:xmptk=\"PhotoPrism\">\n" +
+	" <rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n" +
+	"  <rdf:Description rdf:about=\"\">\n" +
+	"  </rdf:Description>\n" +
+	" </rdf:RDF>\n" +
+	"</x:xmpmeta>\n" +
+	"<?xpacket end=\"w\"?>\n"
+
+// SaveXMP writes the title, description, copyright, artist, keywords, people, rating, favorite flag,
+// creation date and GPS position to an XMP sidecar file. Existing files are merged so that other properties,
+// including unknown namespaces, are preserved. Files that cannot be parsed are never overwritten.
+func (data *Data) SaveXMP(fileName string) error {
+	if fs.LowerExt(fileName) != fs.ExtXMP {
+		return fmt.Errorf("metadata: %s is not an xmp file", clean.Log(filepath.Base(fileName)))
+	}
+
+	src := []byte(xmpSkeleton)
+
+	if fs.FileExistsNotEmpty(fileName) {
+		if b, err := os.ReadFile(fileName); err != nil {
+			return fmt.Errorf("metadata: %s in %s (read xmp)", err, clean.Log(filepath.Base(fileName)))
+		} else {
+			src = b
+		}
+	}
+
+	doc, err := parseXmpNodes(src)
+
+	if err != nil {
+		return fmt.Errorf("metadata: %s in %s (parse xmp)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	if err = data.mergeXMP(doc); err != nil {
+		return fmt.Errorf("metadata: %s in %s (merge xmp)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	var b bytes.Buffer
+
+	doc.write(&b)
+
+	if err = writeFileAtomic(fileName, b.Bytes()); err != nil {
+		return fmt.Errorf("metadata: %s in %s (write xmp)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	return nil
+}
+
+// mergeXMP updates the XMP document tree with the metadata values.
+func (data *Data) mergeXMP(doc *xmpNode) error {
+	rdf := doc.find(NsRDF, "RDF")
+
+	if len(rdf) == 0 {
+		return errors.New("rdf element not found")
+	}
+
+	var descriptions []*xmpNode
+
+	for _, e := range rdf[0].elements() {
+		if e.is(NsRDF, "Description") {
+			descriptions = append(descriptions, e)
+		}
+	}
+
+	if len(descriptions) == 0 {
+		desc := rdf[0].appendChild(NsRDF, "Description")
+		desc.setAttr(NsRDF, "about", "")
+		descriptions = append(descriptions, desc)
+	}
+
+	// The favorite flag is always replaced, as it has no neutral value.
+	removeXmpProperty(descriptions, NsFStop, "favorite")
+
+	// Title, description, keywords, people, and rating are always replaced, so that values
+	// that have been removed are not restored when the sidecar file is indexed again.
+	removeXmpProperty(descriptions, NsDC, "title")
+	removeXmpProperty(descriptions, NsDC, "description")
+	removeXmpProperty(descriptions, NsDC, "subject")
+	removeXmpProperty(descriptions, NsIptc4xmpExt, "PersonInImage")
+	removeXmpProperty(descriptions, NsXMP, "Rating")
+
+	desc := descriptions[0]
+
+	setXmpAlt(desc, NsDC, "title", data.Title)
+	setXmpAlt(desc, NsDC, "description", data.Description)
+
+	// Other existing values are only removed if a replacement is written, so that
+	// properties set by other applications are preserved otherwise.
+	if data.Copyright != "" {
+		removeXmpProperty(descriptions, NsDC, "rights")
+		setXmpAlt(desc, NsDC, "rights", data.Copyright)
+	}
+
+	if data.Artist != "" {
+		removeXmpProperty(descriptions, NsDC, "creator")
+		setXmpList(desc, NsDC, "creator", "Seq", []string{data.Artist})
+	}
+
+	setXmpList(desc, NsDC, "subject", "Bag", data.Keywords)
+	setXmpList(desc, NsIptc4xmpExt, "PersonInImage", "Bag", splitXmpList(data.Subject))
+
+	desc.appendChild(NsXMP, "Rating").setText(strconv.Itoa(SanitizeRating(data.Rating)))
+
+	if dateCreated := data.xmpDateCreated(); dateCreated != "" {
+		removeXmpProperty(descriptions, NsPhotoshop, "DateCreated")
+		desc.appendChild(NsPhotoshop, "DateCreated").setText(dateCreated)
+	}
+
+	if data.Lat != 0 || data.Lng != 0 {
+		removeXmpProperty(descriptions, NsExif, "GPSLatitude")
+		removeXmpProperty(descriptions, NsExif, "GPSLongitude")
+		desc.appendChild(NsExif, "GPSLatitude").setText(xmpGpsCoord(data.Lat, 'N', 'S'))
+		desc.appendChild(NsExif, "GPSLongitude").setText(xmpGpsCoord(data.Lng, 'E', 'W'))
+	}
+
+	if data.Favorite {
+		desc.setAttr(NsFStop, "favorite", "1")
+	}
+
+	return nil
+}
+
+// xmpDateCreated returns the time when the picture was taken as XMP date string, including
+// the time zone offset if the time zone is known, or an empty string if the time is unknown.
+func (data *Data) xmpDateCreated() string {
+	if data.TakenAt.IsZero() {
+		return ""
+	} else if loc := txt.TimeZone(data.TimeZone); loc != nil && data.TimeZone != "" {
+		return data.TakenAt.In(loc).Format(time.RFC3339)
+	} else if !data.TakenAtLocal.IsZero() {
+		return data.TakenAtLocal.Format("2006-01-02T15:04:05")
+	}
+
+	return data.TakenAt.UTC().Format(time.RFC3339)
+}
+
+// xmpGpsCoord returns a GPS coordinate in the XMP format "DDD,MM.mmmmmmK", e.g. "52,27.581400N".
+func xmpGpsCoord(value float64, pos, neg rune) string {
+	ref := pos
+
+	if value < 0 {
+		ref = neg
+		value = -value
+	}
+
+	deg := math.Floor(value)
+
+	return fmt.Sprintf("%d,%.6f%c", int(deg), (value-deg)*60, ref)
+}
+
+// removeXmpProperty removes a property from all descriptions so that no duplicates remain.
+func removeXmpProperty(descriptions []*xmpNode, ns, name string) {
+	for _, desc := range descriptions {
+		desc.removeProperty(ns, name)
+	}
+}
+
+// setXmpAlt adds a language alternative property with a default value.
+func setXmpAlt(desc *xmpNode, ns, name, value string) {
+	if value == "" {
+		return
+	}
+
+	li := desc.appendChild(ns, name).appendChild(NsRDF, "Alt").appendChild(NsRDF, "li")
+	li.Attr = append(li.Attr, xml.Attr{Name: xml.Name{Space: "xml", Local: "lang"}, Value: "x-default"})
+	li.setText(value)
+}
+
+// setXmpList adds an unordered (Bag) or ordered (Seq) array property.
+func setXmpList(desc *xmpNode, ns, name, kind string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	list := desc.appendChild(ns, name).appendChild(NsRDF, kind)
+
+	for _, v := range values {
+		list.appendChild(NsRDF, "li").setText(v)
+	}
+}
+
+// splitXmpList splits a comma separated string into a list of values.
+func splitXmpList(s string) (result []string) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// writeFileAtomic writes data to a temporary file first and then renames it, so that
+// readers never see a partially written file.
+func writeFileAtomic(fileName string, data []byte) error {
+	mode := fs.ModeFile
+
+	if info, err := os.Stat(fileName); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fileName), "."+filepath.Base(fileName)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	tmpName := tmp.Name()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	} else if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	} else if err = os.Chmod(tmpName, mode); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	if err = os.Rename(tmpName, fileName); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_SaveXMP(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "new.xmp")
+
+		data := Data{
+			Title:       "Night & Day",
+			Description: "Example <file> for development",
+			Copyright:   "© PhotoPrism",
+			Artist:      "Michael Mayer",
+			Keywords:    Keywords{"berlin", "night"},
+			Subject:     "Jens Mander, Gopher",
+			Rating:      4,
+			Favorite:    true,
+		}
+
+		if err := data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Night & Day", result.Title)
+		assert.Equal(t, "Example <file> for development", result.Description)
+		assert.Equal(t, "© PhotoPrism", result.Copyright)
+		assert.Equal(t, "Michael Mayer", result.Artist)
+		assert.Equal(t, Keywords{"berlin", "night"}, result.Keywords)
+		assert.Equal(t, "Jens Mander, Gopher", result.Subject)
+		assert.Equal(t, 4, result.Rating)
+		assert.True(t, result.Favorite)
+	})
+	t.Run("DateCreated", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "date.xmp")
+
+		data := Data{
+			TakenAt:      time.Date(2023, 6, 1, 10, 30, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC),
+			TimeZone:     "Europe/Berlin",
+		}
+
+		if err := data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(b), "<photoshop:DateCreated>2023-06-01T12:30:00+02:00</photoshop:DateCreated>")
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, data.TakenAt, result.TakenAt)
+	})
+	t.Run("GPS", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "gps.xmp")
+
+		data := Data{
+			Lat: 52.45969,
+			Lng: -13.321832,
+		}
+
+		if err := data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(b), "<exif:GPSLatitude>52,27.581400N</exif:GPSLatitude>")
+		assert.Contains(t, string(b), "<exif:GPSLongitude>13,19.309920W</exif:GPSLongitude>")
+	})
+	t.Run("Merge", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "photoshop.xmp")
+
+		if b, err := os.ReadFile("testdata/photoshop.xmp"); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		data := Data{
+			Title:    "Changed Title",
+			Keywords: Keywords{"cat"},
+			Rating:   2,
+		}
+
+		if err := data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Changed Title", result.Title)
+		assert.Equal(t, "", result.Description)
+		assert.Equal(t, "Michael Mayer", result.Artist)
+		assert.Equal(t, Keywords{"cat"}, result.Keywords)
+		assert.Equal(t, 2, result.Rating)
+		assert.False(t, result.Favorite)
+
+		// Values that are not managed by PhotoPrism must be preserved.
+		assert.Equal(t, "HUAWEI", result.CameraMake)
+		assert.Equal(t, "HUAWEI P30 Rear Main Camera", result.LensModel)
+
+		b, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(b), `<photoshop:AuthorsPosition>Maintainer</photoshop:AuthorsPosition>`)
+		assert.Contains(t, string(b), `xmlns:Iptc4xmpCore="http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/"`)
+	})
+	t.Run("PreserveExisting", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "existing.xmp")
+
+		src := `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:xmp="http://ns.adobe.com/xap/1.0/">
+   <dc:title><rdf:Alt><rdf:li xml:lang="x-default">Existing Title</rdf:li></rdf:Alt></dc:title>
+   <dc:rights><rdf:Alt><rdf:li xml:lang="x-default">Existing Copyright</rdf:li></rdf:Alt></dc:rights>
+   <dc:creator><rdf:Seq><rdf:li>Existing Creator</rdf:li></rdf:Seq></dc:creator>
+   <xmp:Rating>3</xmp:Rating>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+`
+
+		if err := os.WriteFile(fileName, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		// Copyright and creator must not be removed if they are not set.
+		data := Data{Title: "Existing Title", Description: "New Description", Rating: 3}
+
+		if err := data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Existing Title", result.Title)
+		assert.Equal(t, "New Description", result.Description)
+		assert.Equal(t, "Existing Copyright", result.Copyright)
+		assert.Equal(t, "Existing Creator", result.Artist)
+		assert.Equal(t, 3, result.Rating)
+
+		// Values that are set replace existing properties.
+		data = Data{Title: "New Title", Description: "New Description", Artist: "New Creator", Rating: 5}
+
+		if err = data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		if result, err = XMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "New Title", result.Title)
+		assert.Equal(t, "New Description", result.Description)
+		assert.Equal(t, "Existing Copyright", result.Copyright)
+		assert.Equal(t, "New Creator", result.Artist)
+		assert.Equal(t, 5, result.Rating)
+	})
+	t.Run("RemoveValues", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "remove.xmp")
+
+		data := Data{
+			Title:       "Title",
+			Description: "Description",
+			Keywords:    Keywords{"cat", "dog"},
+			Subject:     "Jane Doe, John Doe",
+			Rating:      4,
+		}
+
+		if err := data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Title", result.Title)
+		assert.Equal(t, "Description", result.Description)
+		assert.Equal(t, Keywords{"cat", "dog"}, result.Keywords)
+		assert.Equal(t, "Jane Doe, John Doe", result.Subject)
+		assert.Equal(t, 4, result.Rating)
+
+		// Removed values must also be removed from the sidecar file.
+		data = Data{}
+
+		if err = data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		if result, err = XMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", result.Title)
+		assert.Equal(t, "", result.Description)
+		assert.Empty(t, result.Keywords)
+		assert.Equal(t, "", result.Subject)
+		assert.Equal(t, 0, result.Rating)
+
+		b, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(b), "<xmp:Rating>0</xmp:Rating>")
+		assert.NotContains(t, string(b), "dc:subject")
+		assert.NotContains(t, string(b), "PersonInImage")
+	})
+	t.Run("UnknownNamespace", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "unknown.xmp")
+
+		src := `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:foo="https://example.com/foo/1.0/" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="1" foo:bar="baz">
+   <foo:Items>
+    <rdf:Bag>
+     <rdf:li>one</rdf:li>
+    </rdf:Bag>
+   </foo:Items>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+`
+
+		if err := os.WriteFile(fileName, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		data := Data{Title: "Foo", Rating: 5, Favorite: true}
+
+		if err := data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(b)
+
+		assert.Contains(t, s, `foo:bar="baz"`)
+		assert.Contains(t, s, "<foo:Items>\n    <rdf:Bag>\n     <rdf:li>one</rdf:li>")
+		assert.Contains(t, s, `<xmp:Rating>5</xmp:Rating>`)
+		assert.NotContains(t, s, `xmp:Rating="1"`)
+		assert.Contains(t, s, `xmlns:fstop="http://www.fstopapp.com/xmp/"`)
+		assert.Contains(t, s, `fstop:favorite="1"`)
+		assert.Contains(t, s, `<rdf:li xml:lang="x-default">Foo</rdf:li>`)
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Foo", result.Title)
+		assert.Equal(t, 5, result.Rating)
+		assert.True(t, result.Favorite)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "invalid.xmp")
+		src := "<x:xmpmeta><rdf:RDF></x:xmpmeta>"
+
+		if err := os.WriteFile(fileName, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		data := Data{Title: "Foo"}
+
+		assert.Error(t, data.SaveXMP(fileName))
+
+		b, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, src, string(b))
+	})
+	t.Run("NotXmp", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "image.jpg")
+		data := Data{Title: "Foo"}
+
+		assert.Error(t, data.SaveXMP(fileName))
+		assert.NoFileExists(t, fileName)
+	})
+}
@@ -0,0 +1,55 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/entity/search"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// UpdateSmartAlbums evaluates the search filters of all smart albums and updates their cached
+// picture counts. Cover thumbs are updated by query.UpdateAlbumCovers.
+func UpdateSmartAlbums() (updated int, err error) {
+	start := time.Now()
+
+	albums, err := query.SmartAlbums()
+
+	if err != nil {
+		return updated, err
+	}
+
+	for _, a := range albums {
+		values := entity.Map{}
+
+		// Count matching pictures.
+		if _, count, searchErr := search.PhotoIds(form.SearchPhotos{Scope: a.AlbumUID, Count: search.MaxResults}); searchErr != nil {
+			log.Warnf("albums: %s (count %s)", searchErr, clean.Log(a.AlbumTitle))
+			continue
+		} else if count != a.CachedCount {
+			values["CachedCount"] = count
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		// Update columns directly so that the album modification time remains unchanged.
+		if err = entity.UnscopedDb().Model(&entity.Album{}).Where("id = ?", a.ID).UpdateColumns(values).Error; err != nil {
+			return updated, err
+		}
+
+		updated++
+	}
+
+	if updated > 0 {
+		entity.FlushAlbumCache()
+		log.Debugf("albums: updated %s [%s]", english.Plural(updated, "smart album", "smart albums"), time.Since(start))
+	}
+
+	return updated, nil
+}
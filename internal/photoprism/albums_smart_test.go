@@ -0,0 +1,82 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/entity/search"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestUpdateSmartAlbums(t *testing.T) {
+	album, err := entity.NewSmartAlbum("Smart Album Test", "public:true", "")
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer album.DeletePermanently()
+
+	if _, err = UpdateSmartAlbums(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, count, err := search.PhotoIds(form.SearchPhotos{Scope: album.AlbumUID, Count: search.MaxResults})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := query.AlbumByUID(album.AlbumUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, count, result.CachedCount)
+
+	// Unchanged albums must not be updated again.
+	updated, err := UpdateSmartAlbums()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, updated)
+}
+
+func TestUpdateSmartAlbums_UserLibrary(t *testing.T) {
+	defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+	entity.LibraryMode = entity.LibraryUser
+
+	album, err := entity.NewSmartAlbum("Smart Album Bob", "public:true", entity.UserFixtures.Pointer("bob").UserUID)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if err = album.Create(); err != nil {
+		t.Fatal(err)
+	} else if err = entity.UnscopedDb().Model(album).UpdateColumn("cached_count", 1).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	defer album.DeletePermanently()
+
+	if _, err = UpdateSmartAlbums(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := query.AlbumByUID(album.AlbumUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The bob fixture has not uploaded any photos, and none have been shared with this account.
+	assert.Equal(t, 0, result.CachedCount)
+}
@@ -1,3 +1,40 @@
 package backup
 
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Database backup formats.
+const (
+	FormatSQL   = "sql"
+	FormatJSONL = "jsonl"
+)
+
 const SqlBackupFileNamePattern = "[2-9][0-9][0-9][0-9]-[0-1][0-9]-[0-3][0-9].sql"
+const JsonlBackupFileNamePattern = "[2-9][0-9][0-9][0-9]-[0-1][0-9]-[0-3][0-9].jsonl"
+
+// Format returns the backup format based on the file extension if no format was specified.
+func Format(fileName, format string) string {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatJSONL:
+		return FormatJSONL
+	case FormatSQL:
+		return FormatSQL
+	}
+
+	if strings.EqualFold(filepath.Ext(fileName), "."+FormatJSONL) {
+		return FormatJSONL
+	}
+
+	return FormatSQL
+}
+
+// FileNamePattern returns the file name pattern of backups in the specified format.
+func FileNamePattern(format string) string {
+	if format == FormatJSONL {
+		return JsonlBackupFileNamePattern
+	}
+
+	return SqlBackupFileNamePattern
+}
@@ -1,6 +1,7 @@
 package backup
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -22,8 +23,9 @@ import (
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
-// Database creates a database backup dump with the specified file and path name.
-func Database(backupPath, fileName string, toStdOut, force bool, retain int) (err error) {
+// Database creates a database backup dump with the specified file and path name. The format can be
+// either FormatSQL for a native dump, or FormatJSONL for a portable backup that can be restored with any driver.
+func Database(backupPath, fileName, format string, toStdOut, force bool, retain int) (err error) {
 	// Ensure that only one database backup/restore operation is running at a time.
 	backupDatabaseMutex.Lock()
 	defer backupDatabaseMutex.Unlock()
@@ -34,6 +36,10 @@ func Database(backupPath, fileName string, toStdOut, force bool, retain int) (er
 	// Get configuration.
 	c := get.Config()
 
+	// Get backup format and file name pattern.
+	format = Format(fileName, format)
+	fileNamePattern := FileNamePattern(format)
+
 	if !toStdOut {
 		if backupPath == "" {
 			backupPath = c.BackupDatabasePath()
@@ -50,7 +56,7 @@ func Database(backupPath, fileName string, toStdOut, force bool, retain int) (er
 		}
 
 		if fileName == "" {
-			backupFile := time.Now().UTC().Format("2006-01-02") + ".sql"
+			backupFile := time.Now().UTC().Format("2006-01-02") + "." + format
 			fileName = filepath.Join(backupPath, backupFile)
 		}
 
@@ -74,6 +80,10 @@ func Database(backupPath, fileName string, toStdOut, force bool, retain int) (er
 
 	switch c.DatabaseDriver() {
 	case config.MySQL, config.MariaDB:
+		if format == FormatJSONL {
+			break
+		}
+
 		// Connect via Unix Domain Socket?
 		if socketName := c.DatabaseServer(); strings.HasPrefix(socketName, "/") {
 			cmd = exec.Command(
@@ -95,9 +105,30 @@ func Database(backupPath, fileName string, toStdOut, force bool, retain int) (er
 				c.DatabaseName(),
 			)
 		}
+	case config.Postgres:
+		if format == FormatJSONL {
+			break
+		}
+
+		cmd = exec.Command(
+			c.PgDumpBin(),
+			"-h", c.DatabaseHost(),
+			"-p", c.DatabasePortString(),
+			"-U", c.DatabaseUser(),
+			"--clean",
+			"--if-exists",
+			"--no-owner",
+			"--no-privileges",
+			c.DatabaseName(),
+		)
+
+		// Pass the password as environment variable, as pg_dump has no command flag for it.
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+c.DatabasePassword())
 	case config.SQLite3:
 		if !fs.FileExistsNotEmpty(c.DatabaseFile()) {
 			return fmt.Errorf("sqlite database file %s not found", clean.LogQuote(c.DatabaseFile()))
+		} else if format == FormatJSONL {
+			break
 		}
 
 		cmd = exec.Command(
@@ -121,25 +152,34 @@ func Database(backupPath, fileName string, toStdOut, force bool, retain int) (er
 		defer f.Close()
 	}
 
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	cmd.Stdout = f
+	if cmd == nil {
+		// Stream all tables in the portable JSONL format.
+		if counts, jsonlErr := WriteJsonl(f, c.Db(), c.Version()); jsonlErr != nil {
+			return jsonlErr
+		} else {
+			log.Infof("backup: saved %s", english.Plural(counts.Rows(), "row", "rows"))
+		}
+	} else {
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		cmd.Stdout = f
 
-	// Log exact command for debugging in trace mode.
-	log.Trace(cmd.String())
+		// Log exact command for debugging in trace mode.
+		log.Trace(cmd.String())
 
-	// Run backup command.
-	if cmdErr := cmd.Run(); cmdErr != nil {
-		if errStr := strings.TrimSpace(stderr.String()); errStr != "" {
-			return errors.New(errStr)
-		}
+		// Run backup command.
+		if cmdErr := cmd.Run(); cmdErr != nil {
+			if errStr := strings.TrimSpace(stderr.String()); errStr != "" {
+				return errors.New(errStr)
+			}
 
-		return cmdErr
+			return cmdErr
+		}
 	}
 
 	// Delete old backups if the number of backup files to keep has been specified.
 	if !toStdOut && backupPath != "" && retain > 0 {
-		files, globErr := filepath.Glob(filepath.Join(regexp.QuoteMeta(backupPath), SqlBackupFileNamePattern))
+		files, globErr := filepath.Glob(filepath.Join(regexp.QuoteMeta(backupPath), fileNamePattern))
 
 		if globErr != nil {
 			return globErr
@@ -188,11 +228,20 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 				return globErr
 			}
 
+			// Portable backups are restored if they are the most recent.
+			if jsonlFiles, jsonlErr := filepath.Glob(filepath.Join(regexp.QuoteMeta(backupPath), JsonlBackupFileNamePattern)); jsonlErr != nil {
+				return jsonlErr
+			} else {
+				files = append(files, jsonlFiles...)
+			}
+
 			if len(files) == 0 {
 				return fmt.Errorf("failed to find a backup in %s, index cannot be restored", backupPath)
 			}
 
-			sort.Strings(files)
+			sort.Slice(files, func(i, j int) bool {
+				return filepath.Base(files[i]) < filepath.Base(files[j])
+			})
 
 			fileName = files[len(files)-1]
 
@@ -230,6 +279,33 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 		log.Warnf("restore: existing index with %d pictures will be replaced", counts.Photos)
 	}
 
+	// Read from stdin or file.
+	var f *os.File
+	if fromStdIn {
+		log.Infof("restore: restoring database backup from stdin")
+		f = os.Stdin
+	} else if f, err = os.OpenFile(fileName, os.O_RDONLY, 0); err != nil {
+		return fmt.Errorf("failed to open %s: %s", clean.Log(fileName), err)
+	} else {
+		log.Infof("restore: restoring database backup from %s", clean.Log(filepath.Base(fileName)))
+		defer f.Close()
+	}
+
+	r := bufio.NewReader(f)
+
+	// Restore portable backups with any database driver.
+	if IsJsonl(r) {
+		if counts, jsonlErr := RestoreJsonl(r, c.Db()); jsonlErr != nil {
+			log.Errorf("restore: failed to restore index database")
+			return jsonlErr
+		} else {
+			log.Infof("restore: restored %s", english.Plural(counts.Rows(), "row", "rows"))
+			log.Infof("restore: index database successfully restored")
+		}
+
+		return nil
+	}
+
 	tables := entity.Entities
 
 	var cmd *exec.Cmd
@@ -259,6 +335,19 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 				c.DatabaseName(),
 			)
 		}
+	case config.Postgres:
+		cmd = exec.Command(
+			c.PsqlBin(),
+			"-h", c.DatabaseHost(),
+			"-p", c.DatabasePortString(),
+			"-U", c.DatabaseUser(),
+			"-d", c.DatabaseName(),
+			"-q",
+			"-X",
+		)
+
+		// Pass the password as environment variable, as psql has no command flag for it.
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+c.DatabasePassword())
 	case config.SQLite3:
 		log.Infoln("restore: dropping existing sqlite database tables")
 		tables.Drop(c.Db())
@@ -270,18 +359,6 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 		return fmt.Errorf("unsupported database type: %s", c.DatabaseDriver())
 	}
 
-	// Read from stdin or file.
-	var f *os.File
-	if fromStdIn {
-		log.Infof("restore: restoring database backup from stdin")
-		f = os.Stdin
-	} else if f, err = os.OpenFile(fileName, os.O_RDONLY, 0); err != nil {
-		return fmt.Errorf("failed to open %s: %s", clean.Log(fileName), err)
-	} else {
-		log.Infof("restore: restoring database backup from %s", clean.Log(filepath.Base(fileName)))
-		defer f.Close()
-	}
-
 	var stderr bytes.Buffer
 	var stdin io.WriteCloser
 	cmd.Stderr = &stderr
@@ -294,7 +371,7 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 
 	go func() {
 		defer stdin.Close()
-		if _, err = io.Copy(stdin, f); err != nil {
+		if _, err = io.Copy(stdin, r); err != nil {
 			log.Errorf(err.Error())
 		}
 	}()
@@ -22,7 +22,7 @@ func TestDatabase(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err = Database(backupPath, "", true, true, 2)
+		err = Database(backupPath, "", FormatSQL, true, true, 2)
 
 		assert.Error(t, err)
 
@@ -41,7 +41,7 @@ func TestDatabase(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err = Database(backupPath, "", false, true, 2)
+		err = Database(backupPath, "", FormatSQL, false, true, 2)
 
 		assert.Error(t, err)
 
@@ -0,0 +1,357 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/migrate"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// JsonlFormat is the name of the portable database backup format.
+const JsonlFormat = "photoprism-jsonl"
+
+// JsonlVersion is the version of the portable database backup format,
+// it must be increased whenever incompatible changes are made.
+const JsonlVersion = 1
+
+// JsonlHeader represents the first line of a portable database backup.
+type JsonlHeader struct {
+	Format  string    `json:"format"`
+	Version int       `json:"version"`
+	App     string    `json:"app,omitempty"`
+	Driver  string    `json:"driver,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// JsonlLine represents a table row, or the number of rows in a table after its last row.
+type JsonlLine struct {
+	Table string                     `json:"table"`
+	Row   map[string]json.RawMessage `json:"row,omitempty"`
+	Count *int                       `json:"count,omitempty"`
+}
+
+// jsonlNull represents a NULL value.
+var jsonlNull = json.RawMessage("null")
+
+// JsonlCounts maps table names to the number of rows in a portable database backup.
+type JsonlCounts map[string]int
+
+// Rows returns the total number of rows.
+func (c JsonlCounts) Rows() (n int) {
+	for _, count := range c {
+		n += count
+	}
+
+	return n
+}
+
+// IsJsonl checks if the buffered reader starts with a portable database backup header.
+func IsJsonl(r *bufio.Reader) bool {
+	b, _ := r.Peek(32)
+	return bytes.HasPrefix(bytes.TrimSpace(b), []byte("{"))
+}
+
+// jsonlTables returns the names of all tables to be included in a portable backup in a stable order.
+func jsonlTables() (names []string) {
+	names = make([]string, 0, len(entity.Entities))
+
+	for name := range entity.Entities {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// jsonlColumns returns the database columns of an entity model.
+func jsonlColumns(db *gorm.DB, model interface{}) (fields []*gorm.Field) {
+	for _, field := range db.NewScope(model).Fields() {
+		if field.IsNormal && !field.IsIgnored && field.DBName != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// isBytes checks if the field contains binary data, e.g. a json.RawMessage.
+func isBytes(field *gorm.Field) bool {
+	return field.Field.Kind() == reflect.Slice && field.Field.Type().Elem().Kind() == reflect.Uint8
+}
+
+// jsonlValue returns the field value, with binary data as plain byte slice so that it is
+// base64 encoded in backups and not expanded to a list when used as query argument.
+func jsonlValue(field *gorm.Field) interface{} {
+	if isBytes(field) {
+		return field.Field.Bytes()
+	}
+
+	return field.Field.Interface()
+}
+
+// WriteJsonl streams all entity tables as JSON lines to the writer and returns the row counts.
+func WriteJsonl(w io.Writer, db *gorm.DB, app string) (counts JsonlCounts, err error) {
+	if db == nil {
+		return counts, errors.New("database not connected")
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	header := JsonlHeader{
+		Format:  JsonlFormat,
+		Version: JsonlVersion,
+		App:     app,
+		Driver:  db.Dialect().GetName(),
+		Created: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err = enc.Encode(header); err != nil {
+		return counts, err
+	}
+
+	counts = make(JsonlCounts, len(entity.Entities))
+
+	for _, name := range jsonlTables() {
+		if counts[name], err = writeJsonlTable(enc, db, name); err != nil {
+			return counts, fmt.Errorf("%s in %s", err, clean.Log(name))
+		}
+	}
+
+	return counts, nil
+}
+
+// writeJsonlTable encodes all rows of the specified table.
+func writeJsonlTable(enc *json.Encoder, db *gorm.DB, name string) (count int, err error) {
+	modelType := reflect.TypeOf(entity.Entities[name]).Elem()
+
+	rows, err := db.Unscoped().Table(name).Rows()
+
+	if err != nil {
+		return count, err
+	}
+
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+
+	if err != nil {
+		return count, err
+	}
+
+	for rows.Next() {
+		m := reflect.New(modelType).Interface()
+		fields := make(map[string]*gorm.Field)
+
+		for _, field := range jsonlColumns(db, m) {
+			fields[field.DBName] = field
+		}
+
+		// Scan into pointers so that NULL values can be preserved.
+		dest := make([]interface{}, len(cols))
+
+		for i, col := range cols {
+			if field, ok := fields[col]; ok {
+				dest[i] = reflect.New(reflect.PtrTo(field.Field.Type())).Interface()
+			} else {
+				dest[i] = new(interface{})
+			}
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return count, err
+		}
+
+		line := JsonlLine{Table: name, Row: make(map[string]json.RawMessage, len(fields))}
+
+		for i, col := range cols {
+			field, ok := fields[col]
+
+			if !ok {
+				continue
+			}
+
+			if v := reflect.ValueOf(dest[i]).Elem(); v.IsNil() {
+				line.Row[col] = jsonlNull
+				continue
+			} else {
+				field.Field.Set(v.Elem())
+			}
+
+			if line.Row[col], err = json.Marshal(jsonlValue(field)); err != nil {
+				return count, err
+			}
+		}
+
+		if err = enc.Encode(line); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	if err = rows.Err(); err != nil {
+		return count, err
+	}
+
+	return count, enc.Encode(JsonlLine{Table: name, Count: &count})
+}
+
+// ReadJsonl reads a portable database backup and calls the function for each row.
+// An error is returned if the backup is incomplete or has an unsupported format.
+func ReadJsonl(r io.Reader, rowFunc func(table string, row map[string]json.RawMessage) error) (header JsonlHeader, counts JsonlCounts, err error) {
+	dec := json.NewDecoder(r)
+
+	if err = dec.Decode(&header); err != nil {
+		return header, counts, fmt.Errorf("invalid backup header (%s)", err)
+	} else if header.Format != JsonlFormat {
+		return header, counts, fmt.Errorf("unknown backup format %s", clean.Log(header.Format))
+	} else if header.Version < 1 || header.Version > JsonlVersion {
+		return header, counts, fmt.Errorf("unsupported backup version %d", header.Version)
+	}
+
+	rows := make(JsonlCounts)
+	counts = make(JsonlCounts)
+
+	for {
+		var line JsonlLine
+
+		if err = dec.Decode(&line); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return header, counts, fmt.Errorf("invalid backup line (%s)", err)
+		} else if line.Table == "" {
+			return header, counts, errors.New("invalid backup line (missing table)")
+		}
+
+		if line.Count != nil {
+			if *line.Count != rows[line.Table] {
+				return header, counts, fmt.Errorf("expected %d rows in %s, found %d", *line.Count, clean.Log(line.Table), rows[line.Table])
+			}
+
+			counts[line.Table] = *line.Count
+			continue
+		}
+
+		rows[line.Table]++
+
+		if rowFunc == nil {
+			continue
+		} else if err = rowFunc(line.Table, line.Row); err != nil {
+			return header, counts, err
+		}
+	}
+
+	// Make sure that all tables are complete.
+	for table, n := range rows {
+		if _, ok := counts[table]; !ok {
+			return header, counts, fmt.Errorf("backup is incomplete, found %d rows in %s without count", n, clean.Log(table))
+		}
+	}
+
+	return header, counts, nil
+}
+
+// RestoreJsonl replaces the contents of all entity tables with the rows in the portable database backup.
+// Tables and columns that do not exist in the current version are skipped.
+func RestoreJsonl(r io.Reader, db *gorm.DB) (counts JsonlCounts, err error) {
+	if db == nil {
+		return counts, errors.New("database not connected")
+	}
+
+	// Make sure all tables exist.
+	entity.Entities.Migrate(db, migrate.Opt(true, false, nil))
+
+	tx := db.Begin()
+
+	if err = tx.Error; err != nil {
+		return counts, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Remove existing data.
+	for _, name := range jsonlTables() {
+		if err = tx.Exec(fmt.Sprintf("DELETE FROM %s", tx.Dialect().Quote(name))).Error; err != nil {
+			return counts, fmt.Errorf("%s in %s", err, clean.Log(name))
+		}
+	}
+
+	skipped := make(map[string]bool)
+
+	insertRow := func(table string, row map[string]json.RawMessage) error {
+		model, ok := entity.Entities[table]
+
+		if !ok {
+			if !skipped[table] {
+				log.Warnf("restore: skipped unknown table %s", clean.Log(table))
+				skipped[table] = true
+			}
+
+			return nil
+		}
+
+		m := reflect.New(reflect.TypeOf(model).Elem()).Interface()
+		fields := jsonlColumns(tx, m)
+		cols := make([]string, 0, len(fields))
+		vars := make([]string, 0, len(fields))
+		values := make([]interface{}, 0, len(fields))
+
+		for _, field := range fields {
+			cols = append(cols, tx.Dialect().Quote(field.DBName))
+			vars = append(vars, "?")
+
+			if raw, found := row[field.DBName]; !found {
+				// Keep default value.
+			} else if bytes.Equal(raw, jsonlNull) {
+				values = append(values, nil)
+				continue
+			} else if isBytes(field) {
+				var b []byte
+
+				if unmarshalErr := json.Unmarshal(raw, &b); unmarshalErr != nil {
+					return fmt.Errorf("%s in %s.%s", unmarshalErr, clean.Log(table), clean.Log(field.DBName))
+				}
+
+				field.Field.SetBytes(b)
+			} else if unmarshalErr := json.Unmarshal(raw, field.Field.Addr().Interface()); unmarshalErr != nil {
+				return fmt.Errorf("%s in %s.%s", unmarshalErr, clean.Log(table), clean.Log(field.DBName))
+			}
+
+			values = append(values, jsonlValue(field))
+		}
+
+		stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tx.Dialect().Quote(table), strings.Join(cols, ","), strings.Join(vars, ","))
+
+		return tx.Exec(stmt, values...).Error
+	}
+
+	if _, counts, err = ReadJsonl(r, insertRow); err != nil {
+		return counts, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return counts, err
+	}
+
+	entity.Entities.UpdateSequences(db)
+
+	return counts, nil
+}
@@ -0,0 +1,133 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, FormatSQL, Format("", ""))
+	assert.Equal(t, FormatSQL, Format("-", ""))
+	assert.Equal(t, FormatSQL, Format("2024-01-02.sql", ""))
+	assert.Equal(t, FormatJSONL, Format("2024-01-02.jsonl", ""))
+	assert.Equal(t, FormatJSONL, Format("2024-01-02.JSONL", ""))
+	assert.Equal(t, FormatJSONL, Format("-", "jsonl"))
+	assert.Equal(t, FormatSQL, Format("backup.jsonl", "sql"))
+	assert.Equal(t, SqlBackupFileNamePattern, FileNamePattern(FormatSQL))
+	assert.Equal(t, JsonlBackupFileNamePattern, FileNamePattern(FormatJSONL))
+}
+
+func TestWriteJsonl(t *testing.T) {
+	var buf bytes.Buffer
+
+	counts, err := WriteJsonl(&buf, get.Config().Db(), "test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, counts, len(entity.Entities))
+	assert.Greater(t, counts[entity.Photo{}.TableName()], 0)
+	assert.True(t, IsJsonl(bufio.NewReader(bytes.NewReader(buf.Bytes()))))
+
+	header, read, err := ReadJsonl(bytes.NewReader(buf.Bytes()), nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, JsonlFormat, header.Format)
+	assert.Equal(t, JsonlVersion, header.Version)
+	assert.Equal(t, "test", header.App)
+	assert.Equal(t, counts, read)
+}
+
+func TestReadJsonl(t *testing.T) {
+	t.Run("UnknownFormat", func(t *testing.T) {
+		_, _, err := ReadJsonl(strings.NewReader(`{"format":"foo","version":1}`), nil)
+		assert.Error(t, err)
+	})
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		_, _, err := ReadJsonl(strings.NewReader(`{"format":"photoprism-jsonl","version":99}`), nil)
+		assert.Error(t, err)
+	})
+	t.Run("Incomplete", func(t *testing.T) {
+		backup := `{"format":"photoprism-jsonl","version":1}
+{"table":"labels","row":{"id":1}}
+`
+		_, _, err := ReadJsonl(strings.NewReader(backup), nil)
+		assert.Error(t, err)
+	})
+	t.Run("CountMismatch", func(t *testing.T) {
+		backup := `{"format":"photoprism-jsonl","version":1}
+{"table":"labels","row":{"id":1}}
+{"table":"labels","count":2}
+`
+		_, _, err := ReadJsonl(strings.NewReader(backup), nil)
+		assert.Error(t, err)
+	})
+	t.Run("Success", func(t *testing.T) {
+		backup := `{"format":"photoprism-jsonl","version":1}
+{"table":"labels","row":{"id":1}}
+{"table":"labels","count":1}
+`
+		var tables []string
+
+		_, counts, err := ReadJsonl(strings.NewReader(backup), func(table string, row map[string]json.RawMessage) error {
+			tables = append(tables, table)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"labels"}, tables)
+		assert.Equal(t, 1, counts.Rows())
+	})
+	t.Run("NotJsonl", func(t *testing.T) {
+		assert.False(t, IsJsonl(bufio.NewReader(strings.NewReader("-- MariaDB dump"))))
+	})
+}
+
+func TestRestoreJsonl(t *testing.T) {
+	var buf bytes.Buffer
+
+	counts, err := WriteJsonl(&buf, get.Config().Db(), "test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open("sqlite3", filepath.Join(t.TempDir(), "restore.db"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	restored, err := RestoreJsonl(&buf, db)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts, restored)
+
+	var photos int
+
+	if err = db.Model(&entity.Photo{}).Unscoped().Count(&photos).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts[entity.Photo{}.TableName()], photos)
+}
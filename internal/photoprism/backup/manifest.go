@@ -0,0 +1,163 @@
+package backup
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Originals backup folder and manifest names.
+const (
+	ManifestsDir             = "manifests"
+	BlobsDir                 = "files"
+	manifestFileNameTemplate = "2006-01-02"
+)
+
+// manifestFileNameRegexp matches the names of manifest files.
+var manifestFileNameRegexp = regexp.MustCompile(`^[2-9]\d{3}-[0-1]\d-[0-3]\d\.json$`)
+
+// ManifestFile represents an original or sidecar file in a backup manifest.
+type ManifestFile struct {
+	Root    string `json:"root"`
+	Name    string `json:"name"`
+	Hash    string `json:"hash"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"`
+}
+
+// Key returns the root and file name, e.g. for use as map key.
+func (m ManifestFile) Key() string {
+	return path.Join(m.Root, m.Name)
+}
+
+// Manifest lists the originals and sidecar files in an incremental backup.
+type Manifest struct {
+	Created time.Time      `json:"created"`
+	App     string         `json:"app,omitempty"`
+	Files   []ManifestFile `json:"files"`
+}
+
+// NewManifest returns a new, empty backup manifest.
+func NewManifest(app string) *Manifest {
+	return &Manifest{
+		Created: time.Now().UTC().Truncate(time.Second),
+		App:     app,
+		Files:   []ManifestFile{},
+	}
+}
+
+// Map returns the manifest files indexed by key.
+func (m *Manifest) Map() map[string]ManifestFile {
+	result := make(map[string]ManifestFile, len(m.Files))
+
+	for _, f := range m.Files {
+		result[f.Key()] = f
+	}
+
+	return result
+}
+
+// Hashes returns the set of file hashes referenced by the manifest.
+func (m *Manifest) Hashes() map[string]bool {
+	result := make(map[string]bool, len(m.Files))
+
+	for _, f := range m.Files {
+		result[f.Hash] = true
+	}
+
+	return result
+}
+
+// Save uploads the manifest to the target.
+func (m *Manifest) Save(t Target) (name string, err error) {
+	sort.Slice(m.Files, func(i, j int) bool {
+		return m.Files[i].Key() < m.Files[j].Key()
+	})
+
+	data, err := json.Marshal(m)
+
+	if err != nil {
+		return name, err
+	}
+
+	tmp, err := os.CreateTemp("", "manifest-*.json")
+
+	if err != nil {
+		return name, err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return name, err
+	} else if err = tmp.Close(); err != nil {
+		return name, err
+	}
+
+	name = path.Join(ManifestsDir, m.Created.Format(manifestFileNameTemplate)+".json")
+
+	return name, t.Upload(tmp.Name(), name)
+}
+
+// Manifests returns the names of all manifests in the target, sorted from oldest to newest.
+func Manifests(t Target) (names []string, err error) {
+	found, err := t.List(ManifestsDir)
+
+	if err != nil {
+		return names, err
+	}
+
+	for _, name := range found {
+		if manifestFileNameRegexp.MatchString(path.Base(name)) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// LoadManifest downloads and parses a manifest from the target.
+func LoadManifest(t Target, name string) (*Manifest, error) {
+	tmp, err := os.CreateTemp("", "manifest-*.json")
+
+	if err != nil {
+		return nil, err
+	}
+
+	_ = tmp.Close()
+
+	defer os.Remove(tmp.Name())
+
+	if err = t.Download(name, tmp.Name()); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(tmp.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// blobName returns the name under which a file with the specified hash is stored in the target.
+func blobName(hash string) string {
+	if len(hash) < 2 {
+		return path.Join(BlobsDir, hash)
+	}
+
+	return path.Join(BlobsDir, hash[:2], hash)
+}
@@ -0,0 +1,274 @@
+package backup
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	pfs "github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// OriginalsResult represents the outcome of an incremental originals backup.
+type OriginalsResult struct {
+	Manifest string
+	Files    int
+	Uploaded int
+	Removed  int
+}
+
+// Originals creates an incremental backup of all indexed originals and sidecar files in the specified
+// local path or WebDAV URL. Files are stored by their SHA1 hash, so that only new and changed files are
+// copied, and a manifest is saved for each day. If force is true, all files are hashed and uploaded again.
+func Originals(dest string, force bool, retain int) (result OriginalsResult, err error) {
+	// Make sure only one backup operation is running at a time.
+	backupOriginalsMutex.Lock()
+	defer backupOriginalsMutex.Unlock()
+
+	c := get.Config()
+
+	if dest == "" {
+		dest = c.BackupOriginalsPath()
+	}
+
+	t, err := NewTarget(dest)
+
+	if err != nil {
+		return result, err
+	}
+
+	log.Infof("backup: creating incremental backup of originals and sidecar files in %s", t)
+
+	// Find existing manifests.
+	manifests, err := Manifests(t)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Hashes of unchanged sidecar files can be taken from the latest manifest.
+	previous := make(map[string]ManifestFile)
+
+	if len(manifests) > 0 && !force {
+		if m, loadErr := LoadManifest(t, manifests[len(manifests)-1]); loadErr != nil {
+			log.Warnf("backup: %s (load manifest)", loadErr)
+		} else {
+			previous = m.Map()
+		}
+	}
+
+	// Find files that have already been stored.
+	blobs, err := t.List(BlobsDir)
+
+	if err != nil {
+		return result, err
+	}
+
+	stored := make(map[string]bool, len(blobs))
+
+	for _, name := range blobs {
+		stored[path.Base(name)] = true
+	}
+
+	m := NewManifest(c.Version())
+	uploaded := make(map[string]bool)
+
+	// Remember the last upload error, so that it can be returned once the manifest has been saved.
+	var uploadErr error
+
+	// addFile uploads the file if its hash has not been stored yet and adds it to the manifest.
+	addFile := func(root, name, fileName, hash string, info os.FileInfo) {
+		if hash == "" {
+			log.Warnf("backup: failed to hash %s", clean.Log(name))
+			return
+		}
+
+		if uploaded[hash] || stored[hash] && !force {
+			// Already stored.
+		} else if copyErr := t.Upload(fileName, blobName(hash)); copyErr != nil {
+			log.Errorf("backup: %s (upload %s)", copyErr, clean.Log(name))
+			uploadErr = copyErr
+			return
+		} else {
+			log.Debugf("backup: uploaded %s", clean.Log(name))
+			uploaded[hash] = true
+			result.Uploaded++
+		}
+
+		m.Files = append(m.Files, ManifestFile{
+			Root:    root,
+			Name:    name,
+			Hash:    hash,
+			Size:    info.Size(),
+			ModTime: info.ModTime().UTC().Unix(),
+		})
+	}
+
+	// Add indexed originals, using the hash stored in the index unless the file has changed.
+	limit := 10000
+	notFound := 0
+
+	for offset := 0; ; offset += limit {
+		files, queryErr := query.Files(limit, offset, "", false)
+
+		if queryErr != nil {
+			return result, queryErr
+		} else if len(files) == 0 {
+			break
+		}
+
+		for _, f := range files {
+			if f.FileRoot != entity.RootOriginals {
+				continue
+			}
+
+			fileName := photoprism.FileName(f.FileRoot, f.FileName)
+			info, statErr := os.Stat(fileName)
+
+			if statErr != nil {
+				log.Debugf("backup: %s not found", clean.Log(f.FileName))
+				notFound++
+				continue
+			}
+
+			hash := f.FileHash
+
+			if force || !rnd.IsSHA1(hash) || f.Changed(info.Size(), info.ModTime()) {
+				hash = pfs.Hash(fileName)
+			}
+
+			addFile(entity.RootOriginals, f.FileName, fileName, hash, info)
+		}
+	}
+
+	if notFound > 0 {
+		log.Warnf("backup: skipped %s that could not be found", english.Plural(notFound, "indexed file", "indexed files"))
+	}
+
+	// Add sidecar files, if they are stored in a separate folder.
+	if sidecarPath := c.SidecarPath(); filepath.IsAbs(sidecarPath) && pfs.PathExists(sidecarPath) {
+		walkErr := filepath.WalkDir(sidecarPath, func(fileName string, d fs.DirEntry, err error) error {
+			if err != nil {
+				log.Warnf("backup: %s", err)
+				return nil
+			}
+
+			isHidden := strings.HasPrefix(d.Name(), ".")
+
+			if d.IsDir() {
+				if isHidden && fileName != sidecarPath {
+					return filepath.SkipDir
+				}
+
+				return nil
+			} else if isHidden || !d.Type().IsRegular() {
+				return nil
+			}
+
+			info, infoErr := d.Info()
+
+			if infoErr != nil {
+				log.Warnf("backup: %s", infoErr)
+				return nil
+			}
+
+			name := pfs.RelName(fileName, sidecarPath)
+			hash := ""
+
+			if p, ok := previous[path.Join(entity.RootSidecar, name)]; ok && p.Size == info.Size() && p.ModTime == info.ModTime().UTC().Unix() {
+				hash = p.Hash
+			} else {
+				hash = pfs.Hash(fileName)
+			}
+
+			addFile(entity.RootSidecar, name, fileName, hash, info)
+
+			return nil
+		})
+
+		if walkErr != nil {
+			return result, walkErr
+		}
+	}
+
+	result.Files = len(m.Files)
+
+	// Save manifest.
+	if result.Manifest, err = m.Save(t); err != nil {
+		return result, err
+	}
+
+	log.Infof("backup: saved manifest %s with %s", clean.Log(path.Base(result.Manifest)), english.Plural(result.Files, "file", "files"))
+
+	// Remove old manifests and files that are no longer referenced.
+	if retain > 0 {
+		if result.Removed, err = pruneOriginals(t, retain); err != nil {
+			return result, err
+		}
+	}
+
+	return result, uploadErr
+}
+
+// pruneOriginals removes manifests exceeding the retention limit and files that are no longer referenced.
+func pruneOriginals(t Target, retain int) (removed int, err error) {
+	manifests, err := Manifests(t)
+
+	if err != nil || len(manifests) <= retain {
+		return removed, err
+	}
+
+	// Collect the hashes of all files in the manifests to keep.
+	keep := make(map[string]bool)
+
+	for _, name := range manifests[len(manifests)-retain:] {
+		m, loadErr := LoadManifest(t, name)
+
+		if loadErr != nil {
+			return removed, loadErr
+		}
+
+		for hash := range m.Hashes() {
+			keep[hash] = true
+		}
+	}
+
+	for _, name := range manifests[:len(manifests)-retain] {
+		if err = t.Delete(name); err != nil {
+			return removed, err
+		}
+
+		log.Infof("backup: removed manifest %s", clean.Log(path.Base(name)))
+	}
+
+	blobs, err := t.List(BlobsDir)
+
+	if err != nil {
+		return removed, err
+	}
+
+	for _, name := range blobs {
+		if keep[path.Base(name)] {
+			continue
+		} else if err = t.Delete(name); err != nil {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	if removed > 0 {
+		log.Infof("backup: removed %s no longer referenced", english.Plural(removed, "file", "files"))
+	}
+
+	return removed, nil
+}
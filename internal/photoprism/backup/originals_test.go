@@ -0,0 +1,167 @@
+package backup
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestOriginals(t *testing.T) {
+	dest := t.TempDir()
+
+	// Add a sidecar file to be backed up.
+	sidecarDir := filepath.Join(get.Config().SidecarPath(), "backup-test")
+	sidecarFile := filepath.Join(sidecarDir, "example.yml")
+
+	if err := os.MkdirAll(sidecarDir, fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(sidecarFile, []byte("Title: Example\n"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(sidecarDir)
+
+	result, err := Originals(dest, false, 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, result.Files, 1)
+	assert.GreaterOrEqual(t, result.Uploaded, 1)
+	assert.FileExists(t, filepath.Join(dest, blobName(fs.Hash(sidecarFile))))
+
+	assert.Equal(t, path.Join(ManifestsDir, filepath.Base(result.Manifest)), result.Manifest)
+	assert.FileExists(t, filepath.Join(dest, result.Manifest))
+
+	// Unchanged files must not be copied again.
+	again, err := Originals(dest, false, 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, result.Manifest, again.Manifest)
+	assert.Equal(t, result.Files, again.Files)
+	assert.Equal(t, 0, again.Uploaded)
+
+	t.Run("Verify", func(t *testing.T) {
+		verified, verifyErr := VerifyOriginals(dest, "")
+
+		if verifyErr != nil {
+			t.Fatal(verifyErr)
+		}
+
+		assert.Equal(t, result.Manifest, verified.Manifest)
+		assert.Equal(t, result.Files, verified.Files)
+		assert.Equal(t, result.Files, verified.Verified)
+		assert.Empty(t, verified.Missing)
+		assert.Empty(t, verified.Corrupted)
+	})
+	t.Run("ManifestNotFound", func(t *testing.T) {
+		_, verifyErr := VerifyOriginals(dest, "2001-01-01")
+		assert.Error(t, verifyErr)
+	})
+	t.Run("Retain", func(t *testing.T) {
+		target, targetErr := NewTarget(dest)
+
+		if targetErr != nil {
+			t.Fatal(targetErr)
+		}
+
+		// Add older manifests that reference a file that is no longer needed.
+		unused := blobName("0000000000000000000000000000000000000000")
+
+		if err = os.MkdirAll(filepath.Dir(filepath.Join(dest, unused)), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(filepath.Join(dest, unused), []byte("unused"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, name := range []string{"2020-01-01.json", "2020-01-02.json"} {
+			if err = os.WriteFile(filepath.Join(dest, ManifestsDir, name), []byte(`{"files":[]}`), fs.ModeFile); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		removed, pruneErr := pruneOriginals(target, 2)
+
+		if pruneErr != nil {
+			t.Fatal(pruneErr)
+		}
+
+		assert.Equal(t, 1, removed)
+		assert.NoFileExists(t, filepath.Join(dest, unused))
+		assert.NoFileExists(t, filepath.Join(dest, ManifestsDir, "2020-01-01.json"))
+		assert.FileExists(t, filepath.Join(dest, ManifestsDir, "2020-01-02.json"))
+		assert.FileExists(t, filepath.Join(dest, result.Manifest))
+	})
+}
+
+func TestVerifyOriginals(t *testing.T) {
+	t.Run("Corrupted", func(t *testing.T) {
+		dest := t.TempDir()
+
+		target, err := NewTarget(dest)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		src := filepath.Join(t.TempDir(), "example.txt")
+
+		if err = os.WriteFile(src, []byte("example"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		hash := fs.Hash(src)
+
+		if err = target.Upload(src, blobName(hash)); err != nil {
+			t.Fatal(err)
+		}
+
+		m := NewManifest("test")
+		m.Files = append(m.Files,
+			ManifestFile{Root: "sidecar", Name: "example.txt", Hash: hash, Size: 7},
+			ManifestFile{Root: "sidecar", Name: "missing.txt", Hash: "1111111111111111111111111111111111111111", Size: 7},
+		)
+
+		if _, err = m.Save(target); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := VerifyOriginals(dest, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, result.Verified)
+		assert.Equal(t, []string{"sidecar/missing.txt"}, result.Missing)
+		assert.False(t, result.Ok())
+
+		// Modify the copy.
+		if err = os.WriteFile(filepath.Join(dest, blobName(hash)), []byte("changed"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err = VerifyOriginals(dest, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, result.Verified)
+		assert.Equal(t, []string{"sidecar/example.txt"}, result.Corrupted)
+	})
+	t.Run("NoBackup", func(t *testing.T) {
+		_, err := VerifyOriginals(t.TempDir(), "")
+		assert.Error(t, err)
+	})
+}
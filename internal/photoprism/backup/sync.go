@@ -6,7 +6,8 @@ import (
 )
 
 var (
-	backupDatabaseMutex = sync.Mutex{}
-	backupAlbumsMutex   = sync.Mutex{}
-	backupAlbumsTime    = time.Time{}
+	backupDatabaseMutex  = sync.Mutex{}
+	backupAlbumsMutex    = sync.Mutex{}
+	backupAlbumsTime     = time.Time{}
+	backupOriginalsMutex = sync.Mutex{}
 )
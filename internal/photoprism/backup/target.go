@@ -0,0 +1,212 @@
+package backup
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/service/webdav"
+	"github.com/photoprism/photoprism/pkg/clean"
+	pfs "github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Target represents a backup storage location, e.g. a local folder or a WebDAV server.
+// All file names are slash-separated and relative to the target's base path.
+type Target interface {
+	String() string
+	List(dir string) ([]string, error)
+	Upload(src, name string) error
+	Download(name, dest string) error
+	Hash(name string) (string, error)
+	Delete(name string) error
+}
+
+// NewTarget returns a backup target based on the specified local path or WebDAV URL.
+func NewTarget(dest string) (Target, error) {
+	dest = strings.TrimSpace(dest)
+
+	switch {
+	case dest == "":
+		return nil, errors.New("backup target is empty")
+	case strings.HasPrefix(dest, "http://") || strings.HasPrefix(dest, "https://"):
+		client, err := webdav.NewClient(dest, "", "", webdav.TimeoutLow)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Remove password from URL so that it can be logged.
+		if u, parseErr := url.Parse(dest); parseErr == nil {
+			dest = u.Redacted()
+		}
+
+		return &webdavTarget{client: client, url: dest}, nil
+	default:
+		if err := pfs.MkdirAll(dest); err != nil {
+			return nil, err
+		}
+
+		return &localTarget{path: dest}, nil
+	}
+}
+
+// localTarget stores backup files in a local folder.
+type localTarget struct {
+	path string
+}
+
+// String returns the target path for use in logs.
+func (t *localTarget) String() string {
+	return clean.Log(t.path)
+}
+
+// fileName returns the absolute local file name.
+func (t *localTarget) fileName(name string) string {
+	return filepath.Join(t.path, filepath.FromSlash(name))
+}
+
+// List returns the names of all files in the specified directory and its subdirectories.
+func (t *localTarget) List(dir string) (result []string, err error) {
+	root := t.fileName(dir)
+
+	if !pfs.PathExists(root) {
+		return result, nil
+	}
+
+	err = filepath.WalkDir(root, func(fileName string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		} else if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+
+		if rel, relErr := filepath.Rel(t.path, fileName); relErr != nil {
+			return relErr
+		} else {
+			result = append(result, filepath.ToSlash(rel))
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Upload copies a local file to the target.
+func (t *localTarget) Upload(src, name string) error {
+	dest := t.fileName(name)
+	tmp := dest + ".tmp"
+
+	// Remove leftovers of interrupted uploads, as existing files are not truncated when copying.
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Copy to a temporary file first so that no incomplete files remain.
+	if err := pfs.Copy(src, tmp); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, dest)
+}
+
+// Download copies a file from the target to a local file.
+func (t *localTarget) Download(name, dest string) error {
+	// Remove existing file first, as it would otherwise not be truncated.
+	if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return pfs.Copy(t.fileName(name), dest)
+}
+
+// Hash returns the SHA1 hash of a file in the target.
+func (t *localTarget) Hash(name string) (string, error) {
+	fileName := t.fileName(name)
+
+	if !pfs.FileExists(fileName) {
+		return "", os.ErrNotExist
+	}
+
+	return pfs.Hash(fileName), nil
+}
+
+// Delete removes a file from the target.
+func (t *localTarget) Delete(name string) error {
+	return os.Remove(t.fileName(name))
+}
+
+// webdavTarget stores backup files on a WebDAV server.
+type webdavTarget struct {
+	client *webdav.Client
+	url    string
+}
+
+// String returns the server URL without password for use in logs.
+func (t *webdavTarget) String() string {
+	return clean.Log(t.url)
+}
+
+// List returns the names of all files in the specified directory and its subdirectories.
+func (t *webdavTarget) List(dir string) (result []string, err error) {
+	files, err := t.client.Files(dir, true)
+
+	if err != nil {
+		// Ignore missing directories, e.g. when creating the first backup.
+		if webdav.IsNotFound(err) {
+			return result, nil
+		}
+
+		return result, err
+	}
+
+	for _, f := range files {
+		result = append(result, strings.TrimPrefix(f.Abs, "/"))
+	}
+
+	return result, nil
+}
+
+// Upload uploads a local file to the server.
+func (t *webdavTarget) Upload(src, name string) error {
+	if err := t.client.MkdirAll(path.Dir(name)); err != nil {
+		return err
+	}
+
+	return t.client.Upload(src, name)
+}
+
+// Download downloads a file from the server.
+func (t *webdavTarget) Download(name, dest string) error {
+	return t.client.Download(name, dest, true)
+}
+
+// Hash downloads a file from the server and returns its SHA1 hash.
+func (t *webdavTarget) Hash(name string) (string, error) {
+	tmp, err := os.CreateTemp("", "backup-*")
+
+	if err != nil {
+		return "", err
+	}
+
+	tmpName := tmp.Name()
+	_ = tmp.Close()
+
+	defer os.Remove(tmpName)
+
+	if err = t.Download(name, tmpName); err != nil {
+		return "", err
+	}
+
+	return pfs.Hash(tmpName), nil
+}
+
+// Delete removes a file from the server.
+func (t *webdavTarget) Delete(name string) error {
+	return t.client.Delete(name)
+}
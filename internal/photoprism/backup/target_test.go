@@ -0,0 +1,53 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalTarget_Upload(t *testing.T) {
+	t.Run("StaleTempFile", func(t *testing.T) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "src.txt")
+		dest := filepath.Join(dir, "target")
+
+		if err := os.WriteFile(src, []byte("new"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		target, err := NewTarget(dest)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Simulate a larger temporary file left by an interrupted upload.
+		if err = os.WriteFile(filepath.Join(dest, "file.txt.tmp"), []byte("interrupted upload"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, target.Upload(src, "file.txt"))
+
+		if b, readErr := os.ReadFile(filepath.Join(dest, "file.txt")); assert.NoError(t, readErr) {
+			assert.Equal(t, "new", string(b))
+		}
+
+		assert.NoFileExists(t, filepath.Join(dest, "file.txt.tmp"))
+
+		// Existing files must be replaced when downloading.
+		restored := filepath.Join(dir, "restored.txt")
+
+		if err = os.WriteFile(restored, []byte("existing content"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, target.Download("file.txt", restored))
+
+		if b, readErr := os.ReadFile(restored); assert.NoError(t, readErr) {
+			assert.Equal(t, "new", string(b))
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Originals backup verification errors.
+var (
+	errMissing   = errors.New("missing")
+	errCorrupted = errors.New("corrupted")
+)
+
+// VerifyResult represents the outcome of an originals backup verification.
+type VerifyResult struct {
+	Manifest  string
+	Files     int
+	Verified  int
+	Missing   []string
+	Corrupted []string
+	Outdated  []string
+}
+
+// Ok checks if all files in the backup could be verified and match the index.
+func (r VerifyResult) Ok() bool {
+	return len(r.Missing) == 0 && len(r.Corrupted) == 0 && len(r.Outdated) == 0
+}
+
+// VerifyOriginals re-hashes the files in an originals backup and compares them with the manifest and the index.
+// The latest manifest is verified if no name, e.g. "2024-01-02", is specified.
+func VerifyOriginals(dest, manifestName string) (result VerifyResult, err error) {
+	// Make sure only one backup operation is running at a time.
+	backupOriginalsMutex.Lock()
+	defer backupOriginalsMutex.Unlock()
+
+	if dest == "" {
+		dest = get.Config().BackupOriginalsPath()
+	}
+
+	t, err := NewTarget(dest)
+
+	if err != nil {
+		return result, err
+	}
+
+	manifests, err := Manifests(t)
+
+	if err != nil {
+		return result, err
+	} else if len(manifests) == 0 {
+		return result, fmt.Errorf("no backup found in %s", t)
+	}
+
+	// Find manifest.
+	if manifestName == "" {
+		result.Manifest = manifests[len(manifests)-1]
+	} else {
+		name := strings.TrimSuffix(path.Base(manifestName), ".json") + ".json"
+
+		for _, m := range manifests {
+			if path.Base(m) == name {
+				result.Manifest = m
+				break
+			}
+		}
+
+		if result.Manifest == "" {
+			return result, fmt.Errorf("manifest %s not found in %s", clean.Log(name), t)
+		}
+	}
+
+	log.Infof("backup: verifying %s in %s", clean.Log(path.Base(result.Manifest)), t)
+
+	m, err := LoadManifest(t, result.Manifest)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Files = len(m.Files)
+
+	// Re-hash each stored file once, as identical files share the same copy.
+	checked := make(map[string]error)
+
+	for _, f := range m.Files {
+		hashErr, found := checked[f.Hash]
+
+		if !found {
+			if hash, err := t.Hash(blobName(f.Hash)); err != nil {
+				log.Debugf("backup: %s (hash %s)", err, clean.Log(f.Key()))
+				hashErr = errMissing
+			} else if hash != f.Hash {
+				hashErr = errCorrupted
+			}
+
+			checked[f.Hash] = hashErr
+		}
+
+		switch hashErr {
+		case nil:
+			result.Verified++
+		case errMissing:
+			result.Missing = append(result.Missing, f.Key())
+		default:
+			result.Corrupted = append(result.Corrupted, f.Key())
+		}
+	}
+
+	// Compare the backup with the originals in the index.
+	files := m.Map()
+	limit := 10000
+
+	for offset := 0; ; offset += limit {
+		indexed, queryErr := query.Files(limit, offset, "", false)
+
+		if queryErr != nil {
+			return result, queryErr
+		} else if len(indexed) == 0 {
+			break
+		}
+
+		for _, f := range indexed {
+			if f.FileRoot != entity.RootOriginals {
+				continue
+			}
+
+			key := path.Join(f.FileRoot, f.FileName)
+
+			if backup, ok := files[key]; !ok || f.FileHash != "" && f.FileHash != backup.Hash {
+				result.Outdated = append(result.Outdated, key)
+			}
+		}
+	}
+
+	log.Infof("backup: verified %s, %d missing, %d corrupted, %d outdated",
+		english.Plural(result.Verified, "file", "files"), len(result.Missing), len(result.Corrupted), len(result.Outdated))
+
+	return result, nil
+}
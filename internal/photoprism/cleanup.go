@@ -164,8 +164,27 @@ func (w *CleanUp) Cache(opt CleanUpOptions) (deleted int, err error) {
 		err = fastwalk.Walk(dir, func(fileName string, info os.FileMode) error {
 			base := filepath.Base(fileName)
 
-			if info.IsDir() || strings.HasPrefix(base, ".") {
+			if strings.HasPrefix(base, ".") {
 				return nil
+			} else if info.IsDir() {
+				// Example: 01244519acf35c62a5fea7a5a7dcefdbec4fb2f5.hls
+				if i := strings.Index(base, "."); i < 39 || fileHashes[base[:i]] {
+					return nil
+				}
+
+				logName := clean.Log(fs.RelName(fileName, filepath.Dir(dir)))
+
+				if opt.Dry {
+					deleted++
+					log.Debugf("cleanup: %s would be deleted", logName)
+				} else if err := os.RemoveAll(fileName); err != nil {
+					log.Warnf("cleanup: %s in %s", err, logName)
+				} else {
+					deleted++
+					log.Debugf("cleanup: deleted %s from cache", logName)
+				}
+
+				return filepath.SkipDir
 			}
 
 			// Example: 01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_3x3_resize.png
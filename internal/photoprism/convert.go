@@ -20,6 +20,9 @@ import (
 type Convert struct {
 	conf               *config.Config
 	cmdMutex           sync.Mutex
+	streamMutex        sync.Mutex
+	streamJobs         chan streamJob
+	streams            map[string]streamStatus
 	sipsExclude        fs.ExtList
 	darktableExclude   fs.ExtList
 	rawTherapeeExclude fs.ExtList
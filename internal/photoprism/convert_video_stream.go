@@ -0,0 +1,292 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/thumb"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ToStream segments a video file into adaptive streaming renditions and saves the
+// playlists and segments in the specified stream folder, unless they already exist.
+func (w *Convert) ToStream(f *MediaFile, streamDir string, format ffmpeg.StreamFormat, encoder ffmpeg.AvcEncoder) (playlistName string, err error) {
+	// Abort if the source media file is nil.
+	if f == nil {
+		return "", fmt.Errorf("convert: file is nil - you may have found a bug")
+	}
+
+	// Abort if the source media file does not exist.
+	if !f.Exists() {
+		return "", fmt.Errorf("convert: %s not found", clean.Log(f.RootRelName()))
+	} else if f.Empty() {
+		return "", fmt.Errorf("convert: %s is empty", clean.Log(f.RootRelName()))
+	} else if !f.IsVideo() {
+		return "", fmt.Errorf("convert: %s is not a video", clean.Log(f.RootRelName()))
+	} else if format.PlaylistName() == "" {
+		return "", fmt.Errorf("convert: unsupported streaming format %s", clean.Log(format.String()))
+	}
+
+	playlistName = filepath.Join(streamDir, format.PlaylistName())
+
+	// Return if the playlist already exists.
+	if fs.FileExists(playlistName) {
+		return playlistName, nil
+	}
+
+	// Make sure only one convert command runs at a time.
+	w.cmdMutex.Lock()
+	defer w.cmdMutex.Unlock()
+
+	// Check again, as the stream may have been created in the meantime.
+	if fs.FileExists(playlistName) {
+		return playlistName, nil
+	}
+
+	// Create the stream in a temporary folder so that incomplete playlists are never served.
+	tmpDir := streamDir + ".tmp"
+
+	if err = os.RemoveAll(tmpDir); err != nil {
+		return "", fmt.Errorf("convert: failed to remove %s (%s)", clean.Log(filepath.Base(tmpDir)), err)
+	} else if err = fs.MkdirAll(tmpDir); err != nil {
+		return "", fmt.Errorf("convert: failed to create %s (%s)", clean.Log(filepath.Base(tmpDir)), err)
+	}
+
+	// Try again without audio and with the software encoder if segmenting fails.
+	if err = w.segmentStream(f, tmpDir, format, encoder, true); err == nil {
+		// Done.
+	} else if err = w.segmentStream(f, tmpDir, format, encoder, false); err == nil {
+		// Done.
+	} else if encoder != ffmpeg.SoftwareEncoder {
+		if err = w.segmentStream(f, tmpDir, format, ffmpeg.SoftwareEncoder, true); err != nil {
+			err = w.segmentStream(f, tmpDir, format, ffmpeg.SoftwareEncoder, false)
+		}
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return "", err
+	}
+
+	// Replace existing stream folder, if any.
+	if err = os.RemoveAll(streamDir); err != nil {
+		return "", fmt.Errorf("convert: failed to remove %s (%s)", clean.Log(filepath.Base(streamDir)), err)
+	} else if err = os.Rename(tmpDir, streamDir); err != nil {
+		return "", fmt.Errorf("convert: failed to rename %s (%s)", clean.Log(filepath.Base(tmpDir)), err)
+	}
+
+	return playlistName, nil
+}
+
+// StreamQueueSize is the maximum number of videos that can wait to be segmented for streaming.
+const StreamQueueSize = 64
+
+// StreamRetryAfter is the time after which a video is segmented again if creating the stream has failed.
+var StreamRetryAfter = 5 * time.Minute
+
+// streamJob represents a video that waits to be segmented for adaptive streaming.
+type streamJob struct {
+	file      *MediaFile
+	streamDir string
+	format    ffmpeg.StreamFormat
+	encoder   ffmpeg.AvcEncoder
+}
+
+// streamStatus represents a queued video stream, or the error if creating it has failed.
+type streamStatus struct {
+	err      error
+	failedAt time.Time
+}
+
+// QueueStream adds a video to the queue of files that are segmented for adaptive streaming by a
+// background worker, so that requests do not have to wait until FFmpeg is done. It returns nil if
+// the stream is waiting to be created, and an error if the queue is full or creating it has failed.
+func (w *Convert) QueueStream(f *MediaFile, streamDir string, format ffmpeg.StreamFormat, encoder ffmpeg.AvcEncoder) error {
+	if f == nil {
+		return fmt.Errorf("convert: file is nil - you may have found a bug")
+	}
+
+	w.streamMutex.Lock()
+	defer w.streamMutex.Unlock()
+
+	// Start worker when the first video is queued.
+	if w.streams == nil {
+		w.streams = make(map[string]streamStatus)
+		w.streamJobs = make(chan streamJob, StreamQueueSize)
+		go w.streamWorker()
+	}
+
+	// Already queued or failed recently?
+	if status, found := w.streams[streamDir]; !found {
+		// Not queued yet.
+	} else if status.err == nil || time.Since(status.failedAt) < StreamRetryAfter {
+		return status.err
+	}
+
+	select {
+	case w.streamJobs <- streamJob{file: f, streamDir: streamDir, format: format, encoder: encoder}:
+		w.streams[streamDir] = streamStatus{}
+		return nil
+	default:
+		return fmt.Errorf("convert: too many videos waiting to be streamed")
+	}
+}
+
+// streamWorker creates the queued video streams one after another. Failed streams are remembered
+// for the StreamRetryAfter duration, so that they are not created again with every request.
+func (w *Convert) streamWorker() {
+	for job := range w.streamJobs {
+		playlistName, err := w.ToStream(job.file, job.streamDir, job.format, job.encoder)
+
+		if err != nil {
+			log.Errorf("convert: %s", clean.Error(err))
+
+			// Remove incomplete stream files, if any.
+			_ = os.RemoveAll(job.streamDir + ".tmp")
+
+			if !fs.FileExists(playlistName) {
+				_ = os.RemoveAll(job.streamDir)
+			}
+		}
+
+		w.streamMutex.Lock()
+
+		if err != nil {
+			w.streams[job.streamDir] = streamStatus{err: err, failedAt: time.Now()}
+		} else {
+			delete(w.streams, job.streamDir)
+		}
+
+		w.streamMutex.Unlock()
+	}
+}
+
+// segmentStream runs the FFmpeg command that segments a video into the specified stream folder.
+func (w *Convert) segmentStream(f *MediaFile, streamDir string, format ffmpeg.StreamFormat, encoder ffmpeg.AvcEncoder, audio bool) error {
+	relName := f.RelName(w.conf.OriginalsPath())
+	renditions := w.StreamRenditions(f)
+
+	opt, err := w.conf.FFmpegOptions(encoder, renditions[len(renditions)-1].Bitrate)
+
+	if err != nil {
+		return fmt.Errorf("convert: failed to stream %s (%s)", clean.Log(f.BaseName()), err)
+	}
+
+	cmd, err := ffmpeg.StreamCommand(f.FileName(), streamDir, format, renditions, audio, opt)
+
+	if err != nil {
+		return fmt.Errorf("convert: failed to stream %s (%s)", clean.Log(f.BaseName()), err)
+	}
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	cmd.Env = append(cmd.Env, []string{
+		fmt.Sprintf("HOME=%s", w.conf.CmdCachePath()),
+	}...)
+
+	event.Publish("index.converting", event.Data{
+		"fileType": f.FileType(),
+		"fileName": relName,
+		"baseName": filepath.Base(relName),
+		"xmpName":  "",
+	})
+
+	log.Infof("%s: creating %s stream for %s with %d renditions", encoder, format, relName, len(renditions))
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Segment source media file.
+	start := time.Now()
+	if err = cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			err = errors.New(stderr.String())
+		}
+
+		// Log ffmpeg output for debugging.
+		if err.Error() != "" {
+			log.Debug(err)
+		}
+
+		// Log filename and transcoding time.
+		log.Warnf("%s: failed to create %s stream for %s [%s]", encoder, format, relName, time.Since(start))
+
+		return err
+	}
+
+	// Log filename and transcoding time.
+	log.Infof("%s: created %s stream for %s [%s]", encoder, format, relName, time.Since(start))
+
+	return nil
+}
+
+// StreamRenditions returns the adaptive streaming renditions for a video, sorted by size.
+// They are selected from the standard video sizes up to the configured size limit.
+func (w *Convert) StreamRenditions(f *MediaFile) (result ffmpeg.Renditions) {
+	limit := w.conf.FFmpegSize()
+	width, height := 0, 0
+
+	if f != nil {
+		width, height = f.Width(), f.Height()
+	}
+
+	// Don't upscale videos.
+	if longEdge := max(width, height); longEdge > 0 && longEdge < limit {
+		limit = longEdge
+	}
+
+	// Find standard sizes up to the limit, starting with the smallest.
+	for i := len(thumb.VideoSizes) - 1; i >= 0; i-- {
+		if size := thumb.VideoSizes[i].Width; size <= limit || len(result) == 0 {
+			result = append(result, ffmpeg.Rendition{Size: size, Bitrate: w.StreamBitrate(width, height, size)})
+		}
+	}
+
+	// Keep the smallest renditions and the largest, if there are too many.
+	if n := len(result); n > ffmpeg.StreamRenditionsMax {
+		result = append(result[:ffmpeg.StreamRenditionsMax-1], result[n-1])
+	}
+
+	return result
+}
+
+// StreamBitrate returns the ideal encoding bitrate of a stream rendition in megabits per second.
+func (w *Convert) StreamBitrate(width, height, size int) string {
+	const quality = 12
+
+	// Calculate the scaled dimensions, assuming 16:9 if they are unknown.
+	var w2, h2 float64
+
+	if width <= 0 || height <= 0 {
+		w2 = float64(size)
+		h2 = w2 * 9 / 16
+	} else if width >= height {
+		w2 = math.Min(float64(size), float64(width))
+		h2 = w2 * float64(height) / float64(width)
+	} else {
+		h2 = math.Min(float64(size), float64(height))
+		w2 = h2 * float64(width) / float64(height)
+	}
+
+	bitrate := int(math.Ceil(w2 * h2 * quality / 1000000))
+
+	if bitrate <= 0 {
+		bitrate = 1
+	} else if limit := w.conf.FFmpegBitrate(); bitrate > limit {
+		bitrate = limit
+	}
+
+	return fmt.Sprintf("%dM", bitrate)
+}
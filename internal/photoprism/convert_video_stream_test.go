@@ -0,0 +1,165 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestConvert_ToStream(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("gopher-video.mp4", func(t *testing.T) {
+		fileName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+		streamDir := conf.VideoStreamPath("gopher-video", ffmpeg.StreamHLS)
+
+		_ = os.RemoveAll(streamDir)
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		playlistName, err := convert.ToStream(mf, streamDir, ffmpeg.StreamHLS, ffmpeg.SoftwareEncoder)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(streamDir, "master.m3u8"), playlistName)
+		assert.Truef(t, fs.FileExists(playlistName), "playlist does not exist: %s", playlistName)
+		assert.True(t, fs.FileExists(filepath.Join(streamDir, "stream_0.m3u8")))
+		assert.False(t, fs.PathExists(streamDir+".tmp"))
+
+		_ = os.RemoveAll(streamDir)
+	})
+	t.Run("NoVideo", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = convert.ToStream(mf, conf.VideoStreamPath("elephants", ffmpeg.StreamHLS), ffmpeg.StreamHLS, ffmpeg.SoftwareEncoder)
+
+		assert.Error(t, err)
+	})
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = convert.ToStream(mf, conf.VideoStreamPath("gopher-video", ffmpeg.StreamNone), ffmpeg.StreamNone, ffmpeg.SoftwareEncoder)
+
+		assert.Error(t, err)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		_, err := convert.ToStream(nil, "", ffmpeg.StreamHLS, ffmpeg.SoftwareEncoder)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConvert_QueueStream(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("Nil", func(t *testing.T) {
+		assert.Error(t, convert.QueueStream(nil, "", ffmpeg.StreamHLS, ffmpeg.SoftwareEncoder))
+	})
+	t.Run("NotVideo", func(t *testing.T) {
+		f, err := NewMediaFile(conf.ExamplesPath() + "/beach_sand.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		streamDir := filepath.Join(conf.TempPath(), "queue-stream-test")
+
+		// Create incomplete stream files.
+		if err = fs.MkdirAll(streamDir + ".tmp"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, convert.QueueStream(f, streamDir, ffmpeg.StreamHLS, ffmpeg.SoftwareEncoder))
+
+		// Failed streams must not be queued again.
+		for i := 0; i < 100; i++ {
+			if err = convert.QueueStream(f, streamDir, ffmpeg.StreamHLS, ffmpeg.SoftwareEncoder); err != nil {
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		assert.Error(t, err)
+		assert.False(t, fs.PathExists(streamDir+".tmp"))
+		assert.False(t, fs.PathExists(streamDir))
+
+		// Failed streams are queued again after StreamRetryAfter.
+		retryAfter := StreamRetryAfter
+		StreamRetryAfter = 0
+		defer func() { StreamRetryAfter = retryAfter }()
+
+		assert.NoError(t, convert.QueueStream(f, streamDir, ffmpeg.StreamHLS, ffmpeg.SoftwareEncoder))
+	})
+}
+
+func TestConvert_StreamRenditions(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	fileName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+
+	t.Run("4K", func(t *testing.T) {
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mf.width = 3840
+		mf.height = 2160
+
+		assert.Equal(t, ffmpeg.Renditions{
+			{Size: 720, Bitrate: "4M"},
+			{Size: 1280, Bitrate: "12M"},
+			{Size: 1920, Bitrate: "25M"},
+			{Size: 3840, Bitrate: "50M"},
+		}, convert.StreamRenditions(mf))
+	})
+	t.Run("Small", func(t *testing.T) {
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mf.width = 640
+		mf.height = 360
+
+		assert.Equal(t, ffmpeg.Renditions{{Size: 720, Bitrate: "3M"}}, convert.StreamRenditions(mf))
+	})
+}
+
+func TestConvert_StreamBitrate(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	assert.Equal(t, "12M", convert.StreamBitrate(1920, 1080, 1280))
+	assert.Equal(t, "12M", convert.StreamBitrate(1080, 1920, 1280))
+	assert.Equal(t, "12M", convert.StreamBitrate(0, 0, 1280))
+	assert.Equal(t, "50M", convert.StreamBitrate(7680, 4320, 7680))
+	assert.Equal(t, "1M", convert.StreamBitrate(160, 90, 720))
+}
@@ -132,6 +132,18 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 		log.Debugf("faces: updated %s, recognized %s, %d unknown [%s]", english.Plural(int(matches.Updated), "marker", "markers"), english.Plural(int(matches.Recognized), "face", "faces"), matches.Unknown, time.Since(start))
 	}
 
+	// Cluster and match pets.
+	if w.conf.DetectPets() {
+		start = time.Now()
+		if pets, err := w.Pets(opt); err != nil {
+			log.Errorf("faces: %s (pets)", err)
+		} else if pets.Updated > 0 {
+			log.Infof("faces: updated %s, recognized %s [%s]", english.Plural(int(pets.Updated), "pet marker", "pet markers"), english.Plural(int(pets.Recognized), "pet", "pets"), time.Since(start))
+		} else {
+			log.Debugf("faces: found no new pet matches [%s]", time.Since(start))
+		}
+	}
+
 	// Remove unused people.
 	start = time.Now()
 	if count, err := entity.DeleteOrphanPeople(); err != nil {
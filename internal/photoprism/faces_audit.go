@@ -88,11 +88,7 @@ func (w *Faces) Audit(fix bool) (err error) {
 			}
 
 			// Compare face 1 with face 2.
-			if matched, dist := f1.Match(face.Embeddings{f2.Embedding()}); matched {
-				if f1.SubjUID == f2.SubjUID {
-					continue
-				}
-
+			if collision, dist := f1.MatchCollision(f2); collision {
 				conflicts++
 
 				r := f1.SampleRadius + face.MatchDist
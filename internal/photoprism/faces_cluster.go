@@ -28,21 +28,41 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 	// Fetch unclustered face embeddings.
 	embeddings, err := query.Embeddings(false, true, face.ClusterSizeThreshold, face.ClusterScoreThreshold)
 
+	if err != nil {
+		return added, err
+	}
+
 	log.Debugf("faces: found %s", english.Plural(len(embeddings), "unclustered sample", "unclustered samples"))
 
+	// Only cluster new embeddings that do not match an existing cluster, unless the force option is set.
+	if !opt.Force && len(embeddings) > 0 {
+		faces, err := query.Faces(false, false, false, false)
+
+		if err != nil {
+			return added, err
+		}
+
+		n := len(embeddings)
+		embeddings = newEmbeddings(embeddings, faces)
+
+		log.Debugf("faces: skipped %s matching existing clusters", english.Plural(n-len(embeddings), "sample", "samples"))
+	}
+
 	// Anything that keeps us from doing this?
-	if err != nil {
-		return added, err
-	} else if samples := len(embeddings); samples < opt.SampleThreshold() {
+	if n := len(embeddings); n < opt.SampleThreshold() {
 		log.Debugf("faces: at least %d samples needed for clustering", opt.SampleThreshold())
 		return added, nil
 	} else {
 		var c clusters.HardClusterer
 
+		samples := embeddings.Float64()
+
+		log.Debugf("faces: clustering %s with %s", english.Plural(len(samples), "sample", "samples"), opt.ClusterAlgorithm())
+
 		// See https://dl.photoprism.app/research/ for research on face clustering algorithms.
-		if c, err = clusters.DBSCAN(face.ClusterCore, face.ClusterDist, w.conf.IndexWorkers(), clusters.EuclideanDist); err != nil {
+		if c, err = opt.Clusterer(samples, w.conf.IndexWorkers()); err != nil {
 			return added, err
-		} else if err = c.Learn(embeddings.Float64()); err != nil {
+		} else if err = learnClusters(c, samples); err != nil {
 			return added, err
 		}
 
@@ -71,6 +91,11 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 		}
 
 		for _, cluster := range results {
+			// K-means assigns every sample to a cluster, so skip clusters that are too small.
+			if len(cluster) < opt.ClusterCore() && opt.ClusterAlgorithm() == ClusterKMeans {
+				continue
+			}
+
 			if f := entity.NewFace("", entity.SrcAuto, cluster); f == nil {
 				log.Errorf("faces: face should not be nil - you may have found a bug")
 			} else if f.SkipMatching() {
@@ -88,3 +113,40 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 
 	return added, nil
 }
+
+// newEmbeddings returns the embeddings that do not match any of the specified face clusters.
+func newEmbeddings(embeddings face.Embeddings, faces entity.Faces) face.Embeddings {
+	if len(faces) == 0 {
+		return embeddings
+	}
+
+	result := make(face.Embeddings, 0, len(embeddings))
+
+	for _, e := range embeddings {
+		matched := false
+
+		for i := range faces {
+			if ok, _ := faces[i].Match(face.Embeddings{e}); ok {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// learnClusters runs the clustering algorithm and returns an error instead of panicking if it fails.
+func learnClusters(c clusters.HardClusterer, samples [][]float64) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("clustering failed (%s)", r)
+		}
+	}()
+
+	return c.Learn(samples)
+}
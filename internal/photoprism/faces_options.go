@@ -1,10 +1,38 @@
 package photoprism
 
-import "github.com/photoprism/photoprism/internal/ai/face"
+import (
+	"fmt"
+	"strings"
 
+	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/clusters"
+)
+
+// Supported face clustering algorithms.
+const (
+	ClusterDBSCAN = "dbscan"
+	ClusterOPTICS = "optics"
+	ClusterKMeans = "kmeans"
+)
+
+// KMeansMaxClusters is the max number of clusters tested when estimating the number of
+// k-means clusters, since each estimation runs k-means once per tested number of clusters.
+const KMeansMaxClusters = 100
+
+// ClusterAlgorithms lists the supported face clustering algorithms.
+var ClusterAlgorithms = []string{ClusterDBSCAN, ClusterOPTICS, ClusterKMeans}
+
+// FacesOptions represents face clustering and matching options.
 type FacesOptions struct {
-	Force     bool
-	Threshold int
+	Force      bool
+	Threshold  int
+	Algorithm  string  // Clustering algorithm, see ClusterAlgorithms.
+	Core       int     // Min number of samples forming a cluster core.
+	Dist       float64 // Similarity distance threshold of samples forming a cluster core.
+	Xi         float64 // Steepness threshold for extracting OPTICS clusters.
+	Clusters   int     // Number of k-means clusters, estimated if zero.
+	Iterations int     // Max number of k-means iterations.
 }
 
 // SampleThreshold returns the face embeddings sample threshold for clustering.
@@ -17,6 +45,95 @@ func (o FacesOptions) SampleThreshold() int {
 	return face.SampleThreshold
 }
 
+// ClusterAlgorithm returns the normalized name of the clustering algorithm, DBSCAN by default.
+func (o FacesOptions) ClusterAlgorithm() string {
+	if s := strings.ToLower(strings.TrimSpace(o.Algorithm)); s != "" {
+		return s
+	}
+
+	return ClusterDBSCAN
+}
+
+// ValidAlgorithm checks if the clustering algorithm is supported.
+func (o FacesOptions) ValidAlgorithm() bool {
+	for _, a := range ClusterAlgorithms {
+		if a == o.ClusterAlgorithm() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClusterCore returns the min number of samples forming a cluster core.
+func (o FacesOptions) ClusterCore() int {
+	if o.Core > 0 {
+		return o.Core
+	}
+
+	return face.ClusterCore
+}
+
+// ClusterDist returns the similarity distance threshold of samples forming a cluster core.
+func (o FacesOptions) ClusterDist() float64 {
+	if o.Dist > 0 {
+		return o.Dist
+	}
+
+	return face.ClusterDist
+}
+
+// ClusterXi returns the steepness threshold for extracting OPTICS clusters.
+func (o FacesOptions) ClusterXi() float64 {
+	if o.Xi > 0 && o.Xi < 1 {
+		return o.Xi
+	}
+
+	return 0.05
+}
+
+// ClusterIterations returns the max number of k-means iterations.
+func (o FacesOptions) ClusterIterations() int {
+	if o.Iterations > 0 {
+		return o.Iterations
+	}
+
+	return 100
+}
+
+// ClusterEstimateMax returns the max number of k-means clusters to test for the number of samples,
+// as each cluster should contain at least a core.
+func (o FacesOptions) ClusterEstimateMax(samples int) int {
+	return min(KMeansMaxClusters, max(2, samples/o.ClusterCore()))
+}
+
+// Clusterer returns a new clusterer for the configured algorithm and parameters. The number of
+// k-means clusters is estimated based on the samples if it has not been specified.
+func (o FacesOptions) Clusterer(samples [][]float64, workers int) (clusters.HardClusterer, error) {
+	switch o.ClusterAlgorithm() {
+	case ClusterDBSCAN:
+		return clusters.DBSCAN(o.ClusterCore(), o.ClusterDist(), workers, clusters.EuclideanDist)
+	case ClusterOPTICS:
+		return clusters.OPTICS(o.ClusterCore(), o.ClusterDist(), o.ClusterXi(), workers, clusters.EuclideanDist)
+	case ClusterKMeans:
+		k := o.Clusters
+
+		if k <= 0 {
+			estimator, err := clusters.KMeansEstimator(o.ClusterIterations(), o.ClusterEstimateMax(len(samples)), clusters.EuclideanDist)
+
+			if err != nil {
+				return nil, err
+			} else if k, err = estimator.Estimate(samples); err != nil {
+				return nil, err
+			}
+		}
+
+		return clusters.KMeans(o.ClusterIterations(), max(2, k), clusters.EuclideanDist)
+	default:
+		return nil, fmt.Errorf("unknown clustering algorithm %s", clean.Log(o.Algorithm))
+	}
+}
+
 // FacesOptionsDefault returns new faces options with default values.
 func FacesOptionsDefault() FacesOptions {
 	result := FacesOptions{}
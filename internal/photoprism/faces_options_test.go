@@ -0,0 +1,97 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
+)
+
+func TestFacesOptions(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		opt := FacesOptionsDefault()
+
+		assert.Equal(t, face.SampleThreshold, opt.SampleThreshold())
+		assert.Equal(t, ClusterDBSCAN, opt.ClusterAlgorithm())
+		assert.True(t, opt.ValidAlgorithm())
+		assert.Equal(t, face.ClusterCore, opt.ClusterCore())
+		assert.Equal(t, face.ClusterDist, opt.ClusterDist())
+		assert.Equal(t, 0.05, opt.ClusterXi())
+		assert.Equal(t, 100, opt.ClusterIterations())
+	})
+	t.Run("Custom", func(t *testing.T) {
+		opt := FacesOptions{Threshold: 3, Algorithm: " OPTICS ", Core: 2, Dist: 0.5, Xi: 0.1, Iterations: 10}
+
+		assert.Equal(t, 3, opt.SampleThreshold())
+		assert.Equal(t, ClusterOPTICS, opt.ClusterAlgorithm())
+		assert.True(t, opt.ValidAlgorithm())
+		assert.Equal(t, 2, opt.ClusterCore())
+		assert.Equal(t, 0.5, opt.ClusterDist())
+		assert.Equal(t, 0.1, opt.ClusterXi())
+		assert.Equal(t, 10, opt.ClusterIterations())
+	})
+	t.Run("InvalidAlgorithm", func(t *testing.T) {
+		opt := FacesOptions{Algorithm: "foo"}
+
+		assert.False(t, opt.ValidAlgorithm())
+
+		_, err := opt.Clusterer(nil, 1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFacesOptions_ClusterEstimateMax(t *testing.T) {
+	opt := FacesOptions{Core: 4}
+
+	assert.Equal(t, 2, opt.ClusterEstimateMax(0))
+	assert.Equal(t, 2, opt.ClusterEstimateMax(5))
+	assert.Equal(t, 25, opt.ClusterEstimateMax(100))
+	assert.Equal(t, KMeansMaxClusters, opt.ClusterEstimateMax(100000))
+}
+
+func TestFacesOptions_Clusterer(t *testing.T) {
+	// Two groups of similar samples that are far apart.
+	samples := [][]float64{
+		{0, 0}, {0.01, 0}, {0, 0.01}, {0.01, 0.01},
+		{5, 5}, {5.01, 5}, {5, 5.01}, {5.01, 5.01},
+	}
+
+	for _, algorithm := range ClusterAlgorithms {
+		t.Run(algorithm, func(t *testing.T) {
+			opt := FacesOptions{Algorithm: algorithm, Core: 2, Dist: 0.5, Clusters: 2}
+
+			c, err := opt.Clusterer(samples, 1)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = learnClusters(c, samples); algorithm == ClusterOPTICS {
+				// Cluster extraction may fail on small sample sets, but must not panic.
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			guesses := c.Guesses()
+
+			assert.Len(t, guesses, len(samples))
+			assert.Equal(t, guesses[0], guesses[3])
+			assert.Equal(t, guesses[4], guesses[7])
+			assert.NotEqual(t, guesses[0], guesses[4])
+		})
+	}
+	t.Run("EstimateClusters", func(t *testing.T) {
+		opt := FacesOptions{Algorithm: ClusterKMeans, Core: 2}
+
+		c, err := opt.Clusterer(samples, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, c.Learn(samples))
+	})
+}
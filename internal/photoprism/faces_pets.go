@@ -0,0 +1,166 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/pet"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/clusters"
+)
+
+// Pets clusters pet embeddings and matches pet markers with known pets.
+func (w *Faces) Pets(opt FacesOptions) (result FacesMatchResult, err error) {
+	if !w.conf.DetectPets() {
+		return result, fmt.Errorf("pet recognition is disabled")
+	}
+
+	// Cluster unclustered pet embeddings.
+	if added, err := w.ClusterPets(); err != nil {
+		log.Errorf("faces: %s (cluster pets)", err)
+	} else if n := len(added); n > 0 {
+		log.Infof("faces: added %s", english.Plural(n, "new pet", "new pets"))
+	}
+
+	faces, err := query.PetFaces()
+
+	if err != nil {
+		return result, err
+	} else if len(faces) == 0 {
+		log.Debugf("faces: found no pets to match")
+		return result, nil
+	}
+
+	matched := 0
+	limit := 500
+	max := query.CountMarkers(entity.MarkerPet)
+
+	for {
+		var markers entity.Markers
+
+		if opt.Force {
+			markers, err = query.PetMarkers(limit, matched, false)
+		} else {
+			markers, err = query.PetMarkers(limit, 0, true)
+		}
+
+		if err != nil {
+			return result, err
+		} else if len(markers) == 0 {
+			break
+		}
+
+		for _, marker := range markers {
+			matched++
+
+			if w.Canceled() {
+				return result, fmt.Errorf("worker canceled")
+			}
+
+			// Pointer to the matching pet.
+			var f *entity.Face
+
+			// Dist to the matching pet.
+			var d float64
+
+			// Find the closest pet match for marker.
+			for i, m := range faces {
+				if ok, dist := m.Match(marker.Embeddings()); ok && (f == nil || dist < d) {
+					f = &faces[i]
+					d = dist
+				}
+			}
+
+			// Marker already has the best matching pet?
+			if marker.HasFace(f, d) {
+				if err := marker.Matched(); err != nil {
+					log.Warnf("faces: %s while updating marker %s match timestamp", err, marker.MarkerUID)
+				}
+
+				continue
+			}
+
+			// No matching pet?
+			if f == nil {
+				if updated, err := marker.ClearFace(); err != nil {
+					log.Warnf("faces: %s (clear pet marker)", err)
+				} else if updated {
+					result.Updated++
+				}
+
+				continue
+			}
+
+			// Assign matching pet to marker.
+			if updated, err := marker.SetFace(f, d); err != nil {
+				log.Warnf("faces: %s while setting a pet for marker %s", err, marker.MarkerUID)
+				continue
+			} else if updated {
+				result.Updated++
+			}
+
+			if marker.SubjUID != "" {
+				result.Recognized++
+			} else {
+				result.Unknown++
+			}
+		}
+
+		if matched > max {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// ClusterPets clusters unclustered pet embeddings and adds the resulting clusters.
+func (w *Faces) ClusterPets() (added entity.Faces, err error) {
+	embeddings, err := query.PetEmbeddings(true)
+
+	if err != nil {
+		return added, err
+	} else if samples := len(embeddings); samples < pet.SampleThreshold {
+		log.Debugf("faces: at least %d pet samples needed for clustering", pet.SampleThreshold)
+		return added, nil
+	}
+
+	var c clusters.HardClusterer
+
+	if c, err = clusters.DBSCAN(pet.ClusterCore, pet.ClusterDist, w.conf.IndexWorkers(), clusters.EuclideanDist); err != nil {
+		return added, err
+	} else if err = c.Learn(embeddings.Float64()); err != nil {
+		return added, err
+	}
+
+	sizes := c.Sizes()
+	results := make([]face.Embeddings, len(sizes))
+
+	for i := range sizes {
+		results[i] = face.Embeddings{}
+	}
+
+	for i, n := range c.Guesses() {
+		if n < 1 {
+			continue
+		}
+
+		results[n-1] = append(results[n-1], embeddings[i])
+	}
+
+	for _, cluster := range results {
+		if f := entity.NewPetFace("", entity.SrcAuto, cluster); f == nil || f.ID == "" {
+			log.Warnf("faces: skipped invalid pet cluster")
+		} else if err := f.Create(); err == nil {
+			added = append(added, *f)
+			log.Debugf("faces: added pet cluster %s based on %s, radius %f", f.ID, english.Plural(f.Samples, "sample", "samples"), f.SampleRadius)
+		} else if err := f.Updates(entity.Map{"UpdatedAt": entity.Now()}); err != nil {
+			log.Errorf("faces: %s", err)
+		}
+	}
+
+	return added, nil
+}
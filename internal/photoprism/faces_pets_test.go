@@ -0,0 +1,112 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/ai/pet"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
+)
+
+func TestFaces_Pets(t *testing.T) {
+	c := config.TestConfig()
+
+	t.Run("Disabled", func(t *testing.T) {
+		m := NewFaces(c)
+
+		_, err := m.Pets(FacesOptions{})
+
+		assert.Error(t, err)
+	})
+	t.Run("ClusterAndMatch", func(t *testing.T) {
+		c.Options().DetectPets = true
+
+		defer func() { c.Options().DetectPets = false }()
+
+		file := entity.FileFixtures.Get("Photo25.jpg")
+		base := face.RandomEmbedding()
+
+		var markers entity.Markers
+
+		// Add pet markers with similar embeddings.
+		for i := 0; i < pet.SampleThreshold; i++ {
+			e := make(face.Embedding, len(base))
+
+			for j := range base {
+				e[j] = face.RandomFloat64(base[j], 0.001)
+			}
+
+			p := pet.Pet{
+				Name:      "dog",
+				Score:     90,
+				Size:      300,
+				Area:      crop.NewArea("dog", float32(i)*0.1, 0.1, 0.3, 0.3),
+				Embedding: e,
+			}
+
+			marker := entity.NewPetMarker(p, file)
+
+			if err := marker.Create(); err != nil {
+				t.Fatal(err)
+			}
+
+			markers = append(markers, *marker)
+		}
+
+		defer func() {
+			for _, marker := range markers {
+				if err := entity.UnscopedDb().Delete(&marker).Error; err != nil {
+					t.Error(err)
+				}
+			}
+
+			if err := entity.UnscopedDb().Delete(entity.Face{}, "face_kind = ?", int(face.PetFace)).Error; err != nil {
+				t.Error(err)
+			}
+		}()
+
+		m := NewFaces(c)
+
+		result, err := m.Pets(FacesOptions{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(pet.SampleThreshold), result.Updated)
+		assert.Equal(t, int64(pet.SampleThreshold), result.Unknown)
+
+		faces, err := query.PetFaces()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, faces, 1) {
+			assert.True(t, faces[0].Pet())
+			assert.Equal(t, entity.MarkerPet, faces[0].MarkerType())
+			assert.Equal(t, pet.SampleThreshold, faces[0].Samples)
+		}
+
+		for _, marker := range markers {
+			if found := entity.FindMarker(marker.MarkerUID); assert.NotNil(t, found) {
+				assert.Equal(t, faces[0].ID, found.FaceID)
+				assert.NotNil(t, found.MatchedAt)
+			}
+		}
+
+		// Unclustered pet embeddings should be gone.
+		embeddings, err := query.PetEmbeddings(true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, embeddings)
+	})
+}
@@ -0,0 +1,131 @@
+package photoprism
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+)
+
+// FaceClusterStats represents quality metrics of a face cluster.
+type FaceClusterStats struct {
+	ID              string
+	SubjUID         string
+	Samples         int
+	SampleRadius    float64
+	Markers         int     // Number of valid markers matched with the cluster.
+	Cohesion        float64 // Average distance of matched markers to the cluster center, -1 if unknown.
+	MaxDist         float64 // Max distance of a matched marker to the cluster center, -1 if unknown.
+	Collisions      int     // Number of clusters with a different subject that match this cluster.
+	CollisionRadius float64
+}
+
+// FacesReport represents quality metrics of all face clusters.
+type FacesReport []FaceClusterStats
+
+// Report returns quality metrics of the existing face clusters, e.g. to tune the clustering parameters.
+func (w *Faces) Report() (result FacesReport, err error) {
+	faces, err := query.Faces(false, false, true, false)
+
+	if err != nil {
+		return result, err
+	}
+
+	stats, err := query.FaceMarkerStatsByID()
+
+	if err != nil {
+		return result, err
+	}
+
+	result = make(FacesReport, len(faces))
+
+	for i := range faces {
+		f := &faces[i]
+
+		r := FaceClusterStats{
+			ID:              f.ID,
+			SubjUID:         f.SubjUID,
+			Samples:         f.Samples,
+			SampleRadius:    f.SampleRadius,
+			Cohesion:        -1,
+			MaxDist:         -1,
+			CollisionRadius: f.CollisionRadius,
+		}
+
+		if s, ok := stats[f.ID]; ok {
+			r.Markers = s.Markers
+			r.Cohesion = s.AvgDist
+			r.MaxDist = s.MaxDist
+		}
+
+		// Count clusters of other subjects that match this cluster.
+		for j := range faces {
+			if collision, _ := f.MatchCollision(faces[j]); collision {
+				r.Collisions++
+			}
+		}
+
+		result[i] = r
+	}
+
+	return result, nil
+}
+
+// Cohesion returns the average cohesion of all clusters with matched markers, or -1 if there are none.
+func (r FacesReport) Cohesion() float64 {
+	var sum float64
+	var n int
+
+	for _, s := range r {
+		if s.Cohesion >= 0 {
+			sum += s.Cohesion
+			n++
+		}
+	}
+
+	if n == 0 {
+		return -1
+	}
+
+	return sum / float64(n)
+}
+
+// Collisions returns the number of clusters that collide with clusters of other subjects.
+func (r FacesReport) Collisions() (count int) {
+	for _, s := range r {
+		if s.Collisions > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Table returns the report rows and columns, e.g. for output on the command line.
+func (r FacesReport) Table() (rows [][]string, cols []string) {
+	cols = []string{"Face ID", "Subject", "Samples", "Sample Radius", "Markers", "Cohesion", "Max Dist", "Collisions", "Collision Radius"}
+	rows = make([][]string, len(r))
+
+	for i, s := range r {
+		subj := ""
+
+		if s.SubjUID != "" {
+			subj = entity.SubjNames.Log(s.SubjUID)
+		}
+
+		rows[i] = []string{
+			s.ID,
+			subj,
+			strconv.Itoa(s.Samples),
+			fmt.Sprintf("%.4f", s.SampleRadius),
+			strconv.Itoa(s.Markers),
+			fmt.Sprintf("%.4f", s.Cohesion),
+			fmt.Sprintf("%.4f", s.MaxDist),
+			strconv.Itoa(s.Collisions),
+			fmt.Sprintf("%.4f", s.CollisionRadius),
+		}
+	}
+
+	return rows, cols
+}
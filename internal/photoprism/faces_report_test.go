@@ -0,0 +1,46 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestFaces_Report(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	result, err := m.Report()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.GreaterOrEqual(t, result.Collisions(), 0)
+	assert.GreaterOrEqual(t, result.Cohesion(), 0.0)
+
+	rows, cols := result.Table()
+
+	assert.Len(t, rows, len(result))
+	assert.Len(t, cols, 9)
+
+	for _, row := range rows {
+		assert.Len(t, row, len(cols))
+	}
+}
+
+func TestFacesReport(t *testing.T) {
+	r := FacesReport{
+		{ID: "A", Cohesion: 0.2, Collisions: 1},
+		{ID: "B", Cohesion: 0.4},
+		{ID: "C", Cohesion: -1},
+	}
+
+	assert.InEpsilon(t, 0.3, r.Cohesion(), 0.0001)
+	assert.Equal(t, 1, r.Collisions())
+	assert.Equal(t, -1.0, FacesReport{}.Cohesion())
+}
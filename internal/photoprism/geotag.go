@@ -0,0 +1,228 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo/track"
+)
+
+// Geotag represents a worker that assigns coordinates to pictures based on GPX, KML, and GeoJSON tracklogs.
+type Geotag struct {
+	conf *config.Config
+}
+
+// GeotagOptions represents geotagging options.
+type GeotagOptions struct {
+	MaxGap time.Duration
+	DryRun bool
+}
+
+// GeotagResult represents a picture and the position it was matched with.
+type GeotagResult struct {
+	PhotoUID  string    `json:"UID"`
+	PhotoName string    `json:"Name"`
+	TakenAt   time.Time `json:"TakenAt"`
+	Lat       float64   `json:"Lat"`
+	Lng       float64   `json:"Lng"`
+	Altitude  int       `json:"Altitude"`
+	Track     string    `json:"Track"`
+	Updated   bool      `json:"Updated"`
+}
+
+// TrackInfo represents a stored tracklog.
+type TrackInfo struct {
+	FileName string    `json:"FileName"`
+	Name     string    `json:"Name"`
+	Start    time.Time `json:"Start"`
+	End      time.Time `json:"End"`
+	Points   int       `json:"Points"`
+}
+
+// NewGeotag returns a new Geotag worker.
+func NewGeotag(conf *config.Config) *Geotag {
+	instance := &Geotag{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// DefaultOptions returns the default geotagging options.
+func (w *Geotag) DefaultOptions() GeotagOptions {
+	return GeotagOptions{MaxGap: w.conf.TrackMaxGap()}
+}
+
+// Tracks reads the tracklogs in the storage folder and returns them along with a summary.
+func (w *Geotag) Tracks() (tracks track.Tracks, infos []TrackInfo, err error) {
+	dir := w.conf.TracksPath()
+
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return tracks, infos, err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !track.Supported(e.Name()) {
+			continue
+		}
+
+		t, openErr := track.Open(filepath.Join(dir, e.Name()))
+
+		if openErr != nil {
+			log.Warnf("geotag: %s in %s", openErr, clean.Log(e.Name()))
+			continue
+		}
+
+		tracks = append(tracks, t)
+		infos = append(infos, TrackInfo{
+			FileName: e.Name(),
+			Name:     t.Name,
+			Start:    t.Start(),
+			End:      t.End(),
+			Points:   t.Points(),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Start.Before(infos[j].Start)
+	})
+
+	return tracks, infos, nil
+}
+
+// Import validates a tracklog file and copies it to the storage folder with the specified base name.
+func (w *Geotag) Import(src, baseName string) (info TrackInfo, err error) {
+	baseName = clean.FileName(filepath.Base(baseName))
+
+	if baseName == "" || !track.Supported(baseName) {
+		return info, fmt.Errorf("unsupported track format")
+	}
+
+	// Parse tracklog to make sure it is valid.
+	t, err := track.Open(src)
+
+	if err != nil {
+		return info, err
+	}
+
+	dest := filepath.Join(w.conf.TracksPath(), baseName)
+
+	// Replace existing tracklogs with the same name.
+	if src != dest {
+		if fs.FileExists(dest) {
+			_ = os.Remove(dest)
+		}
+
+		if err = fs.Copy(src, dest); err != nil {
+			return info, err
+		}
+	}
+
+	log.Infof("geotag: imported track %s with %s", clean.Log(baseName), english.Plural(t.Points(), "point", "points"))
+
+	return TrackInfo{
+		FileName: baseName,
+		Name:     t.Name,
+		Start:    t.Start(),
+		End:      t.End(),
+		Points:   t.Points(),
+	}, nil
+}
+
+// Start assigns positions to pictures without coordinates that were taken within a track segment.
+func (w *Geotag) Start(tracks track.Tracks, opt GeotagOptions) (results []GeotagResult, err error) {
+	if len(tracks) == 0 {
+		return results, fmt.Errorf("no tracks found")
+	}
+
+	if opt.MaxGap <= 0 {
+		opt.MaxGap = w.conf.TrackMaxGap()
+	}
+
+	start := time.Now()
+
+	// Find pictures without coordinates taken during the time covered by the tracks.
+	photos, err := query.PhotosWithoutLocation(tracks.Start(), tracks.End())
+
+	if err != nil {
+		return results, err
+	}
+
+	log.Infof("geotag: found %s without location", english.Plural(len(photos), "picture", "pictures"))
+
+	updated := 0
+
+	for _, p := range photos {
+		pos, ok := tracks.Position(p.TakenAt, opt.MaxGap)
+
+		if !ok {
+			continue
+		}
+
+		result := GeotagResult{
+			PhotoUID:  p.PhotoUID,
+			PhotoName: p.PhotoName,
+			TakenAt:   p.TakenAt,
+			Lat:       pos.Lat,
+			Lng:       pos.Lng,
+			Altitude:  pos.AltitudeInt(),
+			Track:     pos.Name,
+		}
+
+		if opt.DryRun {
+			results = append(results, result)
+			continue
+		}
+
+		// Fetch photo with related entities from index.
+		m, findErr := query.PhotoByUID(p.PhotoUID)
+
+		if findErr != nil {
+			log.Errorf("geotag: %s while loading %s", findErr, p.String())
+			continue
+		}
+
+		m.SetPosition(pos, entity.SrcTrack, false)
+
+		if m.PlaceSrc != entity.SrcTrack {
+			log.Debugf("geotag: %s keeps position from source %s", m.String(), entity.SrcString(m.PlaceSrc))
+		} else if saveErr := m.SaveLocation(); saveErr != nil {
+			log.Errorf("geotag: %s while updating %s", saveErr, m.String())
+		} else {
+			result.Updated = true
+			updated++
+
+			// Write the new position to the YAML sidecar file.
+			if w.conf.SidecarYaml() {
+				_ = m.SaveSidecarYaml(w.conf.OriginalsPath(), w.conf.SidecarPath())
+			}
+
+			// Write the new position to the XMP sidecar file.
+			if w.conf.SidecarXmp() {
+				_ = m.SaveSidecarXmp(w.conf.OriginalsPath())
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	if opt.DryRun {
+		log.Infof("geotag: found positions for %s [%s]", english.Plural(len(results), "picture", "pictures"), time.Since(start))
+	} else {
+		log.Infof("geotag: updated %s [%s]", english.Plural(updated, "picture", "pictures"), time.Since(start))
+	}
+
+	return results, nil
+}
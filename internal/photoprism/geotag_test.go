@@ -0,0 +1,177 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/geo/track"
+)
+
+// testTrack returns a track with a single segment between the specified times.
+func testTrack(name string, start, end time.Time) track.Track {
+	return track.Track{
+		Name: name,
+		Segments: []track.Segment{{
+			geo.Position{Lat: 52.52, Lng: 13.40, Altitude: 34, Time: start},
+			geo.Position{Lat: 52.53, Lng: 13.41, Altitude: 40, Time: end},
+		}},
+	}
+}
+
+func TestNewGeotag(t *testing.T) {
+	w := NewGeotag(config.TestConfig())
+
+	assert.IsType(t, &Geotag{}, w)
+	assert.Equal(t, config.TestConfig().TrackMaxGap(), w.DefaultOptions().MaxGap)
+}
+
+func TestGeotag_Import(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewGeotag(conf)
+
+	t.Run("Success", func(t *testing.T) {
+		info, err := w.Import("../../pkg/geo/track/testdata/track.kml", "berlin.kml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(filepath.Join(conf.TracksPath(), "berlin.kml"))
+
+		assert.Equal(t, "berlin.kml", info.FileName)
+		assert.Equal(t, "Berlin Walk", info.Name)
+		assert.Equal(t, 4, info.Points)
+		assert.FileExists(t, filepath.Join(conf.TracksPath(), "berlin.kml"))
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := w.Import("../../pkg/geo/track/testdata/track.kml", "berlin.txt")
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := w.Import("geotag_test.go", "invalid.gpx")
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(conf.TracksPath(), "invalid.gpx"))
+	})
+}
+
+func TestGeotag_Tracks(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewGeotag(conf)
+
+	if _, err := w.Import("../../pkg/geo/track/testdata/track.gpx", "berlin.gpx"); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(filepath.Join(conf.TracksPath(), "berlin.gpx"))
+
+	tracks, infos, err := w.Tracks()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, tracks, 1)
+
+	if assert.Len(t, infos, 1) {
+		assert.Equal(t, "berlin.gpx", infos[0].FileName)
+		assert.Equal(t, "Berlin Walk", infos[0].Name)
+		assert.Equal(t, 6, infos[0].Points)
+	}
+}
+
+func TestGeotag_Start(t *testing.T) {
+	w := NewGeotag(config.TestConfig())
+
+	t.Run("NoTracks", func(t *testing.T) {
+		_, err := w.Start(nil, w.DefaultOptions())
+		assert.Error(t, err)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("19800101_000002_D640C559")
+		tracks := track.Tracks{testTrack("Dry Run", photo.TakenAt.Add(-time.Minute), photo.TakenAt.Add(time.Minute))}
+
+		results, err := w.Start(tracks, GeotagOptions{MaxGap: 5 * time.Minute, DryRun: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var found *GeotagResult
+
+		for i := range results {
+			if results[i].PhotoUID == photo.PhotoUID {
+				found = &results[i]
+			}
+		}
+
+		if assert.NotNil(t, found) {
+			assert.Equal(t, "Dry Run", found.Track)
+			assert.False(t, found.Updated)
+			assert.InDelta(t, 52.525, found.Lat, 0.00001)
+			assert.InDelta(t, 13.405, found.Lng, 0.00001)
+			assert.Equal(t, 37, found.Altitude)
+		}
+
+		// Dry runs must not change the index.
+		m, err := query.PhotoByUID(photo.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0.0, m.PhotoLat)
+		assert.Equal(t, photo.PlaceSrc, m.PlaceSrc)
+	})
+	t.Run("MaxGap", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("19800101_000002_D640C559")
+		tracks := track.Tracks{testTrack("Gap", photo.TakenAt.Add(-time.Hour), photo.TakenAt.Add(time.Hour))}
+
+		results, err := w.Start(tracks, GeotagOptions{MaxGap: 5 * time.Minute, DryRun: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 0)
+	})
+	t.Run("Update", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("Photo04")
+		tracks := track.Tracks{testTrack("Update", photo.TakenAt.Add(-time.Minute), photo.TakenAt.Add(time.Minute))}
+
+		results, err := w.Start(tracks, GeotagOptions{MaxGap: 5 * time.Minute})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var found *GeotagResult
+
+		for i := range results {
+			if results[i].PhotoUID == photo.PhotoUID {
+				found = &results[i]
+			}
+		}
+
+		if assert.NotNil(t, found) {
+			assert.True(t, found.Updated)
+		}
+
+		m, err := query.PhotoByUID(photo.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.SrcTrack, m.PlaceSrc)
+		assert.InDelta(t, 52.525, m.PhotoLat, 0.00001)
+		assert.InDelta(t, 13.405, m.PhotoLng, 0.00001)
+	})
+}
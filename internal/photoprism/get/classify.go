@@ -9,10 +9,23 @@ import (
 var onceClassify sync.Once
 
 func initClassify() {
-	services.Classify = classify.New(Config().AssetsPath(), Config().DisableClassification())
+	conf := Config()
+
+	// Load image classification backend configuration, if any.
+	opt, err := classify.LoadOptions(conf.ClassifyYaml())
+
+	if err != nil {
+		log.Errorf("classify: %s", err)
+		opt = classify.DefaultOptions()
+	}
+
+	if services.Classify, err = classify.NewClassifier(opt, conf.AssetsPath(), conf.DisableClassification()); err != nil {
+		log.Errorf("classify: %s", err)
+		services.Classify = classify.New(conf.AssetsPath(), conf.DisableClassification())
+	}
 }
 
-func Classify() *classify.TensorFlow {
+func Classify() classify.Classifier {
 	onceClassify.Do(initClassify)
 
 	return services.Classify
@@ -0,0 +1,24 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+)
+
+var onceClip sync.Once
+
+func initClip() {
+	services.Clip = clip.NewModel(conf.ClipModelPath(), !conf.SemanticSearch() && !conf.DetectPets())
+}
+
+func Clip() *clip.Model {
+	onceClip.Do(initClip)
+
+	return services.Clip
+}
+
+// EmbedText returns the embedding of a natural-language search query.
+func EmbedText(text string) (clip.Embedding, error) {
+	return Clip().Text(text)
+}
@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/ai/object"
+)
+
+var onceObjects sync.Once
+
+func initObjects() {
+	services.Objects = object.NewDetector(conf.ObjectsModelPath(), !conf.DetectObjects() && !conf.DetectPets())
+}
+
+func ObjectDetector() *object.Detector {
+	onceObjects.Do(initObjects)
+
+	return services.Objects
+}
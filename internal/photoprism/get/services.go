@@ -28,12 +28,15 @@ import (
 	gc "github.com/patrickmn/go-cache"
 
 	"github.com/photoprism/photoprism/internal/ai/classify"
+	"github.com/photoprism/photoprism/internal/ai/clip"
 	"github.com/photoprism/photoprism/internal/ai/face"
 	"github.com/photoprism/photoprism/internal/ai/nsfw"
+	"github.com/photoprism/photoprism/internal/ai/object"
 	"github.com/photoprism/photoprism/internal/auth/oidc"
 	"github.com/photoprism/photoprism/internal/auth/session"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/entity/search"
 	"github.com/photoprism/photoprism/internal/photoprism"
 )
 
@@ -43,7 +46,7 @@ var services struct {
 	FolderCache *gc.Cache
 	CoverCache  *gc.Cache
 	ThumbCache  *gc.Cache
-	Classify    *classify.TensorFlow
+	Classify    classify.Classifier
 	Convert     *photoprism.Convert
 	Files       *photoprism.Files
 	Photos      *photoprism.Photos
@@ -52,10 +55,14 @@ var services struct {
 	Moments     *photoprism.Moments
 	Faces       *photoprism.Faces
 	Places      *photoprism.Places
+	TimeShift   *photoprism.TimeShift
+	Geotag      *photoprism.Geotag
 	Purge       *photoprism.Purge
 	CleanUp     *photoprism.CleanUp
 	Nsfw        *nsfw.Detector
 	FaceNet     *face.Net
+	Clip        *clip.Model
+	Objects     *object.Detector
 	Query       *query.Query
 	Thumbs      *photoprism.Thumbs
 	Session     *session.Session
@@ -70,6 +77,14 @@ func SetConfig(c *config.Config) {
 	conf = c
 
 	photoprism.SetConfig(c)
+
+	// Enable natural-language search queries?
+	if c.SemanticSearch() {
+		search.EmbedText = EmbedText
+		search.EmbedModel = Clip().Name()
+	} else {
+		search.EmbedText = nil
+	}
 }
 
 func Config() *config.Config {
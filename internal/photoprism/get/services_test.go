@@ -7,7 +7,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/ai/classify"
+	"github.com/photoprism/photoprism/internal/ai/clip"
 	"github.com/photoprism/photoprism/internal/ai/nsfw"
+	"github.com/photoprism/photoprism/internal/ai/object"
 	"github.com/photoprism/photoprism/internal/auth/oidc"
 	"github.com/photoprism/photoprism/internal/auth/session"
 	"github.com/photoprism/photoprism/internal/entity/query"
@@ -34,6 +36,19 @@ func TestClassify(t *testing.T) {
 	assert.IsType(t, &classify.TensorFlow{}, Classify())
 }
 
+func TestClip(t *testing.T) {
+	assert.IsType(t, &clip.Model{}, Clip())
+	assert.True(t, Clip().Disabled())
+
+	_, err := EmbedText("dog on a beach")
+	assert.Error(t, err)
+}
+
+func TestObjectDetector(t *testing.T) {
+	assert.IsType(t, &object.Detector{}, ObjectDetector())
+	assert.True(t, ObjectDetector().Disabled())
+}
+
 func TestConvert(t *testing.T) {
 	assert.IsType(t, &photoprism.Convert{}, Convert())
 }
@@ -50,6 +65,14 @@ func TestMoments(t *testing.T) {
 	assert.IsType(t, &photoprism.Moments{}, Moments())
 }
 
+func TestTimeShift(t *testing.T) {
+	assert.IsType(t, &photoprism.TimeShift{}, TimeShift())
+}
+
+func TestGeotag(t *testing.T) {
+	assert.IsType(t, &photoprism.Geotag{}, Geotag())
+}
+
 func TestPurge(t *testing.T) {
 	assert.IsType(t, &photoprism.Purge{}, Purge())
 }
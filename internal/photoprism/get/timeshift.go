@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceTimeShift sync.Once
+
+func initTimeShift() {
+	services.TimeShift = photoprism.NewTimeShift(Config())
+}
+
+func TimeShift() *photoprism.TimeShift {
+	onceTimeShift.Do(initTimeShift)
+
+	return services.TimeShift
+}
@@ -84,11 +84,15 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		defer mutex.IndexWorker.Stop()
 	}
 
-	if err := ind.tensorFlow.Init(); err != nil {
+	if err := ind.classifier.Init(); err != nil {
 		log.Errorf("import: %s", err.Error())
 		return done
 	}
 
+	ind.initEmbeddings()
+	ind.initObjects()
+	ind.initPets()
+
 	jobs := make(chan ImportJob)
 
 	// Start a fixed number of goroutines to import files.
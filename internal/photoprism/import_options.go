@@ -14,6 +14,7 @@ type ImportOptions struct {
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	Review                 bool
 }
 
 // SetUser sets the user who performs the import operation.
@@ -25,6 +26,16 @@ func (o *ImportOptions) SetUser(user *entity.User) *ImportOptions {
 	return o
 }
 
+// AddToAlbums adds a photo to the albums specified in the options or, if the imported
+// files must be reviewed first, flags it for review until it has been approved.
+func (o *ImportOptions) AddToAlbums(photoUid string) error {
+	if o.Review {
+		return entity.AddPhotoToAlbumsForReview(photoUid, o.Albums)
+	}
+
+	return entity.AddPhotoToUserAlbums(photoUid, o.Albums, o.UID)
+}
+
 // ImportOptionsCopy returns import options for copying files to originals (read-only).
 func ImportOptionsCopy(importPath, destFolder string) ImportOptions {
 	result := ImportOptions{
@@ -75,3 +86,13 @@ func ImportOptionsUpload(uploadPath, destFolder string) ImportOptions {
 
 	return result
 }
+
+// ImportOptionsShareUpload returns options for importing files uploaded by visitors of a share link,
+// which are added to the shared album once they have been reviewed.
+func ImportOptionsShareUpload(uploadPath, destFolder, albumUid string) ImportOptions {
+	result := ImportOptionsUpload(uploadPath, destFolder)
+	result.Albums = []string{albumUid}
+	result.Review = true
+
+	return result
+}
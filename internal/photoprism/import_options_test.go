@@ -27,6 +27,16 @@ func TestImportOptionsCopy(t *testing.T) {
 	})
 }
 
+func TestImportOptionsShareUpload(t *testing.T) {
+	result := ImportOptionsShareUpload("xxx", "foo/bar", "as6sg6bxpogaaba8")
+	assert.Equal(t, "xxx", result.Path)
+	assert.Equal(t, "foo/bar", result.DestFolder)
+	assert.Equal(t, ActionUpload, result.Action)
+	assert.Equal(t, []string{"as6sg6bxpogaaba8"}, result.Albums)
+	assert.Equal(t, true, result.Move)
+	assert.Equal(t, true, result.Review)
+}
+
 func TestImportOptionsMove(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		result := ImportOptionsMove("xxx", "")
@@ -18,7 +18,7 @@ func TestNewImport(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 	imp := NewImport(conf, ind, convert)
 
 	assert.IsType(t, &Import{}, imp)
@@ -36,7 +36,7 @@ func TestImport_DestinationFilename(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 
@@ -81,7 +81,7 @@ func TestImport_Start(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 
@@ -97,12 +97,13 @@ func ImportWorker(jobs <-chan ImportJob) {
 			} else {
 				log.Infof("import: %s", err)
 
-				// Try to add duplicates to selected album(s) as well, see #991.
-				if fileHash := f.Hash(); fileHash == "" {
+				// Try to add duplicates to selected album(s) as well, see #991. Uploads that
+				// must be reviewed first do not change existing pictures of other users.
+				if fileHash := f.Hash(); fileHash == "" || opt.Review {
 					// Do nothing.
 				} else if file, fileErr := entity.FirstFileByHash(fileHash); fileErr != nil {
 					// Do nothing.
-				} else if albumErr := entity.AddPhotoToUserAlbums(file.PhotoUID, opt.Albums, opt.UID); albumErr != nil {
+				} else if albumErr := opt.AddToAlbums(file.PhotoUID); albumErr != nil {
 					log.Warn(albumErr)
 				}
 
@@ -165,6 +166,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 			done := make(map[string]bool)
 			ind := imp.index
 			photoUID := ""
+			photoAdded := false
 
 			if related.Main != nil {
 				f := related.Main
@@ -190,11 +192,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 					continue
 				} else if res.PhotoUID != "" {
 					photoUID = res.PhotoUID
-
-					// Add photo to album if a list of albums was provided when importing.
-					if albumErr := entity.AddPhotoToUserAlbums(photoUID, opt.Albums, opt.UID); albumErr != nil {
-						log.Warn(albumErr)
-					}
+					photoAdded = res.Status == IndexAdded
 				}
 			} else {
 				log.Warnf("import: no main media file found for %s, creation of a preview image may have failed", clean.Log(f.RootRelName()))
@@ -239,6 +237,14 @@ func ImportWorker(jobs <-chan ImportJob) {
 				// Log result.
 				log.Infof("import: %s related %s file %s", res, f.FileType(), clean.Log(f.RootRelName()))
 			}
+
+			// Add photo to album if a list of albums was provided when importing, unless
+			// it must be reviewed and already existed before, e.g. as part of a stack.
+			if photoUID != "" && (photoAdded || !opt.Review) {
+				if albumErr := opt.AddToAlbums(photoUID); albumErr != nil {
+					log.Warn(albumErr)
+				}
+			}
 		}
 	}
 }
@@ -22,7 +22,7 @@ func TestImportWorker_OriginalFileNames(t *testing.T) {
 	nd := nsfw.New(conf.NSFWModelPath())
 	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 	convert := NewConvert(conf)
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 	imp := &Import{conf, ind, convert}
 
 	mediaFileName := conf.ExamplesPath() + "/beach_sand.jpg"
@@ -80,3 +80,82 @@ func TestImportWorker_OriginalFileNames(t *testing.T) {
 	assert.Nil(t, res.Error)
 	assert.Equal(t, file3.OriginalName, mediaFileName3)
 }
+
+func TestImportWorker_ReviewDuplicate(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+	ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
+	imp := &Import{conf, ind, convert}
+
+	mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/beach_sand.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	importFile := func(opt ImportOptions) {
+		jobs := make(chan ImportJob)
+		done := make(chan bool)
+
+		go func() {
+			ImportWorker(jobs)
+			done <- true
+		}()
+
+		jobs <- ImportJob{
+			FileName:  mediaFile.FileName(),
+			Related:   RelatedFiles{Files: MediaFiles{mediaFile}, Main: mediaFile},
+			IndexOpt:  IndexOptionsAll(),
+			ImportOpt: opt,
+			Imp:       imp,
+		}
+
+		close(jobs)
+		<-done
+	}
+
+	// Make sure the file has already been imported.
+	importFile(ImportOptionsCopy(conf.ImportPath(), conf.ImportDest()))
+
+	file, err := entity.FirstFileByHash(mediaFile.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := entity.FindPhoto(entity.Photo{PhotoUID: file.PhotoUID})
+
+	if before == nil {
+		t.Fatal("photo not found")
+	}
+
+	// Upload the same file again for review.
+	albumUid := "as6sg6bxpogaaba8"
+	opt := ImportOptionsCopy(conf.ImportPath(), conf.ImportDest())
+	opt.Albums = []string{albumUid}
+	opt.Review = true
+
+	importFile(opt)
+
+	after := entity.FindPhoto(entity.Photo{PhotoUID: file.PhotoUID})
+
+	if after == nil {
+		t.Fatal("photo not found")
+	}
+
+	assert.Equal(t, before.PhotoQuality, after.PhotoQuality)
+
+	var count int
+
+	if err = entity.UnscopedDb().Model(&entity.PhotoAlbum{}).
+		Where("photo_uid = ? AND album_uid = ? AND review = ?", file.PhotoUID, albumUid, true).
+		Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+}
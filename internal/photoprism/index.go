@@ -12,8 +12,10 @@ import (
 	"github.com/karrick/godirwalk"
 
 	"github.com/photoprism/photoprism/internal/ai/classify"
+	"github.com/photoprism/photoprism/internal/ai/clip"
 	"github.com/photoprism/photoprism/internal/ai/face"
 	"github.com/photoprism/photoprism/internal/ai/nsfw"
+	"github.com/photoprism/photoprism/internal/ai/object"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
@@ -26,36 +28,46 @@ import (
 
 // Index represents an indexer that indexes files in the originals directory.
 type Index struct {
-	conf         *config.Config
-	tensorFlow   *classify.TensorFlow
-	nsfwDetector *nsfw.Detector
-	faceNet      *face.Net
-	convert      *Convert
-	files        *Files
-	photos       *Photos
-	lastRun      time.Time
-	lastFound    int
-	findFaces    bool
-	findLabels   bool
+	conf           *config.Config
+	classifier     classify.Classifier
+	nsfwDetector   *nsfw.Detector
+	faceNet        *face.Net
+	clipModel      *clip.Model
+	objectDetector *object.Detector
+	convert        *Convert
+	files          *Files
+	photos         *Photos
+	lastRun        time.Time
+	lastFound      int
+	findFaces      bool
+	findLabels     bool
+	findEmbeddings bool
+	findObjects    bool
+	findPets       bool
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
-func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert, files *Files, photos *Photos) *Index {
+func NewIndex(conf *config.Config, classifier classify.Classifier, nsfwDetector *nsfw.Detector, faceNet *face.Net, clipModel *clip.Model, objectDetector *object.Detector, convert *Convert, files *Files, photos *Photos) *Index {
 	if conf == nil {
 		log.Errorf("index: config is not set")
 		return nil
 	}
 
 	i := &Index{
-		conf:         conf,
-		tensorFlow:   tensorFlow,
-		nsfwDetector: nsfwDetector,
-		faceNet:      faceNet,
-		convert:      convert,
-		files:        files,
-		photos:       photos,
-		findFaces:    !conf.DisableFaces(),
-		findLabels:   !conf.DisableClassification(),
+		conf:           conf,
+		classifier:     classifier,
+		nsfwDetector:   nsfwDetector,
+		faceNet:        faceNet,
+		clipModel:      clipModel,
+		objectDetector: objectDetector,
+		convert:        convert,
+		files:          files,
+		photos:         photos,
+		findFaces:      !conf.DisableFaces(),
+		findLabels:     !conf.DisableClassification(),
+		findEmbeddings: conf.SemanticSearch() && !clipModel.Disabled(),
+		findObjects:    conf.DetectObjects() && !objectDetector.Disabled(),
+		findPets:       conf.DetectPets() && !objectDetector.Disabled() && !clipModel.Disabled(),
 	}
 
 	return i
@@ -107,12 +119,16 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 
 	defer mutex.IndexWorker.Stop()
 
-	if err := ind.tensorFlow.Init(); err != nil {
+	if err := ind.classifier.Init(); err != nil {
 		log.Errorf("index: %s", clean.Error(err))
 
 		return found, updated
 	}
 
+	ind.initEmbeddings()
+	ind.initObjects()
+	ind.initPets()
+
 	jobs := make(chan IndexJob)
 
 	// Start a fixed number of goroutines to index files.
@@ -0,0 +1,42 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// initEmbeddings loads the CLIP model and skips image embeddings if it is not available.
+func (ind *Index) initEmbeddings() {
+	if !ind.findEmbeddings {
+		return
+	}
+
+	if err := ind.clipModel.Init(); err != nil {
+		log.Errorf("index: %s (semantic search)", clean.Error(err))
+		ind.findEmbeddings = false
+	}
+}
+
+// Embedding computes the image embedding of a JPEG image for semantic search.
+func (ind *Index) Embedding(jpeg *MediaFile) (clip.Embedding, error) {
+	start := time.Now()
+
+	fileName, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumb.Tile224)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ind.clipModel.File(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("index: computed embedding of %s [%s]", clean.Log(jpeg.BaseName()), time.Since(start))
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestIndex_Embedding(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Disabled", func(t *testing.T) {
+		ind := NewIndex(conf, nil, nil, nil, nil, nil, nil, NewFiles(), NewPhotos())
+
+		assert.False(t, ind.findEmbeddings)
+
+		ind.initEmbeddings()
+
+		assert.False(t, ind.findEmbeddings)
+	})
+	t.Run("ModelNotFound", func(t *testing.T) {
+		conf.Options().SemanticSearch = true
+
+		defer func() { conf.Options().SemanticSearch = false }()
+
+		ind := NewIndex(conf, nil, nil, nil, clip.NewModel("testdata/clip", false), nil, nil, NewFiles(), NewPhotos())
+
+		assert.True(t, ind.findEmbeddings)
+
+		ind.initEmbeddings()
+
+		assert.False(t, ind.findEmbeddings)
+
+		mediaFile, err := NewMediaFile("testdata/flash.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ind.Embedding(mediaFile)
+
+		assert.Error(t, err)
+	})
+}
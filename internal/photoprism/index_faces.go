@@ -6,10 +6,24 @@ import (
 	"github.com/dustin/go-humanize/english"
 
 	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
+// AddRegionMarkers adds face markers for named image regions, e.g. faces tagged in Lightroom or digiKam.
+func AddRegionMarkers(file *entity.File, regions meta.Regions) {
+	if file == nil {
+		return
+	}
+
+	for _, r := range regions.Faces() {
+		file.AddSubjectMarker(crop.NewArea("face", r.X, r.Y, r.W, r.H), r.Name, entity.SrcXmp)
+	}
+}
+
 // Faces finds faces in JPEG media files and returns them.
 func (ind *Index) Faces(jpeg *MediaFile, expected int) face.Faces {
 	if jpeg == nil {
@@ -152,7 +152,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		} else if photoQuery = entity.UnscopedDb().First(&photo, "photo_path = ? AND photo_name = ? AND photo_stack > -1", filePath, fileBase); photoQuery.Error == nil {
 			// Found.
 			fileStacked = true
-		} else if photoQuery = entity.UnscopedDb().First(&photo, "id IN (SELECT photo_id FROM files WHERE file_name = LIKE ? AND file_root = ? AND file_sidecar = 0 AND file_missing = 0) AND photo_path = ? AND photo_stack > -1", fs.StripKnownExt(fileName)+".%", entity.RootOriginals, filePath); photoQuery.Error == nil {
+		} else if photoQuery = entity.UnscopedDb().First(&photo, "id IN (SELECT photo_id FROM files WHERE file_name = LIKE ? AND file_root = ? AND file_sidecar = FALSE AND file_missing = FALSE) AND photo_path = ? AND photo_stack > -1", fs.StripKnownExt(fileName)+".%", entity.RootOriginals, filePath); photoQuery.Error == nil {
 			// Found.
 			fileStacked = true
 		}
@@ -248,6 +248,13 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		return result
 	}
 
+	// Create animated preview clip and sprite sheet for videos if enabled.
+	if m.IsVideo() && ind.conf.ThumbVideo() {
+		if previewErr := m.GenerateVideoPreviews(ind.thumbPath(), false); previewErr != nil {
+			log.Warnf("index: %s", previewErr)
+		}
+	}
+
 	// Fetch photo details such as keywords, subject, and artist.
 	details := photo.GetDetails()
 
@@ -287,7 +294,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 	// Flag first JPEG as primary file for this photo.
 	if !file.FilePrimary {
 		if photoExists {
-			if res := entity.UnscopedDb().Where("photo_id = ? AND file_primary = 1 AND file_type IN (?) AND file_error = ''", photo.ID, media.PreviewExpr).First(&primaryFile); res.Error != nil {
+			if res := entity.UnscopedDb().Where("photo_id = ? AND file_primary = TRUE AND file_type IN (?) AND file_error = ''", photo.ID, media.PreviewExpr).First(&primaryFile); res.Error != nil {
 				file.FilePrimary = m.IsPreviewImage()
 			}
 		} else {
@@ -366,8 +373,34 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		}
 	}
 
-	// Reset file perceptive diff and chroma percent.
+	// Detect objects and pets, unless they have already been detected.
+	if (ind.findObjects || ind.findPets) && file.FilePrimary && !o.FacesOnly {
+		markers := file.Markers()
+		findObjects := ind.findObjects && len(markers.Objects()) == 0
+		findPets := ind.findPets && len(markers.Pets()) == 0
+
+		if findObjects || findPets {
+			objects := ind.Objects(m)
+
+			if findObjects {
+				file.AddObjects(objects)
+			}
+
+			if findPets {
+				file.AddPets(ind.Pets(m, objects))
+			}
+		}
+	}
+
+	// Add face markers for named regions in the file metadata, e.g. faces tagged in Lightroom or digiKam.
+	if regions := m.MetaData().Regions.Faces(); len(regions) > 0 && file.FilePrimary {
+		AddRegionMarkers(&file, regions)
+		photo.PhotoFaces = file.Markers().ValidFaceCount()
+	}
+
+	// Reset file perceptive diff, perceptual hash, and chroma percent.
 	file.FileDiff = -1
+	file.FilePHash = ""
 	file.FileChroma = -1
 	file.FileVideo = m.IsVideo()
 	file.MediaType = m.Media().String()
@@ -392,6 +425,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			}
 		}
 
+		// Update perceptual hash to find visually similar pictures.
+		if hash, hashErr := m.PerceptualHash(Config().ThumbCachePath()); hashErr == nil {
+			file.FilePHash = hash.Hex()
+		}
+
 		// Update resolution and aspect ratio.
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
@@ -458,6 +496,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			// Update basic metadata.
 			photo.SetTitle(data.Title, entity.SrcXmp)
 			photo.SetDescription(data.Description, entity.SrcXmp)
+			photo.SetRating(data.Rating, entity.SrcXmp)
 			photo.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcXmp)
 			photo.SetCoordinates(data.Lat, data.Lng, data.Altitude, entity.SrcXmp)
 
@@ -474,6 +513,21 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			if data.Favorite {
 				_ = photo.SetFavorite(data.Favorite)
 			}
+
+			// Add face markers for named regions to the primary file.
+			if regions := data.Regions.Faces(); len(regions) == 0 || !photo.HasID() {
+				// Do nothing.
+			} else if primary, primaryErr := photo.PrimaryFile(); primaryErr != nil {
+				log.Debugf("index: %s in %s (find primary file)", primaryErr, logName)
+			} else {
+				AddRegionMarkers(primary, regions)
+
+				if faces, saveErr := primary.SaveMarkers(); saveErr != nil {
+					log.Errorf("index: %s in %s (save markers)", saveErr, logName)
+				} else {
+					photo.PhotoFaces = faces
+				}
+			}
 		} else {
 			log.Warn(dataErr.Error())
 			file.FileError = dataErr.Error()
@@ -483,6 +537,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			// Update basic metadata.
 			photo.SetTitle(data.Title, entity.SrcMeta)
 			photo.SetDescription(data.Description, entity.SrcMeta)
+			photo.SetRating(data.Rating, entity.SrcMeta)
 			photo.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(data.Lat, data.Lng, data.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(data.CameraSerial)
@@ -574,6 +629,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			// Update basic metadata.
 			photo.SetTitle(data.Title, entity.SrcMeta)
 			photo.SetDescription(data.Description, entity.SrcMeta)
+			photo.SetRating(data.Rating, entity.SrcMeta)
 			photo.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcMeta)
 
 			// Update metadata details.
@@ -632,6 +688,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		if data := m.MetaData(); data.Error == nil {
 			photo.SetTitle(data.Title, entity.SrcMeta)
 			photo.SetDescription(data.Description, entity.SrcMeta)
+			photo.SetRating(data.Rating, entity.SrcMeta)
 			photo.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(data.Lat, data.Lng, data.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(data.CameraSerial)
@@ -761,6 +818,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			// Update basic metadata.
 			photo.SetTitle(data.Title, entity.SrcMeta)
 			photo.SetDescription(data.Description, entity.SrcMeta)
+			photo.SetRating(data.Rating, entity.SrcMeta)
 			photo.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(data.Lat, data.Lng, data.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(data.CameraSerial)
@@ -919,6 +977,15 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			log.Errorf("index: %s in %s (save keywords)", err, logName)
 		}
 
+		// Compute image embedding for semantic search?
+		if ind.findEmbeddings {
+			if embedding, embeddingErr := ind.Embedding(m); embeddingErr != nil {
+				log.Warnf("index: %s in %s (compute embedding)", embeddingErr, logName)
+			} else if err = entity.NewPhotoEmbedding(photo.ID, photo.PhotoUID, ind.clipModel.Name(), embedding).Save(); err != nil {
+				log.Errorf("index: %s in %s (save embedding)", err, logName)
+			}
+		}
+
 		if err = query.AlbumEntryFound(photo.PhotoUID); err != nil {
 			log.Errorf("index: %s in %s (remove missing flag from album entry)", err, logName)
 		}
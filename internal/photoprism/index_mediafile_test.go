@@ -27,7 +27,7 @@ func TestIndex_MediaFile(t *testing.T) {
 		fn := face.NewNet(cfg.FaceNetModelPath(), "", cfg.DisableTensorFlow())
 		convert := NewConvert(cfg)
 
-		ind := NewIndex(cfg, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(cfg, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 		mediaFile, err := NewMediaFile("testdata/flash.jpg")
 
@@ -64,7 +64,7 @@ func TestIndex_MediaFile(t *testing.T) {
 		fn := face.NewNet(cfg.FaceNetModelPath(), "", cfg.DisableTensorFlow())
 		convert := NewConvert(cfg)
 
-		ind := NewIndex(cfg, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(cfg, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 		mediaFile, err := NewMediaFile(cfg.ExamplesPath() + "/blue-go-video.mp4")
 		if err != nil {
@@ -87,7 +87,7 @@ func TestIndex_MediaFile(t *testing.T) {
 		fn := face.NewNet(cfg.FaceNetModelPath(), "", cfg.DisableTensorFlow())
 		convert := NewConvert(cfg)
 
-		ind := NewIndex(cfg, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(cfg, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 
 		result := ind.MediaFile(nil, indexOpt, "blue-go-video.mp4", "")
@@ -0,0 +1,51 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// initObjects loads the object detection model and skips detection if it is not available.
+func (ind *Index) initObjects() {
+	if !ind.findObjects {
+		return
+	}
+
+	if err := ind.objectDetector.Init(); err != nil {
+		log.Errorf("index: %s (object detection)", clean.Error(err))
+		ind.findObjects = false
+	}
+}
+
+// Objects detects objects in JPEG media files and returns them.
+func (ind *Index) Objects(jpeg *MediaFile) object.Objects {
+	if jpeg == nil {
+		return object.Objects{}
+	}
+
+	thumbName, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("index: %s in %s (objects)", err, clean.Log(jpeg.BaseName()))
+		return object.Objects{}
+	}
+
+	start := time.Now()
+
+	objects, err := ind.objectDetector.File(thumbName)
+
+	if err != nil {
+		log.Debugf("%s in %s", err, clean.Log(jpeg.BaseName()))
+	}
+
+	if l := len(objects); l > 0 {
+		log.Infof("index: found %s in %s [%s]", english.Plural(l, "object", "objects"), clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+
+	return objects
+}
@@ -0,0 +1,46 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestIndex_Objects(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Disabled", func(t *testing.T) {
+		ind := NewIndex(conf, nil, nil, nil, nil, nil, nil, NewFiles(), NewPhotos())
+
+		assert.False(t, ind.findObjects)
+
+		ind.initObjects()
+
+		assert.False(t, ind.findObjects)
+		assert.Empty(t, ind.Objects(nil))
+	})
+	t.Run("ModelNotFound", func(t *testing.T) {
+		conf.Options().DetectObjects = true
+
+		defer func() { conf.Options().DetectObjects = false }()
+
+		ind := NewIndex(conf, nil, nil, nil, nil, object.NewDetector("testdata/objects", false), nil, NewFiles(), NewPhotos())
+
+		assert.True(t, ind.findObjects)
+
+		ind.initObjects()
+
+		assert.False(t, ind.findObjects)
+
+		mediaFile, err := NewMediaFile("testdata/flash.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, ind.Objects(mediaFile))
+	})
+}
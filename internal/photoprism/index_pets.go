@@ -0,0 +1,78 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/ai/pet"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// initPets loads the models required to recognize pets and skips recognition if they are not available.
+func (ind *Index) initPets() {
+	if !ind.findPets {
+		return
+	}
+
+	if err := ind.objectDetector.Init(); err != nil {
+		log.Errorf("index: %s (pet recognition)", clean.Error(err))
+		ind.findPets = false
+	} else if err = ind.clipModel.Init(); err != nil {
+		log.Errorf("index: %s (pet recognition)", clean.Error(err))
+		ind.findPets = false
+	}
+}
+
+// Pets returns the pets found in a JPEG media file, along with the embeddings required to recognize them.
+func (ind *Index) Pets(jpeg *MediaFile, objects object.Objects) pet.Pets {
+	result := pet.Pets{}
+
+	if jpeg == nil {
+		return result
+	}
+
+	// Skip if no pets were detected.
+	if objects = pet.Filter(objects); len(objects) == 0 {
+		return result
+	}
+
+	thumbName, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("index: %s in %s (pets)", err, clean.Log(jpeg.BaseName()))
+		return result
+	}
+
+	start := time.Now()
+
+	for _, o := range objects {
+		// Crop a square area around the pet to compute its embedding.
+		area := crop.Areas{o.Area}.Focus(jpeg.AspectRatio())
+
+		img, err := crop.ImageFromThumb(thumbName, area, crop.Sizes[crop.Tile224], false)
+
+		if err != nil {
+			log.Debugf("index: %s in %s (crop pet)", err, clean.Log(jpeg.BaseName()))
+			continue
+		}
+
+		embedding, err := ind.clipModel.Embed(img)
+
+		if err != nil {
+			log.Debugf("index: %s in %s (pet embedding)", err, clean.Log(jpeg.BaseName()))
+			continue
+		}
+
+		result = append(result, pet.New(o, embedding))
+	}
+
+	if l := len(result); l > 0 {
+		log.Infof("index: found %s in %s [%s]", english.Plural(l, "pet", "pets"), clean.Log(jpeg.BaseName()), time.Since(start))
+	}
+
+	return result
+}
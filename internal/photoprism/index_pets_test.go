@@ -0,0 +1,66 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/clip"
+	"github.com/photoprism/photoprism/internal/ai/object"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/thumb/crop"
+)
+
+func TestIndex_Pets(t *testing.T) {
+	conf := config.TestConfig()
+
+	dog := object.Object{Name: "dog", Score: 90, Size: 300, Area: crop.NewArea("dog", 0.2, 0.2, 0.5, 0.6)}
+
+	t.Run("Disabled", func(t *testing.T) {
+		ind := NewIndex(conf, nil, nil, nil, nil, nil, nil, NewFiles(), NewPhotos())
+
+		assert.False(t, ind.findPets)
+
+		ind.initPets()
+
+		assert.False(t, ind.findPets)
+		assert.Empty(t, ind.Pets(nil, object.Objects{dog}))
+	})
+	t.Run("ModelNotFound", func(t *testing.T) {
+		conf.Options().DetectPets = true
+
+		defer func() { conf.Options().DetectPets = false }()
+
+		ind := NewIndex(conf, nil, nil, nil, clip.NewModel("testdata/clip", false), object.NewDetector("testdata/objects", false), nil, NewFiles(), NewPhotos())
+
+		assert.True(t, ind.findPets)
+
+		ind.initPets()
+
+		assert.False(t, ind.findPets)
+	})
+	t.Run("NoPets", func(t *testing.T) {
+		ind := NewIndex(conf, nil, nil, nil, nil, nil, nil, NewFiles(), NewPhotos())
+
+		mediaFile, err := NewMediaFile("testdata/flash.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		car := object.Object{Name: "car", Score: 90, Size: 300, Area: crop.NewArea("car", 0.2, 0.2, 0.5, 0.6)}
+
+		assert.Empty(t, ind.Pets(mediaFile, object.Objects{car}))
+	})
+	t.Run("NoEmbedding", func(t *testing.T) {
+		ind := NewIndex(conf, nil, nil, nil, clip.NewModel("testdata/clip", true), nil, nil, NewFiles(), NewPhotos())
+
+		mediaFile, err := NewMediaFile("testdata/flash.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, ind.Pets(mediaFile, object.Objects{dog}))
+	})
+}
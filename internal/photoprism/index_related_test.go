@@ -58,7 +58,7 @@ func TestIndexRelated(t *testing.T) {
 		fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 		opt := IndexOptionsAll()
 
 		result := IndexRelated(related, ind, opt)
@@ -119,7 +119,7 @@ func TestIndexRelated(t *testing.T) {
 		fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 		opt := IndexOptionsAll()
 
 		result := IndexRelated(related, ind, opt)
@@ -27,7 +27,7 @@ func TestIndex_Start(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath(), "")
 
@@ -76,7 +76,7 @@ func TestIndex_File(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 
 	err := ind.FileName("xxx", IndexOptionsAll())
 
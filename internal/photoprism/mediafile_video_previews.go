@@ -0,0 +1,138 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/capture"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// GenerateVideoPreviews generates an animated preview clip, a sprite sheet and a WebVTT thumbnail
+// track for the video in the specified storage path, existing files are only replaced if the force
+// flag is set to true.
+func (m *MediaFile) GenerateVideoPreviews(thumbPath string, force bool) (err error) {
+	if !m.IsVideo() {
+		// Skip.
+		return nil
+	}
+
+	count := 0
+	start := time.Now()
+
+	defer func() {
+		switch count {
+		case 0:
+			log.Debug(capture.Time(start, fmt.Sprintf("media: generated no new video previews for %s", clean.Log(m.RootRelName()))))
+		default:
+			log.Info(capture.Time(start, fmt.Sprintf("media: generated %s for %s", english.Plural(count, "video preview", "video previews"), clean.Log(m.RootRelName()))))
+		}
+	}()
+
+	cnf := Config()
+	hash := m.Hash()
+	duration := m.Duration()
+
+	opt, err := cnf.FFmpegOptions(ffmpeg.SoftwareEncoder, "1M")
+
+	if err != nil {
+		return fmt.Errorf("media: failed to generate video previews for %s (%s)", clean.Log(m.BaseName()), err)
+	}
+
+	opt.Size = thumb.PreviewClipSize
+
+	// Create short muted preview clip.
+	if clipName, nameErr := thumb.PreviewClip.FileName(hash, thumbPath); nameErr != nil {
+		return nameErr
+	} else if force || !fs.FileExists(clipName) {
+		if cmd, cmdErr := ffmpeg.PreviewClipCommand(m.FileName(), clipName, duration, thumb.PreviewClipDuration, opt); cmdErr != nil {
+			return cmdErr
+		} else if err = m.runPreviewCommand(cmd, clipName); err != nil {
+			return err
+		}
+
+		count++
+	}
+
+	// The sprite sheet layout depends on the video duration.
+	if duration <= 0 {
+		log.Debugf("media: unknown duration, skipped creating sprite sheet for %s", clean.Log(m.RootRelName()))
+		return nil
+	}
+
+	spriteName, err := thumb.PreviewSprite.FileName(hash, thumbPath)
+
+	if err != nil {
+		return err
+	}
+
+	trackName, err := thumb.PreviewTrack.FileName(hash, thumbPath)
+
+	if err != nil {
+		return err
+	}
+
+	if !force && fs.FileExists(spriteName) && fs.FileExists(trackName) {
+		return nil
+	}
+
+	sprite := thumb.NewSprite(duration, m.Width(), m.Height())
+
+	// Create sprite sheet for timeline scrubbing.
+	if cmd, cmdErr := ffmpeg.SpriteCommand(m.FileName(), spriteName, sprite.Interval, sprite.Columns, sprite.Rows, sprite.TileWidth, sprite.TileHeight, opt); cmdErr != nil {
+		return cmdErr
+	} else if err = m.runPreviewCommand(cmd, spriteName); err != nil {
+		return err
+	}
+
+	count++
+
+	// Create WebVTT thumbnail track, which references the sprite sheet relative to its own URL.
+	if err = os.WriteFile(trackName, []byte(sprite.WebVTT(thumb.PreviewSprite.String(), duration)), fs.ModeFile); err != nil {
+		return fmt.Errorf("media: failed to create %s (%s)", clean.Log(thumb.PreviewTrack.String()), err)
+	}
+
+	count++
+
+	return nil
+}
+
+// runPreviewCommand runs an FFmpeg command that creates a video preview file.
+func (m *MediaFile) runPreviewCommand(cmd *exec.Cmd, fileName string) error {
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	cmd.Env = append(cmd.Env, []string{
+		fmt.Sprintf("HOME=%s", Config().CmdCachePath()),
+	}...)
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Run ffmpeg command.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			err = errors.New(stderr.String())
+		}
+
+		// Remove incomplete file, if any.
+		if fs.FileExists(fileName) {
+			_ = os.Remove(fileName)
+		}
+
+		return fmt.Errorf("media: failed to create video preview for %s (%s)", clean.Log(m.BaseName()), clean.Error(err))
+	}
+
+	return nil
+}
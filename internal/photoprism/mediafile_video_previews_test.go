@@ -0,0 +1,59 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestMediaFile_GenerateVideoPreviews(t *testing.T) {
+	conf := config.TestConfig()
+	thumbsPath := conf.CachePath() + "/.test_mediafile_video_previews"
+
+	defer os.RemoveAll(thumbsPath)
+
+	t.Run("gopher-video.mp4", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = mf.GenerateVideoPreviews(thumbsPath, true); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, previewType := range thumb.VideoPreviews {
+			fileName, nameErr := previewType.FileName(mf.Hash(), thumbsPath)
+
+			if nameErr != nil {
+				t.Fatal(nameErr)
+			}
+
+			assert.Truef(t, fs.FileExistsNotEmpty(fileName), "%s does not exist", fileName)
+		}
+	})
+	t.Run("NoVideo", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, mf.GenerateVideoPreviews(thumbsPath, false))
+
+		fileName, err := thumb.PreviewClip.FileName(mf.Hash(), thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, fs.FileExists(fileName))
+	})
+}
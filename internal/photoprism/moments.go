@@ -263,6 +263,16 @@ func (w *Moments) Start() (err error) {
 		log.Errorf("moments: %s (update album dates)", queryErr.Error())
 	}
 
+	// UpdateSmartAlbums updates the cached picture counts of smart albums.
+	if _, updateErr := UpdateSmartAlbums(); updateErr != nil {
+		log.Errorf("moments: %s (update smart albums)", updateErr.Error())
+	}
+
+	// UpdateAlbumSmartCovers updates the cover thumbs of smart albums.
+	if queryErr := query.UpdateAlbumSmartCovers(); queryErr != nil {
+		log.Errorf("moments: %s (update smart album covers)", queryErr.Error())
+	}
+
 	return nil
 }
 
@@ -0,0 +1,26 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/media/phash"
+)
+
+// PerceptualHash returns the perceptual hash of an image based on an existing thumbnail,
+// so that visually similar pictures such as bursts and resized copies can be found.
+func (m *MediaFile) PerceptualHash(thumbPath string) (phash.Hash, error) {
+	if !m.IsPreviewImage() || m.IsThumb() {
+		return 0, fmt.Errorf("%s is not a jpeg", clean.Log(m.BaseName()))
+	}
+
+	img, err := m.Resample(thumbPath, thumb.Tile224)
+
+	if err != nil {
+		log.Debugf("phash: %s in %s (resample)", err, clean.Log(m.BaseName()))
+		return 0, err
+	}
+
+	return phash.New(img), nil
+}
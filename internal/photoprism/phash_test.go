@@ -0,0 +1,77 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/media/phash"
+)
+
+func TestMediaFile_PerceptualHash(t *testing.T) {
+	c := config.TestConfig()
+
+	hash := func(fileName string) phash.Hash {
+		mediaFile, err := NewMediaFile(c.ExamplesPath() + "/" + fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := mediaFile.PerceptualHash(c.ThumbCachePath())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return result
+	}
+
+	t.Run("Similar", func(t *testing.T) {
+		img, err := imaging.Open(c.ExamplesPath() + "/IMG_4120.JPG")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Create a resized copy with lower quality.
+		resized := filepath.Join(t.TempDir(), "IMG_4120_resized.jpg")
+
+		if err = imaging.Save(imaging.Resize(img, 320, 0, imaging.Lanczos), resized, imaging.JPEGQuality(60)); err != nil {
+			t.Fatal(err)
+		}
+
+		mediaFile, err := NewMediaFile(resized)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		copied, err := mediaFile.PerceptualHash(c.ThumbCachePath())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		original := hash("IMG_4120.JPG")
+		other := hash("cat_brown.jpg")
+
+		t.Logf("distance: %d resized, %d other", original.Distance(copied), original.Distance(other))
+
+		assert.True(t, original.Similar(copied, phash.DefaultDistance))
+		assert.False(t, original.Similar(other, phash.DefaultDistance))
+	})
+	t.Run("NotAnImage", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(c.ExamplesPath() + "/Random.docx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.PerceptualHash(c.ThumbCachePath())
+		assert.Error(t, err)
+	})
+}
@@ -35,7 +35,7 @@ func TestResample_Start(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, nil, nil, convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath(), "")
@@ -0,0 +1,152 @@
+package photoprism
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// TimeShift represents a worker that corrects the date and time of multiple pictures,
+// e.g. if they were taken with a camera whose clock was set incorrectly.
+type TimeShift struct {
+	conf *config.Config
+}
+
+// NewTimeShift returns a new TimeShift worker.
+func NewTimeShift(conf *config.Config) *TimeShift {
+	instance := &TimeShift{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start applies the time offset to the selected pictures and returns the updated photos.
+// If a session is specified, only pictures the session user may access are updated.
+func (w *TimeShift) Start(f form.TimeShift, sess *entity.Session) (updated entity.Photos, err error) {
+	if err = f.Validate(); err != nil {
+		return updated, err
+	}
+
+	offset, err := w.Offset(f, sess)
+
+	if err != nil {
+		return updated, err
+	}
+
+	photos, err := w.Photos(f, sess)
+
+	if err != nil {
+		return updated, err
+	} else if len(photos) == 0 {
+		log.Infof("timeshift: found no matching pictures")
+		return updated, nil
+	} else if offset == 0 {
+		log.Infof("timeshift: offset is zero, nothing to do")
+		return updated, nil
+	}
+
+	start := time.Now()
+
+	log.Infof("timeshift: shifting date of %s by %s", english.Plural(len(photos), "picture", "pictures"), offset)
+
+	for i := range photos {
+		p := photos[i]
+
+		if shiftErr := p.ShiftTakenAt(offset, entity.SrcBatch); shiftErr != nil {
+			log.Warnf("timeshift: %s", shiftErr)
+			continue
+		} else if saveErr := p.SaveTakenAt(); saveErr != nil {
+			log.Errorf("timeshift: %s while saving %s", saveErr, p.String())
+			continue
+		}
+
+		// Write the corrected date to the YAML sidecar file.
+		if w.conf.SidecarYaml() {
+			_ = p.SaveSidecarYaml(w.conf.OriginalsPath(), w.conf.SidecarPath())
+		}
+
+		// Write the corrected date to the XMP sidecar file.
+		if w.conf.SidecarXmp() {
+			_ = p.SaveSidecarXmp(w.conf.OriginalsPath())
+		}
+
+		updated = append(updated, p)
+	}
+
+	log.Infof("timeshift: updated %s [%s]", english.Plural(len(updated), "picture", "pictures"), time.Since(start))
+
+	return updated, nil
+}
+
+// Offset returns the time offset specified in the form, or derives it from the
+// difference between the correct local time and the local time of the reference photo.
+func (w *TimeShift) Offset(f form.TimeShift, sess *entity.Session) (time.Duration, error) {
+	if f.Offset != "" {
+		return f.Duration()
+	}
+
+	ref, err := query.PhotoByUID(clean.UID(f.RefUID))
+
+	if err != nil || !sess.CanAccessPhoto(&ref) {
+		return 0, fmt.Errorf("reference photo %s not found", clean.Log(f.RefUID))
+	} else if ref.TakenAtLocal.IsZero() {
+		return 0, fmt.Errorf("reference photo %s has no date", clean.Log(f.RefUID))
+	}
+
+	refTime := f.RefTimeLocal()
+
+	if refTime.IsZero() {
+		return 0, fmt.Errorf("invalid reference time")
+	}
+
+	return refTime.Sub(ref.TakenAtLocal.UTC().Truncate(time.Second)), nil
+}
+
+// Photos returns the selected pictures. If a camera serial number is specified, only
+// pictures taken with this camera are returned, optionally limited to a time range.
+// If a session is specified, the results are limited to pictures the session user may access.
+func (w *TimeShift) Photos(f form.TimeShift, sess *entity.Session) (photos entity.Photos, err error) {
+	after, before := f.TakenAfter(), f.TakenBefore()
+
+	if f.Empty() {
+		if photos, err = query.PhotosByCameraSerial(f.Serial, after, before); err != nil {
+			return photos, err
+		}
+
+		return sess.AccessiblePhotos(photos), nil
+	}
+
+	selected, err := query.SelectedPhotos(f.Selection)
+
+	if err != nil {
+		return photos, err
+	}
+
+	selected = sess.AccessiblePhotos(selected)
+
+	if f.Serial == "" {
+		return selected, nil
+	}
+
+	for _, p := range selected {
+		if p.CameraSerial != f.Serial {
+			continue
+		} else if !after.IsZero() && p.TakenAtLocal.Before(after) {
+			continue
+		} else if !before.IsZero() && p.TakenAtLocal.After(before) {
+			continue
+		}
+
+		photos = append(photos, p)
+	}
+
+	return photos, nil
+}
@@ -0,0 +1,119 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestTimeShift_Start(t *testing.T) {
+	w := NewTimeShift(config.TestConfig())
+
+	t.Run("Serial", func(t *testing.T) {
+		orig := entity.PhotoFixtures.Get("Photo05")
+
+		// Restore original values.
+		defer func() {
+			if err := orig.SaveTakenAt(); err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		updated, err := w.Start(form.TimeShift{Serial: "123", Offset: "-2h"}, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, updated, 1) {
+			assert.Equal(t, orig.PhotoUID, updated[0].PhotoUID)
+			assert.Equal(t, entity.SrcBatch, updated[0].TakenSrc)
+			assert.Equal(t, orig.TakenAt.Add(-2*time.Hour), updated[0].TakenAt.UTC())
+
+			// The local time is derived from the time zone at the photo location.
+			assert.Equal(t, "Indian/Reunion", updated[0].TimeZone)
+			assert.Equal(t, orig.TakenAt.Add(2*time.Hour), updated[0].TakenAtLocal.UTC())
+		}
+	})
+	t.Run("Reference", func(t *testing.T) {
+		orig := entity.PhotoFixtures.Get("Photo19")
+
+		// Restore original values.
+		defer func() {
+			if err := orig.SaveTakenAt(); err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		f := form.TimeShift{
+			Selection: form.Selection{Photos: []string{orig.PhotoUID}},
+			RefUID:    orig.PhotoUID,
+			RefTime:   "2008-01-01 01:30:00",
+		}
+
+		offset, err := w.Offset(f, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 90*time.Minute, offset)
+
+		updated, err := w.Start(f, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, updated, 1) {
+			assert.Equal(t, time.Date(2008, 1, 1, 1, 30, 0, 0, time.UTC), updated[0].TakenAtLocal.UTC())
+		}
+	})
+	t.Run("SerialMismatch", func(t *testing.T) {
+		updated, err := w.Start(form.TimeShift{
+			Selection: form.Selection{Photos: []string{entity.PhotoFixtures.Get("Photo19").PhotoUID}},
+			Serial:    "123",
+			Offset:    "1h",
+		}, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, updated, 0)
+	})
+	t.Run("ReferenceNotFound", func(t *testing.T) {
+		_, err := w.Start(form.TimeShift{Serial: "123", RefUID: "ps6sg6be2lvl0000", RefTime: "2008-01-01 01:30:00"}, nil)
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := w.Start(form.TimeShift{Offset: "1h"}, nil)
+		assert.Error(t, err)
+	})
+	t.Run("UserLibrary", func(t *testing.T) {
+		defer func() { entity.LibraryMode = entity.LibraryShared }()
+
+		entity.LibraryMode = entity.LibraryUser
+
+		// The bob fixture has not uploaded any photos, and none have been shared with this account.
+		sess := entity.SessionFixtures.Pointer("bob")
+
+		updated, err := w.Start(form.TimeShift{Serial: "123", Offset: "-2h"}, sess)
+
+		assert.NoError(t, err)
+		assert.Len(t, updated, 0)
+
+		updated, err = w.Start(form.TimeShift{
+			Selection: form.Selection{Photos: []string{entity.PhotoFixtures.Get("Photo19").PhotoUID}},
+			Offset:    "1h",
+		}, sess)
+
+		assert.NoError(t, err)
+		assert.Len(t, updated, 0)
+
+		_, err = w.Offset(form.TimeShift{RefUID: entity.PhotoFixtures.Get("Photo19").PhotoUID, RefTime: "2008-01-01 01:30:00"}, sess)
+		assert.Error(t, err)
+	})
+}
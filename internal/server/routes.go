@@ -73,9 +73,27 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.ConfirmUserPasscode(APIv1)
 	api.ActivateUserPasscode(APIv1)
 	api.DeactivateUserPasscode(APIv1)
+	api.GetUserPasskeys(APIv1)
+	api.RegisterUserPasskey(APIv1)
+	api.CreateUserPasskey(APIv1)
+	api.DeleteUserPasskey(APIv1)
+	api.AssertUserPasskey(APIv1)
 	api.UpdateUserPassword(APIv1)
 	api.UpdateUser(APIv1)
 
+	// Teams.
+	api.SearchTeams(APIv1)
+	api.GetTeam(APIv1)
+	api.CreateTeam(APIv1)
+	api.UpdateTeam(APIv1)
+	api.DeleteTeam(APIv1)
+	api.GetTeamMembers(APIv1)
+	api.AddTeamMember(APIv1)
+	api.RemoveTeamMember(APIv1)
+	api.GetTeamShares(APIv1)
+	api.ShareWithTeam(APIv1)
+	api.UnshareWithTeam(APIv1)
+
 	// Service Accounts.
 	api.SearchServices(APIv1)
 	api.GetService(APIv1)
@@ -90,6 +108,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 	// Video Streaming.
 	api.GetVideo(APIv1)
+	api.GetVideoStream(APIv1)
+	api.GetVideoPreview(APIv1)
 
 	// Downloads.
 	api.GetDownload(APIv1)
@@ -105,6 +125,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	// Photo Search and Organization.
 	api.SearchPhotos(APIv1)
 	api.SearchGeo(APIv1)
+	api.GetTracks(APIv1)
+	api.UploadTracks(APIv1)
+	api.GeotagPhotos(APIv1)
 	api.GetPhoto(APIv1)
 	api.GetPhotoYaml(APIv1)
 	api.UpdatePhoto(APIv1)
@@ -116,6 +139,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.ApprovePhoto(APIv1)
 	api.LikePhoto(APIv1)
 	api.DislikePhoto(APIv1)
+	api.GetPhotoComments(APIv1)
+	api.CreatePhotoComment(APIv1)
 	api.AddPhotoLabel(APIv1)
 	api.RemovePhotoLabel(APIv1)
 	api.UpdatePhotoLabel(APIv1)
@@ -143,9 +168,14 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.DeleteAlbumLink(APIv1)
 	api.LikeAlbum(APIv1)
 	api.DislikeAlbum(APIv1)
+	api.GetAlbumComments(APIv1)
+	api.CreateAlbumComment(APIv1)
+	api.DeleteComment(APIv1)
 	api.CloneAlbums(APIv1)
 	api.AddPhotosToAlbum(APIv1)
 	api.RemovePhotosFromAlbum(APIv1)
+	api.UploadAlbumFiles(APIv1)
+	api.ProcessAlbumUpload(APIv1)
 
 	// Photo Labels.
 	api.SearchLabels(APIv1)
@@ -180,6 +210,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.BatchPhotosArchive(APIv1)
 	api.BatchPhotosRestore(APIv1)
 	api.BatchPhotosPrivate(APIv1)
+	api.BatchPhotosTime(APIv1)
 	api.BatchPhotosDelete(APIv1)
 	api.BatchAlbumsDelete(APIv1)
 	api.BatchLabelsDelete(APIv1)
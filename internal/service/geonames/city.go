@@ -0,0 +1,28 @@
+package geonames
+
+import (
+	"strconv"
+	"strings"
+)
+
+// City represents a populated place from the GeoNames dataset.
+type City struct {
+	ID          int
+	Name        string
+	Lat         float64
+	Lng         float64
+	FeatureCode string
+	CountryCode string
+	Admin1      string
+	Population  int
+}
+
+// District tests if the place is a section of a populated place, e.g. a city district.
+func (c *City) District() bool {
+	return strings.HasPrefix(c.FeatureCode, "PPLX")
+}
+
+// PlaceID returns a unique place identifier string based on the GeoNames ID.
+func (c *City) PlaceID() string {
+	return strings.ToLower(c.CountryCode) + ":" + strconv.Itoa(c.ID)
+}
@@ -0,0 +1,59 @@
+/*
+Package geonames provides offline reverse geocoding based on the GeoNames cities and admin area datasets.
+
+See https://download.geonames.org/export/dump/
+
+Copyright (c) 2018 - 2024 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package geonames
+
+import (
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/service/maps"
+)
+
+var log = event.Log
+
+// ApiName is the backend API name.
+const ApiName = "geonames"
+
+// DataPath specifies the folder that contains the GeoNames dataset files.
+var DataPath = ""
+
+// MaxDistance specifies the maximum distance in km between a location and the nearest populated place.
+var MaxDistance = 50.0
+
+// CitiesFiles lists the supported GeoNames city dataset files in order of preference.
+var CitiesFiles = []string{"cities500.txt", "cities1000.txt", "cities5000.txt", "cities15000.txt"}
+
+// AdminFile specifies the name of the GeoNames file with first-level admin area names.
+var AdminFile = "admin1CodesASCII.txt"
+
+func init() {
+	maps.RegisterGeocoder(ApiName, maps.GeocoderFunc(func(id string) (maps.LocationSource, error) {
+		if l, err := FindLocation(id); err != nil {
+			return nil, err
+		} else {
+			return l, nil
+		}
+	}))
+}
@@ -0,0 +1,22 @@
+package geonames
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.TraceLevel)
+	event.AuditLog = log
+
+	DataPath = "testdata"
+
+	code := m.Run()
+
+	os.Exit(code)
+}
@@ -0,0 +1,272 @@
+package geonames
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/geo/s2"
+)
+
+// Index represents an S2 cell based lookup table for populated places.
+type Index struct {
+	cells  map[string][]*City
+	admin  map[string]string
+	level  int
+	maxKm  float64
+	places int
+}
+
+var index *Index
+var indexErr error
+var indexPath string
+var indexMutex = sync.Mutex{}
+
+// NewIndex returns a new, empty index for places within the specified maximum distance in km.
+func NewIndex(maxKm float64) *Index {
+	if maxKm <= 0 {
+		maxKm = MaxDistance
+	}
+
+	// Cells and their neighbors must cover the maximum distance.
+	return &Index{
+		cells: make(map[string][]*City),
+		admin: make(map[string]string),
+		level: s2.Level(2 * maxKm),
+		maxKm: maxKm,
+	}
+}
+
+// LoadIndex reads the GeoNames dataset files in the specified folder and returns a new index.
+func LoadIndex(dir string) (*Index, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("geonames: data path not set")
+	}
+
+	start := time.Now()
+	idx := NewIndex(MaxDistance)
+
+	// Read admin area names if the file exists.
+	if fileName := filepath.Join(dir, AdminFile); fs.FileExists(fileName) {
+		if err := idx.readFile(fileName, idx.addAdmin); err != nil {
+			return nil, err
+		}
+	}
+
+	// Read the first city dataset found.
+	for _, name := range CitiesFiles {
+		if fileName := filepath.Join(dir, name); !fs.FileExists(fileName) {
+			continue
+		} else if err := idx.readFile(fileName, idx.addCity); err != nil {
+			return nil, err
+		}
+
+		log.Infof("geonames: indexed %s from %s [%s]", english.Plural(idx.places, "place", "places"), clean.Log(name), time.Since(start))
+
+		return idx, nil
+	}
+
+	return nil, fmt.Errorf("geonames: no city dataset found in %s", clean.Log(dir))
+}
+
+// readFile calls the specified function for each tab-separated line of a file.
+func (idx *Index) readFile(fileName string, add func(values []string)) error {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if line := scanner.Text(); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		} else {
+			add(strings.Split(line, "\t"))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// addAdmin adds an admin area name, e.g. "DE.16	Berlin	Berlin	2950157".
+func (idx *Index) addAdmin(values []string) {
+	if len(values) < 2 || values[0] == "" {
+		return
+	}
+
+	idx.admin[values[0]] = values[1]
+}
+
+// addCity adds a populated place from a line in the GeoNames main table format.
+func (idx *Index) addCity(values []string) {
+	if len(values) < 15 || values[6] != "P" {
+		return
+	}
+
+	lat, latErr := strconv.ParseFloat(values[4], 64)
+	lng, lngErr := strconv.ParseFloat(values[5], 64)
+
+	if latErr != nil || lngErr != nil {
+		return
+	}
+
+	id, _ := strconv.Atoi(values[0])
+	population, _ := strconv.Atoi(values[14])
+
+	idx.Add(&City{
+		ID:          id,
+		Name:        values[1],
+		Lat:         lat,
+		Lng:         lng,
+		FeatureCode: values[7],
+		CountryCode: values[8],
+		Admin1:      values[10],
+		Population:  population,
+	})
+}
+
+// Add adds a populated place to the index.
+func (idx *Index) Add(c *City) {
+	token := s2.TokenLevel(c.Lat, c.Lng, idx.level)
+
+	if token == "" {
+		return
+	}
+
+	idx.cells[token] = append(idx.cells[token], c)
+	idx.places++
+}
+
+// AddAdmin adds the name of a first-level admin area, e.g. "DE.16" for Berlin.
+func (idx *Index) AddAdmin(code, name string) {
+	idx.addAdmin([]string{code, name})
+}
+
+// Len returns the number of indexed places.
+func (idx *Index) Len() int {
+	return idx.places
+}
+
+// Nearest returns the nearest populated place that matches the filter within the maximum distance.
+func (idx *Index) Nearest(lat, lng float64, filter func(c *City) bool) (result *City, km float64) {
+	pos := geo.Position{Lat: lat, Lng: lng}
+	km = idx.maxKm
+
+	for _, token := range s2.Neighbors(s2.TokenLevel(lat, lng, s2.DefaultLevel), idx.level) {
+		for _, c := range idx.cells[token] {
+			if filter != nil && !filter(c) {
+				continue
+			}
+
+			if d := geo.Km(pos, geo.Position{Lat: c.Lat, Lng: c.Lng}); d <= km {
+				result, km = c, d
+			}
+		}
+	}
+
+	return result, km
+}
+
+// State returns the name of the first-level admin area the place belongs to.
+func (idx *Index) State(c *City) string {
+	if c == nil || c.Admin1 == "" {
+		return ""
+	}
+
+	return idx.admin[c.CountryCode+"."+c.Admin1]
+}
+
+// Location returns the location details for the specified coordinates.
+func (idx *Index) Location(lat, lng float64) (result Location, err error) {
+	nearest, _ := idx.Nearest(lat, lng, nil)
+
+	if nearest == nil {
+		return result, fmt.Errorf("no place found within %.0f km", idx.maxKm)
+	}
+
+	result.LocLat = lat
+	result.LocLng = lng
+
+	// Use the nearest city if a section of a populated place, e.g. a city district, was found.
+	if nearest.District() {
+		if city, _ := idx.Nearest(lat, lng, func(c *City) bool { return !c.District() }); city != nil {
+			result.LocDistrict = nearest.Name
+			nearest = city
+		}
+	}
+
+	result.Place = nearest
+	result.LocState = idx.State(nearest)
+
+	return result, nil
+}
+
+// FindLocation returns the location details for the specified S2 cell ID, using the dataset in DataPath.
+func FindLocation(id string) (result Location, err error) {
+	// Normalize S2 Cell ID.
+	id = s2.NormalizeToken(id)
+
+	// Valid?
+	if len(id) == 0 {
+		return result, fmt.Errorf("empty cell id")
+	} else if n := len(id); n < 4 || n > 16 {
+		return result, fmt.Errorf("invalid cell id %s", clean.Log(id))
+	}
+
+	// Convert S2 Cell ID to latitude and longitude.
+	lat, lng := s2.LatLng(id)
+
+	// Return if latitude and longitude are null.
+	if lat == 0.0 || lng == 0.0 {
+		return result, fmt.Errorf("skipping lat %f, lng %f", lat, lng)
+	}
+
+	idx, err := defaultIndex()
+
+	if err != nil {
+		return result, err
+	}
+
+	if result, err = idx.Location(lat, lng); err != nil {
+		return result, err
+	}
+
+	result.ID = id
+
+	return result, nil
+}
+
+// defaultIndex returns the index for the dataset in DataPath, which is loaded on first use.
+func defaultIndex() (*Index, error) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+
+	// Don't try to load the dataset again if it failed before.
+	if indexPath == DataPath && (index != nil || indexErr != nil) {
+		return index, indexErr
+	}
+
+	index, indexErr = LoadIndex(DataPath)
+	indexPath = DataPath
+
+	if indexErr != nil {
+		log.Errorf("%s", indexErr)
+	}
+
+	return index, indexErr
+}
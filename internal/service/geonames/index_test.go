@@ -0,0 +1,111 @@
+package geonames
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/geo/s2"
+)
+
+func TestLoadIndex(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		idx, err := LoadIndex("testdata")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 5, idx.Len())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := LoadIndex("testdata/missing")
+		assert.Error(t, err)
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		_, err := LoadIndex("")
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_Nearest(t *testing.T) {
+	idx := NewIndex(20)
+	idx.Add(&City{ID: 1, Name: "Berlin", Lat: 52.52437, Lng: 13.41053, FeatureCode: "PPLC", CountryCode: "DE"})
+	idx.Add(&City{ID: 2, Name: "Potsdam", Lat: 52.39886, Lng: 13.06566, FeatureCode: "PPLA", CountryCode: "DE"})
+
+	t.Run("Berlin", func(t *testing.T) {
+		result, km := idx.Nearest(52.5208, 13.40953, nil)
+
+		if assert.NotNil(t, result) {
+			assert.Equal(t, "Berlin", result.Name)
+			assert.InDelta(t, 0.4, km, 0.1)
+		}
+	})
+	t.Run("Filter", func(t *testing.T) {
+		result, _ := idx.Nearest(52.45, 13.2, func(c *City) bool { return c.Name != "Potsdam" })
+
+		if assert.NotNil(t, result) {
+			assert.Equal(t, "Berlin", result.Name)
+		}
+	})
+	t.Run("TooFar", func(t *testing.T) {
+		result, _ := idx.Nearest(52.0, 14.5, nil)
+		assert.Nil(t, result)
+	})
+}
+
+func TestFindLocation(t *testing.T) {
+	t.Run("BerlinerFernsehturm", func(t *testing.T) {
+		l, err := FindLocation(s2.Token(52.5208, 13.40953))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de:2950159", l.PlaceID())
+		assert.Equal(t, "Mitte", l.District())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "Berlin", l.State())
+		assert.Equal(t, "de", l.CountryCode())
+		assert.Equal(t, "Berlin, Germany", l.Label())
+		assert.Equal(t, "", l.Name())
+		assert.Equal(t, ApiName, l.Source())
+	})
+	t.Run("Hambach", func(t *testing.T) {
+		l, err := FindLocation(s2.PrefixedToken(49.3265, 8.1331))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de:2866070", l.PlaceID())
+		assert.Equal(t, "Hambach an der Weinstraße", l.District())
+		assert.Equal(t, "Neustadt an der Weinstraße", l.City())
+		assert.Equal(t, "Rheinland-Pfalz", l.State())
+		assert.Equal(t, "Neustadt an der Weinstraße, Rheinland-Pfalz, Germany", l.Label())
+	})
+	t.Run("MexicoCity", func(t *testing.T) {
+		l, err := FindLocation(s2.Token(19.4326, -99.1332))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", l.District())
+		assert.Equal(t, "Mexico City", l.City())
+		assert.Equal(t, "mx", l.CountryCode())
+		assert.Equal(t, "Mexico City, Mexico", l.Label())
+	})
+	t.Run("NorthAtlanticOcean", func(t *testing.T) {
+		_, err := FindLocation("0a3c25fcffad")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := FindLocation("abc")
+		assert.Error(t, err)
+	})
+	t.Run("EmptyID", func(t *testing.T) {
+		_, err := FindLocation("")
+		assert.Error(t, err)
+	})
+}
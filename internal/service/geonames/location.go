@@ -0,0 +1,113 @@
+package geonames
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/service/maps"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Location represents a specific geolocation identified by its S2 ID.
+type Location struct {
+	ID          string
+	LocLat      float64
+	LocLng      float64
+	LocDistrict string
+	LocState    string
+	Place       *City
+}
+
+// CellID returns the S2 cell identifier string.
+func (l Location) CellID() string {
+	return l.ID
+}
+
+// PlaceID returns the place identifier string.
+func (l Location) PlaceID() string {
+	if l.Place == nil {
+		return ""
+	}
+
+	return l.Place.PlaceID()
+}
+
+// Name returns the location name, which is not included in the dataset.
+func (l Location) Name() string {
+	return ""
+}
+
+// Street returns the location street, which is not included in the dataset.
+func (l Location) Street() string {
+	return ""
+}
+
+// Postcode returns the location postcode, which is not included in the dataset.
+func (l Location) Postcode() string {
+	return ""
+}
+
+// Category returns the location category, which is not included in the dataset.
+func (l Location) Category() string {
+	return ""
+}
+
+// Label returns the location label, e.g. "Neustadt an der Weinstraße, Rheinland-Pfalz, Germany".
+func (l Location) Label() string {
+	var parts []string
+
+	for _, s := range []string{l.City(), l.State(), maps.CountryNames[l.CountryCode()]} {
+		if s != "" && (len(parts) == 0 || parts[len(parts)-1] != s) {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// City returns the location address city name.
+func (l Location) City() string {
+	if l.Place == nil {
+		return ""
+	}
+
+	return l.Place.Name
+}
+
+// District returns the location address district name.
+func (l Location) District() string {
+	return l.LocDistrict
+}
+
+// CountryCode returns the location address country code.
+func (l Location) CountryCode() string {
+	if l.Place == nil {
+		return ""
+	}
+
+	return strings.ToLower(l.Place.CountryCode)
+}
+
+// State returns the location address state name.
+func (l Location) State() string {
+	return clean.State(l.LocState, l.CountryCode())
+}
+
+// Latitude returns the location position latitude.
+func (l Location) Latitude() float64 {
+	return l.LocLat
+}
+
+// Longitude returns the location position longitude.
+func (l Location) Longitude() float64 {
+	return l.LocLng
+}
+
+// Keywords returns location keywords, which are not included in the dataset.
+func (l Location) Keywords() []string {
+	return nil
+}
+
+// Source returns the backend API name.
+func (l Location) Source() string {
+	return ApiName
+}
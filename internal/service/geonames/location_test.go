@@ -0,0 +1,37 @@
+package geonames
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/service/maps"
+	"github.com/photoprism/photoprism/pkg/geo/s2"
+)
+
+func TestLocation_Label(t *testing.T) {
+	t.Run("Unknown", func(t *testing.T) {
+		l := Location{}
+
+		assert.Equal(t, "", l.Label())
+		assert.Equal(t, "", l.PlaceID())
+	})
+	t.Run("CityState", func(t *testing.T) {
+		l := Location{Place: &City{Name: "Teotihuacán", CountryCode: "MX"}, LocState: "State of Mexico"}
+
+		assert.Equal(t, "Teotihuacán, State of Mexico, Mexico", l.Label())
+	})
+}
+
+func TestGeocoder(t *testing.T) {
+	l := maps.Location{ID: s2.Token(52.5208, 13.40953)}
+
+	if err := l.QueryApi(ApiName); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "de:2950159", l.PlaceID())
+	assert.Equal(t, "Berlin, Germany", l.Label())
+	assert.Equal(t, "Mitte", l.District())
+	assert.Equal(t, ApiName, l.Source())
+}
@@ -0,0 +1,56 @@
+package maps
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/service/hub/places"
+)
+
+// Geocoder represents a reverse geocoding backend that returns location details for an S2 cell ID.
+type Geocoder interface {
+	FindLocation(id string) (LocationSource, error)
+}
+
+// GeocoderFunc allows the use of ordinary functions as Geocoder.
+type GeocoderFunc func(id string) (LocationSource, error)
+
+// FindLocation calls f(id).
+func (f GeocoderFunc) FindLocation(id string) (LocationSource, error) {
+	return f(id)
+}
+
+var geocoderMutex = sync.RWMutex{}
+
+// geocoders maps the backend API names to the registered geocoders.
+var geocoders = map[string]Geocoder{
+	places.ApiName: GeocoderFunc(func(id string) (LocationSource, error) {
+		if l, err := places.FindLocation(id); err != nil {
+			return nil, err
+		} else {
+			return l, nil
+		}
+	}),
+}
+
+// RegisterGeocoder adds a reverse geocoding backend with the specified API name,
+// replacing any backend previously registered with the same name.
+func RegisterGeocoder(api string, g Geocoder) {
+	if api == "" || g == nil {
+		return
+	}
+
+	geocoderMutex.Lock()
+	defer geocoderMutex.Unlock()
+
+	geocoders[api] = g
+}
+
+// FindGeocoder returns the reverse geocoding backend with the specified API name, if any.
+func FindGeocoder(api string) (g Geocoder, ok bool) {
+	geocoderMutex.RLock()
+	defer geocoderMutex.RUnlock()
+
+	g, ok = geocoders[api]
+
+	return g, ok
+}
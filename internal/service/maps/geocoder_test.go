@@ -0,0 +1,78 @@
+package maps
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/service/hub/places"
+)
+
+// testLocation represents location details returned by a test geocoder.
+type testLocation struct {
+	places.Location
+}
+
+// Source returns the backend API name.
+func (l testLocation) Source() string {
+	return "test"
+}
+
+func TestRegisterGeocoder(t *testing.T) {
+	RegisterGeocoder("test", GeocoderFunc(func(id string) (LocationSource, error) {
+		if id != "47a84e2027f4" {
+			return nil, errors.New("not found")
+		}
+
+		return testLocation{places.Location{
+			ID: id,
+			Place: places.Place{
+				PlaceID:     "de:test",
+				LocLabel:    "Mitte, Berlin, Germany",
+				LocDistrict: "Mitte",
+				LocCity:     "Berlin",
+				LocState:    "Berlin",
+				LocCountry:  "de",
+			},
+		}}, nil
+	}))
+
+	// Empty names and nil values must be ignored.
+	RegisterGeocoder("", nil)
+	RegisterGeocoder("nil", nil)
+
+	t.Run("Found", func(t *testing.T) {
+		l := Location{ID: "47a84e2027f4"}
+
+		if err := l.QueryApi("test"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de:test", l.PlaceID())
+		assert.Equal(t, "Mitte, Berlin, Germany", l.Label())
+		assert.Equal(t, "Mitte", l.District())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "Germany", l.CountryName())
+		assert.Equal(t, "test", l.Source())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		l := Location{ID: "0a3c25fcffad"}
+		assert.Error(t, l.QueryApi("test"))
+	})
+	t.Run("NotRegistered", func(t *testing.T) {
+		_, ok := FindGeocoder("nil")
+		assert.False(t, ok)
+	})
+}
+
+func TestFindGeocoder(t *testing.T) {
+	g, ok := FindGeocoder(places.ApiName)
+
+	assert.True(t, ok)
+	assert.NotNil(t, g)
+
+	_, ok = FindGeocoder("xxx")
+
+	assert.False(t, ok)
+}
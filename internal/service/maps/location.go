@@ -27,6 +27,7 @@ type Location struct {
 	LocSource   string
 }
 
+// LocationSource represents the location details returned by a geocoder.
 type LocationSource interface {
 	CellID() string
 	PlaceID() string
@@ -34,6 +35,7 @@ type LocationSource interface {
 	Street() string
 	Category() string
 	Postcode() string
+	Label() string
 	District() string
 	City() string
 	State() string
@@ -42,17 +44,23 @@ type LocationSource interface {
 	Source() string
 }
 
+// QueryApi retrieves the location details from the geocoder with the specified API name.
 func (l *Location) QueryApi(api string) error {
-	switch api {
-	case places.ApiName:
-		return l.QueryPlaces()
+	if g, ok := FindGeocoder(api); ok {
+		return l.Query(g)
 	}
 
 	return errors.New("maps: location lookup disabled")
 }
 
+// QueryPlaces retrieves the location details from the places API.
 func (l *Location) QueryPlaces() error {
-	s, err := places.FindLocation(l.ID)
+	return l.QueryApi(places.ApiName)
+}
+
+// Query retrieves the location details from the specified geocoder.
+func (l *Location) Query(g Geocoder) error {
+	s, err := g.FindLocation(l.ID)
 
 	if err != nil {
 		return err
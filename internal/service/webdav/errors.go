@@ -0,0 +1,34 @@
+package webdav
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"reflect"
+)
+
+// IsNotFound checks if the error was caused by a "404 Not Found" server response.
+//
+// The HTTP error type of the WebDAV library is not exported, so its status code
+// is read from the exported Code field instead.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	} else if errors.Is(err, os.ErrNotExist) {
+		return true
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.Indirect(reflect.ValueOf(err))
+
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+
+		if code := v.FieldByName("Code"); code.IsValid() && code.CanInt() && code.Int() == http.StatusNotFound {
+			return true
+		}
+	}
+
+	return false
+}
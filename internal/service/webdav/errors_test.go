@@ -0,0 +1,28 @@
+package webdav
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testHttpError struct {
+	Code int
+}
+
+func (err *testHttpError) Error() string {
+	return fmt.Sprintf("%d %s", err.Code, http.StatusText(err.Code))
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, IsNotFound(&testHttpError{Code: http.StatusNotFound}))
+	assert.True(t, IsNotFound(fmt.Errorf("webdav: %w", &testHttpError{Code: http.StatusNotFound})))
+	assert.True(t, IsNotFound(os.ErrNotExist))
+	assert.False(t, IsNotFound(&testHttpError{Code: http.StatusForbidden}))
+	assert.False(t, IsNotFound(errors.New("404 Not Found")))
+	assert.False(t, IsNotFound(nil))
+}
@@ -190,3 +190,38 @@ func ParseThumb(thumb string) (fileHash, area string) {
 
 	return fileHash, area
 }
+
+// Focus returns a square crop area centered on the specified areas, e.g. detected objects.
+// The aspect ratio (width / height) of the image is required to calculate a square in pixels.
+func (a Areas) Focus(aspect float32) Area {
+	if len(a) == 0 || aspect <= 0 {
+		return Area{}
+	}
+
+	var minX, minY, maxX, maxY float32 = 1, 1, 0, 0
+
+	for _, area := range a {
+		if area.Empty() {
+			continue
+		}
+
+		minX = min(minX, area.X)
+		minY = min(minY, area.Y)
+		maxX = max(maxX, area.X+area.W)
+		maxY = max(maxY, area.Y+area.H)
+	}
+
+	if maxX <= minX || maxY <= minY {
+		return Area{}
+	}
+
+	// Calculate the relative width of a square that contains all areas and fits into the image.
+	w := min(max(maxX-minX, (maxY-minY)/aspect), 1, 1/aspect)
+	h := w * aspect
+
+	// Center the square on the areas while keeping it within the image bounds.
+	x := min(max((minX+maxX)/2-w/2, 0), 1-w)
+	y := min(max((minY+maxY)/2-h/2, 0), 1-h)
+
+	return NewArea("focus", x, y, w, h)
+}
@@ -192,3 +192,51 @@ func TestArea_OverlapPercent(t *testing.T) {
 	assert.Equal(t, 0, a1.OverlapPercent(a3))
 	assert.Equal(t, 96, a1.OverlapPercent(a4))
 }
+
+func TestAreas_Focus(t *testing.T) {
+	t.Run("Landscape", func(t *testing.T) {
+		areas := Areas{NewArea("car", 0.6, 0.4, 0.2, 0.3)}
+
+		// The image is twice as wide as high, so the relative height of the square must be twice its width.
+		result := areas.Focus(2)
+
+		assert.Equal(t, "focus", result.Name)
+		assert.InDelta(t, 0.2, result.W, 0.0001)
+		assert.InDelta(t, 0.4, result.H, 0.0001)
+		assert.InDelta(t, 0.6, result.X, 0.0001)
+		assert.InDelta(t, 0.35, result.Y, 0.0001)
+	})
+	t.Run("Wide", func(t *testing.T) {
+		areas := Areas{NewArea("car", 0.1, 0.4, 0.6, 0.2)}
+
+		result := areas.Focus(1)
+
+		assert.InDelta(t, 0.6, result.W, 0.0001)
+		assert.InDelta(t, 0.6, result.H, 0.0001)
+		assert.InDelta(t, 0.1, result.X, 0.0001)
+		assert.InDelta(t, 0.2, result.Y, 0.0001)
+	})
+	t.Run("Edge", func(t *testing.T) {
+		areas := Areas{NewArea("dog", 0.9, 0.9, 0.1, 0.1), NewArea("cat", 0.7, 0.8, 0.1, 0.1)}
+
+		result := areas.Focus(1)
+
+		assert.InDelta(t, 0.3, result.W, 0.0001)
+		assert.InDelta(t, 0.7, result.X, 0.0001)
+		assert.InDelta(t, 0.7, result.Y, 0.0001)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		areas := Areas{NewArea("person", 0, 0, 1, 1)}
+
+		result := areas.Focus(0.5)
+
+		assert.InDelta(t, 1, result.W, 0.0001)
+		assert.InDelta(t, 0.5, result.H, 0.0001)
+		assert.InDelta(t, 0.25, result.Y, 0.0001)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.True(t, Areas{}.Focus(1).Empty())
+		assert.True(t, Areas{{}}.Focus(1).Empty())
+		assert.True(t, Areas{NewArea("car", 0.1, 0.1, 0.1, 0.1)}.Focus(0).Empty())
+	})
+}
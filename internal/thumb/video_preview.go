@@ -0,0 +1,185 @@
+package thumb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Video preview clip and sprite sheet defaults.
+const (
+	PreviewClipSize     = 480
+	PreviewClipDuration = 3 * time.Second
+	SpriteTileWidth     = 160
+	SpriteColumns       = 10
+	SpriteTilesMax      = 100
+)
+
+// VideoPreview represents a video preview file type.
+type VideoPreview string
+
+// Supported video preview file types.
+const (
+	PreviewClip   VideoPreview = "clip"
+	PreviewSprite VideoPreview = "sprite"
+	PreviewTrack  VideoPreview = "vtt"
+)
+
+// VideoPreviews contains all supported video preview file types.
+var VideoPreviews = []VideoPreview{PreviewClip, PreviewSprite, PreviewTrack}
+
+// String returns the video preview type as string.
+func (t VideoPreview) String() string {
+	return string(t)
+}
+
+// Valid checks if the video preview type is supported.
+func (t VideoPreview) Valid() bool {
+	return t.Suffix() != ""
+}
+
+// Suffix returns the cache file suffix of the video preview type.
+func (t VideoPreview) Suffix() string {
+	switch t {
+	case PreviewClip:
+		return "preview.mp4"
+	case PreviewSprite:
+		return "sprite.jpg"
+	case PreviewTrack:
+		return "sprite.vtt"
+	default:
+		return ""
+	}
+}
+
+// ContentType returns the HTTP content type of the video preview type.
+func (t VideoPreview) ContentType() string {
+	switch t {
+	case PreviewClip:
+		return "video/mp4"
+	case PreviewSprite:
+		return "image/jpeg"
+	case PreviewTrack:
+		return "text/vtt"
+	default:
+		return ""
+	}
+}
+
+// FileName returns the cache file name of the video preview, so that it is found
+// and removed together with the thumbnails of the same file hash.
+func (t VideoPreview) FileName(hash, thumbPath string) (fileName string, err error) {
+	if !t.Valid() {
+		return "", fmt.Errorf("thumb: invalid video preview type %s", clean.Log(t.String()))
+	}
+
+	if len(hash) < 4 {
+		return "", fmt.Errorf("thumb: file hash is empty or too short (%s)", clean.Log(hash))
+	}
+
+	if len(thumbPath) == 0 {
+		return "", errors.New("thumb: folder is empty")
+	}
+
+	p := path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3])
+
+	if err = fs.MkdirAll(p); err != nil {
+		return "", err
+	}
+
+	fileName = fmt.Sprintf("%s/%s_%s", p, hash, t.Suffix())
+
+	return fileName, nil
+}
+
+// Sprite represents the layout of a video scrubbing sprite sheet.
+type Sprite struct {
+	Interval   int
+	Tiles      int
+	Columns    int
+	Rows       int
+	TileWidth  int
+	TileHeight int
+}
+
+// NewSprite returns the sprite sheet layout for a video with the specified duration and size.
+func NewSprite(d time.Duration, width, height int) Sprite {
+	seconds := int(math.Ceil(d.Seconds()))
+
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	// Take one tile per second, or less for longer videos.
+	interval := int(math.Ceil(float64(seconds) / SpriteTilesMax))
+
+	if interval < 1 {
+		interval = 1
+	}
+
+	tiles := int(math.Ceil(float64(seconds) / float64(interval)))
+	columns := SpriteColumns
+
+	if tiles < columns {
+		columns = tiles
+	}
+
+	// Keep the aspect ratio, assuming 16:9 if the video size is unknown.
+	tileHeight := SpriteTileWidth * 9 / 16
+
+	if width > 0 && height > 0 {
+		tileHeight = int(math.Round(float64(SpriteTileWidth*height)/float64(width)/2)) * 2
+	}
+
+	if tileHeight < 2 {
+		tileHeight = 2
+	}
+
+	return Sprite{
+		Interval:   interval,
+		Tiles:      tiles,
+		Columns:    columns,
+		Rows:       int(math.Ceil(float64(tiles) / float64(columns))),
+		TileWidth:  SpriteTileWidth,
+		TileHeight: tileHeight,
+	}
+}
+
+// WebVTT returns a WebVTT thumbnail track that maps time ranges to tiles of the sprite sheet.
+func (s Sprite) WebVTT(spriteUrl string, d time.Duration) string {
+	var b strings.Builder
+
+	b.WriteString("WEBVTT\n")
+
+	for i := 0; i < s.Tiles; i++ {
+		start := time.Duration(i*s.Interval) * time.Second
+		end := time.Duration((i+1)*s.Interval) * time.Second
+
+		if d > 0 && end > d {
+			end = d
+		}
+
+		if end <= start {
+			break
+		}
+
+		x := (i % s.Columns) * s.TileWidth
+		y := (i / s.Columns) * s.TileHeight
+
+		b.WriteString(fmt.Sprintf("\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n", vttTime(start), vttTime(end), spriteUrl, x, y, s.TileWidth, s.TileHeight))
+	}
+
+	return b.String()
+}
+
+// vttTime formats a duration as WebVTT timestamp.
+func vttTime(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,73 @@
+package thumb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoPreview_Suffix(t *testing.T) {
+	assert.Equal(t, "preview.mp4", PreviewClip.Suffix())
+	assert.Equal(t, "sprite.jpg", PreviewSprite.Suffix())
+	assert.Equal(t, "sprite.vtt", PreviewTrack.Suffix())
+	assert.Equal(t, "", VideoPreview("foo").Suffix())
+}
+
+func TestVideoPreview_ContentType(t *testing.T) {
+	assert.Equal(t, "video/mp4", PreviewClip.ContentType())
+	assert.Equal(t, "image/jpeg", PreviewSprite.ContentType())
+	assert.Equal(t, "text/vtt", PreviewTrack.ContentType())
+	assert.Equal(t, "", VideoPreview("foo").ContentType())
+}
+
+func TestVideoPreview_FileName(t *testing.T) {
+	thumbPath := "testdata/cache"
+
+	t.Run("Clip", func(t *testing.T) {
+		fileName, err := PreviewClip.FileName("aaa4c3f9b0c6d8e1b6d71e70e8b0a1eee2c2d4b3", thumbPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "testdata/cache/a/a/a/aaa4c3f9b0c6d8e1b6d71e70e8b0a1eee2c2d4b3_preview.mp4", fileName)
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		_, err := VideoPreview("foo").FileName("aaa4c3f9b0c6d8e1b6d71e70e8b0a1eee2c2d4b3", thumbPath)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := PreviewSprite.FileName("aa", thumbPath)
+		assert.Error(t, err)
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		_, err := PreviewTrack.FileName("aaa4c3f9b0c6d8e1b6d71e70e8b0a1eee2c2d4b3", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewSprite(t *testing.T) {
+	t.Run("Short", func(t *testing.T) {
+		s := NewSprite(4500*time.Millisecond, 1920, 1080)
+		assert.Equal(t, Sprite{Interval: 1, Tiles: 5, Columns: 5, Rows: 1, TileWidth: 160, TileHeight: 90}, s)
+	})
+	t.Run("Long", func(t *testing.T) {
+		s := NewSprite(10*time.Minute, 1080, 1920)
+		assert.Equal(t, Sprite{Interval: 6, Tiles: 100, Columns: 10, Rows: 10, TileWidth: 160, TileHeight: 284}, s)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		s := NewSprite(0, 0, 0)
+		assert.Equal(t, Sprite{Interval: 1, Tiles: 1, Columns: 1, Rows: 1, TileWidth: 160, TileHeight: 90}, s)
+	})
+}
+
+func TestSprite_WebVTT(t *testing.T) {
+	s := NewSprite(12500*time.Millisecond, 1920, 1080)
+	vtt := s.WebVTT("sprite", 12500*time.Millisecond)
+
+	assert.Contains(t, vtt, "WEBVTT\n\n00:00:00.000 --> 00:00:01.000\nsprite#xywh=0,0,160,90\n")
+	assert.Contains(t, vtt, "\n00:00:10.000 --> 00:00:11.000\nsprite#xywh=0,90,160,90\n")
+	assert.Contains(t, vtt, "\n00:00:12.000 --> 00:00:12.500\nsprite#xywh=320,90,160,90\n")
+	assert.Equal(t, "01:01:01.001", vttTime(time.Hour+time.Minute+time.Second+time.Millisecond))
+}
@@ -25,13 +25,13 @@ func NewBackup(conf *config.Config) *Backup {
 
 // StartScheduled starts a scheduled run of the backup worker based on the current configuration.
 func (w *Backup) StartScheduled() {
-	if err := w.Start(w.conf.BackupDatabase(), w.conf.BackupAlbums(), true, w.conf.BackupRetain()); err != nil {
+	if err := w.Start(w.conf.BackupDatabase(), w.conf.BackupAlbums(), w.conf.BackupOriginals(), true, w.conf.BackupRetain()); err != nil {
 		log.Errorf("scheduler: %s (backup)", err)
 	}
 }
 
-// Start creates index and album backups based on the current configuration.
-func (w *Backup) Start(database, albums bool, force bool, retain int) (err error) {
+// Start creates index, album, and originals backups based on the current configuration.
+func (w *Backup) Start(database, albums, originals bool, force bool, retain int) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("backup: %s (worker panic)\nstack: %s", r, debug.Stack())
@@ -40,7 +40,7 @@ func (w *Backup) Start(database, albums bool, force bool, retain int) (err error
 	}()
 
 	// Return if no backups should be created.
-	if !database && !albums {
+	if !database && !albums && !originals {
 		return nil
 	}
 
@@ -58,7 +58,7 @@ func (w *Backup) Start(database, albums bool, force bool, retain int) (err error
 	if database {
 		databasePath := w.conf.BackupDatabasePath()
 
-		if err = backup.Database(databasePath, "", false, force, retain); err != nil {
+		if err = backup.Database(databasePath, "", backup.FormatSQL, false, force, retain); err != nil {
 			log.Errorf("backup: %s (database)", err)
 		}
 	}
@@ -78,6 +78,19 @@ func (w *Backup) Start(database, albums bool, force bool, retain int) (err error
 		}
 	}
 
+	if mutex.BackupWorker.Canceled() {
+		return errors.New("canceled")
+	}
+
+	// Create incremental backup of originals and sidecar files.
+	if originals {
+		if result, backupErr := backup.Originals(w.conf.BackupOriginalsPath(), false, retain); backupErr != nil {
+			log.Errorf("backup: %s (originals)", backupErr.Error())
+		} else if result.Uploaded > 0 {
+			log.Infof("backup: uploaded %s", english.Plural(result.Uploaded, "new file", "new files"))
+		}
+	}
+
 	elapsed := time.Since(start)
 
 	// Log success message.
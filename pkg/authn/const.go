@@ -10,7 +10,9 @@ const (
 	Verified    = "verified"
 	Activated   = "activated"
 	Deactivated = "deactivated"
+	Deleted     = "deleted"
 	Passcode    = "passcode"
+	Passkey     = "passkey"
 	Session     = "session"
 	Sessions    = "sessions"
 	Users       = "users"
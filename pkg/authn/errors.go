@@ -75,6 +75,16 @@ var (
 	ErrInvalidPasscodeType        = errors.New("invalid passcode type")
 )
 
+// Passkey-related error messages:
+var (
+	ErrPasskeyRequired     = errors.New("passkey required")
+	ErrPasskeyNotSupported = errors.New("passkey not supported")
+	ErrPasskeyCreateFailed = errors.New("failed to create passkey")
+	ErrPasskeyDeleteFailed = errors.New("failed to delete passkey")
+	ErrPasskeyNotFound     = errors.New("passkey not found")
+	ErrInvalidPasskey      = errors.New("invalid passkey")
+)
+
 // Password-related error messages:
 var (
 	ErrInvalidPassword     = errors.New("invalid password")
@@ -15,6 +15,7 @@ const (
 	GrantImplicit          GrantType = "implicit"
 	GrantSession           GrantType = "session"
 	GrantPassword          GrantType = "password"
+	GrantPasskey           GrantType = "passkey"
 	GrantClientCredentials GrantType = "client_credentials"
 	GrantShareToken        GrantType = "share_token"
 	GrantRefreshToken      GrantType = "refresh_token"
@@ -38,6 +39,8 @@ func Grant(s string) GrantType {
 		return GrantSession
 	case "password", "passwd", "pass":
 		return GrantPassword
+	case "passkey", "webauthn":
+		return GrantPasskey
 	case "client_credentials", "client":
 		return GrantClientCredentials
 	case "share_token", "share":
@@ -68,6 +71,8 @@ func (t GrantType) Pretty() string {
 		return "Session"
 	case GrantPassword:
 		return "Password"
+	case GrantPasskey:
+		return "Passkey"
 	case GrantClientCredentials:
 		return "Client Credentials"
 	case GrantShareToken:
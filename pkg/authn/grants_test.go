@@ -58,6 +58,7 @@ func TestGrantType_Pretty(t *testing.T) {
 	assert.Equal(t, "Client Credentials", GrantClientCredentials.Pretty())
 	assert.Equal(t, "Session", GrantSession.Pretty())
 	assert.Equal(t, "Password", GrantPassword.Pretty())
+	assert.Equal(t, "Passkey", GrantPasskey.Pretty())
 	assert.Equal(t, "Refresh Token", GrantRefreshToken.Pretty())
 	assert.Equal(t, "Authorization Code", GrantAuthorizationCode.Pretty())
 	assert.Equal(t, "JWT Bearer Assertion", GrantJwtBearer.Pretty())
@@ -100,6 +101,8 @@ func TestGrant(t *testing.T) {
 	assert.Equal(t, GrantSession, Grant("session"))
 	assert.Equal(t, GrantPassword, Grant("pass"))
 	assert.Equal(t, GrantPassword, Grant("password"))
+	assert.Equal(t, GrantPasskey, Grant("passkey"))
+	assert.Equal(t, GrantPasskey, Grant("webauthn"))
 	assert.Equal(t, GrantClientCredentials, Grant("client credentials"))
 	assert.Equal(t, GrantClientCredentials, Grant("client_credentials"))
 	assert.Equal(t, GrantShareToken, Grant("share_token"))
@@ -17,6 +17,7 @@ const (
 	MethodSession   MethodType = "session"
 	MethodOAuth2    MethodType = "oauth2"
 	Method2FA       MethodType = "2fa"
+	MethodPasskey   MethodType = "passkey"
 )
 
 // Method casts a string to a normalized method type.
@@ -31,6 +32,8 @@ func Method(s string) MethodType {
 		return MethodOAuth2
 	case "2fa", "mfa", "otp", "totp":
 		return Method2FA
+	case "passkey", "webauthn", "fido2":
+		return MethodPasskey
 	case "access_token":
 		return MethodDefault
 	default:
@@ -45,6 +45,7 @@ func TestMethodType_Pretty(t *testing.T) {
 	assert.Equal(t, "Default", MethodDefault.Pretty())
 	assert.Equal(t, "OAuth2", MethodOAuth2.Pretty())
 	assert.Equal(t, "2FA", Method2FA.Pretty())
+	assert.Equal(t, "Passkey", MethodPasskey.Pretty())
 	assert.Equal(t, "Default", MethodUndefined.Pretty())
 }
 
@@ -67,6 +68,8 @@ func TestMethod(t *testing.T) {
 	assert.Equal(t, Method2FA, Method("2fa"))
 	assert.Equal(t, Method2FA, Method("totp"))
 	assert.Equal(t, Method2FA, Method("2FA"))
+	assert.Equal(t, MethodPasskey, Method("passkey"))
+	assert.Equal(t, MethodPasskey, Method("WebAuthn"))
 }
 
 func TestMethods(t *testing.T) {
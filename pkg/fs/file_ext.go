@@ -17,6 +17,7 @@ const (
 	ExtMP4  = ".mp4"
 	ExtMOV  = ".mov"
 	ExtYAML = ".yml"
+	ExtXMP  = ".xmp"
 )
 
 // Ext returns all extension of a file name including the dots.
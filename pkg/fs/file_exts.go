@@ -124,7 +124,7 @@ var Extensions = FileExtensions{
 	".ept":      VectorEPS,
 	".epsf":     VectorEPS,
 	".epsi":     VectorEPS,
-	".xmp":      SidecarXMP,
+	ExtXMP:      SidecarXMP,
 	".aae":      SidecarAAE,
 	".xml":      SidecarXML,
 	ExtYAML:     SidecarYAML, // .yml
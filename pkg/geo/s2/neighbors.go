@@ -0,0 +1,32 @@
+package s2
+
+import gs2 "github.com/golang/geo/s2"
+
+// Neighbors returns the token of the parent cell at the specified level along with the tokens
+// of all adjacent cells at the same level, e.g. to find nearby places in a cell-based index.
+func Neighbors(token string, level int) (result []string) {
+	token = NormalizeToken(token)
+
+	cell := gs2.CellIDFromToken(token)
+
+	if !cell.IsValid() {
+		return result
+	}
+
+	// Level must not be greater than the cell level.
+	if level > cell.Level() {
+		level = cell.Level()
+	}
+
+	parentCell := cell.Parent(level)
+	neighbors := parentCell.AllNeighbors(level)
+
+	result = make([]string, 0, len(neighbors)+1)
+	result = append(result, parentCell.ToToken())
+
+	for _, n := range neighbors {
+		result = append(result, n.ToToken())
+	}
+
+	return result
+}
@@ -251,3 +251,23 @@ func TestRange(t *testing.T) {
 		assert.Equal(t, "", end)
 	})
 }
+
+func TestNeighbors(t *testing.T) {
+	t.Run("Level7", func(t *testing.T) {
+		result := Neighbors("4799e370ca54c8b9", 7)
+
+		assert.Len(t, result, 9)
+		assert.Equal(t, TokenLevel(48.56344833333333, 8.996878333333333, 7), result[0])
+
+		assert.NotContains(t, result[1:], result[0])
+	})
+	t.Run("Prefixed", func(t *testing.T) {
+		result := Neighbors(TokenPrefix+"4799e370ca54c8b9", 10)
+
+		assert.Len(t, result, 9)
+		assert.True(t, strings.HasPrefix(result[0], "4799e"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Len(t, Neighbors("4799e370ca5q", 1), 0)
+	})
+}
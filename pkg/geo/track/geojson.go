@@ -0,0 +1,120 @@
+package track
+
+import (
+	"fmt"
+
+	geojson "github.com/paulmach/go.geojson"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// ParseGeoJSON parses a GeoJSON tracklog. Line strings must have a "coordTimes" or "times" property
+// with a timestamp for each coordinate, while points must have a "time" or "timestamp" property.
+func ParseGeoJSON(data []byte) (t Track, err error) {
+	fc, err := geojson.UnmarshalFeatureCollection(data)
+
+	if err != nil {
+		return t, fmt.Errorf("invalid geojson file (%s)", err)
+	}
+
+	var points Segment
+
+	for _, f := range fc.Features {
+		if f == nil || f.Geometry == nil {
+			continue
+		}
+
+		if t.Name == "" {
+			t.Name = f.PropertyMustString("name", "")
+		}
+
+		switch f.Geometry.Type {
+		case geojson.GeometryPoint:
+			p := geoJsonPosition(f.Geometry.Point)
+			p.Time = parseTime(f.PropertyMustString("time", f.PropertyMustString("timestamp", "")))
+			points = append(points, p)
+		case geojson.GeometryLineString:
+			t.add(geoJsonSegment(f.Geometry.LineString, geoJsonTimes(f.Properties)))
+		case geojson.GeometryMultiLineString:
+			times := geoJsonMultiTimes(f.Properties)
+
+			for i, line := range f.Geometry.MultiLineString {
+				if i < len(times) {
+					t.add(geoJsonSegment(line, times[i]))
+				}
+			}
+		}
+	}
+
+	if len(points) > 0 {
+		t.add(points)
+	}
+
+	return t, t.validate()
+}
+
+// geoJsonPosition returns the GeoJSON coordinate as geo.Position.
+func geoJsonPosition(c []float64) (p geo.Position) {
+	if len(c) < 2 {
+		return p
+	}
+
+	p.Lng, p.Lat = c[0], c[1]
+
+	if len(c) > 2 {
+		p.Altitude = c[2]
+	}
+
+	return p
+}
+
+// geoJsonSegment returns a segment with the coordinates and the timestamps at the same index.
+func geoJsonSegment(coords [][]float64, times []string) Segment {
+	s := make(Segment, 0, len(coords))
+
+	for i := 0; i < len(coords) && i < len(times); i++ {
+		p := geoJsonPosition(coords[i])
+		p.Time = parseTime(times[i])
+		s = append(s, p)
+	}
+
+	return s
+}
+
+// geoJsonTimes returns the coordinate timestamps of a line string.
+func geoJsonTimes(props map[string]interface{}) []string {
+	for _, key := range []string{"coordTimes", "times"} {
+		if values, ok := props[key].([]interface{}); ok {
+			return geoJsonStrings(values)
+		}
+	}
+
+	return nil
+}
+
+// geoJsonMultiTimes returns the coordinate timestamps of a multi line string.
+func geoJsonMultiTimes(props map[string]interface{}) (result [][]string) {
+	for _, key := range []string{"coordTimes", "times"} {
+		if lines, ok := props[key].([]interface{}); ok {
+			for _, line := range lines {
+				values, _ := line.([]interface{})
+				result = append(result, geoJsonStrings(values))
+			}
+
+			return result
+		}
+	}
+
+	return nil
+}
+
+// geoJsonStrings converts a list of JSON values to strings.
+func geoJsonStrings(values []interface{}) []string {
+	result := make([]string, len(values))
+
+	for i, v := range values {
+		result[i], _ = v.(string)
+	}
+
+	return result
+}
@@ -0,0 +1,92 @@
+package track
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// gpxDocument represents the relevant parts of a GPX document.
+type gpxDocument struct {
+	XMLName  xml.Name   `xml:"gpx"`
+	Name     string     `xml:"metadata>name"`
+	Tracks   []gpxTrack `xml:"trk"`
+	Routes   []gpxTrack `xml:"rte"`
+	Waypoint []gpxPoint `xml:"wpt"`
+}
+
+// gpxTrack represents a GPX track.
+type gpxTrack struct {
+	Name     string       `xml:"name"`
+	Segments []gpxSegment `xml:"trkseg"`
+	Points   []gpxPoint   `xml:"rtept"`
+}
+
+// gpxSegment represents a GPX track segment.
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+// gpxPoint represents a GPX track point or waypoint.
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lng  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+}
+
+// position returns the point as geo.Position.
+func (p gpxPoint) position() geo.Position {
+	return geo.Position{Lat: p.Lat, Lng: p.Lng, Altitude: p.Ele, Time: parseTime(p.Time)}
+}
+
+// ParseGPX parses a GPX tracklog, including routes and waypoints with time information.
+func ParseGPX(data []byte) (t Track, err error) {
+	doc := gpxDocument{}
+
+	if err = xml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return t, fmt.Errorf("invalid gpx file (%s)", err)
+	}
+
+	t.Name = doc.Name
+
+	for _, trk := range append(doc.Tracks, doc.Routes...) {
+		if t.Name == "" {
+			t.Name = trk.Name
+		}
+
+		for _, seg := range trk.Segments {
+			s := make(Segment, 0, len(seg.Points))
+
+			for _, p := range seg.Points {
+				s = append(s, p.position())
+			}
+
+			t.add(s)
+		}
+
+		if len(trk.Points) > 0 {
+			s := make(Segment, 0, len(trk.Points))
+
+			for _, p := range trk.Points {
+				s = append(s, p.position())
+			}
+
+			t.add(s)
+		}
+	}
+
+	if len(doc.Waypoint) > 0 {
+		s := make(Segment, 0, len(doc.Waypoint))
+
+		for _, p := range doc.Waypoint {
+			s = append(s, p.position())
+		}
+
+		t.add(s)
+	}
+
+	return t, t.validate()
+}
@@ -0,0 +1,125 @@
+package track
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// ParseKML parses a KML tracklog. It supports gx:Track elements as well as placemarks
+// with a timestamp and a point, as there is no standard way to store time in KML line strings.
+func ParseKML(data []byte) (t Track, err error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	var path []string
+	var text strings.Builder
+	var when []time.Time
+	var coords []geo.Position
+	var placemark geo.Position
+	var points Segment
+
+	inTrack := false
+
+	for {
+		token, tokenErr := d.Token()
+
+		if errors.Is(tokenErr, io.EOF) {
+			break
+		} else if tokenErr != nil {
+			return t, fmt.Errorf("invalid kml file (%s)", tokenErr)
+		}
+
+		switch e := token.(type) {
+		case xml.StartElement:
+			path = append(path, e.Name.Local)
+			text.Reset()
+
+			switch e.Name.Local {
+			case "Track":
+				inTrack = true
+				when, coords = nil, nil
+			case "Placemark":
+				placemark = geo.Position{}
+			}
+		case xml.CharData:
+			text.Write(e)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			text.Reset()
+
+			switch e.Name.Local {
+			case "name":
+				if t.Name == "" && len(path) > 1 && path[len(path)-2] == "Document" {
+					t.Name = value
+				}
+			case "when":
+				if inTrack {
+					when = append(when, parseTime(value))
+				} else {
+					placemark.Time = parseTime(value)
+				}
+			case "coord":
+				if inTrack {
+					coords = append(coords, parseKmlCoord(value, " "))
+				}
+			case "coordinates":
+				if !inTrack && len(path) > 1 && path[len(path)-2] == "Point" {
+					p := parseKmlCoord(value, ",")
+					placemark.Lat, placemark.Lng, placemark.Altitude = p.Lat, p.Lng, p.Altitude
+				}
+			case "Track":
+				inTrack = false
+
+				// Each coordinate belongs to the time at the same index.
+				s := make(Segment, 0, len(coords))
+
+				for i := 0; i < len(coords) && i < len(when); i++ {
+					p := coords[i]
+					p.Time = when[i]
+					s = append(s, p)
+				}
+
+				t.add(s)
+			case "Placemark":
+				if !placemark.Time.IsZero() {
+					points = append(points, placemark)
+				}
+			}
+
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+		}
+	}
+
+	if len(points) > 0 {
+		t.add(points)
+	}
+
+	return t, t.validate()
+}
+
+// parseKmlCoord parses a KML coordinate, which has the longitude first, followed by latitude and altitude.
+func parseKmlCoord(s, sep string) (p geo.Position) {
+	values := strings.Split(strings.TrimSpace(s), sep)
+
+	if len(values) < 2 {
+		return p
+	}
+
+	p.Lng, _ = strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	p.Lat, _ = strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+
+	if len(values) > 2 {
+		p.Altitude, _ = strconv.ParseFloat(strings.TrimSpace(values[2]), 64)
+	}
+
+	return p
+}
@@ -0,0 +1,258 @@
+/*
+Package track provides parsers for GPX, KML, and GeoJSON tracklogs that can be used to geotag pictures.
+
+Copyright (c) 2018 - 2024 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package track
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// Supported tracklog file extensions.
+const (
+	ExtGPX     = ".gpx"
+	ExtKML     = ".kml"
+	ExtGeoJSON = ".geojson"
+	ExtJSON    = ".json"
+)
+
+// Segment represents a continuous list of track points, sorted by time.
+type Segment []geo.Position
+
+// Track represents a tracklog with one or more segments.
+type Track struct {
+	Name     string
+	Segments []Segment
+}
+
+// Tracks represents a list of tracklogs.
+type Tracks []Track
+
+// Supported tests if the file extension is supported.
+func Supported(fileName string) bool {
+	switch fs.LowerExt(fileName) {
+	case ExtGPX, ExtKML, ExtGeoJSON, ExtJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// Open reads and parses a tracklog file based on its extension.
+func Open(fileName string) (t Track, err error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return t, err
+	}
+
+	switch fs.LowerExt(fileName) {
+	case ExtGPX:
+		t, err = ParseGPX(data)
+	case ExtKML:
+		t, err = ParseKML(data)
+	case ExtGeoJSON, ExtJSON:
+		t, err = ParseGeoJSON(data)
+	default:
+		return t, fmt.Errorf("unsupported track format %s", fs.LowerExt(fileName))
+	}
+
+	if err != nil {
+		return t, err
+	}
+
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+
+	return t, nil
+}
+
+// add appends a segment if it contains points with a valid time.
+func (t *Track) add(s Segment) {
+	result := make(Segment, 0, len(s))
+
+	for _, p := range s {
+		if p.Time.IsZero() || p.Lat == 0 && p.Lng == 0 {
+			continue
+		}
+
+		p.Time = p.Time.UTC()
+		result = append(result, p)
+	}
+
+	if len(result) == 0 {
+		return
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	t.Segments = append(t.Segments, result)
+}
+
+// validate returns an error if the track contains no points.
+func (t *Track) validate() error {
+	if t.Points() == 0 {
+		return errors.New("track contains no points with time information")
+	}
+
+	return nil
+}
+
+// Points returns the number of track points.
+func (t Track) Points() (n int) {
+	for _, s := range t.Segments {
+		n += len(s)
+	}
+
+	return n
+}
+
+// Start returns the time of the first track point.
+func (t Track) Start() (result time.Time) {
+	for _, s := range t.Segments {
+		if len(s) > 0 && (result.IsZero() || s[0].Time.Before(result)) {
+			result = s[0].Time
+		}
+	}
+
+	return result
+}
+
+// End returns the time of the last track point.
+func (t Track) End() (result time.Time) {
+	for _, s := range t.Segments {
+		if len(s) > 0 && s[len(s)-1].Time.After(result) {
+			result = s[len(s)-1].Time
+		}
+	}
+
+	return result
+}
+
+// Position returns the position at the specified time if it falls within a track segment. The position
+// is interpolated between the two closest track points, which must not be further apart than maxGap.
+func (t Track) Position(at time.Time, maxGap time.Duration) (geo.Position, bool) {
+	at = at.UTC()
+
+	for _, s := range t.Segments {
+		if pos, ok := s.Position(at, maxGap); ok {
+			pos.Name = t.Name
+			return pos, true
+		}
+	}
+
+	return geo.Position{}, false
+}
+
+// Position returns the interpolated segment position at the specified time, if any.
+func (s Segment) Position(at time.Time, maxGap time.Duration) (geo.Position, bool) {
+	n := len(s)
+
+	if n == 0 || at.Before(s[0].Time) || at.After(s[n-1].Time) {
+		return geo.Position{}, false
+	}
+
+	// Find the first point that is not before the specified time.
+	i := sort.Search(n, func(i int) bool {
+		return !s[i].Time.Before(at)
+	})
+
+	if s[i].Time.Equal(at) {
+		return s[i], true
+	}
+
+	p1, p2 := s[i-1], s[i]
+
+	// Don't interpolate if the tracker was switched off or had no signal.
+	if p2.Time.Sub(p1.Time) > maxGap {
+		return geo.Position{}, false
+	}
+
+	m := geo.NewMovement(p1, p2)
+	pos := m.EstimatePosition(at)
+	pos.Estimate = false
+
+	return pos, true
+}
+
+// Start returns the time of the first track point.
+func (t Tracks) Start() (result time.Time) {
+	for _, tr := range t {
+		if s := tr.Start(); !s.IsZero() && (result.IsZero() || s.Before(result)) {
+			result = s
+		}
+	}
+
+	return result
+}
+
+// End returns the time of the last track point.
+func (t Tracks) End() (result time.Time) {
+	for _, tr := range t {
+		if e := tr.End(); e.After(result) {
+			result = e
+		}
+	}
+
+	return result
+}
+
+// Position returns the position at the specified time based on the first matching track.
+func (t Tracks) Position(at time.Time, maxGap time.Duration) (geo.Position, bool) {
+	for _, tr := range t {
+		if pos, ok := tr.Position(at, maxGap); ok {
+			return pos, true
+		}
+	}
+
+	return geo.Position{}, false
+}
+
+// parseTime parses a track point timestamp.
+func parseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return time.Time{}
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.UTC()
+	} else if t, err = time.Parse("2006-01-02T15:04:05", s); err == nil {
+		return t.UTC()
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,174 @@
+package track
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported("track.gpx"))
+	assert.True(t, Supported("track.KML"))
+	assert.True(t, Supported("track.geojson"))
+	assert.True(t, Supported("track.json"))
+	assert.False(t, Supported("track.jpg"))
+	assert.False(t, Supported("track"))
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("GPX", func(t *testing.T) {
+		tr, err := Open("testdata/track.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Berlin Walk", tr.Name)
+		assert.Len(t, tr.Segments, 2)
+		assert.Equal(t, 6, tr.Points())
+		assert.Equal(t, time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC), tr.Start())
+		assert.Equal(t, time.Date(2021, 5, 1, 12, 1, 0, 0, time.UTC), tr.End())
+	})
+	t.Run("KML", func(t *testing.T) {
+		tr, err := Open("testdata/track.kml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Berlin Walk", tr.Name)
+		assert.Len(t, tr.Segments, 2)
+		assert.Equal(t, 4, tr.Points())
+		assert.Equal(t, 52.5210, tr.Segments[0][1].Lat)
+		assert.Equal(t, 13.4010, tr.Segments[0][1].Lng)
+		assert.Equal(t, 36.0, tr.Segments[0][1].Altitude)
+		assert.Equal(t, 52.54, tr.Segments[1][0].Lat)
+	})
+	t.Run("GeoJSON", func(t *testing.T) {
+		tr, err := Open("testdata/track.geojson")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Berlin Walk", tr.Name)
+		assert.Len(t, tr.Segments, 2)
+		assert.Equal(t, 4, tr.Points())
+		assert.Equal(t, time.Date(2021, 5, 1, 10, 2, 0, 0, time.UTC), tr.Segments[0][1].Time)
+		assert.Equal(t, 52.5210, tr.Segments[0][1].Lat)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Open("testdata/missing.gpx")
+		assert.Error(t, err)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := Open("track_test.go")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseGPX(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseGPX([]byte("foo"))
+		assert.Error(t, err)
+	})
+	t.Run("NoTime", func(t *testing.T) {
+		_, err := ParseGPX([]byte(`<gpx><trk><trkseg><trkpt lat="52.52" lon="13.4"></trkpt></trkseg></trk></gpx>`))
+		assert.Error(t, err)
+	})
+}
+
+func TestParseKML(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseKML([]byte("<kml><Document>"))
+		assert.Error(t, err)
+	})
+}
+
+func TestParseGeoJSON(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseGeoJSON([]byte("foo"))
+		assert.Error(t, err)
+	})
+}
+
+func TestTrack_Position(t *testing.T) {
+	tr, err := Open("testdata/track.gpx")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	maxGap := 10 * time.Minute
+
+	t.Run("TrackPoint", func(t *testing.T) {
+		pos, ok := tr.Position(time.Date(2021, 5, 1, 10, 2, 0, 0, time.UTC), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, "Berlin Walk", pos.Name)
+		assert.Equal(t, 52.5210, pos.Lat)
+		assert.Equal(t, 13.4010, pos.Lng)
+	})
+	t.Run("Interpolated", func(t *testing.T) {
+		pos, ok := tr.Position(time.Date(2021, 5, 1, 10, 1, 0, 0, time.UTC), maxGap)
+
+		assert.True(t, ok)
+		assert.False(t, pos.Estimate)
+		assert.InDelta(t, 52.5205, pos.Lat, 0.00001)
+		assert.InDelta(t, 13.4005, pos.Lng, 0.00001)
+		assert.Equal(t, 35.0, pos.Altitude)
+	})
+	t.Run("LocalTime", func(t *testing.T) {
+		pos, ok := tr.Position(time.Date(2021, 5, 1, 12, 1, 0, 0, time.FixedZone("CEST", 7200)), maxGap)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 52.5205, pos.Lat, 0.00001)
+	})
+	t.Run("Gap", func(t *testing.T) {
+		_, ok := tr.Position(time.Date(2021, 5, 1, 10, 30, 0, 0, time.UTC), maxGap)
+		assert.False(t, ok)
+
+		_, ok = tr.Position(time.Date(2021, 5, 1, 10, 30, 0, 0, time.UTC), 2*time.Hour)
+		assert.True(t, ok)
+	})
+	t.Run("BetweenSegments", func(t *testing.T) {
+		_, ok := tr.Position(time.Date(2021, 5, 1, 11, 30, 0, 0, time.UTC), 2*time.Hour)
+		assert.False(t, ok)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		_, ok := tr.Position(time.Date(2021, 5, 1, 9, 59, 59, 0, time.UTC), maxGap)
+		assert.False(t, ok)
+
+		_, ok = tr.Position(time.Date(2021, 5, 1, 12, 1, 1, 0, time.UTC), maxGap)
+		assert.False(t, ok)
+	})
+}
+
+func TestTracks(t *testing.T) {
+	gpx, err := Open("testdata/track.gpx")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kml, err := Open("testdata/track.kml")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracks := Tracks{kml, gpx}
+
+	assert.Equal(t, time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC), tracks.Start())
+	assert.Equal(t, time.Date(2021, 5, 1, 12, 1, 0, 0, time.UTC), tracks.End())
+
+	pos, ok := tracks.Position(time.Date(2021, 5, 1, 12, 0, 30, 0, time.UTC), time.Minute)
+
+	assert.True(t, ok)
+	assert.InDelta(t, 52.5405, pos.Lat, 0.00001)
+
+	_, ok = tracks.Position(time.Date(2021, 5, 2, 12, 0, 0, 0, time.UTC), time.Minute)
+
+	assert.False(t, ok)
+}
@@ -37,6 +37,11 @@ const (
 
 	// CacheControlImmutable indicates that the response will not be updated while it's fresh.
 	CacheControlImmutable = "immutable"
+
+	// RetryAfter indicates how long the client should wait in seconds before making a follow-up request,
+	// e.g. when a resource is still being created in the background.
+	// See: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
+	RetryAfter = "Retry-After"
 )
 
 // CacheControl defaults.
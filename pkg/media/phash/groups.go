@@ -0,0 +1,56 @@
+package phash
+
+// Groups returns the indexes of similar hashes grouped together, ignoring hashes without
+// similar counterparts. Two hashes belong to the same group if they are connected by a chain
+// of hashes that differ by no more than maxDist bits.
+func Groups(hashes []Hash, maxDist int) (groups [][]int) {
+	parent := make([]int, len(hashes))
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	for i := range hashes {
+		for j := i + 1; j < len(hashes); j++ {
+			if !hashes[i].Similar(hashes[j], maxDist) {
+				continue
+			}
+
+			if a, b := find(i), find(j); a != b {
+				parent[b] = a
+			}
+		}
+	}
+
+	// Collect groups in order of their first member.
+	members := make(map[int][]int)
+	var roots []int
+
+	for i := range hashes {
+		root := find(i)
+
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+
+		members[root] = append(members[root], i)
+	}
+
+	for _, root := range roots {
+		if len(members[root]) > 1 {
+			groups = append(groups, members[root])
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,21 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroups(t *testing.T) {
+	t.Run("Similar", func(t *testing.T) {
+		hashes := []Hash{0x0, 0xffffffffffffffff, 0x3, 0xfffffffffffffff0, 0x00ff00ff00ff00ff, 0xf}
+		assert.Equal(t, [][]int{{0, 2, 5}, {1, 3}}, Groups(hashes, 4))
+	})
+	t.Run("Chain", func(t *testing.T) {
+		hashes := []Hash{0x0, 0x3, 0xf}
+		assert.Equal(t, [][]int{{0, 1, 2}}, Groups(hashes, 2))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, Groups(nil, DefaultDistance))
+	})
+}
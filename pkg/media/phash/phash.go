@@ -0,0 +1,139 @@
+/*
+Package phash provides perceptual image hashes for finding visually similar pictures.
+
+Copyright (c) 2018 - 2024 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package phash
+
+import (
+	"fmt"
+	"image"
+	"math/bits"
+	"strconv"
+)
+
+// DefaultDistance is the maximum number of different bits for pictures to be considered similar.
+const DefaultDistance = 8
+
+// MaxDistance is the maximum distance that can be specified when searching for similar pictures.
+const MaxDistance = 16
+
+// Hash represents a 64-bit perceptual difference hash (dHash) of an image.
+type Hash uint64
+
+// New returns the perceptual difference hash of an image, which is calculated by reducing it
+// to 9x8 grayscale cells and comparing the brightness of horizontally adjacent cells.
+func New(img image.Image) Hash {
+	if img == nil {
+		return 0
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width < 1 || height < 1 {
+		return 0
+	}
+
+	// Calculate the average luminance of each cell.
+	var cells [8][9]float64
+
+	for row := 0; row < 8; row++ {
+		y0 := bounds.Min.Y + row*height/8
+		y1 := bounds.Min.Y + (row+1)*height/8
+
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for col := 0; col < 9; col++ {
+			x0 := bounds.Min.X + col*width/9
+			x1 := bounds.Min.X + (col+1)*width/9
+
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			sum, n := 0.0, 0
+
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					r, g, b, _ := img.At(x, y).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+					n++
+				}
+			}
+
+			cells[row][col] = sum / float64(n)
+		}
+	}
+
+	// Set a bit for each cell that is brighter than its right neighbor.
+	var h Hash
+
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			h <<= 1
+
+			if cells[row][col] > cells[row][col+1] {
+				h |= 1
+			}
+		}
+	}
+
+	return h
+}
+
+// Parse returns the hash encoded by a hexadecimal string.
+func Parse(s string) (Hash, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("invalid perceptual hash")
+	}
+
+	h, err := strconv.ParseUint(s, 16, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid perceptual hash")
+	}
+
+	return Hash(h), nil
+}
+
+// Hex returns the hash as hexadecimal string with a fixed length of 16 characters.
+func (h Hash) Hex() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// String returns the hash as hexadecimal string.
+func (h Hash) String() string {
+	return h.Hex()
+}
+
+// Distance returns the number of different bits in both hashes.
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// Similar tests if the distance to the other hash does not exceed the specified number of bits.
+func (h Hash) Similar(other Hash, maxDist int) bool {
+	return h.Distance(other) <= maxDist
+}
@@ -0,0 +1,67 @@
+package phash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// gradient returns a test image with a horizontal gradient, optionally reversed.
+func gradient(width, height int, reverse bool) image.Image {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8(x * 255 / width)
+
+			if reverse {
+				v = 255 - v
+			}
+
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+
+	return img
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Gradient", func(t *testing.T) {
+		assert.Equal(t, Hash(0), New(gradient(90, 80, false)))
+		assert.Equal(t, Hash(0xffffffffffffffff), New(gradient(90, 80, true)))
+	})
+	t.Run("Resized", func(t *testing.T) {
+		a := New(gradient(224, 224, true))
+		b := New(gradient(50, 50, true))
+		assert.True(t, a.Similar(b, DefaultDistance))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.Equal(t, Hash(0), New(nil))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, err := Parse("00ff00ff00ff00ff")
+		assert.NoError(t, err)
+		assert.Equal(t, Hash(0x00ff00ff00ff00ff), h)
+		assert.Equal(t, "00ff00ff00ff00ff", h.Hex())
+		assert.Equal(t, "00ff00ff00ff00ff", h.String())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Parse("xyz")
+		assert.Error(t, err)
+		_, err = Parse("zzzzzzzzzzzzzzzz")
+		assert.Error(t, err)
+	})
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0).Distance(0))
+	assert.Equal(t, 1, Hash(1).Distance(0))
+	assert.Equal(t, 64, Hash(0).Distance(0xffffffffffffffff))
+	assert.True(t, Hash(0xff).Similar(0, 8))
+	assert.False(t, Hash(0x1ff).Similar(0, 8))
+}